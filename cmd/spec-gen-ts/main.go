@@ -0,0 +1,25 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Command spec-gen-ts is a spec generator plugin which generates TypeScript code.
+//
+// Usage:
+//
+//	spec generate --plugin ts --plugin-out ts:web/src/pkg [--plugin-opt ts:runtime=./spec.ts] pkg
+package main
+
+import (
+	"github.com/basecomplextech/spec/internal/lang/tsgen"
+	"github.com/basecomplextech/spec/lang"
+)
+
+func main() {
+	lang.ServePlugin(func(req *lang.PluginRequest) ([]lang.PluginFile, error) {
+		opts, err := tsgen.ParseOptions(req.Parameter)
+		if err != nil {
+			return nil, err
+		}
+		return tsgen.Generate(req.Package, opts)
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func breakingCommand() *cli.Command {
+	return &cli.Command{
+		Name: "breaking",
+		Description: "Report backward incompatible changes between two versions of a Spec package.\n" +
+			"The old version is a directory or a git ref, i.e. main or HEAD~1, imports are resolved\n" +
+			"in the import paths for both versions. Exits with 1 on breaking changes, with 2 on errors.",
+		UsageText: "spec breaking [-i import-paths] [--format text|json] --against <old-dir|git-ref> [new-dir]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:     "against",
+				Required: true,
+				Usage:    "old package directory or git ref",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "output format, text or json",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			changes, err := breaking(x)
+			if err != nil {
+				return cli.Exit(err, 2)
+			}
+			if len(changes) == 0 {
+				return nil
+			}
+			return cli.Exit(fmt.Sprintf("%d breaking change(s)", len(changes)), 1)
+		},
+	}
+}
+
+func breaking(x *cli.Context) ([]lang.BreakingChange, error) {
+	// New dir arg
+	dir := "."
+	args := x.Args().Slice()
+	switch len(args) {
+	case 0:
+	case 1:
+		dir = strings.TrimSpace(args[0])
+	default:
+		return nil, fmt.Errorf("invalid new-dir arg: %v", args)
+	}
+
+	imports := x.StringSlice("import")
+	format := x.String("format")
+	if format != "text" && format != "json" {
+		return nil, fmt.Errorf("unknown format %q, expected text or json", format)
+	}
+
+	// Old dir
+	oldDir := x.String("against")
+	if info, err := os.Stat(oldDir); err != nil || !info.IsDir() {
+		tmp, err := os.MkdirTemp("", "spec-breaking-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(tmp)
+
+		oldDir, err = checkoutGitRef(x.String("against"), dir, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compile and compare
+	old, err := lang.Compile(oldDir, imports)
+	if err != nil {
+		return nil, fmt.Errorf("old: %w", err)
+	}
+	new, err := lang.Compile(dir, imports)
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+	changes := lang.Breaking(old, new)
+
+	// Output
+	switch format {
+	case "json":
+		if changes == nil {
+			changes = []lang.BreakingChange{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(changes); err != nil {
+			return nil, err
+		}
+	default:
+		for _, c := range changes {
+			fmt.Printf("%v:%d: %v (%v)\n", filepath.Join(dir, c.File), c.Line, c.Message, c.Kind)
+		}
+	}
+	return changes, nil
+}
+
+// checkoutGitRef writes spec files of a package directory at a git ref into a temp directory,
+// returns the old package directory with the same name as the new one.
+func checkoutGitRef(ref string, dir string, tmp string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	top, err := git(abs, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("against is neither a directory nor a git ref: %w", err)
+	}
+	top = strings.TrimSpace(top)
+
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return "", err
+	}
+	rel = filepath.ToSlash(rel)
+
+	// List files
+	out, err := git(top, "ls-tree", "--name-only", ref, "--", rel+"/")
+	if err != nil {
+		return "", fmt.Errorf("against is neither a directory nor a git ref: %w", err)
+	}
+
+	oldDir := filepath.Join(tmp, filepath.Base(abs))
+	if err := os.Mkdir(oldDir, 0755); err != nil {
+		return "", err
+	}
+
+	// Write spec files
+	for _, name := range strings.Split(strings.TrimSpace(out), "\n") {
+		if path.Ext(name) != ".spec" {
+			continue
+		}
+
+		src, err := git(top, "show", ref+":"+name)
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(oldDir, path.Base(name)), []byte(src), 0644); err != nil {
+			return "", err
+		}
+	}
+	return oldDir, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+			return "", fmt.Errorf("git %v: %s", args[0], strings.TrimSpace(string(exit.Stderr)))
+		}
+		return "", fmt.Errorf("git %v: %w", args[0], err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/logging"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/basecomplextech/spec/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+func callCommand() *cli.Command {
+	return &cli.Command{
+		Name: "call",
+		Description: "Call an RPC method using a service from a schema, prints the response as JSON,\n" +
+			"channel methods read JSON lines from stdin and write JSON lines to stdout",
+		UsageText: "spec call [-i import-paths] [--schema dir] --addr host:port " +
+			"Service[.subservice(json)].method [json-inputs...]",
+		Args: true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "schema",
+				Value: ".",
+				Usage: "schema package directory",
+			},
+			&cli.StringFlag{
+				Name:     "addr",
+				Usage:    "server address as host:port",
+				Required: true,
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "call timeout, no timeout when zero",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			args := x.Args().Slice()
+			if len(args) == 0 {
+				return cli.ShowCommandHelp(x, "call")
+			}
+
+			pkg, err := lang.Compile(x.String("schema"), x.StringSlice("import"))
+			if err != nil {
+				return err
+			}
+
+			inputs := make([][]byte, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				inputs = append(inputs, []byte(arg))
+			}
+
+			call, err := pkg.ParseCall(args[0], inputs)
+			if err != nil {
+				return err
+			}
+
+			client := rpc.NewClient(x.String("addr"), rpc.ClientMode_OnDemand, logging.Null, rpc.Default())
+			defer client.Close()
+
+			ctx := callContext(x)
+			defer ctx.Free()
+
+			return call.Do(ctx, client, os.Stdin, os.Stdout)
+		},
+	}
+}
+
+// callContext returns a context which is cancelled on a timeout or an interrupt.
+func callContext(x *cli.Context) async.CancelContext {
+	parent := async.NoContext()
+	if timeout := x.Duration("timeout"); timeout > 0 {
+		parent = async.TimeoutContext(timeout)
+	}
+	ctx := async.NextContext(parent)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sig:
+			ctx.Cancel()
+		case <-ctx.Wait():
+		}
+		signal.Stop(sig)
+	}()
+	return ctx
+}
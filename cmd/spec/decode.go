@@ -0,0 +1,182 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func decodeCommand() *cli.Command {
+	return &cli.Command{
+		Name: "decode",
+		Description: "Decode binary data into JSON or text using a message type from a schema,\n" +
+			"or dump a self-describing value tree when no type is given",
+		UsageText: "spec decode [-i import-paths] [--schema dir] [--type pkg.Message] [--format json|text] [file]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "schema",
+				Value: ".",
+				Usage: "schema package directory",
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "message type as pkg.Message, dumps a schema-less value tree when empty",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "json",
+				Usage: "output format, json or text",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			data, err := readInput(x)
+			if err != nil {
+				return err
+			}
+
+			switch f := x.String("format"); f {
+			case "json":
+			case "text":
+				return decodeText(x, data)
+			default:
+				return fmt.Errorf("unknown format %q, expected json or text", f)
+			}
+
+			var v any
+			if typ := x.String("type"); typ == "" {
+				v, err = lang.ParseValueTree(data)
+			} else {
+				msg, err1 := compileMessage(x, typ)
+				if err1 != nil {
+					return err1
+				}
+				v, _, err = msg.Parse(data)
+			}
+			if err != nil {
+				return err
+			}
+
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+
+			buf := &bytes.Buffer{}
+			if err := json.Indent(buf, b, "", "  "); err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+
+			_, err = os.Stdout.Write(buf.Bytes())
+			return err
+		},
+	}
+}
+
+// decodeText writes a message as a text object, or a schema-free text when no type is given.
+func decodeText(x *cli.Context, data []byte) error {
+	var s string
+	var err error
+
+	if typ := x.String("type"); typ == "" {
+		s, err = spec.FormatText(data)
+	} else {
+		msg, err1 := compileMessage(x, typ)
+		if err1 != nil {
+			return err1
+		}
+
+		m, _, err1 := msg.Parse(data)
+		if err1 != nil {
+			return err1
+		}
+		s, err = m.FormatText()
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.WriteString(s + "\n")
+	return err
+}
+
+// readInput reads a file from the first argument or stdin.
+func readInput(x *cli.Context) ([]byte, error) {
+	args := x.Args().Slice()
+	switch len(args) {
+	case 0:
+		return io.ReadAll(os.Stdin)
+	case 1:
+		return os.ReadFile(args[0])
+	}
+	return nil, fmt.Errorf("invalid file args: %v", args)
+}
+
+// compileMessage compiles a schema from the --schema flag and returns a message by its type name.
+func compileMessage(x *cli.Context, typ string) (*lang.Message, error) {
+	pkg, err := lang.Compile(x.String("schema"), x.StringSlice("import"))
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := lookupDefinition(pkg, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := def.Message()
+	if msg == nil {
+		return nil, fmt.Errorf("%v is not a message, but %v", typ, def.Type())
+	}
+	return msg, nil
+}
+
+// lookupDefinition returns a definition by a type name as pkg.Name or Name in a package
+// or its imports, including generated requests and responses.
+func lookupDefinition(pkg *lang.Package, typ string) (*lang.Definition, error) {
+	pkgName, name := pkg.Name(), typ
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		pkgName, name = typ[:i], typ[i+1:]
+	}
+
+	seen := make(map[*lang.Package]struct{})
+	queue := []*lang.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+
+		if p.Name() == pkgName || p.ID() == pkgName {
+			for _, file := range p.Files() {
+				for _, def := range file.Definitions() {
+					if def.Name() == name {
+						return def, nil
+					}
+				}
+			}
+		}
+		queue = append(queue, p.Imports()...)
+	}
+	return nil, fmt.Errorf("type not found: %v", typ)
+}
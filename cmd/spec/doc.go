@@ -0,0 +1,59 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func docCommand() *cli.Command {
+	return &cli.Command{
+		Name: "doc",
+		Description: "Render packages and their imports into static Markdown or HTML documentation,\n" +
+			"writes a page per package and an index page",
+		UsageText: "spec doc [-i import-paths] [--format markdown|html] --out dir [src-dirs...]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "markdown",
+				Usage: "output format, markdown or html",
+			},
+			&cli.StringFlag{
+				Name:     "out",
+				Aliases:  []string{"o"},
+				Usage:    "output directory",
+				Required: true,
+			},
+		},
+		Action: func(x *cli.Context) error {
+			format := lang.DocFormat(x.String("format"))
+			switch format {
+			case lang.DocMarkdown, lang.DocHTML:
+			default:
+				return fmt.Errorf("invalid format %q, expected markdown or html", format)
+			}
+
+			dirs := x.Args().Slice()
+			if len(dirs) == 0 {
+				dirs = []string{"."}
+			}
+
+			pkgs, err := lang.CompileAll(dirs, x.StringSlice("import"))
+			if err != nil {
+				return err
+			}
+			return lang.WriteDocs(pkgs, x.String("out"), format)
+		},
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/basecomplextech/spec"
+	"github.com/urfave/cli/v2"
+)
+
+func dumpCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "dump",
+		Description: "Print an annotated layout of an encoded value and the offset where parsing fails",
+		UsageText:   "spec dump [--hex] [file]",
+		Args:        true,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "hex",
+				Usage: "read input as hex text, whitespace is ignored",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			data, err := readInput(x)
+			if err != nil {
+				return err
+			}
+
+			if x.Bool("hex") {
+				s := strings.Join(strings.Fields(string(data)), "")
+				data, err = hex.DecodeString(s)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := spec.Dump(os.Stdout, data); err != nil {
+				return cli.Exit(err, 1)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func encodeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "encode",
+		Description: "Encode JSON or text into binary data using a message type from a schema",
+		UsageText:   "spec encode [-i import-paths] [--schema dir] --type pkg.Message [--format json|text] [file]",
+		Args:        true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "schema",
+				Value: ".",
+				Usage: "schema package directory",
+			},
+			&cli.StringFlag{
+				Name:     "type",
+				Required: true,
+				Usage:    "message type as pkg.Message",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "json",
+				Usage: "input format, json or text",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			data, err := readInput(x)
+			if err != nil {
+				return err
+			}
+
+			msg, err := compileMessage(x, x.String("type"))
+			if err != nil {
+				return err
+			}
+
+			var m lang.DynamicMessage
+			switch f := x.String("format"); f {
+			case "json":
+				m, err = msg.ParseJSON(data)
+			case "text":
+				m, err = msg.ParseText(data)
+			default:
+				return fmt.Errorf("unknown format %q, expected json or text", f)
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = os.Stdout.Write(m.Unwrap().Raw())
+			return err
+		},
+	}
+}
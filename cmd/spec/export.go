@@ -0,0 +1,68 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name: "export",
+		Description: "Export packages and their imports as proto3 files or JSON Schema documents,\n" +
+			"reports lossy mappings as warnings",
+		UsageText: "spec export [-i import-paths] --format proto|jsonschema --out dir [src-dirs...]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:     "format",
+				Usage:    "export format, proto or jsonschema",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "out",
+				Aliases:  []string{"o"},
+				Usage:    "output directory",
+				Required: true,
+			},
+		},
+		Action: func(x *cli.Context) error {
+			format := lang.ExportFormat(x.String("format"))
+			switch format {
+			case lang.ExportProto, lang.ExportJSONSchema:
+			default:
+				return fmt.Errorf("invalid format %q, expected proto or jsonschema", format)
+			}
+
+			dirs := x.Args().Slice()
+			if len(dirs) == 0 {
+				dirs = []string{"."}
+			}
+
+			pkgs, err := lang.CompileAll(dirs, x.StringSlice("import"))
+			if err != nil {
+				return err
+			}
+
+			warnings, err := lang.Export(pkgs, x.String("out"), format)
+			if err != nil {
+				return err
+			}
+			for _, w := range warnings {
+				fmt.Fprintln(os.Stderr, "warning:", w)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func fmtCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "fmt",
+		Description: "Format spec files in the canonical layout",
+		UsageText:   "spec fmt [-w] [-d] [paths...]",
+		Args:        true,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "write",
+				Aliases: []string{"w"},
+				Usage:   "write results to source files instead of stdout",
+			},
+			&cli.BoolFlag{
+				Name:    "diff",
+				Aliases: []string{"d"},
+				Usage:   "print diffs and fail when files are not formatted",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			write := x.Bool("write")
+			diff := x.Bool("diff")
+
+			paths := x.Args().Slice()
+			if len(paths) == 0 {
+				paths = []string{"."}
+			}
+
+			files, err := specFiles(paths)
+			if err != nil {
+				return err
+			}
+
+			unformatted := 0
+			for _, path := range files {
+				changed, err := formatFile(path, write, diff)
+				if err != nil {
+					return err
+				}
+				if changed {
+					unformatted++
+				}
+			}
+
+			if diff && unformatted > 0 {
+				return cli.Exit(fmt.Sprintf("%d file(s) not formatted", unformatted), 1)
+			}
+			return nil
+		},
+	}
+}
+
+// formatFile formats a file, returns true when the file is not formatted.
+func formatFile(path string, write bool, diff bool) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := lang.FormatFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	changed := !bytes.Equal(src, out)
+	switch {
+	case diff:
+		if changed {
+			d := lang.FormatDiff(path+".orig", path, src, out)
+			os.Stdout.Write(d)
+		}
+	case write:
+		if changed {
+			if err := os.WriteFile(path, out, 0644); err != nil {
+				return false, err
+			}
+		}
+	default:
+		os.Stdout.Write(out)
+	}
+	return changed, nil
+}
+
+// specFiles returns spec files in paths, walks directories recursively.
+func specFiles(paths []string) ([]string, error) {
+	var files []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case d.IsDir():
+				return nil
+			case filepath.Ext(p) == ".spec":
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func fromProtoCommand() *cli.Command {
+	return &cli.Command{
+		Name: "from-proto",
+		Description: "Convert proto3 files into spec files, writes a package directory per proto package,\n" +
+			"reports constructs which have no spec equivalent",
+		UsageText: "spec from-proto [-I proto-paths] --out dir files...",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "proto_path",
+				Aliases: []string{"I"},
+				Usage:   "directories to search imported proto files",
+			},
+			&cli.StringFlag{
+				Name:     "out",
+				Aliases:  []string{"o"},
+				Usage:    "output directory",
+				Required: true,
+			},
+		},
+		Action: func(x *cli.Context) error {
+			paths := x.Args().Slice()
+			if len(paths) == 0 {
+				return errors.New("no proto files")
+			}
+
+			issues, err := lang.FromProto(paths, x.StringSlice("proto_path"), x.String("out"))
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				fmt.Fprintln(os.Stderr, issue)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func generateCommand() *cli.Command {
+	return &cli.Command{
+		Name: "generate",
+		Description: "Generate a Go package from a Spec package, imports are resolved in the import paths\n" +
+			"and in the spec.mod module of the package.\n\n" +
+			"Without arguments, generates all packages listed in a spec.yaml config in the current directory\n" +
+			"when it exists, packages are generated in parallel and only changed files are rewritten.\n" +
+			"Packages in one module share compiled imports and are compiled one at a time.",
+		UsageText: "spec generate [--check|--watch] [-i import-paths] [--skip-rpc] [--skip-go] [--mocks] " +
+			"[--plugin name[:path]] [--plugin-out name:dir] [--plugin-opt name:param] [src-dir] [dst-dir]\n" +
+			"spec generate [--check|--watch] [--config spec.yaml]",
+		Args: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "generation config, defaults to spec.yaml when no arguments are given",
+			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "print diffs and fail when generated files are out of date, do not write files",
+			},
+			&cli.BoolFlag{
+				Name:  "watch",
+				Usage: "regenerate when spec files in the packages or their imports change",
+			},
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-rpc",
+				Usage: "skip generating RPC code",
+			},
+			&cli.BoolFlag{
+				Name:  "mocks",
+				Usage: "generate mock clients and fake services for tests",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-go",
+				Usage: "skip generating Go code, i.e. when only running plugins",
+			},
+			&cli.StringSliceFlag{
+				Name:  "plugin",
+				Usage: "generator plugins as name[:path], the path defaults to spec-gen-<name>",
+			},
+			&cli.StringSliceFlag{
+				Name:  "plugin-out",
+				Usage: "plugin output directories as name:dir, default to dst-dir",
+			},
+			&cli.StringSliceFlag{
+				Name:  "plugin-opt",
+				Usage: "plugin parameters as name:param",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			check := x.Bool("check")
+			watch := x.Bool("watch")
+			if check && watch {
+				return fmt.Errorf("flags --check and --watch cannot be used together")
+			}
+
+			config, err := generateConfig(x)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case check:
+				return checkGenerate(config)
+			case watch:
+				return watchGenerate(config)
+			}
+
+			_, err = lang.GenerateAll(config)
+			return err
+		},
+	}
+}
+
+// generateConfig returns a config from a config file, or a single package config from args and flags.
+func generateConfig(x *cli.Context) (*lang.Config, error) {
+	config, err := loadGenerateConfig(x)
+	switch {
+	case err != nil:
+		return nil, err
+	case config != nil:
+		return config, nil
+	}
+
+	// Source/dest args
+	src := ""
+	dst := ""
+
+	args := x.Args().Slice()
+	switch len(args) {
+	case 0:
+		src = "."
+	case 1:
+		src = strings.TrimSpace(x.Args().Get(0))
+	case 2:
+		src = strings.TrimSpace(x.Args().Get(0))
+		dst = strings.TrimSpace(x.Args().Get(1))
+	default:
+		return nil, fmt.Errorf("invalid src/dst args: %v", args)
+	}
+
+	// Flags
+	plugins, err := parsePlugins(x, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	config = &lang.Config{
+		ImportPaths: x.StringSlice("import"),
+		Packages: []lang.PackageConfig{{
+			Src:     src,
+			Out:     dst,
+			SkipRPC: x.Bool("skip-rpc"),
+			Mocks:   x.Bool("mocks"),
+			SkipGo:  x.Bool("skip-go"),
+			Plugins: plugins,
+		}},
+	}
+	return config, nil
+}
+
+// checkGenerate prints diffs between generated files and files on disk,
+// and fails when files are out of date.
+func checkGenerate(config *lang.Config) error {
+	diff, err := lang.CheckAll(config)
+	if err != nil {
+		return err
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+
+	os.Stdout.Write(diff)
+	return cli.Exit("generated files are out of date, run spec generate", 1)
+}
+
+// loadGenerateConfig loads a config from the config flag, or from spec.yaml
+// when no arguments are given, returns nil when there is no config.
+func loadGenerateConfig(x *cli.Context) (*lang.Config, error) {
+	path := x.String("config")
+	if path == "" {
+		if x.NArg() > 0 {
+			return nil, nil
+		}
+		if _, err := os.Stat(lang.ConfigFilename); err != nil {
+			return nil, nil
+		}
+		path = lang.ConfigFilename
+	}
+
+	for _, flag := range []string{"import", "skip-rpc", "skip-go", "mocks", "plugin", "plugin-out", "plugin-opt"} {
+		if x.IsSet(flag) {
+			return nil, fmt.Errorf("flag --%v cannot be used with config %v", flag, path)
+		}
+	}
+	if x.NArg() > 0 {
+		return nil, fmt.Errorf("src/dst args cannot be used with config %v", path)
+	}
+	return lang.LoadConfig(path)
+}
+
+// parsePlugins parses plugin flags.
+func parsePlugins(x *cli.Context, dst string) ([]lang.Plugin, error) {
+	var plugins []lang.Plugin
+	names := make(map[string]int)
+
+	for _, s := range x.StringSlice("plugin") {
+		name, path, _ := strings.Cut(s, ":")
+		if name == "" {
+			return nil, fmt.Errorf("invalid plugin %q, expected name[:path]", s)
+		}
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate plugin %q", name)
+		}
+
+		names[name] = len(plugins)
+		plugins = append(plugins, lang.Plugin{
+			Name: name,
+			Path: path,
+			Out:  dst,
+		})
+	}
+
+	for _, s := range x.StringSlice("plugin-out") {
+		name, dir, ok := strings.Cut(s, ":")
+		i, found := names[name]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("invalid plugin output %q, expected name:dir", s)
+		case !found:
+			return nil, fmt.Errorf("plugin output for unknown plugin %q", name)
+		}
+		plugins[i].Out = dir
+	}
+
+	for _, s := range x.StringSlice("plugin-opt") {
+		name, param, ok := strings.Cut(s, ":")
+		i, found := names[name]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("invalid plugin parameter %q, expected name:param", s)
+		case !found:
+			return nil, fmt.Errorf("plugin parameter for unknown plugin %q", name)
+		}
+		plugins[i].Parameter = param
+	}
+	return plugins, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func graphCommand() *cli.Command {
+	return &cli.Command{
+		Name: "graph",
+		Description: "Output the import graph between packages, or the reference graph between definitions,\n" +
+			"as Graphviz DOT or JSON with both graphs",
+		UsageText: "spec graph [-i import-paths] [--format dot|json] [--defs] [src-dirs...]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "dot",
+				Usage: "output format, dot or json",
+			},
+			&cli.BoolFlag{
+				Name:  "defs",
+				Usage: "output the definition reference graph in the dot format",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			pkgs, err := compileDirs(x)
+			if err != nil {
+				return err
+			}
+			g := lang.BuildGraph(pkgs)
+
+			switch format := x.String("format"); format {
+			case "dot":
+				if x.Bool("defs") {
+					return lang.WriteDefinitionsDOT(os.Stdout, g)
+				}
+				return lang.WriteImportsDOT(os.Stdout, g)
+			case "json":
+				return lang.WriteGraphJSON(os.Stdout, g)
+			default:
+				return fmt.Errorf("unknown format %q, expected dot or json", format)
+			}
+		},
+	}
+}
+
+func unusedCommand() *cli.Command {
+	return &cli.Command{
+		Name: "unused",
+		Description: "Report messages, enums, structs and services which are not reachable\n" +
+			"from any service, or from root types when given",
+		UsageText: "spec unused [-i import-paths] [--root pkg.Type...] [--format text|json] [src-dirs...]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringSliceFlag{
+				Name:  "root",
+				Usage: "root types as Name or pkg.Name, defaults to all services",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "output format, text or json",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			pkgs, err := compileDirs(x)
+			if err != nil {
+				return err
+			}
+
+			unused, err := lang.FindUnused(pkgs, x.StringSlice("root"))
+			if err != nil {
+				return err
+			}
+
+			switch format := x.String("format"); format {
+			case "text":
+				return lang.WriteUnusedText(os.Stdout, unused)
+			case "json":
+				return lang.WriteUnusedJSON(os.Stdout, unused)
+			default:
+				return fmt.Errorf("unknown format %q, expected text or json", format)
+			}
+		},
+	}
+}
+
+// compileDirs compiles packages from source dir args, or the current directory.
+func compileDirs(x *cli.Context) ([]*lang.Package, error) {
+	dirs := x.Args().Slice()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	return lang.CompileAll(dirs, x.StringSlice("import"))
+}
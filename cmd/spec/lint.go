@@ -0,0 +1,114 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+// defaultLintConfig is loaded from the current directory when present.
+const defaultLintConfig = "spec-lint.yaml"
+
+func lintCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "lint",
+		Description: "Check a Spec package against schema style rules",
+		UsageText:   "spec lint [-i import-paths] [--config file] [--format text|json] [--rules] [src-dir]",
+		Args:        true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "lint config file, defaults to " + defaultLintConfig + " when present",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "output format, text or json",
+			},
+			&cli.BoolFlag{
+				Name:  "rules",
+				Usage: "list lint rules and exit",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			if x.Bool("rules") {
+				for _, rule := range lang.LintRules() {
+					fmt.Printf("%-24v %-8v %v\n", rule.Name, rule.Severity, rule.Description)
+				}
+				return nil
+			}
+
+			// Source arg
+			src := "."
+			args := x.Args().Slice()
+			switch len(args) {
+			case 0:
+			case 1:
+				src = strings.TrimSpace(args[0])
+			default:
+				return fmt.Errorf("invalid src arg: %v", args)
+			}
+
+			// Config
+			config, err := loadLintConfig(x.String("config"))
+			if err != nil {
+				return err
+			}
+
+			// Compile and lint
+			pkg, err := lang.Compile(src, x.StringSlice("import"))
+			if err != nil {
+				return err
+			}
+			issues, err := lang.Lint(pkg, config)
+			if err != nil {
+				return err
+			}
+
+			// Output
+			switch format := x.String("format"); format {
+			case "text":
+				err = lang.WriteLintText(os.Stdout, issues)
+			case "json":
+				err = lang.WriteLintJSON(os.Stdout, issues)
+			default:
+				return fmt.Errorf("unknown format %q, expected text or json", format)
+			}
+			if err != nil {
+				return err
+			}
+
+			if lang.LintHasErrors(issues) {
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+}
+
+// loadLintConfig loads a lint config from a path or the default config, returns nil when absent.
+func loadLintConfig(path string) (*lang.LintConfig, error) {
+	if path != "" {
+		return lang.LoadLintConfig(path)
+	}
+
+	if _, err := os.Stat(defaultLintConfig); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lang.LoadLintConfig(defaultLintConfig)
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func lspCommand() *cli.Command {
+	return &cli.Command{
+		Name: "lsp",
+		Description: "Run a language server over stdio, provides diagnostics, go-to-definition,\n" +
+			"hover, completion, references and rename for spec files",
+		UsageText: "spec lsp [-i import-paths]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			return lang.ServeLSP(os.Stdin, os.Stdout, x.StringSlice("import"))
+		},
+	}
+}
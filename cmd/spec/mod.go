@@ -0,0 +1,93 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/urfave/cli/v2"
+)
+
+func modCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "mod",
+		Usage: "spec.mod module maintenance",
+		Subcommands: []*cli.Command{
+			modTidyCommand(),
+		},
+	}
+}
+
+func modTidyCommand() *cli.Command {
+	return &cli.Command{
+		Name: "tidy",
+		Description: "Compile all packages in a module and verify that their imports resolve,\n" +
+			"report unused requirements. Exits with 1 when imports do not resolve.",
+		UsageText: "spec mod tidy [-i import-paths] [dir]",
+		Args:      true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:    "import",
+				Aliases: []string{"i"},
+				Usage:   "import paths",
+			},
+		},
+		Action: func(x *cli.Context) error {
+			dir := "."
+			if x.Args().Len() > 0 {
+				dir = x.Args().First()
+			}
+
+			path, err := lang.FindModule(dir)
+			switch {
+			case err != nil:
+				return err
+			case path == "":
+				return fmt.Errorf("spec.mod not found in %v or its parents", dir)
+			}
+
+			m, err := lang.LoadModule(path)
+			if err != nil {
+				return err
+			}
+
+			result, err := lang.TidyModule(m, x.StringSlice("import"))
+			if err != nil {
+				return err
+			}
+
+			for _, err := range result.Errors {
+				err.File = relativePath(err.File)
+				fmt.Println(err.Error())
+			}
+			for _, req := range result.Unused {
+				fmt.Printf("%v:%d: unused requirement %v\n", relativePath(m.Path), req.Line, req.Path)
+			}
+
+			if n := len(result.Errors); n > 0 {
+				return cli.Exit(fmt.Sprintf("%d of %d packages failed", n, len(result.Packages)), 1)
+			}
+			return nil
+		},
+	}
+}
+
+// relativePath returns a path relative to the working directory, or the path when outside it.
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil || path == "" {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"log"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// watchInterval is an interval between spec file checks.
+const watchInterval = 500 * time.Millisecond
+
+// watchGenerate generates packages, and regenerates them when spec files
+// in the packages or their imports change, until interrupted.
+func watchGenerate(config *lang.Config) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Watch source directories even when packages fail to compile
+	dirs := make(map[string]struct{})
+	for _, p := range config.Packages {
+		addWatchDir(dirs, p.Src)
+	}
+
+	for {
+		pkgs, err := lang.GenerateAll(config)
+		if err != nil {
+			log.Println(err)
+		} else {
+			log.Printf("generated %d package(s)", len(pkgs))
+		}
+
+		// Watch imports, keep previous directories to catch fixes in failed imports
+		for _, pkg := range pkgs {
+			addPackageDirs(dirs, pkg)
+		}
+
+		if !waitChange(ctx, dirs) {
+			return nil
+		}
+	}
+}
+
+// waitChange waits until spec files in directories change, returns false when interrupted.
+func waitChange(ctx context.Context, dirs map[string]struct{}) bool {
+	last := snapshot(dirs)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		if !maps.Equal(last, snapshot(dirs)) {
+			return true
+		}
+	}
+}
+
+// snapshot returns modification times and sizes of spec files in directories.
+func snapshot(dirs map[string]struct{}) map[string]fileStamp {
+	result := make(map[string]fileStamp)
+
+	for dir := range dirs {
+		paths, _ := filepath.Glob(filepath.Join(dir, "*.spec"))
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			result[path] = fileStamp{info.ModTime(), info.Size()}
+		}
+	}
+	return result
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// addPackageDirs adds a package directory and its import directories.
+func addPackageDirs(dirs map[string]struct{}, pkg *lang.Package) {
+	seen := make(map[*lang.Package]struct{})
+
+	var add func(pkg *lang.Package)
+	add = func(pkg *lang.Package) {
+		if _, ok := seen[pkg]; ok {
+			return
+		}
+		seen[pkg] = struct{}{}
+
+		addWatchDir(dirs, pkg.Path())
+		for _, imp := range pkg.Imports() {
+			add(imp)
+		}
+	}
+	add(pkg)
+}
+
+// addWatchDir adds an absolute directory path.
+func addWatchDir(dirs map[string]struct{}, dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dirs[abs] = struct{}{}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"io"
+
+	"github.com/basecomplextech/spec/internal/dump"
+)
+
+// DumpError is a dump error at an absolute byte offset.
+type DumpError = dump.Error
+
+// Dump writes an annotated layout of an encoded value which ends at the end of b:
+// byte ranges, type codes, size prefixes, list and message tables.
+//
+// The layout is written up to the first corrupt byte, the error is returned as [*DumpError]
+// with the exact offset.
+func Dump(w io.Writer, b []byte) error {
+	return dump.Dump(w, b)
+}
+
+// DumpString returns an annotated layout of an encoded value, see [Dump].
+func DumpString(b []byte) (string, error) {
+	return dump.String(b)
+}
@@ -18,13 +18,15 @@ const (
 	TypeFalse = format.TypeFalse
 	TypeByte  = format.TypeByte
 
-	TypeInt16 = format.TypeInt16
-	TypeInt32 = format.TypeInt32
-	TypeInt64 = format.TypeInt64
+	TypeInt16  = format.TypeInt16
+	TypeInt32  = format.TypeInt32
+	TypeInt64  = format.TypeInt64
+	TypeVarint = format.TypeVarint
 
-	TypeUint16 = format.TypeUint16
-	TypeUint32 = format.TypeUint32
-	TypeUint64 = format.TypeUint64
+	TypeUint16  = format.TypeUint16
+	TypeUint32  = format.TypeUint32
+	TypeUint64  = format.TypeUint64
+	TypeUvarint = format.TypeUvarint
 
 	TypeFloat32 = format.TypeFloat32
 	TypeFloat64 = format.TypeFloat64
@@ -36,8 +38,9 @@ const (
 	TypeBytes  = format.TypeBytes
 	TypeString = format.TypeString
 
-	TypeList    = format.TypeList
-	TypeBigList = format.TypeBigList
+	TypeList       = format.TypeList
+	TypeBigList    = format.TypeBigList
+	TypePackedList = format.TypePackedList
 
 	TypeMessage    = format.TypeMessage
 	TypeBigMessage = format.TypeBigMessage
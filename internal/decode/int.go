@@ -10,6 +10,7 @@ import (
 	"math"
 
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
 	"github.com/basecomplextech/spec/internal/format"
 )
 
@@ -54,6 +55,22 @@ func DecodeInt16(b []byte) (int16, int, error) {
 			return 0, 0, errors.New("decode int16: overflow, value too large")
 		}
 
+		n += m
+		return int16(v), n, nil
+
+	case format.TypeVarint:
+		v, m := rvarint.Int64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode int16: invalid data")
+		}
+
+		switch {
+		case v < math.MinInt16:
+			return 0, 0, errors.New("decode int16: overflow, value too small")
+		case v > math.MaxInt16:
+			return 0, 0, errors.New("decode int16: overflow, value too large")
+		}
+
 		n += m
 		return int16(v), n, nil
 	}
@@ -95,6 +112,22 @@ func DecodeInt32(b []byte) (int32, int, error) {
 			return 0, 0, errors.New("decode int32: overflow, value too large")
 		}
 
+		n += m
+		return int32(v), n, nil
+
+	case format.TypeVarint:
+		v, m := rvarint.Int64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode int32: invalid data")
+		}
+
+		switch {
+		case v < math.MinInt32:
+			return 0, 0, errors.New("decode int32: overflow, value too small")
+		case v > math.MaxInt32:
+			return 0, 0, errors.New("decode int32: overflow, value too large")
+		}
+
 		n += m
 		return int32(v), n, nil
 	}
@@ -129,6 +162,14 @@ func DecodeInt64(b []byte) (int64, int, error) {
 		}
 		n += m
 		return int64(v), n, nil
+
+	case format.TypeVarint:
+		v, m := rvarint.Int64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode int64: invalid data")
+		}
+		n += m
+		return v, n, nil
 	}
 
 	return 0, 0, fmt.Errorf("decode int64: invalid type, type=%v", typ)
@@ -93,3 +93,46 @@ func TestDecodeInt64__should_decode_int64_from_int32(t *testing.T) {
 	assert.Equal(t, n, b.Len())
 	assert.Equal(t, int64(math.MaxInt32), v)
 }
+
+// Varint
+
+func TestDecodeInt64__should_decode_varint(t *testing.T) {
+	for _, v := range []int64{0, -1, 1000, math.MinInt64, math.MaxInt64} {
+		b := buffer.New()
+		encode.EncodeVarint(b, v)
+		p := b.Bytes()
+
+		v1, n, err := DecodeInt64(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, len(p), n)
+		assert.Equal(t, v, v1)
+
+		typ, size, err := DecodeTypeSize(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, format.TypeVarint, typ)
+		assert.Equal(t, len(p), size)
+	}
+}
+
+func TestDecodeInt16__should_decode_varint(t *testing.T) {
+	b := buffer.New()
+	encode.EncodeVarint(b, math.MinInt16)
+
+	v, _, err := DecodeInt16(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int16(math.MinInt16), v)
+}
+
+func TestDecodeInt16__should_return_error_when_varint_overflows(t *testing.T) {
+	b := buffer.New()
+	encode.EncodeVarint(b, math.MaxInt16+1)
+
+	_, _, err := DecodeInt16(b.Bytes())
+	assert.Error(t, err)
+}
@@ -23,6 +23,9 @@ func DecodeListTable(b []byte) (_ format.ListTable, size int, err error) {
 		err = errors.New("decode list: invalid data")
 		return
 	}
+	if typ == format.TypePackedList {
+		return decodePackedList(b)
+	}
 	if typ != format.TypeList && typ != format.TypeBigList {
 		err = fmt.Errorf("decode list: invalid type, type=%v", typ)
 		return
@@ -73,6 +76,73 @@ func DecodeListTable(b []byte) (_ format.ListTable, size int, err error) {
 
 // private
 
+func decodePackedList(b []byte) (_ format.ListTable, size int, err error) {
+	// Type and element type
+	if len(b) < 2 {
+		err = errors.New("decode list: invalid data")
+		return
+	}
+	end := len(b) - 2
+	size = 2
+
+	elemType := format.Type(b[end])
+	if !format.IsPackable(elemType) {
+		err = fmt.Errorf("decode list: invalid packed element type, type=%v", elemType)
+		return
+	}
+
+	// Element size
+	elemSize, n := decodeSize(b[:end])
+	if n < 0 {
+		err = errors.New("decode list: invalid element size")
+		return
+	}
+	end -= n
+	size += n
+
+	switch exp := format.PackedSize(elemType); {
+	case elemSize == 0:
+		err = errors.New("decode list: invalid element size")
+		return
+	case exp > 0 && int(elemSize) != exp:
+		err = fmt.Errorf("decode list: invalid element size, type=%v, size=%d", elemType, elemSize)
+		return
+	}
+
+	// Count
+	count, n := decodeSize(b[:end])
+	if n < 0 {
+		err = errors.New("decode list: invalid count")
+		return
+	}
+	end -= n
+	size += n
+
+	// Data size
+	dataSize, n := decodeSize(b[:end])
+	if n < 0 {
+		err = errors.New("decode list: invalid data size")
+		return
+	}
+	end -= n
+	size += n
+
+	// Data
+	if uint64(count)*uint64(elemSize) > uint64(dataSize) {
+		err = errors.New("decode list: invalid packed data")
+		return
+	}
+	if end < int(dataSize) {
+		err = errors.New("decode list: invalid data")
+		return
+	}
+	size += int(dataSize)
+
+	// Done
+	t := format.NewPackedListTable(elemType, elemSize, count, dataSize)
+	return t, size, nil
+}
+
 func decodeListTable(b []byte, size uint32, big bool) (_ []byte, err error) {
 	// Element size
 	elemSize := format.ListElementSize_Small
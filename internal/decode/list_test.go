@@ -141,3 +141,62 @@ func TestDecodeListTable__should_return_error_when_invalid_data(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid data")
 }
+
+// Packed
+
+func testEncodePackedList(t tests.T, count int, elemSize int, elemType format.Type) []byte {
+	buf := buffer.New()
+	buf.Grow(count * elemSize)
+
+	_, err := encode.EncodePackedList(buf, count*elemSize, count, elemSize, elemType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeListTable__should_decode_packed_list(t *testing.T) {
+	b := testEncodePackedList(t, 10, 4, format.TypeInt32)
+
+	table, n, err := DecodeListTable(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(b), n)
+	assert.True(t, table.Packed())
+	assert.Equal(t, format.TypeInt32, table.ElemType())
+	assert.Equal(t, 10, table.Len())
+
+	typ, size, err := DecodeTypeSize(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, format.TypePackedList, typ)
+	assert.Equal(t, len(b), size)
+}
+
+func TestDecodeListTable__should_return_error_when_invalid_packed_element_type(t *testing.T) {
+	b := testEncodePackedList(t, 10, 4, format.TypeInt32)
+	b[len(b)-2] = byte(format.TypeString)
+
+	_, _, err := DecodeListTable(b)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid packed element type")
+}
+
+func TestDecodeListTable__should_return_error_when_invalid_packed_element_size(t *testing.T) {
+	b := testEncodePackedList(t, 10, 4, format.TypeInt32)
+	b[len(b)-2] = byte(format.TypeInt64)
+
+	_, _, err := DecodeListTable(b)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid element size")
+}
+
+func TestDecodeListTable__should_return_error_when_invalid_packed_data(t *testing.T) {
+	b := testEncodePackedList(t, 10, 4, format.TypeInt32)
+
+	_, _, err := DecodeListTable(b[10:])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid data")
+}
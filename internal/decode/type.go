@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
 	"github.com/basecomplextech/spec/internal/format"
 )
 
@@ -56,6 +57,13 @@ func DecodeTypeSize(b []byte) (format.Type, int, error) {
 		}
 		return t, n + m, nil
 
+	case format.TypeVarint:
+		_, m := rvarint.Uint64(v)
+		if m <= 0 {
+			return 0, 0, fmt.Errorf("decode varint: invalid data")
+		}
+		return t, n + m, nil
+
 	// Uint
 
 	case format.TypeUint16, format.TypeUint32, format.TypeUint64:
@@ -65,6 +73,13 @@ func DecodeTypeSize(b []byte) (format.Type, int, error) {
 		}
 		return t, n + m, nil
 
+	case format.TypeUvarint:
+		_, m := rvarint.Uint64(v)
+		if m <= 0 {
+			return 0, 0, fmt.Errorf("decode uvarint: invalid data")
+		}
+		return t, n + m, nil
+
 	// Float
 
 	case format.TypeFloat32:
@@ -154,6 +169,13 @@ func DecodeTypeSize(b []byte) (format.Type, int, error) {
 		}
 		return t, size, nil
 
+	case format.TypePackedList:
+		_, size, err := decodePackedList(b)
+		if err != nil {
+			return 0, 0, err
+		}
+		return t, size, nil
+
 	// Message
 
 	case format.TypeMessage, format.TypeBigMessage:
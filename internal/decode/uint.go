@@ -10,6 +10,7 @@ import (
 	"math"
 
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
 	"github.com/basecomplextech/spec/internal/format"
 )
 
@@ -48,6 +49,19 @@ func DecodeUint16(b []byte) (uint16, int, error) {
 			return 0, 0, errors.New("decode int16: overflow, value too large")
 		}
 
+		n += m
+		return uint16(v), n, nil
+
+	case format.TypeUvarint:
+		v, m := rvarint.Uint64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode uint16: invalid data")
+		}
+
+		if v > math.MaxUint16 {
+			return 0, 0, errors.New("decode uint16: overflow, value too large")
+		}
+
 		n += m
 		return uint16(v), n, nil
 	}
@@ -85,6 +99,19 @@ func DecodeUint32(b []byte) (uint32, int, error) {
 			return 0, 0, errors.New("decode int32: overflow, value too large")
 		}
 
+		n += m
+		return uint32(v), n, nil
+
+	case format.TypeUvarint:
+		v, m := rvarint.Uint64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode uint32: invalid data")
+		}
+
+		if v > math.MaxUint32 {
+			return 0, 0, errors.New("decode uint32: overflow, value too large")
+		}
+
 		n += m
 		return uint32(v), n, nil
 	}
@@ -119,6 +146,14 @@ func DecodeUint64(b []byte) (uint64, int, error) {
 		}
 		n += m
 		return v, n, nil
+
+	case format.TypeUvarint:
+		v, m := rvarint.Uint64(b[:end])
+		if m <= 0 {
+			return 0, 0, errors.New("decode uint64: invalid data")
+		}
+		n += m
+		return v, n, nil
 	}
 
 	return 0, 0, fmt.Errorf("decode uint64: invalid type, type=%v", typ)
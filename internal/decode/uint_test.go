@@ -92,3 +92,35 @@ func TestDecodeUint64__should_decode_uint64_from_uint32(t *testing.T) {
 	assert.Equal(t, n, b.Len())
 	assert.Equal(t, uint64(math.MaxUint32), v)
 }
+
+// Uvarint
+
+func TestDecodeUint64__should_decode_uvarint(t *testing.T) {
+	for _, v := range []uint64{0, 127, 128, 300, math.MaxUint64} {
+		b := buffer.New()
+		encode.EncodeUvarint(b, v)
+		p := b.Bytes()
+
+		v1, n, err := DecodeUint64(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, len(p), n)
+		assert.Equal(t, v, v1)
+
+		typ, size, err := DecodeTypeSize(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, format.TypeUvarint, typ)
+		assert.Equal(t, len(p), size)
+	}
+}
+
+func TestDecodeUint32__should_return_error_when_uvarint_overflows(t *testing.T) {
+	b := buffer.New()
+	encode.EncodeUvarint(b, math.MaxUint32+1)
+
+	_, _, err := DecodeUint32(b.Bytes())
+	assert.Error(t, err)
+}
@@ -0,0 +1,564 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package dump writes annotated layouts of encoded values.
+//
+// Values are walked from their tails the same way as they are decoded, each line contains
+// an absolute byte range, the first bytes in hex and an annotation:
+//
+//	0000-0009  78 00 01 3c 01 00 ..  message, size=10, data=4, table=3 (small, 1 entries)
+//	0009-0009  50                      type 80 message
+//	0008-0008  03                      table size 3
+//	0007-0007  04                      data size 4
+//	0004-0006  01 00 04                table
+//	0004-0006  01 00 04                  tag=1 offset=4
+//	0000-0003  78 00 01 3c             #1 string "x", size=1
+//	...
+//
+// When a value is corrupt, the walk stops and returns an [Error] with the exact offset.
+package dump
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// maxHexBytes is the max number of bytes in a hex column.
+const maxHexBytes = 6
+
+// Error is a dump error at an absolute offset.
+type Error struct {
+	Offset int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("offset %d (0x%x): %v", e.Offset, e.Offset, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Dump writes an annotated layout of a value which ends at the end of b.
+// The layout is written up to the first error, the error is returned as [*Error].
+func Dump(w io.Writer, b []byte) error {
+	d := &dumper{b: b}
+
+	size, err := d.value(len(b), 0, "")
+	if err == nil && size < len(b) {
+		d.line(0, len(b)-size, 0, "%d leading bytes before value", len(b)-size)
+	}
+	if err != nil {
+		d.line(err.Offset, err.Offset+1, 0, "error: %v", err.Err)
+	}
+
+	if _, err1 := w.Write(d.buf.Bytes()); err1 != nil {
+		return err1
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// String returns an annotated layout of a value and an error if any, see [Dump].
+func String(b []byte) (string, error) {
+	buf := &bytes.Buffer{}
+	err := Dump(buf, b)
+	return buf.String(), err
+}
+
+// private
+
+type dumper struct {
+	b   []byte
+	buf bytes.Buffer
+}
+
+// line writes a line with a byte range [start, end).
+func (d *dumper) line(start int, end int, depth int, format string, args ...any) {
+	start = max(start, 0)
+	end = min(end, len(d.b))
+
+	var hx string
+	if start < end {
+		n := min(end-start, maxHexBytes)
+		hx = hex.EncodeToString(d.b[start : start+n])
+
+		// Space separated bytes
+		var sb strings.Builder
+		for i := 0; i < len(hx); i += 2 {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(hx[i : i+2])
+		}
+		if end-start > maxHexBytes {
+			sb.WriteString(" ..")
+		}
+		hx = sb.String()
+	}
+
+	last := max(end-1, start)
+	fmt.Fprintf(&d.buf, "%04x-%04x  %-20v  %v", start, last, hx, strings.Repeat("  ", depth))
+	fmt.Fprintf(&d.buf, format, args...)
+	d.buf.WriteByte('\n')
+}
+
+func (d *dumper) errorf(offset int, format string, args ...any) *Error {
+	return &Error{Offset: max(offset, 0), Err: fmt.Errorf(format, args...)}
+}
+
+// value dumps a value which ends at end, returns its size.
+func (d *dumper) value(end int, depth int, label string) (int, *Error) {
+	if end <= 0 {
+		return 0, d.errorf(0, "unexpected end of data, expected type")
+	}
+
+	b := d.b[:end]
+	typ := format.Type(b[end-1])
+	if err := typ.Check(); err != nil {
+		return 0, d.errorf(end-1, "unsupported type %d", typ)
+	}
+
+	switch typ {
+	case format.TypeList, format.TypeBigList:
+		return d.list(end, depth, label, typ)
+	case format.TypePackedList:
+		return d.packedList(end, depth, label)
+	case format.TypeMessage, format.TypeBigMessage:
+		return d.message(end, depth, label, typ)
+	case format.TypeBytes, format.TypeString:
+		return d.bytes(end, depth, label, typ)
+	case format.TypeStruct:
+		return d.struct_(end, depth, label)
+	}
+	return d.scalar(end, depth, label, typ)
+}
+
+// scalar dumps a primitive value.
+func (d *dumper) scalar(end int, depth int, label string, typ format.Type) (int, *Error) {
+	b := d.b[:end]
+
+	var v any
+	var n int
+	var err error
+
+	switch typ {
+	case format.TypeTrue, format.TypeFalse:
+		v, n, err = decode.DecodeBool(b)
+	case format.TypeByte:
+		v, n, err = decode.DecodeByte(b)
+
+	case format.TypeInt16:
+		v, n, err = decode.DecodeInt16(b)
+	case format.TypeInt32:
+		v, n, err = decode.DecodeInt32(b)
+	case format.TypeInt64, format.TypeVarint:
+		v, n, err = decode.DecodeInt64(b)
+
+	case format.TypeUint16:
+		v, n, err = decode.DecodeUint16(b)
+	case format.TypeUint32:
+		v, n, err = decode.DecodeUint32(b)
+	case format.TypeUint64, format.TypeUvarint:
+		v, n, err = decode.DecodeUint64(b)
+
+	case format.TypeFloat32:
+		v, n, err = decode.DecodeFloat32(b)
+	case format.TypeFloat64:
+		v, n, err = decode.DecodeFloat64(b)
+
+	case format.TypeBin64:
+		v, n, err = decode.DecodeBin64(b)
+	case format.TypeBin128:
+		v, n, err = decode.DecodeBin128(b)
+	case format.TypeBin256:
+		v, n, err = decode.DecodeBin256(b)
+	}
+	if err != nil {
+		return 0, d.errorf(end-1, "%v", err)
+	}
+
+	d.line(end-n, end, depth, "%v%v %v", label, typ, v)
+	return n, nil
+}
+
+// bytes dumps bytes or a string.
+func (d *dumper) bytes(end int, depth int, label string, typ format.Type) (int, *Error) {
+	pos := end - 1
+
+	// Size
+	dataSize, m := compactint.ReverseUint32(d.b[:pos])
+	if m <= 0 {
+		return 0, d.errorf(pos-1, "invalid %v size", typ)
+	}
+	pos -= m
+	sizeStart := pos
+
+	// Null terminator
+	zero := 0
+	if typ == format.TypeString {
+		zero = 1
+	}
+	pos -= zero
+
+	// Data
+	start := pos - int(dataSize)
+	if start < 0 {
+		return 0, d.errorf(sizeStart, "%v size %d exceeds available %d bytes", typ, dataSize, max(pos, 0))
+	}
+
+	size := end - start
+	data := d.b[start:pos]
+	if typ == format.TypeString {
+		d.line(start, end, depth, "%v%v %q, size=%d", label, typ, preview(string(data)), dataSize)
+	} else {
+		d.line(start, end, depth, "%v%v, size=%d", label, typ, dataSize)
+	}
+
+	d.line(end-1, end, depth+1, "type %d %v", byte(typ), typ)
+	d.line(sizeStart, sizeStart+m, depth+1, "size %d", dataSize)
+	if zero > 0 {
+		if d.b[pos] != 0 {
+			return 0, d.errorf(pos, "string terminator is %d, expected 0", d.b[pos])
+		}
+		d.line(pos, pos+1, depth+1, "null terminator")
+	}
+	if dataSize > 0 {
+		d.line(start, pos, depth+1, "data")
+	}
+	return size, nil
+}
+
+// struct_ dumps a struct, its fields are not self-describing and dumped as raw data.
+func (d *dumper) struct_(end int, depth int, label string) (int, *Error) {
+	pos := end - 1
+
+	dataSize, m := compactint.ReverseUint32(d.b[:pos])
+	if m <= 0 {
+		return 0, d.errorf(pos-1, "invalid struct data size")
+	}
+	pos -= m
+	sizeStart := pos
+
+	start := pos - int(dataSize)
+	if start < 0 {
+		return 0, d.errorf(sizeStart, "struct data size %d exceeds available %d bytes", dataSize, pos)
+	}
+
+	d.line(start, end, depth, "%vstruct, size=%d", label, end-start)
+	d.line(end-1, end, depth+1, "type %d struct", byte(format.TypeStruct))
+	d.line(sizeStart, sizeStart+m, depth+1, "data size %d", dataSize)
+	if dataSize > 0 {
+		d.line(start, pos, depth+1, "data")
+	}
+	return end - start, nil
+}
+
+// container is a list or message header.
+type container struct {
+	start      int // value start
+	tableStart int
+	tableEnd   int
+	dataStart  int
+	dataSize   int
+}
+
+// header dumps a list or message header and returns its layout.
+func (d *dumper) header(end int, depth int, label string, typ format.Type, entrySize int) (
+	c container, err *Error) {
+
+	pos := end - 1
+	name := "list"
+	if typ == format.TypeMessage || typ == format.TypeBigMessage {
+		name = "message"
+	}
+
+	// Table size
+	tableSize, m := compactint.ReverseUint32(d.b[:pos])
+	if m <= 0 {
+		return c, d.errorf(pos-1, "invalid %v table size", name)
+	}
+	pos -= m
+	tableSizeStart := pos
+
+	// Data size
+	dataSize, m1 := compactint.ReverseUint32(d.b[:pos])
+	if m1 <= 0 {
+		return c, d.errorf(pos-1, "invalid %v data size", name)
+	}
+	pos -= m1
+	dataSizeStart := pos
+
+	// Table
+	c.tableEnd = pos
+	c.tableStart = pos - int(tableSize)
+	if c.tableStart < 0 {
+		return c, d.errorf(tableSizeStart, "%v table size %d exceeds available %d bytes",
+			name, tableSize, pos)
+	}
+	if int(tableSize)%entrySize != 0 {
+		return c, d.errorf(tableSizeStart, "%v table size %d is not a multiple of %d",
+			name, tableSize, entrySize)
+	}
+
+	// Data
+	c.dataSize = int(dataSize)
+	c.dataStart = c.tableStart - c.dataSize
+	if c.dataStart < 0 {
+		return c, d.errorf(dataSizeStart, "%v data size %d exceeds available %d bytes",
+			name, dataSize, c.tableStart)
+	}
+	c.start = c.dataStart
+
+	kind := "small"
+	if typ == format.TypeBigList || typ == format.TypeBigMessage {
+		kind = "big"
+	}
+
+	d.line(c.start, end, depth, "%v%v, size=%d, data=%d, table=%d (%v, %d entries)",
+		label, typ, end-c.start, dataSize, tableSize, kind, int(tableSize)/entrySize)
+	d.line(end-1, end, depth+1, "type %d %v", byte(typ), typ)
+	d.line(tableSizeStart, tableSizeStart+m, depth+1, "table size %d", tableSize)
+	d.line(dataSizeStart, dataSizeStart+m1, depth+1, "data size %d", dataSize)
+	return c, nil
+}
+
+// list dumps a list table and its elements.
+func (d *dumper) list(end int, depth int, label string, typ format.Type) (int, *Error) {
+	big := typ == format.TypeBigList
+	entrySize := format.ListElementSize_Small
+	if big {
+		entrySize = format.ListElementSize_Big
+	}
+
+	c, err := d.header(end, depth, label, typ, entrySize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Table
+	n := (c.tableEnd - c.tableStart) / entrySize
+	offsets := make([]int, n)
+	d.line(c.tableStart, c.tableEnd, depth+1, "table")
+
+	for i := 0; i < n; i++ {
+		pos := c.tableStart + i*entrySize
+		off := readUint(d.b[pos:pos+entrySize], entrySize)
+
+		d.line(pos, pos+entrySize, depth+2, "[%d] offset=%d", i, off)
+		if off > c.dataSize {
+			return 0, d.errorf(pos, "list element %d offset %d exceeds data size %d", i, off, c.dataSize)
+		}
+		if i > 0 && off < offsets[i-1] {
+			return 0, d.errorf(pos, "list element %d offset %d is less than previous %d",
+				i, off, offsets[i-1])
+		}
+		offsets[i] = off
+	}
+
+	// Elements
+	for i, off := range offsets {
+		prev := 0
+		if i > 0 {
+			prev = offsets[i-1]
+		}
+
+		size, err := d.value(c.dataStart+off, depth+1, fmt.Sprintf("[%d] ", i))
+		if err != nil {
+			return 0, err
+		}
+		if size != off-prev {
+			return 0, d.errorf(c.dataStart+off-1, "list element %d size %d, expected %d",
+				i, size, off-prev)
+		}
+	}
+	return end - c.start, nil
+}
+
+// packedList dumps a packed list and its fixed-size elements.
+func (d *dumper) packedList(end int, depth int, label string) (int, *Error) {
+	pos := end - 1
+
+	// Element type
+	if pos < 1 {
+		return 0, d.errorf(pos, "unexpected end of data, expected packed element type")
+	}
+	pos--
+	elemType := format.Type(d.b[pos])
+	if !format.IsPackable(elemType) {
+		return 0, d.errorf(pos, "packed element type %d is not packable", elemType)
+	}
+
+	// Element size, count, data size
+	var sizes [3]uint32
+	var starts, lens [3]int
+	names := [3]string{"element size", "count", "data size"}
+
+	for i := range sizes {
+		v, m := compactint.ReverseUint32(d.b[:pos])
+		if m <= 0 {
+			return 0, d.errorf(pos-1, "invalid packed list %v", names[i])
+		}
+		pos -= m
+		sizes[i], starts[i], lens[i] = v, pos, m
+	}
+	elemSize, count, dataSize := int(sizes[0]), int(sizes[1]), int(sizes[2])
+
+	if exp := format.PackedSize(elemType); elemSize == 0 || (exp > 0 && elemSize != exp) {
+		return 0, d.errorf(starts[0], "packed element size %d is invalid for %v", elemSize, elemType)
+	}
+	if count*elemSize > dataSize {
+		return 0, d.errorf(starts[1], "packed list count %d of %d bytes exceeds data size %d",
+			count, elemSize, dataSize)
+	}
+
+	start := pos - dataSize
+	if start < 0 {
+		return 0, d.errorf(starts[2], "packed list data size %d exceeds available %d bytes", dataSize, pos)
+	}
+
+	d.line(start, end, depth, "%v%v, size=%d, data=%d, count=%d, elem=%v (%d bytes)",
+		label, format.TypePackedList, end-start, dataSize, count, elemType, elemSize)
+	d.line(end-1, end, depth+1, "type %d %v", byte(format.TypePackedList), format.TypePackedList)
+	d.line(end-2, end-1, depth+1, "element type %d %v", byte(elemType), elemType)
+	for i, name := range names {
+		d.line(starts[i], starts[i]+lens[i], depth+1, "%v %d", name, sizes[i])
+	}
+
+	// Padding
+	elemStart := pos - count*elemSize
+	if elemStart > start {
+		d.line(start, elemStart, depth+1, "padding")
+	}
+
+	// Elements
+	for i := 0; i < count; i++ {
+		off := elemStart + i*elemSize
+		label1 := fmt.Sprintf("[%d] ", i)
+
+		if elemType != format.TypeStruct {
+			v := packedValue(elemType, d.b[off:off+elemSize])
+			d.line(off, off+elemSize, depth+1, "%v%v %v", label1, elemType, v)
+			continue
+		}
+
+		size, err := d.struct_(off+elemSize, depth+1, label1)
+		if err != nil {
+			return 0, err
+		}
+		if size != elemSize {
+			return 0, d.errorf(off+elemSize-1, "packed struct %d size %d, expected %d", i, size, elemSize)
+		}
+	}
+	return end - start, nil
+}
+
+// message dumps a message table and its fields.
+func (d *dumper) message(end int, depth int, label string, typ format.Type) (int, *Error) {
+	big := typ == format.TypeBigMessage
+	tagSize, offSize := 1, 2
+	if big {
+		tagSize, offSize = 2, 4
+	}
+	entrySize := tagSize + offSize
+
+	c, err := d.header(end, depth, label, typ, entrySize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Table
+	type field struct {
+		tag    int
+		offset int
+	}
+
+	n := (c.tableEnd - c.tableStart) / entrySize
+	fields := make([]field, n)
+	d.line(c.tableStart, c.tableEnd, depth+1, "table")
+
+	for i := 0; i < n; i++ {
+		pos := c.tableStart + i*entrySize
+		tag := readUint(d.b[pos:pos+tagSize], tagSize)
+		off := readUint(d.b[pos+tagSize:pos+entrySize], offSize)
+
+		d.line(pos, pos+entrySize, depth+2, "tag=%d offset=%d", tag, off)
+		if off > c.dataSize {
+			return 0, d.errorf(pos+tagSize, "field %d offset %d exceeds data size %d", tag, off, c.dataSize)
+		}
+		if i > 0 && tag <= fields[i-1].tag {
+			return 0, d.errorf(pos, "field tag %d is not greater than previous %d", tag, fields[i-1].tag)
+		}
+		fields[i] = field{tag: tag, offset: off}
+	}
+
+	// Fields
+	for _, f := range fields {
+		_, err := d.value(c.dataStart+f.offset, depth+1, fmt.Sprintf("#%d ", f.tag))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return end - c.start, nil
+}
+
+// util
+
+func readUint(b []byte, size int) int {
+	switch size {
+	case 1:
+		return int(b[0])
+	case 2:
+		return int(binary.BigEndian.Uint16(b))
+	case 4:
+		return int(binary.BigEndian.Uint32(b))
+	}
+	panic(errors.New("unsupported uint size"))
+}
+
+// packedValue returns a little-endian packed number or a bin hex string.
+func packedValue(typ format.Type, b []byte) any {
+	switch typ {
+	case format.TypeInt16:
+		return int16(binary.LittleEndian.Uint16(b))
+	case format.TypeInt32:
+		return int32(binary.LittleEndian.Uint32(b))
+	case format.TypeInt64:
+		return int64(binary.LittleEndian.Uint64(b))
+
+	case format.TypeUint16:
+		return binary.LittleEndian.Uint16(b)
+	case format.TypeUint32:
+		return binary.LittleEndian.Uint32(b)
+	case format.TypeUint64:
+		return binary.LittleEndian.Uint64(b)
+
+	case format.TypeFloat32:
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case format.TypeFloat64:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return hex.EncodeToString(b)
+}
+
+func preview(s string) string {
+	const max = 32
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dump
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSubmessage(t *testing.T) []byte {
+	w := writer.New(false).Message()
+	w.Field(1).String("x")
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDump__should_dump_message(t *testing.T) {
+	b := testSubmessage(t)
+
+	s, err := String(b)
+	require.NoError(t, err)
+
+	exp := `0000-0009  78 00 01 3c 01 00 ..  message, size=10, data=4, table=3 (small, 1 entries)
+0009-0009  50                      type 80 message
+0008-0008  03                      table size 3
+0007-0007  04                      data size 4
+0004-0006  01 00 04                table
+0004-0006  01 00 04                  tag=1 offset=4
+0000-0003  78 00 01 3c             #1 string "x", size=1
+0003-0003  3c                        type 60 string
+0002-0002  01                        size 1
+0001-0001  00                        null terminator
+0000-0000  78                        data
+`
+	assert.Equal(t, exp, s)
+}
+
+func TestDump__should_dump_nested_values(t *testing.T) {
+	w := writer.New(false).Message()
+	w.Field(1).Int64(-1)
+	w.Field(2).Bytes([]byte("data"))
+	w.Field(300).Bool(true)
+
+	list := w.Field(10).List()
+	list.Int32(1)
+	list.Int32(2)
+	list.End()
+
+	sub := w.Field(20).Message()
+	sub.Field(1).Float64(1.5)
+	sub.End()
+
+	b, err := w.Build()
+	require.NoError(t, err)
+
+	s, err := String(b)
+	require.NoError(t, err)
+
+	assert.Contains(t, s, "big_message")
+	assert.Contains(t, s, "#1 int64 -1")
+	assert.Contains(t, s, "#2 bytes, size=4")
+	assert.Contains(t, s, "#10 list")
+	assert.Contains(t, s, "[1] int32 2")
+	assert.Contains(t, s, "#20 message")
+	assert.Contains(t, s, "#1 float64 1.5")
+	assert.Contains(t, s, "#300 true true")
+}
+
+func TestDump__should_return_error_offset_on_unsupported_type(t *testing.T) {
+	b := testSubmessage(t)
+	b[3] = 77
+
+	s, err := String(b)
+
+	var derr *Error
+	require.True(t, errors.As(err, &derr))
+	assert.Equal(t, 3, derr.Offset)
+	assert.Contains(t, s, "error: unsupported type 77")
+}
+
+func TestDump__should_return_error_offset_on_invalid_table_offset(t *testing.T) {
+	b := testSubmessage(t)
+	b[6] = 9 // Field offset > data size
+
+	_, err := String(b)
+
+	var derr *Error
+	require.True(t, errors.As(err, &derr))
+	assert.Equal(t, 5, derr.Offset)
+}
+
+func TestDump__should_return_error_on_truncated_data(t *testing.T) {
+	b := testSubmessage(t)
+
+	_, err := String(b[5:])
+
+	var derr *Error
+	require.True(t, errors.As(err, &derr))
+	assert.Equal(t, 3, derr.Offset)
+	assert.Contains(t, derr.Error(), "table size 3 exceeds available 2 bytes")
+}
+
+func TestDump__should_report_leading_bytes(t *testing.T) {
+	b := append([]byte{1, 2}, testSubmessage(t)...)
+
+	s, err := String(b)
+	require.NoError(t, err)
+	assert.Contains(t, s, "2 leading bytes before value")
+}
@@ -7,6 +7,7 @@ package encode
 import (
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
 	"github.com/basecomplextech/spec/internal/format"
 )
 
@@ -45,3 +46,27 @@ func EncodeInt64(b buffer.Buffer, v int64) (int, error) {
 
 	return n + 1, nil
 }
+
+// EncodeVarint encodes an int64 as a zigzag reverse varint, see [format.TypeVarint].
+func EncodeVarint(b buffer.Buffer, v int64) (int, error) {
+	p := [rvarint.MaxLen64]byte{}
+	n := rvarint.PutInt64(p[:], v)
+	off := rvarint.MaxLen64 - n
+
+	buf := b.Grow(n + 1)
+	copy(buf[:n], p[off:])
+	buf[n] = byte(format.TypeVarint)
+
+	return n + 1, nil
+}
+
+// VarintShorter returns true if a varint is shorter than a compactint for the value.
+func VarintShorter(v int64) bool {
+	return uvarintShorter(zigzag64(v))
+}
+
+// private
+
+func zigzag64(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
@@ -39,6 +39,32 @@ func EncodeListTable(b buffer.Buffer, dataSize int, table []format.ListElement)
 	return n, nil
 }
 
+// EncodePackedList encodes a packed list footer after count elements of elemSize,
+// the data size includes the elements and their padding, see [format.PackedSize].
+func EncodePackedList(b buffer.Buffer, dataSize int, count int, elemSize int, elemType format.Type) (int, error) {
+	if dataSize > format.MaxSize {
+		return 0, fmt.Errorf("encode: list too large, max size=%d, actual size=%d", format.MaxSize, dataSize)
+	}
+	if !format.IsPackable(elemType) {
+		return 0, fmt.Errorf("encode: list element type is not packable, type=%v", elemType)
+	}
+	if count*elemSize > dataSize {
+		return 0, fmt.Errorf("encode: invalid packed list, data size=%d, count=%d, elem size=%d",
+			dataSize, count, elemSize)
+	}
+
+	// Write data size, count, element size
+	n := encodeSize(b, uint32(dataSize))
+	n += encodeSize(b, uint32(count))
+	n += encodeSize(b, uint32(elemSize))
+
+	// Write element type and type
+	p := b.Grow(2)
+	p[0] = byte(elemType)
+	p[1] = byte(format.TypePackedList)
+	return n + 2, nil
+}
+
 // private
 
 func encodeListTable(b buffer.Buffer, table []format.ListElement, big bool) (int, error) {
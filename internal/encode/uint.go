@@ -7,6 +7,7 @@ package encode
 import (
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
 	"github.com/basecomplextech/spec/internal/format"
 )
 
@@ -45,3 +46,50 @@ func EncodeUint64(b buffer.Buffer, v uint64) (int, error) {
 
 	return n + 1, nil
 }
+
+// EncodeUvarint encodes a uint64 as a reverse varint, see [format.TypeUvarint].
+func EncodeUvarint(b buffer.Buffer, v uint64) (int, error) {
+	p := [rvarint.MaxLen64]byte{}
+	n := rvarint.PutUint64(p[:], v)
+	off := rvarint.MaxLen64 - n
+
+	buf := b.Grow(n + 1)
+	copy(buf[:n], p[off:])
+	buf[n] = byte(format.TypeUvarint)
+
+	return n + 1, nil
+}
+
+// UvarintShorter returns true if a varint is shorter than a compactint for the value.
+func UvarintShorter(v uint64) bool {
+	return uvarintShorter(v)
+}
+
+// private
+
+func uvarintShorter(v uint64) bool {
+	return uvarintSize(v) < compactSize(v)
+}
+
+// compactSize returns the size of a compactint, see [compactint.PutReverseUint64].
+func compactSize(v uint64) int {
+	switch {
+	case v <= 0xfc:
+		return 1
+	case v <= 0xffff:
+		return 3
+	case v <= 0xffffffff:
+		return 5
+	}
+	return 9
+}
+
+// uvarintSize returns the size of a varint, see [rvarint.PutUint64].
+func uvarintSize(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
@@ -20,11 +20,18 @@ const (
 //	+----------------+----------------+----------------+
 //	|    off0(2/4)   |    off1(2/4)   |    off2(2/4)   |
 //	+----------------+----------------+----------------+
+//
+// Packed lists have no table, see [NewPackedListTable].
 type ListTable struct {
 	table listTable
 
 	data uint32 // data size
 	big  bool   // small/big table format
+
+	// Packed
+	elemType Type   // packed element type or undefined
+	elemSize uint32 // packed element size
+	count    uint32 // packed element count
 }
 
 // ListElement specifies a value offset in a list byte array.
@@ -63,11 +70,39 @@ func NewListTable(table listTable, data uint32, big bool) ListTable {
 	}
 }
 
+// NewPackedListTable returns a packed list table, elements are the last count*elemSize data bytes.
+func NewPackedListTable(elemType Type, elemSize uint32, count uint32, data uint32) ListTable {
+	return ListTable{
+		data:     data,
+		elemType: elemType,
+		elemSize: elemSize,
+		count:    count,
+	}
+}
+
 // Len returns the number of elements in the table.
 func (t ListTable) Len() int {
+	if t.elemType != TypeUndefined {
+		return int(t.count)
+	}
 	return t.table.len(t.big)
 }
 
+// Packed returns true if the list is packed.
+func (t ListTable) Packed() bool {
+	return t.elemType != TypeUndefined
+}
+
+// ElemType returns the packed element type or undefined.
+func (t ListTable) ElemType() Type {
+	return t.elemType
+}
+
+// ElemSize returns the packed element size or 0.
+func (t ListTable) ElemSize() int {
+	return int(t.elemSize)
+}
+
 // DataSize returns the size of the list data.
 func (t ListTable) DataSize() uint32 {
 	return t.data
@@ -75,11 +110,18 @@ func (t ListTable) DataSize() uint32 {
 
 // Elements parses the table and returns a slice of elements.
 func (t ListTable) Elements() []ListElement {
+	if t.elemType != TypeUndefined {
+		return t.packedElements()
+	}
 	return t.table.elements(t.big)
 }
 
 // Offset returns an element start/end by an index or -1/-1.
 func (t ListTable) Offset(i int) (int, int) {
+	if t.elemType != TypeUndefined {
+		return t.packedOffset(i)
+	}
+
 	if t.big {
 		return t.table.offset_big(i)
 	} else {
@@ -87,6 +129,35 @@ func (t ListTable) Offset(i int) (int, int) {
 	}
 }
 
+// packed
+
+func (t ListTable) packedElements() []ListElement {
+	n := int(t.count)
+
+	result := make([]ListElement, 0, n)
+	for i := 0; i < n; i++ {
+		_, end := t.packedOffset(i)
+		result = append(result, ListElement{Offset: uint32(end)})
+	}
+	return result
+}
+
+func (t ListTable) packedOffset(i int) (int, int) {
+	n := int(t.count)
+
+	// Check count
+	switch {
+	case i < 0:
+		return -1, -1
+	case i >= n:
+		return -1, -1
+	}
+
+	size := int(t.elemSize)
+	start := int(t.data) - (n-i)*size
+	return start, start + size
+}
+
 // internal
 
 type listTable []byte
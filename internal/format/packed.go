@@ -0,0 +1,94 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package format
+
+import "github.com/basecomplextech/baselibrary/bin"
+
+// PackedList is a list of fixed-size elements of one type stored contiguously without a table.
+// The element type is stored once, the element offset is computed from its index.
+//
+//	+----------+------------------+-----------+-----------+-----------+-----------+---------+
+//	| pad(0-7) | elements(n*size) | datasize  |  count    | elemsize  | elemtype  | type    |
+//	|          |                  | (varint)  | (varint)  | (varint)  | (1)       | (1)     |
+//	+----------+------------------+-----------+-----------+-----------+-----------+---------+
+//
+// Numbers are stored as little-endian fixed-width values, bins as raw bytes,
+// structs as complete struct values of the same size.
+//
+// The writer pads the elements to their natural alignment relative to the buffer start,
+// the data size includes the padding, so readers can ignore it.
+
+// PackedSize returns the element size of a packable scalar type, or 0.
+// Structs are packable as well, but their size is specified by the first element.
+func PackedSize(t Type) int {
+	switch t {
+	case TypeInt16, TypeUint16:
+		return 2
+	case TypeInt32, TypeUint32, TypeFloat32:
+		return 4
+	case TypeInt64, TypeUint64, TypeFloat64:
+		return 8
+
+	case TypeBin64:
+		return 8
+	case TypeBin128:
+		return 16
+	case TypeBin256:
+		return 32
+	}
+	return 0
+}
+
+// PackedAlign returns the element alignment of a packable type, or 1.
+func PackedAlign(t Type) int {
+	switch t {
+	case TypeInt16, TypeUint16:
+		return 2
+	case TypeInt32, TypeUint32, TypeFloat32:
+		return 4
+	case TypeInt64, TypeUint64, TypeFloat64:
+		return 8
+	}
+	return 1
+}
+
+// IsPackable returns true if elements of the type can be stored in a packed list.
+func IsPackable(t Type) bool {
+	return t == TypeStruct || PackedSize(t) > 0
+}
+
+// PackedType returns a packed element type for a builtin number or bin type, or undefined.
+func PackedType[T any]() Type {
+	var zero T
+
+	switch any(zero).(type) {
+	case int16:
+		return TypeInt16
+	case int32:
+		return TypeInt32
+	case int64:
+		return TypeInt64
+
+	case uint16:
+		return TypeUint16
+	case uint32:
+		return TypeUint32
+	case uint64:
+		return TypeUint64
+
+	case float32:
+		return TypeFloat32
+	case float64:
+		return TypeFloat64
+
+	case bin.Bin64:
+		return TypeBin64
+	case bin.Bin128:
+		return TypeBin128
+	case bin.Bin256:
+		return TypeBin256
+	}
+	return TypeUndefined
+}
@@ -28,10 +28,16 @@ const (
 	TypeInt32 Type = 11
 	TypeInt64 Type = 12
 
+	// TypeVarint is a zigzag varint-encoded signed integer of any width.
+	TypeVarint Type = 13
+
 	TypeUint16 Type = 20
 	TypeUint32 Type = 21
 	TypeUint64 Type = 22
 
+	// TypeUvarint is a varint-encoded unsigned integer of any width.
+	TypeUvarint Type = 23
+
 	TypeFloat32 Type = 40
 	TypeFloat64 Type = 41
 
@@ -45,6 +51,9 @@ const (
 	TypeList    Type = 70
 	TypeBigList Type = 71
 
+	// TypePackedList is a list of fixed-size elements stored contiguously, see [PackedSize].
+	TypePackedList Type = 72
+
 	TypeMessage    Type = 80
 	TypeBigMessage Type = 81
 
@@ -61,10 +70,12 @@ func (t Type) Check() error {
 		TypeInt16,
 		TypeInt32,
 		TypeInt64,
+		TypeVarint,
 
 		TypeUint16,
 		TypeUint32,
 		TypeUint64,
+		TypeUvarint,
 
 		TypeFloat32,
 		TypeFloat64,
@@ -78,6 +89,7 @@ func (t Type) Check() error {
 
 		TypeList,
 		TypeBigList,
+		TypePackedList,
 
 		TypeMessage,
 		TypeBigMessage,
@@ -104,6 +116,8 @@ func (t Type) String() string {
 		return "int32"
 	case TypeInt64:
 		return "int64"
+	case TypeVarint:
+		return "varint"
 
 	case TypeUint16:
 		return "uint16"
@@ -111,6 +125,8 @@ func (t Type) String() string {
 		return "uint32"
 	case TypeUint64:
 		return "uint64"
+	case TypeUvarint:
+		return "uvarint"
 
 	case TypeFloat32:
 		return "float32"
@@ -133,6 +149,8 @@ func (t Type) String() string {
 		return "list"
 	case TypeBigList:
 		return "big_list"
+	case TypePackedList:
+		return "packed_list"
 
 	case TypeMessage:
 		return "message"
@@ -0,0 +1,314 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package breaking reports backward incompatible changes between two versions of a spec package.
+//
+// Messages are addressed by field tags and type codes, structs are encoded positionally,
+// so the checker compares messages by tags, enums by numbers and structs by field order.
+package breaking
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Kind is a breaking change kind.
+type Kind string
+
+const (
+	DefinitionRemoved     Kind = "definition-removed"
+	DefinitionTypeChanged Kind = "definition-type-changed"
+
+	FieldRemoved     Kind = "field-removed"
+	FieldTypeChanged Kind = "field-type-changed"
+	FieldTagChanged  Kind = "field-tag-changed"
+	FieldTagReused   Kind = "field-tag-reused"
+
+	EnumValueRemoved    Kind = "enum-value-removed"
+	EnumValueRenumbered Kind = "enum-value-renumbered"
+
+	StructLayoutChanged Kind = "struct-layout-changed"
+
+	MethodRemoved        Kind = "method-removed"
+	MethodTypeChanged    Kind = "method-type-changed"
+	MethodInputChanged   Kind = "method-input-changed"
+	MethodOutputChanged  Kind = "method-output-changed"
+	MethodChannelChanged Kind = "method-channel-changed"
+)
+
+// Change is a backward incompatible change.
+//
+// The location points to the new schema, or to the old schema when an element is removed.
+type Change struct {
+	Kind    Kind   `json:"kind"`
+	File    string `json:"file"` // File name in a package
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// String returns a change as "file:line: message (kind)".
+func (c Change) String() string {
+	return fmt.Sprintf("%v:%d: %v (%v)", c.File, c.Line, c.Message, c.Kind)
+}
+
+// Compare returns backward incompatible changes from an old to a new package, sorted by location.
+func Compare(old *model.Package, new *model.Package) []Change {
+	c := &comparer{
+		old:    old,
+		new:    new,
+		defs:   make(map[string]*model.Definition),
+		inputs: make(map[string]struct{}),
+	}
+
+	// Package definitions exclude generated requests and responses
+	for _, file := range new.Files {
+		for _, def := range file.Definitions {
+			c.defs[def.Name] = def
+		}
+	}
+
+	// Generated requests are only read by servers, so they may widen fields
+	for _, file := range old.Files {
+		for _, def := range file.Definitions {
+			if def.Type != model.DefinitionService {
+				continue
+			}
+			for _, m := range def.Service.Methods {
+				if m.Request != nil && m.Request.Kind == model.KindMessage && m.Request.Ref.Message.Generated {
+					c.inputs[m.Request.Ref.Name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	for _, file := range old.Files {
+		for _, def := range file.Definitions {
+			c.compareDefinition(def)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		a, b := c.changes[i], c.changes[j]
+		switch {
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		}
+		return a.Kind < b.Kind
+	})
+	return c.changes
+}
+
+// private
+
+type comparer struct {
+	old     *model.Package
+	new     *model.Package
+	defs    map[string]*model.Definition // New definitions including generated
+	inputs  map[string]struct{}          // Old generated method requests
+	changes []Change
+}
+
+func (c *comparer) report(kind Kind, file *model.File, line int, format string, a ...any) {
+	c.changes = append(c.changes, Change{
+		Kind:    kind,
+		File:    file.Name,
+		Line:    line,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (c *comparer) compareDefinition(old *model.Definition) {
+	new, ok := c.defs[old.Name]
+	if !ok {
+		// Removed generated requests and responses are reported as changed methods
+		if old.Type == model.DefinitionMessage && old.Message.Generated {
+			return
+		}
+
+		c.report(DefinitionRemoved, old.File, old.Line, "%v %v removed", old.Type, old.Name)
+		return
+	}
+	if new.Type != old.Type {
+		c.report(DefinitionTypeChanged, new.File, new.Line, "%v %v changed to %v",
+			old.Type, old.Name, new.Type)
+		return
+	}
+
+	switch old.Type {
+	case model.DefinitionEnum:
+		c.compareEnum(old, new)
+	case model.DefinitionMessage:
+		c.compareMessage(old, new)
+	case model.DefinitionStruct:
+		c.compareStruct(old, new)
+	case model.DefinitionService:
+		c.compareService(old, new)
+	}
+}
+
+// enum
+
+func (c *comparer) compareEnum(old *model.Definition, new *model.Definition) {
+	for _, v := range old.Enum.Values {
+		nv, ok := new.Enum.ValueNames[v.Name]
+		switch {
+		case ok && nv.Number != v.Number:
+			c.report(EnumValueRenumbered, new.File, nv.Line, "enum value %v.%v renumbered from %d to %d",
+				old.Name, v.Name, v.Number, nv.Number)
+
+		case !ok:
+			// Renamed values are compatible, numbers are encoded
+			if _, ok := new.Enum.ValueNumbers[v.Number]; ok {
+				continue
+			}
+			c.report(EnumValueRemoved, old.File, v.Line, "enum value %v.%v removed", old.Name, v.Name)
+		}
+	}
+}
+
+// message
+
+func (c *comparer) compareMessage(old *model.Definition, new *model.Definition) {
+	oldFields := old.Message.Fields
+	newFields := new.Message.Fields
+	_, input := c.inputs[old.Name]
+
+	for _, f := range oldFields.List {
+		nf, ok := newFields.Tags[f.Tag]
+		if !ok {
+			if moved, ok := newFields.Names[f.Name]; ok {
+				c.report(FieldTagChanged, new.File, moved.Line, "field %v.%v tag changed from %d to %d",
+					old.Name, f.Name, f.Tag, moved.Tag)
+				continue
+			}
+
+			c.report(FieldRemoved, old.File, f.Line, "field %v.%v removed, tag %d",
+				old.Name, f.Name, f.Tag)
+			continue
+		}
+
+		if nf.Name != f.Name {
+			c.report(FieldTagReused, new.File, nf.Line, "field %v.%v reuses tag %d of field %v",
+				new.Name, nf.Name, f.Tag, f.Name)
+			continue
+		}
+
+		ot, nt := c.typeName(c.old, f.Type), c.typeName(c.new, nf.Type)
+		if ot != nt && !(input && widened(f.Type, nf.Type)) {
+			c.report(FieldTypeChanged, new.File, nf.Line, "field %v.%v type changed from %v to %v",
+				old.Name, f.Name, ot, nt)
+		}
+	}
+}
+
+// struct
+
+func (c *comparer) compareStruct(old *model.Definition, new *model.Definition) {
+	oldFields := old.Struct.Fields.Values()
+	newFields := new.Struct.Fields.Values()
+
+	ol := make([]string, 0, len(oldFields))
+	for _, f := range oldFields {
+		ol = append(ol, c.typeName(c.old, f.Type))
+	}
+	nl := make([]string, 0, len(newFields))
+	for _, f := range newFields {
+		nl = append(nl, c.typeName(c.new, f.Type))
+	}
+
+	if len(ol) != len(nl) {
+		c.report(StructLayoutChanged, new.File, new.Line, "struct %v layout changed from %v to %v",
+			old.Name, ol, nl)
+		return
+	}
+
+	for i := range ol {
+		if ol[i] != nl[i] {
+			c.report(StructLayoutChanged, new.File, newFields[i].Line,
+				"struct %v field %d type changed from %v to %v", old.Name, i, ol[i], nl[i])
+		}
+	}
+}
+
+// service
+
+func (c *comparer) compareService(old *model.Definition, new *model.Definition) {
+	for _, m := range old.Service.Methods {
+		nm, ok := new.Service.MethodNames[m.Name]
+		if !ok {
+			c.report(MethodRemoved, old.File, m.Line, "method %v.%v removed", old.Name, m.Name)
+			continue
+		}
+
+		name := old.Name + "." + m.Name
+		if m.Type != nm.Type {
+			c.report(MethodTypeChanged, new.File, nm.Line, "method %v type changed from %v to %v",
+				name, m.Type, nm.Type)
+			continue
+		}
+
+		// Generated requests and responses are compared as messages
+		if ot, nt := c.typeName(c.old, m.Request), c.typeName(c.new, nm.Request); ot != nt {
+			c.report(MethodInputChanged, new.File, nm.Line, "method %v input changed from %v to %v",
+				name, ot, nt)
+		}
+
+		ot, nt := c.typeName(c.old, m.Response), c.typeName(c.new, nm.Response)
+		if m.Type == model.MethodType_Subservice {
+			ot, nt = c.typeName(c.old, m.Subservice), c.typeName(c.new, nm.Subservice)
+		}
+		if ot != nt {
+			c.report(MethodOutputChanged, new.File, nm.Line, "method %v output changed from %v to %v",
+				name, ot, nt)
+		}
+
+		if m.Channel != nil && nm.Channel != nil {
+			ot := c.typeName(c.old, m.Channel.In) + ", " + c.typeName(c.old, m.Channel.Out)
+			nt := c.typeName(c.new, nm.Channel.In) + ", " + c.typeName(c.new, nm.Channel.Out)
+			if ot != nt {
+				c.report(MethodChannelChanged, new.File, nm.Line, "method %v channel changed from (%v) to (%v)",
+					name, ot, nt)
+			}
+		}
+	}
+}
+
+// type
+
+// typeName returns a type name comparable across package versions,
+// local types are unqualified, imported types are qualified with package ids.
+func (c *comparer) typeName(pkg *model.Package, typ *model.Type) string {
+	if typ == nil {
+		return "none"
+	}
+
+	switch typ.Kind {
+	case model.KindList:
+		return "[]" + c.typeName(pkg, typ.Element)
+	case model.KindEnum, model.KindMessage, model.KindStruct, model.KindService:
+		if typ.Ref.Package == pkg {
+			return typ.Ref.Name
+		}
+		return typ.Ref.Package.ID + "." + typ.Ref.Name
+	}
+	return typ.Kind.String()
+}
+
+// widened returns true if an integer field is widened, i.e. int16/int32 to int64
+// or uint16/uint32 to uint64. Wider decoders accept narrower values, but not vice versa,
+// so widening is compatible only in method requests. Lists are never widened,
+// because packed lists change their element size.
+func widened(old *model.Type, new *model.Type) bool {
+	switch new.Kind {
+	case model.KindInt64:
+		return old.Kind == model.KindInt16 || old.Kind == model.KindInt32
+	case model.KindUint64:
+		return old.Kind == model.KindUint16 || old.Kind == model.KindUint32
+	}
+	return false
+}
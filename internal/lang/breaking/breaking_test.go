@@ -0,0 +1,225 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package breaking
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCompare(t *testing.T, old string, new string) []Change {
+	return Compare(langtest.Source(t, old), langtest.Source(t, new))
+}
+
+func testKinds(changes []Change) []Kind {
+	var kinds []Kind
+	for _, c := range changes {
+		kinds = append(kinds, c.Kind)
+	}
+	return kinds
+}
+
+// Compare
+
+func TestCompare__should_return_no_changes_for_compatible_changes(t *testing.T) {
+	changes := testCompare(t, `
+enum Enum {
+	UNDEFINED = 0;
+	ONE       = 1;
+}
+
+message Message {
+	a int32 1;
+}
+
+struct Struct {
+	a int32;
+}
+
+service Service {
+	method(a int32 1) (b int32 1);
+}
+`, `
+enum Enum {
+	UNDEFINED = 0;
+	FIRST     = 1;
+	TWO       = 2;
+}
+
+message Message {
+	a int32  1;
+	b string 2;
+}
+
+struct Struct {
+	renamed int32;
+}
+
+message Message2 {}
+
+service Service {
+	method(a int32 1, c string 2) (b int32 1);
+	method2() ();
+}
+`)
+	assert.Empty(t, changes)
+}
+
+func TestCompare__should_report_removed_definitions(t *testing.T) {
+	changes := testCompare(t, `
+message Message {}
+struct Struct { a int32; }
+`, `
+struct Message { a int32; }
+`)
+	assert.Equal(t, []Kind{DefinitionTypeChanged, DefinitionRemoved}, testKinds(changes))
+}
+
+func TestCompare__should_report_message_field_changes(t *testing.T) {
+	changes := testCompare(t, `
+message Message {
+	a int32  1;
+	b string 2;
+	c int64  3;
+	d bool   4;
+}
+`, `
+message Message {
+	a string 1;
+	x bool   2;
+	d bool   5;
+}
+`)
+	assert.Equal(t, []Kind{FieldTypeChanged, FieldTagReused, FieldRemoved, FieldTagChanged}, testKinds(changes))
+}
+
+func TestCompare__should_not_report_widened_request_fields(t *testing.T) {
+	changes := testCompare(t, `
+service Service {
+	method(a int16 1, b int32 2, c uint16 3, d uint32 4) (e int32 1);
+}
+`, `
+service Service {
+	method(a int64 1, b int64 2, c uint64 3, d uint64 4) (e int32 1);
+}
+`)
+	assert.Empty(t, changes)
+}
+
+func TestCompare__should_report_widened_fields_outside_requests(t *testing.T) {
+	changes := testCompare(t, `
+message Message {
+	a int32  1;
+	b uint32 2;
+}
+
+struct Struct {
+	a int32;
+}
+
+service Service {
+	method(a int32 1) (b int32 1);
+}
+`, `
+message Message {
+	a int64  1;
+	b uint64 2;
+}
+
+struct Struct {
+	a int64;
+}
+
+service Service {
+	method(a int32 1) (b int64 1);
+}
+`)
+	assert.Equal(t, []Kind{
+		FieldTypeChanged,
+		FieldTypeChanged,
+		StructLayoutChanged,
+		FieldTypeChanged,
+	}, testKinds(changes))
+}
+
+func TestCompare__should_report_widened_list_elements(t *testing.T) {
+	changes := testCompare(t, `
+service Service {
+	method(a []int32 1) (b int32 1);
+}
+`, `
+service Service {
+	method(a []int64 1) (b int32 1);
+}
+`)
+	assert.Equal(t, []Kind{FieldTypeChanged}, testKinds(changes))
+}
+
+func TestCompare__should_report_enum_changes(t *testing.T) {
+	changes := testCompare(t, `
+enum Enum {
+	UNDEFINED = 0;
+	ONE       = 1;
+	TWO       = 2;
+}
+`, `
+enum Enum {
+	UNDEFINED = 0;
+	ONE       = 3;
+}
+`)
+	assert.Equal(t, []Kind{EnumValueRenumbered, EnumValueRemoved}, testKinds(changes))
+}
+
+func TestCompare__should_report_struct_layout_changes(t *testing.T) {
+	changes := testCompare(t, `
+struct Struct1 { a int32; b int64; }
+struct Struct2 { a int32; b int64; }
+`, `
+struct Struct1 { b int64; a int32; }
+struct Struct2 { a int32; b int64; c int64; }
+`)
+	assert.Equal(t, []Kind{StructLayoutChanged, StructLayoutChanged, StructLayoutChanged}, testKinds(changes))
+}
+
+func TestCompare__should_report_method_changes(t *testing.T) {
+	changes := testCompare(t, `
+message Request {}
+message Response {}
+message In {}
+message Out {}
+
+service Service {
+	method1(Request) Response;
+	method2(Request) (<-In, Out->) Response;
+	method3(a int32 1) (b int32 1);
+	method4(Request) oneway;
+	method5(Request) Response;
+}
+`, `
+message Request {}
+message Response {}
+message In {}
+message Out {}
+
+service Service {
+	method1(Response) Request;
+	method2(Request) (<-Out, In->) Response;
+	method3(a string 1) (b string 1);
+	method4(Request) Response;
+}
+`)
+	assert.Equal(t, []Kind{
+		MethodInputChanged,
+		MethodOutputChanged,
+		MethodChannelChanged,
+		FieldTypeChanged,
+		FieldTypeChanged,
+		MethodTypeChanged,
+		MethodRemoved,
+	}, testKinds(changes))
+}
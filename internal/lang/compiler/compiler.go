@@ -8,11 +8,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/module"
 	"github.com/basecomplextech/spec/internal/lang/parser"
 )
 
+// Compiler compiles packages, it is safe for concurrent use.
+//
+// Compiled packages are cached and shared by subsequent compilations,
+// so each imported package is compiled once.
 type Compiler interface {
 	// Compile parses, compiles and returns a package from a directory.
 	Compile(path string) (*model.Package, error)
@@ -31,6 +37,19 @@ type compiler struct {
 	opts   Options
 	parser parser.Parser
 	paths  []string // import paths
+
+	mu       sync.Mutex
+	contexts map[string]*moduleContext // package contexts by manifest paths, empty path without module
+}
+
+// moduleContext is a package context shared by packages in a module.
+//
+// Packages in different modules are compiled in parallel, packages in one module
+// are compiled one at a time, because they share compiled imports.
+type moduleContext struct {
+	mu       sync.Mutex
+	x        *model.Context
+	resolver *module.Resolver // nil without module
 }
 
 func newCompiler(opts Options) (*compiler, error) {
@@ -50,6 +69,8 @@ func newCompiler(opts Options) (*compiler, error) {
 		opts:   opts,
 		parser: parser,
 		paths:  paths,
+
+		contexts: make(map[string]*moduleContext),
 	}
 	return c, nil
 }
@@ -69,12 +90,54 @@ func (c *compiler) Compile(dir string) (*model.Package, error) {
 		}
 	}
 
-	x := model.NewContext(c.parser, c.paths)
-	return x.Compile(id, dir)
+	c.mu.Lock()
+	mc, err := c.context(dir)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	// Use module package ids
+	if mc.resolver != nil {
+		if mid, ok := mc.resolver.PackageID(dir); ok {
+			id = mid
+		}
+	}
+	return mc.x.Compile(id, dir)
 }
 
 // private
 
+// context returns a package context for a directory, imports are resolved in its module.
+func (c *compiler) context(dir string) (*moduleContext, error) {
+	path, err := module.Find(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mc, ok := c.contexts[path]
+	if ok {
+		return mc, nil
+	}
+
+	mc = &moduleContext{x: model.NewContext(c.parser, c.paths)}
+	if path != "" {
+		m, err := module.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		mc.resolver = module.NewResolver(m)
+		mc.x.Resolver = mc.resolver
+	}
+
+	c.contexts[path] = mc
+	return mc, nil
+}
+
 func getCurrentDirectoryName() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
@@ -5,9 +5,13 @@
 package compiler
 
 import (
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -152,7 +156,7 @@ func TestCompiler__should_compile_file_definitions(t *testing.T) {
 	file1 := pkg.Files[1]
 
 	assert.Len(t, file0.Definitions, 1)
-	assert.Len(t, file1.Definitions, 4)
+	assert.Len(t, file1.Definitions, 6)
 }
 
 func TestCompiler__should_compile_package_definitions(t *testing.T) {
@@ -163,12 +167,13 @@ func TestCompiler__should_compile_package_definitions(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	assert.Len(t, pkg.Definitions, 5)
+	assert.Len(t, pkg.Definitions, 7)
 
 	assert.Contains(t, pkg.DefinitionNames, "Enum")
 	assert.Contains(t, pkg.DefinitionNames, "Message")
 	assert.Contains(t, pkg.DefinitionNames, "Submessage")
 	assert.Contains(t, pkg.DefinitionNames, "Struct")
+	assert.Contains(t, pkg.DefinitionNames, "Record")
 }
 
 // Enums
@@ -312,6 +317,47 @@ func TestCompiler__should_compile_struct_field_names(t *testing.T) {
 	assert.True(t, str.Fields.Contains("value"))
 }
 
+func TestCompiler__should_compile_key_struct(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := pkg.Files[1].DefinitionNames["Record"]
+	assert.Equal(t, model.DefinitionStruct, def.Type)
+	assert.True(t, def.Struct.Key)
+
+	def1 := pkg.Files[1].DefinitionNames["Struct"]
+	assert.False(t, def1.Struct.Key)
+}
+
+func TestCompiler__should_return_error_when_key_struct_field_invalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	src := `key struct Key {
+    id      bin128;
+    value   Value;
+}
+
+struct Value {
+    a int32;
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testCompiler(t)
+	_, err := c.Compile(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key structs support only other key structs")
+
+	_, line, _ := syntax.Position(err)
+	assert.Equal(t, 3, line)
+}
+
 // Types
 
 func TestCompiler__should_compile_builtin_type(t *testing.T) {
@@ -548,3 +594,117 @@ func TestCompiler__should_generate_response_from_fields(t *testing.T) {
 	assert.Equal(t, "ServiceMethod11Response", resp.Name)
 	assert.True(t, resp.Ref.Message.Generated)
 }
+
+// Errors
+
+func TestCompiler__should_return_error_positions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	src := `message Message {
+    a string 1;
+    b Unknown 2;
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testCompiler(t)
+	_, err := c.Compile(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "type not found: Unknown")
+
+	path1, line, _ := syntax.Position(err)
+	assert.Equal(t, path, path1)
+	assert.Equal(t, 3, line)
+}
+
+func TestCompiler__should_return_syntax_error_positions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	src := `message Message {
+    a string 1;
+    b string
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testCompiler(t)
+	_, err := c.Compile(dir)
+	require.Error(t, err)
+
+	path1, line, column := syntax.Position(err)
+	assert.Equal(t, path, path1)
+	assert.Equal(t, 4, line)
+	assert.Equal(t, 1, column)
+}
+
+// Modules
+
+func TestCompiler__should_resolve_module_imports(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"spec.mod":       "module github.com/example/api\n",
+		"ids/ids.spec":   "struct ID {\n    value int64;\n}\n",
+		"users/one.spec": "import (\n    \"github.com/example/api/ids\"\n)\n\nmessage User {\n    id ids.ID 1;\n}\n",
+	}
+	for name, text := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+	}
+
+	c, err := newCompiler(Options{})
+	require.NoError(t, err)
+
+	pkg, err := c.Compile(filepath.Join(root, "users"))
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/example/api/users", pkg.ID)
+
+	imp := pkg.Files[0].Imports[0]
+	assert.Equal(t, "github.com/example/api/ids", imp.Package.ID)
+	assert.Equal(t, filepath.Join(root, "ids"), imp.Package.Path)
+}
+
+func TestCompiler__should_compile_packages_concurrently(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a/spec.mod":       "module github.com/example/a\n",
+		"a/ids/ids.spec":   "struct ID {\n    value int64;\n}\n",
+		"a/users/one.spec": "import (\n    \"github.com/example/a/ids\"\n)\n\nmessage User {\n    id ids.ID 1;\n}\n",
+		"a/teams/one.spec": "import (\n    \"github.com/example/a/ids\"\n)\n\nmessage Team {\n    id ids.ID 1;\n}\n",
+		"b/spec.mod":       "module github.com/example/b\n",
+		"b/items/one.spec": "message Item {\n    name string 1;\n}\n",
+	}
+	for name, text := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+	}
+
+	c, err := newCompiler(Options{})
+	require.NoError(t, err)
+
+	dirs := []string{"a/users", "a/teams", "b/items"}
+	pkgs := make([]*model.Package, len(dirs))
+	errs := make([]error, len(dirs))
+
+	wg := sync.WaitGroup{}
+	for i, dir := range dirs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pkgs[i], errs[i] = c.Compile(filepath.Join(root, dir))
+		}()
+	}
+	wg.Wait()
+
+	for i := range dirs {
+		require.NoError(t, errs[i])
+	}
+	assert.Equal(t, "github.com/example/a/users", pkgs[0].ID)
+	assert.Equal(t, "github.com/example/b/items", pkgs[2].ID)
+	assert.Same(t, pkgs[0].Files[0].Imports[0].Package, pkgs[1].Files[0].Imports[0].Package)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package doc renders compiled spec packages into static Markdown or HTML documentation.
+//
+// Each package is rendered into a page with its enums, messages, structs and services,
+// and an index page lists all packages. Imported packages are rendered as well,
+// so types from imported packages link to their definitions.
+//
+// Pages are named by package ids with slashes replaced by dots, i.e. "api.users.md".
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Format is an output format.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// Options specify the documentation options.
+type Options struct {
+	Format Format // Output format, defaults to markdown
+}
+
+// File is a rendered documentation page.
+type File struct {
+	Name    string // File name relative to the output directory
+	Content []byte
+}
+
+// Render renders packages, their imports and an index page.
+func Render(pkgs []*model.Package, opts Options) ([]File, error) {
+	m, err := newMarkup(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	all := model.Transitive(pkgs)
+	ids := make(map[string]struct{}, len(all))
+	for _, pkg := range all {
+		ids[pkg.ID] = struct{}{}
+	}
+
+	files := make([]File, 0, len(all)+1)
+	files = append(files, File{Name: "index" + m.ext(), Content: renderIndex(m, all)})
+
+	for _, pkg := range all {
+		m, _ := newMarkup(opts.Format)
+		w := &pageWriter{m: m, pkg: pkg, ids: ids}
+		w.page()
+
+		files = append(files, File{Name: pageName(pkg.ID) + m.ext(), Content: m.bytes()})
+	}
+	return files, nil
+}
+
+// Write renders packages and writes the pages into an output directory.
+func Write(pkgs []*model.Package, out string, opts Options) error {
+	files, err := Render(pkgs, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0777); err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(out, file.Name)
+		if err := os.WriteFile(path, file.Content, 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// private
+
+func renderIndex(m markup, pkgs []*model.Package) []byte {
+	m.begin("Packages")
+
+	rows := make([][]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		href := pageName(pkg.ID) + m.ext()
+		rows = append(rows, []string{
+			m.link(m.text(pkg.Name), href),
+			m.code(pkg.ID),
+			m.text(strings.Join(definitionCounts(pkg), ", ")),
+		})
+	}
+	m.table([]string{"Package", "ID", "Definitions"}, rows)
+
+	m.end()
+	return m.bytes()
+}
+
+// pageName returns a page name without an extension, relative path elements are skipped.
+func pageName(id string) string {
+	var parts []string
+	for _, part := range strings.Split(id, "/") {
+		switch part {
+		case "", ".", "..":
+			continue
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "package"
+	}
+	return strings.Join(parts, ".")
+}
+
+// definitionCounts returns "2 messages, 1 service" like counts.
+func definitionCounts(pkg *model.Package) []string {
+	counts := make(map[model.DefinitionType]int)
+	for _, def := range pkg.Definitions {
+		counts[def.Type]++
+	}
+
+	var result []string
+	for _, s := range sections {
+		n := counts[s.typ]
+		switch {
+		case n == 1:
+			result = append(result, "1 "+s.singular)
+		case n > 1:
+			result = append(result, strconv.Itoa(n)+" "+strings.ToLower(s.title))
+		}
+	}
+	return result
+}
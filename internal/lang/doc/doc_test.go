@@ -0,0 +1,144 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRender(t *testing.T, pkgs []*model.Package, format Format) map[string]string {
+	files, err := Render(pkgs, Options{Format: format})
+	require.NoError(t, err)
+
+	result := make(map[string]string, len(files))
+	for _, file := range files {
+		result[file.Name] = string(file.Content)
+	}
+	return result
+}
+
+// Render
+
+func TestRender__should_render_packages_and_imports(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+	files := testRender(t, []*model.Package{pkg}, FormatMarkdown)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"index.md",
+		"pkg4.md",
+		"pkg1.md",
+		"pkg2.md",
+		"pkg3.pkg3a.md",
+	}, names)
+
+	index := files["index.md"]
+	assert.Contains(t, index, "| [pkg1](pkg1.md) | `pkg1` | 1 enum, 2 messages, 4 structs |")
+}
+
+func TestRender__should_render_services(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+	page := testRender(t, []*model.Package{pkg}, FormatMarkdown)["pkg4.md"]
+
+	assert.Contains(t, page, "<a id=\"Service.method20\"></a>\n\n#### `method20`\n\n"+
+		"```\nmethod20(a int64 1, b float64 2, c bool 3) (<-In, Out->) (a int64 1, b float64 2, c bool 3)\n```\n\n"+
+		"Method20 doc comment.\n\n")
+	assert.Contains(t, page, "- In: [`In`](#In), sent by the client\n- Out: [`Out`](#Out), sent by the server\n")
+	assert.Contains(t, page, "Subservice: [`Subservice`](#Subservice)")
+	assert.Contains(t, page, "- subservice [`Subservice`](#Subservice)\n")
+	assert.Contains(t, page, "### subservice Subservice")
+	assert.Contains(t, page, "Oneway, the client does not wait for a response.")
+	assert.Contains(t, page, "| 60 | `a60` | [`pkg1.Enum`](pkg1.md#Enum) |  |")
+	assert.Contains(t, page, "| 72 | `a72` | `[]`[`pkg1.Struct`](pkg1.md#Struct) |  |")
+}
+
+func TestRender__should_render_definitions(t *testing.T) {
+	pkg := langtest.Source(t, `
+// Status is a user status.
+enum Status {
+    // Unknown status.
+    UNKNOWN = 0;
+    ACTIVE = 1;
+}
+
+// Point is a point.
+struct Point {
+    // X coordinate.
+    x int32;
+    y int32;
+}
+
+message User {
+    // User name,
+    // may be empty.
+    name string 1;
+    status Status 2;
+    points []Point 3;
+}
+`)
+	page := testRender(t, []*model.Package{pkg}, FormatMarkdown)["test.md"]
+
+	assert.Contains(t, page, "# Package test\n\n`test`\n\n")
+	assert.Contains(t, page, "- enum [`Status`](#Status)\n")
+	assert.Contains(t, page, "<a id=\"Status\"></a>\n\n### enum Status\n\nStatus is a user status.\n\n")
+	assert.Contains(t, page, "| `UNKNOWN` | 0 | Unknown status. |\n| `ACTIVE` | 1 |  |\n")
+	assert.Contains(t, page, "| 0 | `x` | `int32` | X coordinate. |\n")
+	assert.Contains(t, page, "| 1 | `name` | `string` | User name, may be empty. |\n")
+	assert.Contains(t, page, "| 2 | `status` | [`Status`](#Status) |  |\n")
+	assert.Contains(t, page, "| 3 | `points` | `[]`[`Point`](#Point) |  |\n")
+}
+
+func TestRender__should_render_key_structs(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	page := testRender(t, []*model.Package{pkg}, FormatMarkdown)["pkg1.md"]
+
+	assert.Contains(t, page, "- key struct [`Record`](#Record)\n")
+	assert.Contains(t, page, "### key struct Record")
+	assert.Contains(t, page, "- struct [`Struct`](#Struct)\n")
+}
+
+func TestRender__should_render_html(t *testing.T) {
+	pkg := langtest.Source(t, `
+// Returns a < b & c.
+message Compare {
+    a int32 1;
+}
+`)
+	page := testRender(t, []*model.Package{pkg}, FormatHTML)["test.html"]
+
+	assert.Contains(t, page, "<title>Package test</title>")
+	assert.Contains(t, page, "<h3 id=\"Compare\">message Compare</h3>\n<p>Returns a &lt; b &amp; c.</p>\n")
+	assert.Contains(t, page, "<tr><td>1</td><td><code>a</code></td><td><code>int32</code></td><td></td></tr>")
+}
+
+func TestRender__should_return_error_on_unknown_format(t *testing.T) {
+	_, err := Render(nil, Options{Format: "pdf"})
+	assert.EqualError(t, err, `unknown doc format "pdf"`)
+}
+
+// Write
+
+func TestWrite__should_write_pages(t *testing.T) {
+	pkg := langtest.Package(t, "pkg2")
+	out := filepath.Join(t.TempDir(), "docs")
+
+	err := Write([]*model.Package{pkg}, out, Options{})
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(out, "index.md"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(out, "pkg2.md"))
+	assert.NoError(t, err)
+}
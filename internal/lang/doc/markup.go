@@ -0,0 +1,229 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// markup writes pages in an output format.
+//
+// Block methods write to the page, inline methods return formatted text
+// which is passed to block methods as is.
+type markup interface {
+	// ext returns a page file extension.
+	ext() string
+
+	// bytes returns the written page.
+	bytes() []byte
+
+	// blocks
+
+	begin(title string)
+	end()
+	heading(level int, anchor string, text string)
+	para(text string)
+	doc(text string)
+	pre(text string)
+	list(items []string)
+	table(header []string, rows [][]string)
+
+	// inline
+
+	text(s string) string
+	code(s string) string
+	link(text string, href string) string
+	inlineDoc(s string) string
+}
+
+func newMarkup(format Format) (markup, error) {
+	switch format {
+	case FormatMarkdown, "":
+		return &markdown{}, nil
+	case FormatHTML:
+		return &htmlMarkup{}, nil
+	}
+	return nil, fmt.Errorf("unknown doc format %q", format)
+}
+
+// markdown
+
+type markdown struct {
+	b bytes.Buffer
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `&lt;`,
+	">", `&gt;`,
+	"|", `\|`,
+)
+
+func (m *markdown) ext() string   { return ".md" }
+func (m *markdown) bytes() []byte { return m.b.Bytes() }
+
+func (m *markdown) begin(title string) {
+	fmt.Fprintf(&m.b, "# %v\n\n", m.text(title))
+}
+
+func (m *markdown) end() {}
+
+func (m *markdown) heading(level int, anchor string, text string) {
+	if anchor != "" {
+		fmt.Fprintf(&m.b, "<a id=\"%v\"></a>\n\n", html.EscapeString(anchor))
+	}
+	fmt.Fprintf(&m.b, "%v %v\n\n", strings.Repeat("#", level), text)
+}
+
+func (m *markdown) para(text string) {
+	fmt.Fprintf(&m.b, "%v\n\n", text)
+}
+
+func (m *markdown) doc(text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(&m.b, "%v\n\n", strings.TrimSpace(text))
+}
+
+func (m *markdown) pre(text string) {
+	fmt.Fprintf(&m.b, "```\n%v\n```\n\n", text)
+}
+
+func (m *markdown) list(items []string) {
+	for _, item := range items {
+		fmt.Fprintf(&m.b, "- %v\n", item)
+	}
+	m.b.WriteString("\n")
+}
+
+func (m *markdown) table(header []string, rows [][]string) {
+	fmt.Fprintf(&m.b, "| %v |\n", strings.Join(header, " | "))
+	fmt.Fprintf(&m.b, "|%v\n", strings.Repeat(" --- |", len(header)))
+	for _, row := range rows {
+		fmt.Fprintf(&m.b, "| %v |\n", strings.Join(row, " | "))
+	}
+	m.b.WriteString("\n")
+}
+
+func (m *markdown) text(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func (m *markdown) code(s string) string {
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+func (m *markdown) link(text string, href string) string {
+	return fmt.Sprintf("[%v](%v)", text, href)
+}
+
+func (m *markdown) inlineDoc(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// html
+
+type htmlMarkup struct {
+	b bytes.Buffer
+}
+
+const htmlStyle = `body { font-family: sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; }
+code { font-family: monospace; }`
+
+func (m *htmlMarkup) ext() string   { return ".html" }
+func (m *htmlMarkup) bytes() []byte { return m.b.Bytes() }
+
+func (m *htmlMarkup) begin(title string) {
+	title = html.EscapeString(title)
+
+	m.b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n")
+	m.b.WriteString("<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&m.b, "<title>%v</title>\n", title)
+	fmt.Fprintf(&m.b, "<style>\n%v\n</style>\n", htmlStyle)
+	m.b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&m.b, "<h1>%v</h1>\n", title)
+}
+
+func (m *htmlMarkup) end() {
+	m.b.WriteString("</body>\n</html>\n")
+}
+
+func (m *htmlMarkup) heading(level int, anchor string, text string) {
+	if anchor == "" {
+		fmt.Fprintf(&m.b, "<h%d>%v</h%d>\n", level, text, level)
+		return
+	}
+	fmt.Fprintf(&m.b, "<h%d id=\"%v\">%v</h%d>\n", level, html.EscapeString(anchor), text, level)
+}
+
+func (m *htmlMarkup) para(text string) {
+	fmt.Fprintf(&m.b, "<p>%v</p>\n", text)
+}
+
+func (m *htmlMarkup) doc(text string) {
+	for _, p := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			m.para(html.EscapeString(p))
+		}
+	}
+}
+
+func (m *htmlMarkup) pre(text string) {
+	fmt.Fprintf(&m.b, "<pre><code>%v</code></pre>\n", html.EscapeString(text))
+}
+
+func (m *htmlMarkup) list(items []string) {
+	m.b.WriteString("<ul>\n")
+	for _, item := range items {
+		fmt.Fprintf(&m.b, "<li>%v</li>\n", item)
+	}
+	m.b.WriteString("</ul>\n")
+}
+
+func (m *htmlMarkup) table(header []string, rows [][]string) {
+	m.b.WriteString("<table>\n<tr>")
+	for _, h := range header {
+		fmt.Fprintf(&m.b, "<th>%v</th>", h)
+	}
+	m.b.WriteString("</tr>\n")
+
+	for _, row := range rows {
+		m.b.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&m.b, "<td>%v</td>", cell)
+		}
+		m.b.WriteString("</tr>\n")
+	}
+	m.b.WriteString("</table>\n")
+}
+
+func (m *htmlMarkup) text(s string) string {
+	return html.EscapeString(s)
+}
+
+func (m *htmlMarkup) code(s string) string {
+	return "<code>" + html.EscapeString(s) + "</code>"
+}
+
+func (m *htmlMarkup) link(text string, href string) string {
+	return fmt.Sprintf("<a href=\"%v\">%v</a>", html.EscapeString(href), text)
+}
+
+func (m *htmlMarkup) inlineDoc(s string) string {
+	return html.EscapeString(strings.Join(strings.Fields(s), " "))
+}
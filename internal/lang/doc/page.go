@@ -0,0 +1,298 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package doc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// section groups package definitions of a type.
+type section struct {
+	typ      model.DefinitionType
+	title    string
+	singular string
+}
+
+var sections = []section{
+	{model.DefinitionEnum, "Enums", "enum"},
+	{model.DefinitionMessage, "Messages", "message"},
+	{model.DefinitionStruct, "Structs", "struct"},
+	{model.DefinitionService, "Services", "service"},
+}
+
+// pageWriter writes a package page.
+type pageWriter struct {
+	m   markup
+	pkg *model.Package
+	ids map[string]struct{} // Rendered package ids
+}
+
+func (w *pageWriter) page() {
+	m := w.m
+	m.begin("Package " + w.pkg.Name)
+	m.para(m.code(w.pkg.ID))
+
+	w.imports()
+	w.contents()
+
+	for _, s := range sections {
+		defs := w.definitions(s.typ)
+		if len(defs) == 0 {
+			continue
+		}
+
+		m.heading(2, "", m.text(s.title))
+		for _, def := range defs {
+			w.definition(def)
+		}
+	}
+	m.end()
+}
+
+func (w *pageWriter) imports() {
+	seen := make(map[string]struct{})
+	var items []string
+
+	for _, file := range w.pkg.Files {
+		for _, imp := range file.Imports {
+			if _, ok := seen[imp.ID]; ok {
+				continue
+			}
+			seen[imp.ID] = struct{}{}
+			items = append(items, w.packageLink(imp.ID))
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	w.m.heading(2, "", "Imports")
+	w.m.list(items)
+}
+
+func (w *pageWriter) contents() {
+	m := w.m
+	var items []string
+
+	for _, s := range sections {
+		for _, def := range w.definitions(s.typ) {
+			keyword := s.singular
+			if def.Type == model.DefinitionService && def.Service.Sub {
+				keyword = "subservice"
+			}
+			if def.Type == model.DefinitionStruct && def.Struct.Key {
+				keyword = "key struct"
+			}
+
+			item := fmt.Sprintf("%v %v", m.text(keyword), m.link(m.code(def.Name), "#"+def.Name))
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	m.heading(2, "", "Contents")
+	m.list(items)
+}
+
+func (w *pageWriter) definitions(typ model.DefinitionType) []*model.Definition {
+	var defs []*model.Definition
+	for _, def := range w.pkg.Definitions {
+		if def.Type == typ {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// definition
+
+func (w *pageWriter) definition(def *model.Definition) {
+	m := w.m
+
+	switch def.Type {
+	case model.DefinitionEnum:
+		m.heading(3, def.Name, m.text("enum "+def.Name))
+		m.doc(def.Doc)
+		w.enum(def.Enum)
+
+	case model.DefinitionMessage:
+		m.heading(3, def.Name, m.text("message "+def.Name))
+		m.doc(def.Doc)
+		w.fields(def.Message.Fields)
+
+	case model.DefinitionStruct:
+		keyword := "struct"
+		if def.Struct.Key {
+			keyword = "key struct"
+		}
+
+		m.heading(3, def.Name, m.text(keyword+" "+def.Name))
+		m.doc(def.Doc)
+		w.struct_(def.Struct)
+
+	case model.DefinitionService:
+		keyword := "service"
+		if def.Service.Sub {
+			keyword = "subservice"
+		}
+
+		m.heading(3, def.Name, m.text(keyword+" "+def.Name))
+		m.doc(def.Doc)
+		w.service(def.Service)
+	}
+}
+
+func (w *pageWriter) enum(enum *model.Enum) {
+	m := w.m
+
+	rows := make([][]string, 0, len(enum.Values))
+	for _, v := range enum.Values {
+		rows = append(rows, []string{
+			m.code(v.Name),
+			strconv.Itoa(v.Number),
+			m.inlineDoc(v.Doc),
+		})
+	}
+	m.table([]string{"Value", "Number", "Description"}, rows)
+}
+
+func (w *pageWriter) fields(fields *model.Fields) {
+	m := w.m
+	if len(fields.List) == 0 {
+		m.para(m.text("No fields."))
+		return
+	}
+
+	rows := make([][]string, 0, len(fields.List))
+	for _, f := range fields.List {
+		rows = append(rows, []string{
+			strconv.Itoa(f.Tag),
+			m.code(f.Name),
+			w.typeLink(f.Type),
+			m.inlineDoc(f.Doc),
+		})
+	}
+	m.table([]string{"Tag", "Field", "Type", "Description"}, rows)
+}
+
+func (w *pageWriter) struct_(s *model.Struct) {
+	m := w.m
+	m.para(m.text("Struct fields are encoded in the declaration order without tags, " +
+		"the field order must not change."))
+
+	fields := s.Fields.Values()
+	rows := make([][]string, 0, len(fields))
+	for i, f := range fields {
+		rows = append(rows, []string{
+			strconv.Itoa(i),
+			m.code(f.Name),
+			w.typeLink(f.Type),
+			m.inlineDoc(f.Doc),
+		})
+	}
+	m.table([]string{"#", "Field", "Type", "Description"}, rows)
+}
+
+// service
+
+func (w *pageWriter) service(srv *model.Service) {
+	m := w.m
+
+	for _, method := range srv.Methods {
+		anchor := srv.Def.Name + "." + method.Name
+		m.heading(4, anchor, m.code(method.Name))
+		m.pre(method.Signature())
+		m.doc(method.Doc)
+		w.method(method)
+	}
+}
+
+func (w *pageWriter) method(method *model.Method) {
+	m := w.m
+
+	// Request
+	if req := method.Request; req != nil {
+		if req.Ref.Message.Generated {
+			m.para(m.text("Request:"))
+			w.fields(req.Ref.Message.Fields)
+		} else {
+			m.para(m.text("Request: ") + w.typeLink(req))
+		}
+	}
+
+	// Channel
+	if ch := method.Channel; ch != nil {
+		var items []string
+		if ch.In != nil {
+			items = append(items, m.text("In: ")+w.typeLink(ch.In)+m.text(", sent by the client"))
+		}
+		if ch.Out != nil {
+			items = append(items, m.text("Out: ")+w.typeLink(ch.Out)+m.text(", sent by the server"))
+		}
+
+		m.para(m.text("Channel:"))
+		m.list(items)
+	}
+
+	// Response
+	switch {
+	case method.Oneway:
+		m.para(m.text("Oneway, the client does not wait for a response."))
+
+	case method.Subservice != nil:
+		m.para(m.text("Subservice: ") + w.typeLink(method.Subservice))
+
+	case method.Response != nil && method.Response.Ref.Message.Generated:
+		m.para(m.text("Response:"))
+		w.fields(method.Response.Ref.Message.Fields)
+
+	case method.Response != nil:
+		m.para(m.text("Response: ") + w.typeLink(method.Response))
+	}
+}
+
+// links
+
+// typeLink returns a type with links to definitions in rendered packages.
+func (w *pageWriter) typeLink(typ *model.Type) string {
+	m := w.m
+
+	switch {
+	case typ.Kind == model.KindList:
+		return m.code("[]") + w.typeLink(typ.Element)
+	case typ.Ref == nil:
+		return m.code(typ.SourceName())
+	}
+
+	href := w.definitionHref(typ.Ref)
+	if href == "" {
+		return m.code(typ.SourceName())
+	}
+	return m.link(m.code(typ.SourceName()), href)
+}
+
+// definitionHref returns a definition link, or an empty string when its package is not rendered.
+func (w *pageWriter) definitionHref(def *model.Definition) string {
+	if def.Package == w.pkg {
+		return "#" + def.Name
+	}
+	if _, ok := w.ids[def.Package.ID]; !ok {
+		return ""
+	}
+	return pageName(def.Package.ID) + w.m.ext() + "#" + def.Name
+}
+
+func (w *pageWriter) packageLink(id string) string {
+	m := w.m
+	if _, ok := w.ids[id]; !ok {
+		return m.code(id)
+	}
+	return m.link(m.code(id), pageName(id)+m.ext())
+}
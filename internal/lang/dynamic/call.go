@@ -0,0 +1,390 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/proto/prpc"
+	"github.com/basecomplextech/spec/rpc"
+)
+
+// Call is an RPC call which is built at runtime from a service definition.
+//
+// A call path is "Service.method" or a subservice chain as "Service.subservice(input).method",
+// chain methods are sent as calls in one request. Inputs are json objects, see [ParseJSON].
+type Call struct {
+	steps []callStep
+}
+
+type callStep struct {
+	method *model.Method
+	input  []byte // Json input, nil when the method has no request
+}
+
+// ParseCall parses a call path in a package, inline "(input)" json inputs are optional,
+// other inputs are taken from the inputs in the chain order.
+func ParseCall(pkg *model.Package, path string, inputs [][]byte) (*Call, error) {
+	segments, err := splitCallPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("invalid call %q, expected Service.method", path)
+	}
+
+	// Service
+	name := segments[0].name
+	def, ok := pkg.DefinitionNames[name]
+	if !ok || def.Type != model.DefinitionService {
+		return nil, fmt.Errorf("service not found: %v", name)
+	}
+	if segments[0].input != nil {
+		return nil, fmt.Errorf("invalid call %q, service %v has no input", path, name)
+	}
+
+	// Methods
+	c := &Call{}
+	srv := def.Service
+	for i, seg := range segments[1:] {
+		method, ok := srv.MethodNames[seg.name]
+		if !ok {
+			return nil, fmt.Errorf("method not found: %v.%v", srv.Def.Name, seg.name)
+		}
+
+		last := i == len(segments)-2
+		switch {
+		case !last && method.Subservice == nil:
+			return nil, fmt.Errorf("method %v.%v is not a subservice method", srv.Def.Name, method.Name)
+		case last && method.Subservice != nil:
+			return nil, fmt.Errorf("method %v.%v returns a subservice, call one of its methods",
+				srv.Def.Name, method.Name)
+		}
+
+		// Input
+		step := callStep{method: method}
+		switch {
+		case method.Request == nil:
+			if seg.input != nil {
+				return nil, fmt.Errorf("method %v.%v has no input", srv.Def.Name, method.Name)
+			}
+		case seg.input != nil:
+			step.input = seg.input
+		case len(inputs) > 0:
+			step.input = inputs[0]
+			inputs = inputs[1:]
+		default:
+			step.input = []byte("{}")
+		}
+		c.steps = append(c.steps, step)
+
+		if method.Subservice != nil {
+			srv = method.Subservice.Ref.Service
+		}
+	}
+
+	if len(inputs) > 0 {
+		return nil, fmt.Errorf("too many inputs for call %q", path)
+	}
+	return c, nil
+}
+
+// Method returns the called method, i.e. the last method in a chain.
+func (c *Call) Method() *model.Method {
+	return c.steps[len(c.steps)-1].method
+}
+
+// Request builds and returns an RPC request, the request must be freed by the caller.
+func (c *Call) Request() (*rpc.Request, error) {
+	req := rpc.NewRequest()
+	ok := false
+	defer func() {
+		if !ok {
+			req.Free()
+		}
+	}()
+
+	for _, step := range c.steps {
+		m := step.method
+		if m.Request == nil {
+			if st := req.AddEmpty(m.Name); !st.OK() {
+				return nil, statusError(st)
+			}
+			continue
+		}
+
+		msg, err := ParseJSON(m.Request.Ref.Message, step.input)
+		if err != nil {
+			return nil, fmt.Errorf("%v input: %w", m.Name, err)
+		}
+		if st := req.AddMessage(m.Name, msg.Unwrap()); !st.OK() {
+			return nil, statusError(st)
+		}
+	}
+
+	ok = true
+	return req, nil
+}
+
+// Do sends the call and writes the response as json into out.
+//
+// Channel methods read input messages as json lines from in, and write output messages
+// and the response as json lines into out.
+func (c *Call) Do(ctx async.Context, client rpc.Client, in io.Reader, out io.Writer) error {
+	req, err := c.Request()
+	if err != nil {
+		return err
+	}
+	defer req.Free()
+
+	preq, st := req.Build()
+	if !st.OK() {
+		return statusError(st)
+	}
+
+	m := c.Method()
+	switch {
+	case m.Oneway:
+		return statusError(client.RequestOneway(ctx, preq))
+	case m.Channel != nil:
+		return c.channel(ctx, client, preq, in, out)
+	}
+
+	resp, st := client.Request(ctx, preq)
+	if !st.OK() {
+		return statusError(st)
+	}
+	defer resp.Release()
+
+	if m.Response == nil {
+		return nil
+	}
+	return writeResponse(out, m.Response.Ref.Message, resp.Unwrap(), true)
+}
+
+// internal
+
+func (c *Call) channel(ctx async.Context, client rpc.Client, preq prpc.Request,
+	in io.Reader, out io.Writer) error {
+
+	m := c.Method()
+	ch, st := client.Channel(ctx, preq)
+	if !st.OK() {
+		return statusError(st)
+	}
+	defer ch.Free()
+
+	// Send input messages, cancel receiving on errors
+	ctx1 := async.NextContext(ctx)
+	defer ctx1.Cancel()
+
+	sendErr := make(chan error, 1)
+	if m.Channel.In != nil {
+		go func() {
+			err := sendLines(ctx1, ch, m.Channel.In.Ref.Message, in)
+			if err != nil {
+				sendErr <- err
+				ctx1.Cancel()
+			}
+		}()
+	}
+
+	// Receive output messages and response
+	err := c.receive(ctx1, ch, out)
+	if err != nil {
+		select {
+		case err1 := <-sendErr:
+			return err1
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Call) receive(ctx async.Context, ch rpc.Channel, out io.Writer) error {
+	m := c.Method()
+
+	if m.Channel.Out != nil {
+		def := m.Channel.Out.Ref.Message
+		for {
+			b, st := ch.Receive(ctx)
+			if st.Code == status.CodeEnd {
+				break
+			}
+			if !st.OK() {
+				return statusError(st)
+			}
+
+			msg, _, err := ParseDynamicMessage(def, b)
+			if err != nil {
+				return err
+			}
+			if err := writeJSONLine(out, msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	result, st := ch.Response(ctx)
+	if !st.OK() {
+		return statusError(st)
+	}
+	if m.Response == nil {
+		return nil
+	}
+	return writeResponse(out, m.Response.Ref.Message, result, false)
+}
+
+// sendLines sends json lines as channel messages, and sends an end on EOF.
+func sendLines(ctx async.Context, ch rpc.Channel, def *model.Message, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 16<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		msg, err := ParseJSON(def, data)
+		if err != nil {
+			return fmt.Errorf("input line %d: %w", line, err)
+		}
+		if st := ch.Send(ctx, msg.Unwrap().Raw()); !st.OK() {
+			return statusError(st)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return statusError(ch.SendEnd(ctx))
+}
+
+func writeResponse(out io.Writer, def *model.Message, b []byte, indent bool) error {
+	msg, _, err := ParseDynamicMessage(def, b)
+	if err != nil {
+		return err
+	}
+	if !indent {
+		return writeJSONLine(out, msg)
+	}
+
+	data, err := json.MarshalIndent(msg, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = out.Write(data)
+	return err
+}
+
+func writeJSONLine(out io.Writer, msg DynamicMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = out.Write(data)
+	return err
+}
+
+func statusError(st status.Status) error {
+	if st.OK() {
+		return nil
+	}
+	return fmt.Errorf("rpc error: %v", st)
+}
+
+// path
+
+type callSegment struct {
+	name  string
+	input []byte // Inline json input or nil
+}
+
+// splitCallPath splits "Service.subservice({...}).method" into segments.
+func splitCallPath(path string) ([]callSegment, error) {
+	var segments []callSegment
+
+	s := path
+	for {
+		i := strings.IndexAny(s, ".(")
+		if i < 0 {
+			segments = append(segments, callSegment{name: s})
+			break
+		}
+
+		seg := callSegment{name: s[:i]}
+		if s[i] == '(' {
+			n, err := matchParen(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid call %q: %w", path, err)
+			}
+
+			input := bytes.TrimSpace([]byte(s[i+1 : i+n-1]))
+			if len(input) > 0 {
+				seg.input = input
+			}
+			s = s[i+n:]
+		} else {
+			s = s[i:]
+		}
+		segments = append(segments, seg)
+
+		if s == "" {
+			break
+		}
+		if s[0] != '.' {
+			return nil, fmt.Errorf("invalid call %q, expected . after %v", path, seg.name)
+		}
+		s = s[1:]
+	}
+
+	for _, seg := range segments {
+		if seg.name == "" {
+			return nil, fmt.Errorf("invalid call %q, empty name", path)
+		}
+	}
+	return segments, nil
+}
+
+// matchParen returns the length of a parenthesized json input, skips parens in strings.
+func matchParen(s string) (int, error) {
+	depth := 0
+	quoted := false
+	escaped := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unclosed parenthesis")
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/logging"
+	"github.com/basecomplextech/baselibrary/ref"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCallSchema = `
+message Request {
+    msg string 1;
+}
+
+message Response {
+    msg string 1;
+}
+
+message In {
+    n int64 1;
+}
+
+message Out {
+    n int64 1;
+}
+
+service Service {
+    echo(Request) Response;
+    ping();
+    notify(Request) oneway;
+    sub(id string 1) Sub;
+    double(Request) (<-In, Out->) Response;
+}
+
+subservice Sub {
+    hello(Request) Response;
+}
+`
+
+func testCallMessage(t *testing.T, pkg *model.Package, name string, field string, v any) []byte {
+	w := NewDynamicWriter(pkg.DefinitionNames[name].Message)
+	require.NoError(t, w.Set(field, v))
+
+	msg, err := w.Build()
+	require.NoError(t, err)
+	return slices.Clone(msg.Unwrap().Raw())
+}
+
+// testCallServer handles echo, sub.hello and double methods.
+func testCallServer(t *testing.T, pkg *model.Package) rpc.Client {
+	request := pkg.DefinitionNames["Request"].Message
+	in := pkg.DefinitionNames["In"].Message
+
+	handle := func(ctx rpc.Context, ch rpc.ServerChannel) (ref.R[[]byte], status.Status) {
+		req, st := ch.Request(ctx)
+		if !st.OK() {
+			return nil, st
+		}
+
+		calls := req.Calls()
+		call := calls.Get(calls.Len() - 1)
+		input := OpenDynamicMessage(request, call.Input().Raw())
+		msg, _ := input.String("msg")
+
+		var result string
+		switch call.Method().Unwrap() {
+		case "echo":
+			result = msg.Unwrap()
+
+		case "hello":
+			sub := calls.Get(0)
+			id := sub.Input().String(1).Unwrap()
+			result = id + ":" + msg.Unwrap()
+
+		case "double":
+			for {
+				b, st := ch.Receive(ctx)
+				if st.Code == status.CodeEnd {
+					break
+				}
+				if !st.OK() {
+					return nil, st
+				}
+
+				n, _ := OpenDynamicMessage(in, b).Int64("n")
+				out := testCallMessage(t, pkg, "Out", "n", n*2)
+				if st := ch.Send(ctx, out); !st.OK() {
+					return nil, st
+				}
+			}
+			result = msg.Unwrap()
+
+		case "ping":
+			return nil, status.OK
+		case "notify":
+			return nil, rpc.SkipResponse
+		default:
+			return nil, status.NotFound("method not found")
+		}
+
+		resp := testCallMessage(t, pkg, "Response", "msg", result)
+		return ref.NewNoop(resp), status.OK
+	}
+
+	logger := logging.TestLogger(t)
+	server := rpc.NewServer("localhost:0", rpc.HandleFunc(handle), logger, rpc.Default())
+	st := server.Start()
+	require.True(t, st.OK(), st)
+
+	t.Cleanup(func() {
+		select {
+		case <-server.Stop():
+		case <-time.After(time.Second):
+			t.Fatal("server not stopped")
+		}
+	})
+
+	select {
+	case <-server.Listening().Wait():
+	case <-time.After(time.Second):
+		t.Fatal("server not listening")
+	}
+
+	client := rpc.NewClient(server.Address(), rpc.ClientMode_OnDemand, logger, rpc.Default())
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testCallDo(t *testing.T, pkg *model.Package, path string, stdin string, inputs ...string) (string, error) {
+	ins := make([][]byte, 0, len(inputs))
+	for _, in := range inputs {
+		ins = append(ins, []byte(in))
+	}
+
+	call, err := ParseCall(pkg, path, ins)
+	require.NoError(t, err)
+
+	client := testCallServer(t, pkg)
+	ctx := async.TimeoutContext(5 * time.Second)
+
+	out := &bytes.Buffer{}
+	err = call.Do(ctx, client, strings.NewReader(stdin), out)
+	return out.String(), err
+}
+
+// ParseCall
+
+func TestParseCall__should_parse_subservice_chain(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	call, err := ParseCall(pkg, `Service.sub({"id": "a(b)"}).hello`, [][]byte{[]byte(`{"msg": "hi"}`)})
+	require.NoError(t, err)
+
+	req, err := call.Request()
+	require.NoError(t, err)
+	defer req.Free()
+
+	preq, st := req.Build()
+	require.True(t, st.OK(), st)
+
+	calls := preq.Calls()
+	require.Equal(t, 2, calls.Len())
+	assert.Equal(t, "sub", calls.Get(0).Method().Unwrap())
+	assert.Equal(t, "a(b)", calls.Get(0).Input().String(1).Unwrap())
+	assert.Equal(t, "hello", calls.Get(1).Method().Unwrap())
+	assert.Equal(t, "hi", calls.Get(1).Input().String(1).Unwrap())
+}
+
+func TestParseCall__should_return_error_on_invalid_call(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	tests := []struct {
+		path   string
+		inputs int
+		err    string
+	}{
+		{"Service", 0, `invalid call "Service", expected Service.method`},
+		{"Unknown.echo", 0, "service not found: Unknown"},
+		{"Service.unknown", 0, "method not found: Service.unknown"},
+		{"Service.sub", 0, "method Service.sub returns a subservice, call one of its methods"},
+		{"Service.echo.hello", 0, "method Service.echo is not a subservice method"},
+		{"Service.ping({})", 0, "method Service.ping has no input"},
+		{"Service.echo", 2, `too many inputs for call "Service.echo"`},
+		{"Service.sub({.hello", 0, `invalid call "Service.sub({.hello": unclosed parenthesis`},
+	}
+
+	for _, tt := range tests {
+		inputs := make([][]byte, tt.inputs)
+		for i := range inputs {
+			inputs[i] = []byte("{}")
+		}
+
+		_, err := ParseCall(pkg, tt.path, inputs)
+		assert.EqualError(t, err, tt.err, tt.path)
+	}
+}
+
+// Do
+
+func TestCall_Do__should_call_method_and_write_json_response(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	out, err := testCallDo(t, pkg, "Service.echo", "", `{"msg": "hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"msg\": \"hello\"\n}\n", out)
+}
+
+func TestCall_Do__should_call_subservice_method(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	out, err := testCallDo(t, pkg, `Service.sub({"id": "123"}).hello`, "", `{"msg": "hello"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"msg\": \"123:hello\"\n}\n", out)
+}
+
+func TestCall_Do__should_call_methods_without_response(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	out, err := testCallDo(t, pkg, "Service.ping", "")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	out, err = testCallDo(t, pkg, "Service.notify", "", `{"msg": "hello"}`)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestCall_Do__should_stream_channel_messages_as_json_lines(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	stdin := "{\"n\": 1}\n\n{\"n\": 2}\n{\"n\": 3}\n"
+	out, err := testCallDo(t, pkg, "Service.double", stdin, `{"msg": "done"}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"n":2}
+{"n":4}
+{"n":6}
+{"msg":"done"}
+`, out)
+}
+
+func TestCall_Do__should_return_error_on_invalid_channel_input(t *testing.T) {
+	pkg := langtest.Source(t, testCallSchema)
+
+	_, err := testCallDo(t, pkg, "Service.double", "{\"n\": 1}\n{\"x\": 1}\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `input line 2: In: unknown field "x"`)
+}
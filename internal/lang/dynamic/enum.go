@@ -0,0 +1,87 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// DynamicEnum is an enum value which type is known only at runtime.
+type DynamicEnum struct {
+	def    *model.Enum
+	number int32
+}
+
+// NewDynamicEnum returns a new enum value.
+func NewDynamicEnum(def *model.Enum, number int32) DynamicEnum {
+	return DynamicEnum{
+		def:    def,
+		number: number,
+	}
+}
+
+// ParseDynamicEnum returns an enum value by its name, or an error if the value is not found.
+func ParseDynamicEnum(def *model.Enum, name string) (DynamicEnum, error) {
+	val, ok := def.ValueNames[name]
+	if !ok {
+		return DynamicEnum{}, fmt.Errorf("%v: unknown enum value %q", def.Def.Name, name)
+	}
+	return NewDynamicEnum(def, int32(val.Number)), nil
+}
+
+// Definition returns the enum definition.
+func (e DynamicEnum) Definition() *model.Enum {
+	return e.def
+}
+
+// Number returns the enum value number.
+func (e DynamicEnum) Number() int32 {
+	return e.number
+}
+
+// Value returns the enum value definition, or nil if the number is unknown.
+func (e DynamicEnum) Value() *model.EnumValue {
+	if e.def == nil {
+		return nil
+	}
+	return e.def.ValueNumbers[int(e.number)]
+}
+
+// Name returns the enum value name, or an empty string if the number is unknown.
+func (e DynamicEnum) Name() string {
+	val := e.Value()
+	if val == nil {
+		return ""
+	}
+	return val.Name
+}
+
+// String returns the enum value name, or the number if the value is unknown.
+func (e DynamicEnum) String() string {
+	val := e.Value()
+	if val == nil {
+		return fmt.Sprintf("%d", e.number)
+	}
+	return val.Name
+}
+
+// internal
+
+func decodeEnum(def *model.Enum, b []byte) (DynamicEnum, int, error) {
+	v, n, err := decode.DecodeInt32(b)
+	if err != nil {
+		return DynamicEnum{}, 0, err
+	}
+	return NewDynamicEnum(def, v), n, nil
+}
+
+func encodeEnum(b buffer.Buffer, e DynamicEnum) (int, error) {
+	return encode.EncodeInt32(b, e.number)
+}
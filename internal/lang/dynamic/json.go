@@ -0,0 +1,524 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// JSON mapping:
+//   - bools, integers and floats are json booleans and numbers.
+//   - bin64, bin128 and bin256 are hex strings.
+//   - bytes are base64 strings.
+//   - enums are value names, or numbers when values are unknown.
+//   - structs and messages are objects with fields in the definition order.
+//   - lists are arrays.
+//   - any values and any messages are schema-less value trees, see [ParseValueTree].
+//
+// Absent message fields are omitted, unknown message fields are ignored.
+
+// MarshalJSON returns a json object with present message fields.
+func (m DynamicMessage) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := appendJSON(buf, m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON returns a json array with list elements.
+func (l DynamicList) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := appendJSON(buf, l); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON returns a json object with struct fields.
+func (s DynamicStruct) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := appendJSON(buf, s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON returns a json string with the enum value name, or a number if the value is unknown.
+func (e DynamicEnum) MarshalJSON() ([]byte, error) {
+	if val := e.Value(); val != nil {
+		return json.Marshal(val.Name)
+	}
+	return json.Marshal(e.number)
+}
+
+// WriteJSON writes message fields from a json object, see [DynamicMessage.MarshalJSON].
+// Null fields are skipped, any values and any messages are not supported.
+func (w DynamicWriter) WriteJSON(data []byte) error {
+	var obj map[string]json.RawMessage
+	if err := unmarshalJSON(data, &obj); err != nil {
+		return fmt.Errorf("%v: %w", w.def.Def.Name, err)
+	}
+
+	for name := range obj {
+		if w.def.Fields.Get(name) == nil {
+			return fmt.Errorf("%v: unknown field %q", w.def.Def.Name, name)
+		}
+	}
+
+	for _, field := range w.def.Fields.List {
+		raw, ok := obj[field.Name]
+		if !ok || isJSONNull(raw) {
+			continue
+		}
+
+		if err := w.writeJSONField(field, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseJSON writes and returns a message from a json object, see [DynamicWriter.WriteJSON].
+func ParseJSON(def *model.Message, data []byte) (_ DynamicMessage, err error) {
+	w := NewDynamicWriter(def)
+	if err := w.WriteJSON(data); err != nil {
+		return DynamicMessage{}, err
+	}
+	return w.Build()
+}
+
+// internal
+
+func (w DynamicWriter) writeJSONField(field *model.Field, raw json.RawMessage) error {
+	switch field.Type.Kind {
+	case model.KindMessage:
+		mw, err := w.Message(field.Name)
+		if err != nil {
+			return err
+		}
+		if err := mw.WriteJSON(raw); err != nil {
+			return err
+		}
+		return mw.End()
+
+	case model.KindList:
+		lw, err := w.List(field.Name)
+		if err != nil {
+			return err
+		}
+		if err := lw.writeJSON(raw); err != nil {
+			return fmt.Errorf("%v.%v: %w", w.def.Def.Name, field.Name, err)
+		}
+		return lw.End()
+	}
+
+	v, err := parseJSONScalar(field.Type, raw)
+	if err != nil {
+		return fmt.Errorf("%v.%v: %w", w.def.Def.Name, field.Name, err)
+	}
+	return w.Set(field.Name, v)
+}
+
+func (w DynamicListWriter) writeJSON(data []byte) error {
+	var elems []json.RawMessage
+	if err := unmarshalJSON(data, &elems); err != nil {
+		return err
+	}
+
+	for i, raw := range elems {
+		switch w.elem.Kind {
+		case model.KindMessage:
+			mw, err := w.Message()
+			if err != nil {
+				return err
+			}
+			if err := mw.WriteJSON(raw); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := mw.End(); err != nil {
+				return err
+			}
+
+		case model.KindList:
+			lw, err := w.List()
+			if err != nil {
+				return err
+			}
+			if err := lw.writeJSON(raw); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := lw.End(); err != nil {
+				return err
+			}
+
+		default:
+			v, err := parseJSONScalar(w.elem, raw)
+			if err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := w.Add(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseJSONScalar parses a fixed value, i.e. a primitive, bytes, string, enum or struct.
+func parseJSONScalar(t *model.Type, raw json.RawMessage) (any, error) {
+	switch t.Kind {
+	case model.KindBool:
+		var v bool
+		err := unmarshalJSON(raw, &v)
+		return v, err
+
+	case model.KindByte:
+		v, err := parseJSONUint(raw, 8)
+		return byte(v), err
+
+	case model.KindInt16:
+		v, err := parseJSONInt(raw, 16)
+		return int16(v), err
+	case model.KindInt32:
+		v, err := parseJSONInt(raw, 32)
+		return int32(v), err
+	case model.KindInt64:
+		return parseJSONInt(raw, 64)
+
+	case model.KindUint16:
+		v, err := parseJSONUint(raw, 16)
+		return uint16(v), err
+	case model.KindUint32:
+		v, err := parseJSONUint(raw, 32)
+		return uint32(v), err
+	case model.KindUint64:
+		return parseJSONUint(raw, 64)
+
+	case model.KindFloat32:
+		v, err := parseJSONFloat(raw, 32)
+		return float32(v), err
+	case model.KindFloat64:
+		return parseJSONFloat(raw, 64)
+
+	case model.KindBin64:
+		var v bin.Bin64
+		err := unmarshalJSON(raw, &v)
+		return v, err
+	case model.KindBin128:
+		var v bin.Bin128
+		err := unmarshalJSON(raw, &v)
+		return v, err
+	case model.KindBin256:
+		var v bin.Bin256
+		err := unmarshalJSON(raw, &v)
+		return v, err
+
+	case model.KindBytes:
+		var v []byte
+		err := unmarshalJSON(raw, &v)
+		return v, err
+	case model.KindString:
+		var v string
+		err := unmarshalJSON(raw, &v)
+		return v, err
+
+	case model.KindEnum:
+		var v any
+		if err := unmarshalJSON(raw, &v); err != nil {
+			return nil, err
+		}
+		if s, ok := v.(string); ok {
+			return ParseDynamicEnum(t.Ref.Enum, s)
+		}
+
+		n, err := parseJSONInt(raw, 32)
+		if err != nil {
+			return nil, err
+		}
+		return NewDynamicEnum(t.Ref.Enum, int32(n)), nil
+
+	case model.KindStruct:
+		return parseJSONStruct(t.Ref.Struct, raw)
+	}
+
+	return nil, fmt.Errorf("unsupported json type %v", t.SourceName())
+}
+
+func parseJSONStruct(def *model.Struct, raw json.RawMessage) (DynamicStruct, error) {
+	var obj map[string]json.RawMessage
+	if err := unmarshalJSON(raw, &obj); err != nil {
+		return DynamicStruct{}, err
+	}
+
+	s := NewDynamicStruct(def)
+	for name, raw := range obj {
+		i := def.Fields.Index(name)
+		if i < 0 {
+			return DynamicStruct{}, fmt.Errorf("%v: unknown field %q", def.Def.Name, name)
+		}
+
+		field := def.Fields.Value(i)
+		v, err := parseJSONScalar(field.Type, raw)
+		if err != nil {
+			return DynamicStruct{}, fmt.Errorf("%v.%v: %w", def.Def.Name, name, err)
+		}
+		s.values[i] = v
+	}
+	return s, nil
+}
+
+func parseJSONInt(raw json.RawMessage, bits int) (int64, error) {
+	var n json.Number
+	if err := unmarshalJSON(raw, &n); err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(n.String(), 10, bits)
+}
+
+func parseJSONUint(raw json.RawMessage, bits int) (uint64, error) {
+	var n json.Number
+	if err := unmarshalJSON(raw, &n); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(n.String(), 10, bits)
+}
+
+func parseJSONFloat(raw json.RawMessage, bits int) (float64, error) {
+	var n json.Number
+	if err := unmarshalJSON(raw, &n); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(n.String(), bits)
+}
+
+func unmarshalJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+// append
+
+// appendJSON appends a json value, see [DynamicMessage.Get] for the value types.
+func appendJSON(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case DynamicMessage:
+		return appendJSONMessage(buf, v)
+
+	case DynamicList:
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			elem, err := v.Get(i)
+			if err != nil {
+				return err
+			}
+			if err := appendJSON(buf, elem); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case DynamicStruct:
+		buf.WriteByte('{')
+		for i, field := range v.def.Fields.Values() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			appendJSONKey(buf, field.Name)
+
+			if err := appendJSON(buf, v.values[i]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case []byte:
+		buf.WriteByte('"')
+		buf.WriteString(base64.StdEncoding.EncodeToString(v))
+		buf.WriteByte('"')
+		return nil
+
+	case types.Value:
+		node, err := ParseValueTree(v)
+		if err != nil {
+			return err
+		}
+		return appendJSONMarshal(buf, node)
+
+	case types.Message:
+		node, err := ParseValueTree(v.Raw())
+		if err != nil {
+			return err
+		}
+		return appendJSONMarshal(buf, node)
+	}
+
+	return appendJSONMarshal(buf, v)
+}
+
+func appendJSONMessage(buf *bytes.Buffer, m DynamicMessage) error {
+	buf.WriteByte('{')
+
+	n := 0
+	for _, field := range m.def.Fields.List {
+		tag := uint16(field.Tag)
+		if !m.msg.HasField(tag) {
+			continue
+		}
+
+		v, err := decodeValue(field.Type, m.msg.FieldRaw(tag))
+		if err != nil {
+			return fmt.Errorf("%v.%v: %w", m.def.Def.Name, field.Name, err)
+		}
+
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		appendJSONKey(buf, field.Name)
+
+		if err := appendJSON(buf, v); err != nil {
+			return fmt.Errorf("%v.%v: %w", m.def.Def.Name, field.Name, err)
+		}
+		n++
+	}
+
+	buf.WriteByte('}')
+	return nil
+}
+
+func appendJSONKey(buf *bytes.Buffer, key string) {
+	b, _ := json.Marshal(key)
+	buf.Write(b)
+	buf.WriteByte(':')
+}
+
+func appendJSONMarshal(buf *bytes.Buffer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+// tree
+
+// ValueNode is a schema-less value tree node, see [ParseValueTree].
+type ValueNode struct {
+	Type     string       `json:"type"`               // Format type, i.e. "int32", "message", "list"
+	Value    any          `json:"value,omitempty"`    // Primitive, bytes or string value
+	Fields   []*FieldNode `json:"fields,omitempty"`   // Message fields
+	Elements []*ValueNode `json:"elements,omitempty"` // List elements
+}
+
+// FieldNode is a message field in a schema-less value tree.
+type FieldNode struct {
+	Tag uint16 `json:"tag"`
+	*ValueNode
+}
+
+// ParseValueTree recursively parses a value without a schema using its self-describing types.
+//
+// Bytes are returned as base64 strings in json, structs as raw bytes
+// because their field types are not encoded.
+func ParseValueTree(b []byte) (*ValueNode, error) {
+	v, _, err := types.ParseValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := v.Type()
+	node := &ValueNode{Type: typ.String()}
+
+	switch typ {
+	case format.TypeTrue:
+		node.Value = true
+	case format.TypeFalse:
+		node.Value = false
+	case format.TypeByte:
+		node.Value = v.Byte()
+
+	case format.TypeInt16:
+		node.Value = v.Int16()
+	case format.TypeInt32:
+		node.Value = v.Int32()
+	case format.TypeInt64, format.TypeVarint:
+		node.Value = v.Int64()
+
+	case format.TypeUint16:
+		node.Value = v.Uint16()
+	case format.TypeUint32:
+		node.Value = v.Uint32()
+	case format.TypeUint64, format.TypeUvarint:
+		node.Value = v.Uint64()
+
+	case format.TypeFloat32:
+		node.Value = v.Float32()
+	case format.TypeFloat64:
+		node.Value = v.Float64()
+
+	case format.TypeBin64:
+		node.Value = v.Bin64()
+	case format.TypeBin128:
+		node.Value = v.Bin128()
+	case format.TypeBin256:
+		node.Value = v.Bin256()
+
+	case format.TypeBytes:
+		node.Value = []byte(v.Bytes())
+	case format.TypeString:
+		node.Value = string(v.String())
+
+	case format.TypeStruct:
+		node.Value = []byte(v)
+
+	case format.TypeList, format.TypeBigList, format.TypePackedList:
+		list := v.List()
+		node.Elements = make([]*ValueNode, 0, list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			elem, err := ParseValueTree(list.GetBytes(i))
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			node.Elements = append(node.Elements, elem)
+		}
+
+	case format.TypeMessage, format.TypeBigMessage:
+		msg := v.Message()
+		node.Fields = make([]*FieldNode, 0, msg.Fields())
+
+		for i := 0; i < msg.Fields(); i++ {
+			tag, _ := msg.TagAt(i)
+
+			field, err := ParseValueTree(msg.FieldAt(i))
+			if err != nil {
+				return nil, fmt.Errorf("#%d: %w", tag, err)
+			}
+			node.Fields = append(node.Fields, &FieldNode{Tag: tag, ValueNode: field})
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/tests/pkg1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MarshalJSON
+
+func TestDynamicMessage_MarshalJSON__should_marshal_message(t *testing.T) {
+	m := testMessage(t)
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var obj map[string]any
+	require.NoError(t, json.Unmarshal(b, &obj))
+
+	o := pkg1.TestObject(t)
+	assert.Equal(t, o.Bool, obj["bool"])
+	assert.Equal(t, float64(o.Int64), obj["int64"])
+	assert.Equal(t, o.String, obj["string"])
+	assert.Equal(t, "ONE", obj["enum1"])
+	assert.Equal(t, map[string]any{"key": float64(o.Struct1.Key), "value": float64(o.Struct1.Value)}, obj["struct1"])
+	assert.Len(t, obj["ints"], len(o.Ints))
+	assert.Equal(t, "message", obj["message1"].(map[string]any)["type"])
+}
+
+func TestDynamicMessage_MarshalJSON__should_omit_absent_fields(t *testing.T) {
+	def := testDefinition(t, "Submessage")
+
+	w := NewDynamicWriter(def.Message)
+	require.NoError(t, w.String("value", "hello"))
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `{"value":"hello"}`, string(b))
+}
+
+// ParseJSON
+
+func TestParseJSON__should_roundtrip_message(t *testing.T) {
+	m := testMessage(t)
+	def := m.Definition()
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	// Remove any fields
+	var obj map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &obj))
+	delete(obj, "message1")
+	delete(obj, "any")
+	b, err = json.Marshal(obj)
+	require.NoError(t, err)
+
+	m1, err := ParseJSON(def, b)
+	require.NoError(t, err)
+
+	b1, err := json.Marshal(m1)
+	require.NoError(t, err)
+
+	var exp, act map[string]any
+	require.NoError(t, json.Unmarshal(b, &exp))
+	require.NoError(t, json.Unmarshal(b1, &act))
+	assert.Equal(t, exp, act)
+}
+
+func TestParseJSON__should_return_error_on_unknown_field(t *testing.T) {
+	def := testDefinition(t, "Submessage")
+
+	_, err := ParseJSON(def.Message, []byte(`{"unknown": 1}`))
+	assert.Error(t, err)
+}
+
+func TestParseJSON__should_return_error_on_out_of_range_value(t *testing.T) {
+	def := testDefinition(t, "Message")
+
+	_, err := ParseJSON(def.Message, []byte(`{"int16": 100000}`))
+	assert.Error(t, err)
+}
+
+// ParseValueTree
+
+func TestParseValueTree__should_parse_message_without_schema(t *testing.T) {
+	m := testMessage(t)
+
+	node, err := ParseValueTree(m.Unwrap().Raw())
+	require.NoError(t, err)
+	assert.Equal(t, "message", node.Type)
+
+	fields := make(map[uint16]*ValueNode)
+	for _, f := range node.Fields {
+		fields[f.Tag] = f.ValueNode
+	}
+
+	o := pkg1.TestObject(t)
+	assert.Equal(t, "int64", fields[12].Type)
+	assert.Equal(t, o.Int64, fields[12].Value)
+	assert.Equal(t, "string", fields[50].Type)
+	assert.Equal(t, o.String, fields[50].Value)
+	assert.Equal(t, "packed_list", fields[70].Type)
+	assert.Len(t, fields[70].Elements, len(o.Ints))
+	assert.Equal(t, "message", fields[62].Type)
+}
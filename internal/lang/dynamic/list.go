@@ -0,0 +1,102 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// DynamicList is a list which element type is known only at runtime.
+type DynamicList struct {
+	elem *model.Type
+	list types.List
+}
+
+// NewDynamicList returns a new dynamic list.
+func NewDynamicList(elem *model.Type, list types.List) DynamicList {
+	return DynamicList{
+		elem: elem,
+		list: list,
+	}
+}
+
+// OpenDynamicList opens and returns a list from bytes, or an empty list on error.
+// The method decodes the list table, but not the elements.
+func OpenDynamicList(elem *model.Type, b []byte) DynamicList {
+	list := types.OpenList(b)
+	return NewDynamicList(elem, list)
+}
+
+// OpenDynamicListErr opens and returns a list from bytes, or an error.
+// The method decodes the list table, but not the elements.
+func OpenDynamicListErr(elem *model.Type, b []byte) (DynamicList, error) {
+	list, err := types.OpenListErr(b)
+	if err != nil {
+		return DynamicList{}, err
+	}
+	return NewDynamicList(elem, list), nil
+}
+
+// Element returns the list element type.
+func (l DynamicList) Element() *model.Type {
+	return l.elem
+}
+
+// Len returns the number of elements in the list.
+func (l DynamicList) Len() int {
+	return l.list.Len()
+}
+
+// Empty returns true if bytes are empty or list has no elements.
+func (l DynamicList) Empty() bool {
+	return l.list.Empty()
+}
+
+// Unwrap returns the underlying raw list.
+func (l DynamicList) Unwrap() types.List {
+	return l.list
+}
+
+// Elements
+
+// Get decodes and returns an element at index i, panics on out of range.
+// See [DynamicMessage.Get] for the returned value types.
+func (l DynamicList) Get(i int) (any, error) {
+	b := l.list.GetBytes(i)
+
+	v, err := decodeValue(l.elem, b)
+	if err != nil {
+		return nil, fmt.Errorf("[%d]: %w", i, err)
+	}
+	return v, nil
+}
+
+// Message opens and returns a message element at index i, panics on out of range.
+func (l DynamicList) Message(i int) (DynamicMessage, error) {
+	if err := checkKind(l.elem, model.KindMessage); err != nil {
+		return DynamicMessage{}, err
+	}
+
+	b := l.list.GetBytes(i)
+	return OpenDynamicMessageErr(l.elem.Ref.Message, b)
+}
+
+// Values decodes and returns all elements.
+func (l DynamicList) Values() ([]any, error) {
+	n := l.list.Len()
+	result := make([]any, 0, n)
+
+	for i := 0; i < n; i++ {
+		v, err := l.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,395 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// DynamicMessage is a message which type is known only at runtime.
+// It wraps a raw message and reads its fields by names using a message definition.
+type DynamicMessage struct {
+	def *model.Message
+	msg types.Message
+}
+
+// NewDynamicMessage returns a new dynamic message.
+func NewDynamicMessage(def *model.Message, msg types.Message) DynamicMessage {
+	return DynamicMessage{
+		def: def,
+		msg: msg,
+	}
+}
+
+// OpenDynamicMessage opens and returns a message from bytes, or an empty message on error.
+// The method decodes the message table, but not the fields.
+func OpenDynamicMessage(def *model.Message, b []byte) DynamicMessage {
+	msg := types.OpenMessage(b)
+	return NewDynamicMessage(def, msg)
+}
+
+// OpenDynamicMessageErr opens and returns a message from bytes, or an error.
+// The method decodes the message table, but not the fields.
+func OpenDynamicMessageErr(def *model.Message, b []byte) (DynamicMessage, error) {
+	msg, err := types.OpenMessageErr(b)
+	if err != nil {
+		return DynamicMessage{}, err
+	}
+	return NewDynamicMessage(def, msg), nil
+}
+
+// ParseDynamicMessage recursively parses and returns a message.
+// The method also checks that all known fields can be decoded as their schema types.
+func ParseDynamicMessage(def *model.Message, b []byte) (_ DynamicMessage, size int, err error) {
+	msg, size, err := types.ParseMessage(b)
+	if err != nil {
+		return DynamicMessage{}, 0, err
+	}
+
+	m := NewDynamicMessage(def, msg)
+	for _, field := range def.Fields.List {
+		if _, err = m.Get(field.Name); err != nil {
+			return DynamicMessage{}, 0, err
+		}
+	}
+	return m, size, nil
+}
+
+// Definition returns the message definition.
+func (m DynamicMessage) Definition() *model.Message {
+	return m.def
+}
+
+// Empty returns true if bytes are empty or message has no fields.
+func (m DynamicMessage) Empty() bool {
+	return m.msg.Empty()
+}
+
+// Unwrap returns the underlying raw message.
+func (m DynamicMessage) Unwrap() types.Message {
+	return m.msg
+}
+
+// Fields
+
+// Field returns a field definition by a name, or an error if the field is not found.
+func (m DynamicMessage) Field(name string) (*model.Field, error) {
+	if m.def == nil {
+		return nil, fmt.Errorf("dynamic message: no definition")
+	}
+
+	field := m.def.Fields.Get(name)
+	if field == nil {
+		return nil, fmt.Errorf("%v: unknown field %q", m.def.Def.Name, name)
+	}
+	return field, nil
+}
+
+// Has returns true if the message contains a field.
+func (m DynamicMessage) Has(name string) bool {
+	field, err := m.Field(name)
+	if err != nil {
+		return false
+	}
+	return m.msg.HasField(uint16(field.Tag))
+}
+
+// Get decodes and returns a field value by a name, or nil if the field is absent.
+//
+// The value type depends on the field type:
+//   - primitive types are returned as Go types, i.e. int64, float64, bin.Bin128, etc.
+//   - bytes and strings are returned as []byte and string, backed by the message bytes.
+//   - enums are returned as [DynamicEnum].
+//   - structs are returned as [DynamicStruct].
+//   - messages are returned as [DynamicMessage].
+//   - lists are returned as [DynamicList].
+//   - any values and any messages are returned as types.Value and types.Message.
+func (m DynamicMessage) Get(name string) (any, error) {
+	field, err := m.Field(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := uint16(field.Tag)
+	if !m.msg.HasField(tag) {
+		return nil, nil
+	}
+
+	b := m.msg.FieldRaw(tag)
+	v, err := decodeValue(field.Type, b)
+	if err != nil {
+		return nil, fmt.Errorf("%v.%v: %w", m.def.Def.Name, name, err)
+	}
+	return v, nil
+}
+
+// Value returns a raw field value by a name, or nil if the field is absent.
+func (m DynamicMessage) Value(name string) (types.Value, error) {
+	field, err := m.Field(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.msg.Field(uint16(field.Tag)), nil
+}
+
+// Types
+
+// Bool decodes and returns a bool field.
+func (m DynamicMessage) Bool(name string) (bool, error) {
+	b, err := m.fieldRaw(name, model.KindBool)
+	if err != nil {
+		return false, err
+	}
+	v, _, err := decode.DecodeBool(b)
+	return v, err
+}
+
+// Byte decodes and returns a byte field.
+func (m DynamicMessage) Byte(name string) (byte, error) {
+	b, err := m.fieldRaw(name, model.KindByte)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeByte(b)
+	return v, err
+}
+
+// Int
+
+// Int16 decodes and returns an int16 field.
+func (m DynamicMessage) Int16(name string) (int16, error) {
+	b, err := m.fieldRaw(name, model.KindInt16)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeInt16(b)
+	return v, err
+}
+
+// Int32 decodes and returns an int16 or int32 field.
+func (m DynamicMessage) Int32(name string) (int32, error) {
+	b, err := m.fieldRaw(name, model.KindInt16, model.KindInt32)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeInt32(b)
+	return v, err
+}
+
+// Int64 decodes and returns an int16, int32 or int64 field.
+func (m DynamicMessage) Int64(name string) (int64, error) {
+	b, err := m.fieldRaw(name, model.KindInt16, model.KindInt32, model.KindInt64)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeInt64(b)
+	return v, err
+}
+
+// Uint
+
+// Uint16 decodes and returns a uint16 field.
+func (m DynamicMessage) Uint16(name string) (uint16, error) {
+	b, err := m.fieldRaw(name, model.KindUint16)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeUint16(b)
+	return v, err
+}
+
+// Uint32 decodes and returns a uint16 or uint32 field.
+func (m DynamicMessage) Uint32(name string) (uint32, error) {
+	b, err := m.fieldRaw(name, model.KindUint16, model.KindUint32)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeUint32(b)
+	return v, err
+}
+
+// Uint64 decodes and returns a uint16, uint32 or uint64 field.
+func (m DynamicMessage) Uint64(name string) (uint64, error) {
+	b, err := m.fieldRaw(name, model.KindUint16, model.KindUint32, model.KindUint64)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeUint64(b)
+	return v, err
+}
+
+// Float
+
+// Float32 decodes and returns a float32 field.
+func (m DynamicMessage) Float32(name string) (float32, error) {
+	b, err := m.fieldRaw(name, model.KindFloat32)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeFloat32(b)
+	return v, err
+}
+
+// Float64 decodes and returns a float32 or float64 field.
+func (m DynamicMessage) Float64(name string) (float64, error) {
+	b, err := m.fieldRaw(name, model.KindFloat32, model.KindFloat64)
+	if err != nil {
+		return 0, err
+	}
+	v, _, err := decode.DecodeFloat64(b)
+	return v, err
+}
+
+// Bin
+
+// Bin64 decodes and returns a bin64 field.
+func (m DynamicMessage) Bin64(name string) (bin.Bin64, error) {
+	b, err := m.fieldRaw(name, model.KindBin64)
+	if err != nil {
+		return bin.Bin64{}, err
+	}
+	v, _, err := decode.DecodeBin64(b)
+	return v, err
+}
+
+// Bin128 decodes and returns a bin128 field.
+func (m DynamicMessage) Bin128(name string) (bin.Bin128, error) {
+	b, err := m.fieldRaw(name, model.KindBin128)
+	if err != nil {
+		return bin.Bin128{}, err
+	}
+	v, _, err := decode.DecodeBin128(b)
+	return v, err
+}
+
+// Bin256 decodes and returns a bin256 field.
+func (m DynamicMessage) Bin256(name string) (bin.Bin256, error) {
+	b, err := m.fieldRaw(name, model.KindBin256)
+	if err != nil {
+		return bin.Bin256{}, err
+	}
+	v, _, err := decode.DecodeBin256(b)
+	return v, err
+}
+
+// Bytes/string
+
+// Bytes decodes and returns a bytes field.
+func (m DynamicMessage) Bytes(name string) (format.Bytes, error) {
+	b, err := m.fieldRaw(name, model.KindBytes)
+	if err != nil {
+		return nil, err
+	}
+	v, _, err := decode.DecodeBytes(b)
+	return v, err
+}
+
+// String decodes and returns a string field.
+func (m DynamicMessage) String(name string) (format.String, error) {
+	b, err := m.fieldRaw(name, model.KindString)
+	if err != nil {
+		return "", err
+	}
+	v, _, err := decode.DecodeString(b)
+	return v, err
+}
+
+// Enum/struct
+
+// Enum decodes and returns an enum field.
+func (m DynamicMessage) Enum(name string) (DynamicEnum, error) {
+	field, err := m.fieldKind(name, model.KindEnum)
+	if err != nil {
+		return DynamicEnum{}, err
+	}
+
+	b := m.msg.FieldRaw(uint16(field.Tag))
+	v, _, err := decodeEnum(field.Type.Ref.Enum, b)
+	return v, err
+}
+
+// Struct decodes and returns a struct field.
+func (m DynamicMessage) Struct(name string) (DynamicStruct, error) {
+	field, err := m.fieldKind(name, model.KindStruct)
+	if err != nil {
+		return DynamicStruct{}, err
+	}
+
+	b := m.msg.FieldRaw(uint16(field.Tag))
+	v, _, err := decodeStruct(field.Type.Ref.Struct, b)
+	return v, err
+}
+
+// List/message
+
+// List opens and returns a list field.
+func (m DynamicMessage) List(name string) (DynamicList, error) {
+	field, err := m.fieldKind(name, model.KindList)
+	if err != nil {
+		return DynamicList{}, err
+	}
+
+	b := m.msg.FieldRaw(uint16(field.Tag))
+	return OpenDynamicListErr(field.Type.Element, b)
+}
+
+// Message opens and returns a message field.
+func (m DynamicMessage) Message(name string) (DynamicMessage, error) {
+	field, err := m.fieldKind(name, model.KindMessage)
+	if err != nil {
+		return DynamicMessage{}, err
+	}
+
+	b := m.msg.FieldRaw(uint16(field.Tag))
+	return OpenDynamicMessageErr(field.Type.Ref.Message, b)
+}
+
+// Any/message
+
+// Any returns an any field as a raw value.
+func (m DynamicMessage) Any(name string) (types.Value, error) {
+	field, err := m.fieldKind(name, model.KindAny)
+	if err != nil {
+		return nil, err
+	}
+	return m.msg.Field(uint16(field.Tag)), nil
+}
+
+// AnyMessage opens and returns an any message field as a raw message.
+func (m DynamicMessage) AnyMessage(name string) (types.Message, error) {
+	b, err := m.fieldRaw(name, model.KindAnyMessage)
+	if err != nil {
+		return types.Message{}, err
+	}
+	return types.OpenMessageErr(b)
+}
+
+// internal
+
+func (m DynamicMessage) fieldKind(name string, kinds ...model.Kind) (*model.Field, error) {
+	field, err := m.Field(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkKind(field.Type, kinds...); err != nil {
+		return nil, fmt.Errorf("%v.%v: %w", m.def.Def.Name, name, err)
+	}
+	return field, nil
+}
+
+func (m DynamicMessage) fieldRaw(name string, kinds ...model.Kind) ([]byte, error) {
+	field, err := m.fieldKind(name, kinds...)
+	if err != nil {
+		return nil, err
+	}
+	return m.msg.FieldRaw(uint16(field.Tag)), nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/tests/pkg1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDefinition(t *testing.T, name string) *model.Definition {
+	pkg := langtest.Package(t, "pkg1")
+
+	def, ok := pkg.DefinitionNames[name]
+	if !ok {
+		t.Fatalf("definition %q not found", name)
+	}
+	return def
+}
+
+func testMessage(t *testing.T) DynamicMessage {
+	o := pkg1.TestObject(t)
+
+	msg, err := o.Write(pkg1.NewMessageWriter())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := testDefinition(t, "Message")
+	return NewDynamicMessage(def.Message, msg.Unwrap())
+}
+
+// Get
+
+func TestDynamicMessage_Get__should_return_field_values(t *testing.T) {
+	m := testMessage(t)
+
+	v, err := m.Get("int64")
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), v)
+
+	v, err = m.Get("string")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", v)
+
+	v, err = m.Get("bytes1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("goodbye, world"), v)
+
+	v, err = m.Get("enum1")
+	require.NoError(t, err)
+	assert.Equal(t, "ONE", v.(DynamicEnum).Name())
+}
+
+func TestDynamicMessage_Get__should_return_nil_when_field_absent(t *testing.T) {
+	def := testDefinition(t, "Message")
+	m := OpenDynamicMessage(def.Message, nil)
+
+	v, err := m.Get("int64")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestDynamicMessage_Get__should_return_error_on_unknown_field(t *testing.T) {
+	m := testMessage(t)
+
+	_, err := m.Get("unknown")
+	assert.Error(t, err)
+}
+
+// Typed
+
+func TestDynamicMessage__should_return_typed_values(t *testing.T) {
+	m := testMessage(t)
+
+	b, err := m.Bool("bool")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	i32, err := m.Int32("int32")
+	require.NoError(t, err)
+	assert.Equal(t, int32(math.MaxInt32), i32)
+
+	u64, err := m.Uint64("uint64")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+
+	f64, err := m.Float64("float64")
+	require.NoError(t, err)
+	assert.Equal(t, float64(math.MaxFloat64), f64)
+
+	b128, err := m.Bin128("bin128")
+	require.NoError(t, err)
+	assert.Equal(t, bin.Int128(0, 2), b128)
+
+	s, err := m.String("string")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", s.Unwrap())
+}
+
+func TestDynamicMessage__should_widen_integers(t *testing.T) {
+	m := testMessage(t)
+
+	v, err := m.Int64("int16")
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt16), v)
+}
+
+func TestDynamicMessage__should_return_error_on_invalid_kind(t *testing.T) {
+	m := testMessage(t)
+
+	_, err := m.Int16("int64")
+	assert.Error(t, err)
+
+	_, err = m.String("int64")
+	assert.Error(t, err)
+}
+
+func TestDynamicMessage_Struct__should_return_struct(t *testing.T) {
+	m := testMessage(t)
+
+	s, err := m.Struct("struct1")
+	require.NoError(t, err)
+
+	expected := pkg1.TestStruct()
+	key, err := s.Get("key")
+	require.NoError(t, err)
+	value, err := s.Get("value")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Key, key)
+	assert.Equal(t, expected.Value, value)
+}
+
+// Nested
+
+func TestDynamicMessage_Message__should_return_nested_message(t *testing.T) {
+	m := testMessage(t)
+
+	sub, err := m.Message("submessage")
+	require.NoError(t, err)
+
+	v, err := sub.String("value")
+	require.NoError(t, err)
+	assert.Equal(t, "value 000", v.Unwrap())
+}
+
+func TestDynamicMessage_Message__should_return_imported_message(t *testing.T) {
+	m := testMessage(t)
+
+	sub, err := m.Message("submessage1")
+	require.NoError(t, err)
+	assert.Equal(t, "Submessage", sub.Definition().Def.Name)
+	assert.Equal(t, "pkg2", sub.Definition().Package.Name)
+}
+
+func TestDynamicMessage_List__should_return_lists(t *testing.T) {
+	m := testMessage(t)
+
+	ints, err := m.List("ints")
+	require.NoError(t, err)
+	require.Equal(t, 10, ints.Len())
+
+	values, err := ints.Values()
+	require.NoError(t, err)
+	for i, v := range values {
+		assert.Equal(t, int64(i), v)
+	}
+
+	structs, err := m.List("structs")
+	require.NoError(t, err)
+	require.Equal(t, 10, structs.Len())
+
+	v, err := structs.Get(3)
+	require.NoError(t, err)
+	key, _ := v.(DynamicStruct).Get("key")
+	assert.Equal(t, int32(3), key)
+
+	subs, err := m.List("submessages")
+	require.NoError(t, err)
+
+	sub, err := subs.Message(1)
+	require.NoError(t, err)
+	s, err := sub.String("value")
+	require.NoError(t, err)
+	assert.Equal(t, "value 001", s.Unwrap())
+}
+
+// Parse
+
+func TestParseDynamicMessage__should_parse_message(t *testing.T) {
+	m := testMessage(t)
+	b := m.Unwrap().Raw()
+
+	m1, n, err := ParseDynamicMessage(m.Definition(), b)
+	require.NoError(t, err)
+	assert.Equal(t, len(b), n)
+	assert.Equal(t, b, m1.Unwrap().Raw())
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// DynamicStruct is a struct value which type is known only at runtime.
+// Its field values are stored in the struct field order.
+type DynamicStruct struct {
+	def    *model.Struct
+	values []any
+}
+
+// NewDynamicStruct returns a new struct with zero field values.
+func NewDynamicStruct(def *model.Struct) DynamicStruct {
+	fields := def.Fields.Values()
+
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		values[i] = zeroValue(field.Type)
+	}
+
+	return DynamicStruct{
+		def:    def,
+		values: values,
+	}
+}
+
+// Definition returns the struct definition.
+func (s DynamicStruct) Definition() *model.Struct {
+	return s.def
+}
+
+// Get returns a field value by a name.
+func (s DynamicStruct) Get(name string) (any, error) {
+	i := s.def.Fields.Index(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%v: unknown field %q", s.def.Def.Name, name)
+	}
+	return s.values[i], nil
+}
+
+// Set sets a field value by a name, the value must match the field type.
+func (s DynamicStruct) Set(name string, v any) error {
+	i := s.def.Fields.Index(name)
+	if i < 0 {
+		return fmt.Errorf("%v: unknown field %q", s.def.Def.Name, name)
+	}
+
+	field := s.def.Fields.Value(i)
+	if err := checkScalar(field.Type, v); err != nil {
+		return fmt.Errorf("%v.%v: %w", s.def.Def.Name, name, err)
+	}
+
+	s.values[i] = v
+	return nil
+}
+
+// Values returns field values in the struct field order.
+func (s DynamicStruct) Values() []any {
+	return s.values
+}
+
+// internal
+
+func decodeStruct(def *model.Struct, b []byte) (s DynamicStruct, size int, err error) {
+	s = NewDynamicStruct(def)
+
+	dataSize, size, err := decode.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return s, size, err
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+	fields := def.Fields.Values()
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := fields[i]
+
+		var v any
+		v, n, err = decodeScalar(field.Type, b[:off])
+		if err != nil {
+			return s, 0, fmt.Errorf("%v.%v: %w", def.Def.Name, field.Name, err)
+		}
+
+		// Clone bytes/strings, structs are values
+		switch field.Type.Kind {
+		case model.KindBytes:
+			v = bytes.Clone(v.([]byte))
+		case model.KindString:
+			v = strings.Clone(v.(string))
+		}
+
+		s.values[i] = v
+		off -= n
+	}
+	return s, size, nil
+}
+
+func encodeStruct(b buffer.Buffer, s DynamicStruct) (int, error) {
+	var dataSize int
+
+	fields := s.def.Fields.Values()
+	for i, field := range fields {
+		n, err := encodeScalar(b, field.Type, s.values[i])
+		if err != nil {
+			return 0, fmt.Errorf("%v.%v: %w", s.def.Def.Name, field.Name, err)
+		}
+		dataSize += n
+	}
+
+	n, err := encode.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/text"
+	"github.com/basecomplextech/spec/internal/types"
+	"github.com/basecomplextech/spec/internal/writer"
+)
+
+// Text mapping, see the text package for the schema-free format:
+//   - messages and structs are objects keyed by field names, i.e. {id: 1, name: "a"}.
+//   - bools, integers and floats are untyped literals, non-finite floats are nan, inf and -inf.
+//   - bin64, bin128 and bin256 are hex literals, i.e. 0x0123456789abcdef.
+//   - bytes and strings are string literals, bytes also accept hex literals.
+//   - enums are value names, or numbers when values are unknown.
+//   - lists are [...].
+//   - any values and any messages are schema-free text values.
+//
+// Absent message fields are omitted, unknown message fields are ignored.
+
+// FormatText returns a text object with present message fields in the definition order.
+func (m DynamicMessage) FormatText() (string, error) {
+	p := &textPrinter{}
+	if err := p.message(m, 0); err != nil {
+		return "", err
+	}
+	return p.String(), nil
+}
+
+// WriteText writes message fields from a text object, see [DynamicMessage.FormatText].
+func (w DynamicWriter) WriteText(src []byte) error {
+	node, err := text.ParseNode(src)
+	if err != nil {
+		return fmt.Errorf("%v: %w", w.def.Def.Name, err)
+	}
+	return w.writeText(node)
+}
+
+// ParseText writes and returns a message from a text object, see [DynamicWriter.WriteText].
+func ParseText(def *model.Message, src []byte) (_ DynamicMessage, err error) {
+	w := NewDynamicWriter(def)
+	if err := w.WriteText(src); err != nil {
+		return DynamicMessage{}, err
+	}
+	return w.Build()
+}
+
+// internal
+
+func (w DynamicWriter) writeText(node *text.Node) error {
+	if node.Kind != text.NodeObject {
+		return fmt.Errorf("%v: %w", w.def.Def.Name, node.Errorf("expected object, got %v", node))
+	}
+
+	for _, f := range node.Fields {
+		field := w.def.Fields.Get(f.Key)
+		if field == nil {
+			return fmt.Errorf("%v: line %d: unknown field %q", w.def.Def.Name, f.Line, f.Key)
+		}
+		if w.w.HasField(uint16(field.Tag)) {
+			return fmt.Errorf("%v: line %d: duplicate field %q", w.def.Def.Name, f.Line, f.Key)
+		}
+
+		if err := w.writeTextField(field, f.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w DynamicWriter) writeTextField(field *model.Field, node *text.Node) error {
+	switch field.Type.Kind {
+	case model.KindMessage:
+		mw, err := w.Message(field.Name)
+		if err != nil {
+			return err
+		}
+		if err := mw.writeText(node); err != nil {
+			return err
+		}
+		return mw.End()
+
+	case model.KindList:
+		lw, err := w.List(field.Name)
+		if err != nil {
+			return err
+		}
+		if err := lw.writeText(node); err != nil {
+			return fmt.Errorf("%v.%v: %w", w.def.Def.Name, field.Name, err)
+		}
+		return lw.End()
+	}
+
+	v, err := parseTextValue(field.Type, node)
+	if err != nil {
+		return fmt.Errorf("%v.%v: %w", w.def.Def.Name, field.Name, err)
+	}
+	return w.Set(field.Name, v)
+}
+
+func (w DynamicListWriter) writeText(node *text.Node) error {
+	if node.Kind != text.NodeList {
+		return node.Errorf("expected list, got %v", node)
+	}
+
+	for i, elem := range node.Args {
+		switch w.elem.Kind {
+		case model.KindMessage:
+			mw, err := w.Message()
+			if err != nil {
+				return err
+			}
+			if err := mw.writeText(elem); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := mw.End(); err != nil {
+				return err
+			}
+
+		case model.KindList:
+			lw, err := w.List()
+			if err != nil {
+				return err
+			}
+			if err := lw.writeText(elem); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := lw.End(); err != nil {
+				return err
+			}
+
+		default:
+			v, err := parseTextValue(w.elem, elem)
+			if err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if err := w.Add(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseTextValue parses a fixed value or an any value.
+func parseTextValue(t *model.Type, node *text.Node) (any, error) {
+	switch t.Kind {
+	case model.KindAny, model.KindAnyMessage:
+		if t.Kind == model.KindAnyMessage && node.Kind != text.NodeObject {
+			return nil, node.Errorf("expected message, got %v", node)
+		}
+
+		w := writer.New(false)
+		defer w.Free()
+
+		b, err := text.Write(w, node)
+		if err != nil {
+			return nil, err
+		}
+
+		// Clone bytes, the writer is freed
+		v := types.Value(bytes.Clone(b))
+		if t.Kind == model.KindAnyMessage {
+			return types.OpenMessageErr(v)
+		}
+		return v, nil
+	}
+
+	return parseTextScalar(t, node)
+}
+
+// parseTextScalar parses a fixed value, i.e. a primitive, bytes, string, enum or struct.
+func parseTextScalar(t *model.Type, node *text.Node) (any, error) {
+	switch t.Kind {
+	case model.KindBool:
+		return node.Bool()
+	case model.KindByte:
+		v, err := node.Uint(8)
+		return byte(v), err
+
+	case model.KindInt16:
+		v, err := node.Int(16)
+		return int16(v), err
+	case model.KindInt32:
+		v, err := node.Int(32)
+		return int32(v), err
+	case model.KindInt64:
+		return node.Int(64)
+
+	case model.KindUint16:
+		v, err := node.Uint(16)
+		return uint16(v), err
+	case model.KindUint32:
+		v, err := node.Uint(32)
+		return uint32(v), err
+	case model.KindUint64:
+		return node.Uint(64)
+
+	case model.KindFloat32:
+		v, err := node.Float(32)
+		return float32(v), err
+	case model.KindFloat64:
+		return node.Float(64)
+
+	case model.KindBin64:
+		return node.Bin64()
+	case model.KindBin128:
+		return node.Bin128()
+	case model.KindBin256:
+		return node.Bin256()
+
+	case model.KindBytes:
+		return node.Bytes()
+	case model.KindString:
+		if node.Kind != text.NodeString {
+			return nil, node.Errorf("expected string, got %v", node)
+		}
+		return node.Text, nil
+
+	case model.KindEnum:
+		switch node.Kind {
+		case text.NodeIdent:
+			e, err := ParseDynamicEnum(t.Ref.Enum, node.Text)
+			if err != nil {
+				return nil, node.Errorf("%v", err)
+			}
+			return e, nil
+		case text.NodeInt:
+			n, err := node.Int(32)
+			if err != nil {
+				return nil, err
+			}
+			return NewDynamicEnum(t.Ref.Enum, int32(n)), nil
+		}
+		return nil, node.Errorf("expected enum value, got %v", node)
+
+	case model.KindStruct:
+		return parseTextStruct(t.Ref.Struct, node)
+	}
+
+	return nil, node.Errorf("unsupported text type %v", t.SourceName())
+}
+
+func parseTextStruct(def *model.Struct, node *text.Node) (DynamicStruct, error) {
+	if node.Kind != text.NodeObject {
+		return DynamicStruct{}, node.Errorf("expected object, got %v", node)
+	}
+
+	s := NewDynamicStruct(def)
+	for _, f := range node.Fields {
+		i := def.Fields.Index(f.Key)
+		if i < 0 {
+			return DynamicStruct{}, fmt.Errorf("%v: line %d: unknown field %q", def.Def.Name, f.Line, f.Key)
+		}
+
+		field := def.Fields.Value(i)
+		v, err := parseTextScalar(field.Type, f.Value)
+		if err != nil {
+			return DynamicStruct{}, fmt.Errorf("%v.%v: %w", def.Def.Name, f.Key, err)
+		}
+		s.values[i] = v
+	}
+	return s, nil
+}
+
+// printer
+
+// textIndent is a nested value indentation, matches the text package.
+const textIndent = "    "
+
+type textPrinter struct {
+	strings.Builder
+}
+
+// value prints a value, see [DynamicMessage.Get] for the value types.
+func (p *textPrinter) value(v any, depth int) error {
+	switch v := v.(type) {
+	case DynamicMessage:
+		return p.message(v, depth)
+	case DynamicList:
+		return p.list(v, depth)
+	case DynamicStruct:
+		return p.struct_(v)
+
+	case DynamicEnum:
+		if val := v.Value(); val != nil {
+			p.WriteString(val.Name)
+		} else {
+			p.WriteString(strconv.FormatInt(int64(v.number), 10))
+		}
+
+	case bool:
+		p.WriteString(strconv.FormatBool(v))
+	case byte:
+		p.WriteString(strconv.FormatUint(uint64(v), 10))
+
+	case int16:
+		p.WriteString(strconv.FormatInt(int64(v), 10))
+	case int32:
+		p.WriteString(strconv.FormatInt(int64(v), 10))
+	case int64:
+		p.WriteString(strconv.FormatInt(v, 10))
+
+	case uint16:
+		p.WriteString(strconv.FormatUint(uint64(v), 10))
+	case uint32:
+		p.WriteString(strconv.FormatUint(uint64(v), 10))
+	case uint64:
+		p.WriteString(strconv.FormatUint(v, 10))
+
+	case float32:
+		p.WriteString(formatTextFloat(float64(v), 32))
+	case float64:
+		p.WriteString(formatTextFloat(v, 64))
+
+	case bin.Bin64:
+		p.WriteString("0x" + hex.EncodeToString(v.Marshal()))
+	case bin.Bin128:
+		p.WriteString("0x" + hex.EncodeToString(v.Marshal()))
+	case bin.Bin256:
+		p.WriteString("0x" + hex.EncodeToString(v.Marshal()))
+
+	case []byte:
+		p.WriteString(strconv.Quote(string(v)))
+	case format.Bytes:
+		p.WriteString(strconv.Quote(string(v)))
+	case string:
+		p.WriteString(strconv.Quote(v))
+	case format.String:
+		p.WriteString(strconv.Quote(string(v)))
+
+	case types.Value:
+		return p.any(v, depth)
+	case types.Message:
+		return p.any(v.Raw(), depth)
+
+	default:
+		return fmt.Errorf("unsupported text value %T", v)
+	}
+	return nil
+}
+
+func (p *textPrinter) message(m DynamicMessage, depth int) error {
+	n := 0
+	for _, field := range m.def.Fields.List {
+		tag := uint16(field.Tag)
+		if !m.msg.HasField(tag) {
+			continue
+		}
+
+		v, err := decodeValue(field.Type, m.msg.FieldRaw(tag))
+		if err != nil {
+			return fmt.Errorf("%v.%v: %w", m.def.Def.Name, field.Name, err)
+		}
+
+		if n == 0 {
+			p.WriteByte('{')
+		}
+		p.newline(depth + 1)
+		p.WriteString(field.Name)
+		p.WriteString(": ")
+
+		if err := p.value(v, depth+1); err != nil {
+			return fmt.Errorf("%v.%v: %w", m.def.Def.Name, field.Name, err)
+		}
+		p.WriteByte(',')
+		n++
+	}
+
+	if n == 0 {
+		p.WriteString("{}")
+		return nil
+	}
+	p.newline(depth)
+	p.WriteByte('}')
+	return nil
+}
+
+// list prints a list on one line, or on multiple lines when it contains lists or messages.
+func (p *textPrinter) list(l DynamicList, depth int) error {
+	if l.Len() == 0 {
+		p.WriteString("[]")
+		return nil
+	}
+
+	multiline := false
+	switch l.elem.Kind {
+	case model.KindList, model.KindMessage, model.KindAny, model.KindAnyMessage:
+		multiline = true
+	}
+
+	p.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		switch {
+		case multiline:
+			p.newline(depth + 1)
+		case i > 0:
+			p.WriteString(", ")
+		}
+
+		elem, err := l.Get(i)
+		if err != nil {
+			return err
+		}
+		if err := p.value(elem, depth+1); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+		if multiline {
+			p.WriteByte(',')
+		}
+	}
+	if multiline {
+		p.newline(depth)
+	}
+	p.WriteByte(']')
+	return nil
+}
+
+// struct_ prints struct fields on one line in the definition order.
+func (p *textPrinter) struct_(s DynamicStruct) error {
+	p.WriteByte('{')
+	for i, field := range s.def.Fields.Values() {
+		if i > 0 {
+			p.WriteString(", ")
+		}
+		p.WriteString(field.Name)
+		p.WriteString(": ")
+
+		if err := p.value(s.values[i], 0); err != nil {
+			return err
+		}
+	}
+	p.WriteByte('}')
+	return nil
+}
+
+// any prints a schema-free text value indented at the depth.
+func (p *textPrinter) any(b []byte, depth int) error {
+	s, err := text.Format(b)
+	if err != nil {
+		return err
+	}
+
+	s = strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(textIndent, depth))
+	p.WriteString(s)
+	return nil
+}
+
+func (p *textPrinter) newline(depth int) {
+	p.WriteByte('\n')
+	p.WriteString(strings.Repeat(textIndent, depth))
+}
+
+func formatTextFloat(v float64, bits int) string {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, bits)
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FormatText
+
+func TestDynamicMessage_FormatText__should_format_message(t *testing.T) {
+	m := testMessage(t)
+
+	s, err := m.FormatText()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(s, "{\n    bool: true,\n    byte: 255,\n"))
+	assert.Contains(t, s, "\n    bin64: 0x0000000000000001,\n")
+	assert.Contains(t, s, "\n    string: \"hello, world\",\n")
+	assert.Contains(t, s, "\n    enum1: ONE,\n")
+	assert.Contains(t, s, "\n    struct1: {key: 1, value: -1},\n")
+	assert.Contains(t, s, "\n    message1: {\n        ")
+	assert.Contains(t, s, "\n        2: int32(2),\n")
+	assert.Contains(t, s, "\n    ints: [0, 1, 2, 3, 4, 5, 6, 7, 8, 9],\n")
+	assert.Contains(t, s, "\n    submessages: [\n        {\n            value: \"value 000\",\n        },\n")
+}
+
+func TestDynamicMessage_FormatText__should_omit_absent_fields(t *testing.T) {
+	def := testDefinition(t, "Submessage")
+
+	w := NewDynamicWriter(def.Message)
+	require.NoError(t, w.String("value", "hello"))
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	s, err := m.FormatText()
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    value: \"hello\",\n}", s)
+}
+
+// ParseText
+
+func TestParseText__should_roundtrip_message(t *testing.T) {
+	m := testMessage(t)
+	def := m.Definition()
+
+	s, err := m.FormatText()
+	require.NoError(t, err)
+
+	m1, err := ParseText(def, []byte(s))
+	require.NoError(t, err)
+
+	s1, err := m1.FormatText()
+	require.NoError(t, err)
+	assert.Equal(t, s, s1)
+}
+
+func TestParseText__should_parse_enum_numbers_and_hex_bytes(t *testing.T) {
+	def := testDefinition(t, "Message")
+
+	m, err := ParseText(def.Message, []byte(`{enum1: 2, bytes1: 0x6869, int16: -0x10}`))
+	require.NoError(t, err)
+
+	e, err := m.Enum("enum1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), e.Number())
+
+	b, err := m.Bytes("bytes1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hi"), b.Unwrap())
+
+	n, err := m.Int16("int16")
+	require.NoError(t, err)
+	assert.Equal(t, int16(-16), n)
+}
+
+func TestParseText__should_return_errors(t *testing.T) {
+	def := testDefinition(t, "Message")
+
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`{unknown: 1}`, `Message: line 1: unknown field "unknown"`},
+		{"{\nint16: 100000}", `Message.int16: line 2: invalid int16 100000`},
+		{`{bool: true, bool: false}`, `Message: line 1: duplicate field "bool"`},
+		{`{string: 1}`, `Message.string: line 1: expected string, got 1`},
+		{`{enum1: UNKNOWN}`, `Message.enum1: line 1: Enum: unknown enum value "UNKNOWN"`},
+		{`[]`, `Message: line 1: expected object, got list`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseText(def.Message, []byte(tt.src))
+		assert.EqualError(t, err, tt.err, tt.src)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// decodeValue decodes a value of any type, see [DynamicMessage.Get].
+func decodeValue(t *model.Type, b []byte) (any, error) {
+	switch t.Kind {
+	case model.KindAny:
+		return types.OpenValueErr(b)
+	case model.KindAnyMessage:
+		return types.OpenMessageErr(b)
+
+	case model.KindList:
+		return OpenDynamicListErr(t.Element, b)
+	case model.KindMessage:
+		return OpenDynamicMessageErr(t.Ref.Message, b)
+	}
+
+	v, _, err := decodeScalar(t, b)
+	return v, err
+}
+
+// decodeScalar decodes a fixed value, i.e. a primitive, bytes, string, enum or struct,
+// and returns the value and its size.
+func decodeScalar(t *model.Type, b []byte) (any, int, error) {
+	switch t.Kind {
+	case model.KindBool:
+		return decode.DecodeBool(b)
+	case model.KindByte:
+		return decode.DecodeByte(b)
+
+	case model.KindInt16:
+		return decode.DecodeInt16(b)
+	case model.KindInt32:
+		return decode.DecodeInt32(b)
+	case model.KindInt64:
+		return decode.DecodeInt64(b)
+
+	case model.KindUint16:
+		return decode.DecodeUint16(b)
+	case model.KindUint32:
+		return decode.DecodeUint32(b)
+	case model.KindUint64:
+		return decode.DecodeUint64(b)
+
+	case model.KindFloat32:
+		return decode.DecodeFloat32(b)
+	case model.KindFloat64:
+		return decode.DecodeFloat64(b)
+
+	case model.KindBin64:
+		return decode.DecodeBin64(b)
+	case model.KindBin128:
+		return decode.DecodeBin128(b)
+	case model.KindBin256:
+		return decode.DecodeBin256(b)
+
+	case model.KindBytes:
+		v, n, err := decode.DecodeBytes(b)
+		return []byte(v), n, err
+	case model.KindString:
+		v, n, err := decode.DecodeString(b)
+		return string(v), n, err
+
+	case model.KindEnum:
+		return decodeEnum(t.Ref.Enum, b)
+	case model.KindStruct:
+		return decodeStruct(t.Ref.Struct, b)
+	}
+
+	return nil, 0, fmt.Errorf("unsupported type %v", t.SourceName())
+}
+
+// zeroValue returns a zero value of a fixed type.
+func zeroValue(t *model.Type) any {
+	switch t.Kind {
+	case model.KindBool:
+		return false
+	case model.KindByte:
+		return byte(0)
+
+	case model.KindInt16:
+		return int16(0)
+	case model.KindInt32:
+		return int32(0)
+	case model.KindInt64:
+		return int64(0)
+
+	case model.KindUint16:
+		return uint16(0)
+	case model.KindUint32:
+		return uint32(0)
+	case model.KindUint64:
+		return uint64(0)
+
+	case model.KindFloat32:
+		return float32(0)
+	case model.KindFloat64:
+		return float64(0)
+
+	case model.KindBin64:
+		return bin.Bin64{}
+	case model.KindBin128:
+		return bin.Bin128{}
+	case model.KindBin256:
+		return bin.Bin256{}
+
+	case model.KindBytes:
+		return []byte(nil)
+	case model.KindString:
+		return ""
+
+	case model.KindEnum:
+		return NewDynamicEnum(t.Ref.Enum, 0)
+	case model.KindStruct:
+		return NewDynamicStruct(t.Ref.Struct)
+	}
+	return nil
+}
+
+// checkValue returns an error if a value does not match a type, see [DynamicWriter.Set].
+func checkValue(t *model.Type, v any) error {
+	var ok bool
+
+	switch t.Kind {
+	case model.KindAny:
+		_, ok = v.(types.Value)
+	case model.KindAnyMessage:
+		switch v.(type) {
+		case types.Message, DynamicMessage:
+			ok = true
+		}
+
+	case model.KindList:
+		var l DynamicList
+		l, ok = v.(DynamicList)
+		ok = ok && sameType(l.elem, t.Element)
+	case model.KindMessage:
+		var m DynamicMessage
+		m, ok = v.(DynamicMessage)
+		ok = ok && m.def == t.Ref.Message
+
+	default:
+		return checkScalar(t, v)
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid value %T for type %v", v, t.SourceName())
+	}
+	return nil
+}
+
+// checkScalar returns an error if a value does not match a fixed type.
+func checkScalar(t *model.Type, v any) error {
+	var ok bool
+
+	switch t.Kind {
+	case model.KindBool:
+		_, ok = v.(bool)
+	case model.KindByte:
+		_, ok = v.(byte)
+
+	case model.KindInt16:
+		_, ok = v.(int16)
+	case model.KindInt32:
+		_, ok = v.(int32)
+	case model.KindInt64:
+		_, ok = v.(int64)
+
+	case model.KindUint16:
+		_, ok = v.(uint16)
+	case model.KindUint32:
+		_, ok = v.(uint32)
+	case model.KindUint64:
+		_, ok = v.(uint64)
+
+	case model.KindFloat32:
+		_, ok = v.(float32)
+	case model.KindFloat64:
+		_, ok = v.(float64)
+
+	case model.KindBin64:
+		_, ok = v.(bin.Bin64)
+	case model.KindBin128:
+		_, ok = v.(bin.Bin128)
+	case model.KindBin256:
+		_, ok = v.(bin.Bin256)
+
+	case model.KindBytes:
+		switch v.(type) {
+		case []byte, format.Bytes:
+			ok = true
+		}
+	case model.KindString:
+		switch v.(type) {
+		case string, format.String:
+			ok = true
+		}
+
+	case model.KindEnum:
+		var e DynamicEnum
+		e, ok = v.(DynamicEnum)
+		ok = ok && e.def == t.Ref.Enum
+	case model.KindStruct:
+		var s DynamicStruct
+		s, ok = v.(DynamicStruct)
+		ok = ok && s.def == t.Ref.Struct
+
+	default:
+		return fmt.Errorf("unsupported type %v", t.SourceName())
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid value %T for type %v", v, t.SourceName())
+	}
+	return nil
+}
+
+// encodeScalar checks and encodes a fixed value into a buffer.
+func encodeScalar(b buffer.Buffer, t *model.Type, v any) (int, error) {
+	if err := checkScalar(t, v); err != nil {
+		return 0, err
+	}
+
+	switch t.Kind {
+	case model.KindBool:
+		return encode.EncodeBool(b, v.(bool))
+	case model.KindByte:
+		return encode.EncodeByte(b, v.(byte))
+
+	case model.KindInt16:
+		return encode.EncodeInt16(b, v.(int16))
+	case model.KindInt32:
+		return encode.EncodeInt32(b, v.(int32))
+	case model.KindInt64:
+		return encode.EncodeInt64(b, v.(int64))
+
+	case model.KindUint16:
+		return encode.EncodeUint16(b, v.(uint16))
+	case model.KindUint32:
+		return encode.EncodeUint32(b, v.(uint32))
+	case model.KindUint64:
+		return encode.EncodeUint64(b, v.(uint64))
+
+	case model.KindFloat32:
+		return encode.EncodeFloat32(b, v.(float32))
+	case model.KindFloat64:
+		return encode.EncodeFloat64(b, v.(float64))
+
+	case model.KindBin64:
+		return encode.EncodeBin64(b, v.(bin.Bin64))
+	case model.KindBin128:
+		return encode.EncodeBin128(b, v.(bin.Bin128))
+	case model.KindBin256:
+		return encode.EncodeBin256(b, v.(bin.Bin256))
+
+	case model.KindBytes:
+		switch v := v.(type) {
+		case format.Bytes:
+			return encode.EncodeBytes(b, v)
+		default:
+			return encode.EncodeBytes(b, v.([]byte))
+		}
+	case model.KindString:
+		switch v := v.(type) {
+		case format.String:
+			return encode.EncodeString(b, string(v))
+		default:
+			return encode.EncodeString(b, v.(string))
+		}
+
+	case model.KindEnum:
+		return encodeEnum(b, v.(DynamicEnum))
+	case model.KindStruct:
+		return encodeStruct(b, v.(DynamicStruct))
+	}
+
+	return 0, fmt.Errorf("unsupported type %v", t.SourceName())
+}
+
+// util
+
+// checkKind returns an error if the type kind is not one of the kinds.
+func checkKind(t *model.Type, kinds ...model.Kind) error {
+	for _, kind := range kinds {
+		if t.Kind == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid type %v", t.SourceName())
+}
+
+// sameType returns true if two types are equal.
+func sameType(t0, t1 *model.Type) bool {
+	if t0.Kind != t1.Kind {
+		return false
+	}
+
+	switch t0.Kind {
+	case model.KindList:
+		return sameType(t0.Element, t1.Element)
+	case model.KindEnum, model.KindMessage, model.KindStruct, model.KindService:
+		return t0.Ref == t1.Ref
+	}
+	return true
+}
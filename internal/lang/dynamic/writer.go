@@ -0,0 +1,364 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/alloc"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/types"
+	"github.com/basecomplextech/spec/internal/writer"
+)
+
+// DynamicWriter writes a message which type is known only at runtime.
+// The writer validates field names and value types against the message definition
+// before writing them.
+type DynamicWriter struct {
+	def *model.Message
+	w   writer.MessageWriter
+}
+
+// NewDynamicWriter returns a new dynamic message writer with a new empty buffer.
+//
+// The writer is released on end.
+func NewDynamicWriter(def *model.Message) DynamicWriter {
+	w := writer.New(true /* release */)
+	return NewDynamicWriterTo(def, w.Message())
+}
+
+// NewDynamicWriterBuffer returns a new dynamic message writer with the given buffer.
+//
+// The writer is freed on end.
+func NewDynamicWriterBuffer(def *model.Message, buf buffer.Buffer) DynamicWriter {
+	w := writer.Acquire(buf)
+	return NewDynamicWriterTo(def, w.Message())
+}
+
+// NewDynamicWriterTo returns a new dynamic message writer which writes to the given writer.
+func NewDynamicWriterTo(def *model.Message, w writer.MessageWriter) DynamicWriter {
+	return DynamicWriter{
+		def: def,
+		w:   w,
+	}
+}
+
+// Definition returns the message definition.
+func (w DynamicWriter) Definition() *model.Message {
+	return w.def
+}
+
+// Set writes a field value by a name, the value must match the field type.
+// See [DynamicMessage.Get] for the value types, additionally enums accept int32 numbers
+// and string names.
+func (w DynamicWriter) Set(name string, v any) error {
+	field, err := w.field(name)
+	if err != nil {
+		return err
+	}
+
+	fw := w.w.Field(uint16(field.Tag))
+	if err := writeValue(fw, field.Type, v); err != nil {
+		return fmt.Errorf("%v.%v: %w", w.def.Def.Name, name, err)
+	}
+	return nil
+}
+
+// Bool writes a bool field.
+func (w DynamicWriter) Bool(name string, v bool) error {
+	return w.Set(name, v)
+}
+
+// Byte writes a byte field.
+func (w DynamicWriter) Byte(name string, v byte) error {
+	return w.Set(name, v)
+}
+
+// Int
+
+// Int16 writes an int16 field.
+func (w DynamicWriter) Int16(name string, v int16) error {
+	return w.Set(name, v)
+}
+
+// Int32 writes an int32 field.
+func (w DynamicWriter) Int32(name string, v int32) error {
+	return w.Set(name, v)
+}
+
+// Int64 writes an int64 field.
+func (w DynamicWriter) Int64(name string, v int64) error {
+	return w.Set(name, v)
+}
+
+// Uint
+
+// Uint16 writes a uint16 field.
+func (w DynamicWriter) Uint16(name string, v uint16) error {
+	return w.Set(name, v)
+}
+
+// Uint32 writes a uint32 field.
+func (w DynamicWriter) Uint32(name string, v uint32) error {
+	return w.Set(name, v)
+}
+
+// Uint64 writes a uint64 field.
+func (w DynamicWriter) Uint64(name string, v uint64) error {
+	return w.Set(name, v)
+}
+
+// Float
+
+// Float32 writes a float32 field.
+func (w DynamicWriter) Float32(name string, v float32) error {
+	return w.Set(name, v)
+}
+
+// Float64 writes a float64 field.
+func (w DynamicWriter) Float64(name string, v float64) error {
+	return w.Set(name, v)
+}
+
+// Bin
+
+// Bin64 writes a bin64 field.
+func (w DynamicWriter) Bin64(name string, v bin.Bin64) error {
+	return w.Set(name, v)
+}
+
+// Bin128 writes a bin128 field.
+func (w DynamicWriter) Bin128(name string, v bin.Bin128) error {
+	return w.Set(name, v)
+}
+
+// Bin256 writes a bin256 field.
+func (w DynamicWriter) Bin256(name string, v bin.Bin256) error {
+	return w.Set(name, v)
+}
+
+// Bytes/string
+
+// Bytes writes a bytes field.
+func (w DynamicWriter) Bytes(name string, v []byte) error {
+	return w.Set(name, v)
+}
+
+// String writes a string field.
+func (w DynamicWriter) String(name string, v string) error {
+	return w.Set(name, v)
+}
+
+// Enum/struct
+
+// Enum writes an enum field by a value name.
+func (w DynamicWriter) Enum(name string, value string) error {
+	return w.Set(name, value)
+}
+
+// Struct writes a struct field.
+func (w DynamicWriter) Struct(name string, v DynamicStruct) error {
+	return w.Set(name, v)
+}
+
+// List/message
+
+// List begins a list field and returns a list writer.
+func (w DynamicWriter) List(name string) (DynamicListWriter, error) {
+	field, err := w.fieldKind(name, model.KindList)
+	if err != nil {
+		return DynamicListWriter{}, err
+	}
+
+	lw := w.w.Field(uint16(field.Tag)).List()
+	return NewDynamicListWriterTo(field.Type.Element, lw), nil
+}
+
+// Message begins a message field and returns a message writer.
+func (w DynamicWriter) Message(name string) (DynamicWriter, error) {
+	field, err := w.fieldKind(name, model.KindMessage)
+	if err != nil {
+		return DynamicWriter{}, err
+	}
+
+	mw := w.w.Field(uint16(field.Tag)).Message()
+	return NewDynamicWriterTo(field.Type.Ref.Message, mw), nil
+}
+
+// End/build
+
+// End ends the message.
+func (w DynamicWriter) End() error {
+	return w.w.End()
+}
+
+// Build ends the message and returns it.
+func (w DynamicWriter) Build() (_ DynamicMessage, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenDynamicMessageErr(w.def, bytes)
+}
+
+// Unwrap returns the underlying message writer.
+func (w DynamicWriter) Unwrap() writer.MessageWriter {
+	return w.w
+}
+
+// internal
+
+func (w DynamicWriter) field(name string) (*model.Field, error) {
+	field := w.def.Fields.Get(name)
+	if field == nil {
+		return nil, fmt.Errorf("%v: unknown field %q", w.def.Def.Name, name)
+	}
+	return field, nil
+}
+
+func (w DynamicWriter) fieldKind(name string, kinds ...model.Kind) (*model.Field, error) {
+	field, err := w.field(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkKind(field.Type, kinds...); err != nil {
+		return nil, fmt.Errorf("%v.%v: %w", w.def.Def.Name, name, err)
+	}
+	return field, nil
+}
+
+// write
+
+// valueWriter is implemented by field and list writers.
+type valueWriter interface {
+	Any(b []byte) error
+
+	Bool(v bool) error
+	Byte(v byte) error
+
+	Int16(v int16) error
+	Int32(v int32) error
+	Int64(v int64) error
+
+	Uint16(v uint16) error
+	Uint32(v uint32) error
+	Uint64(v uint64) error
+
+	Float32(v float32) error
+	Float64(v float64) error
+
+	Bin64(v bin.Bin64) error
+	Bin128(v bin.Bin128) error
+	Bin256(v bin.Bin256) error
+
+	Bytes(v []byte) error
+	String(v string) error
+}
+
+// writeValue checks and writes a value.
+func writeValue(w valueWriter, t *model.Type, v any) error {
+	// Convert enum names and numbers
+	if t.Kind == model.KindEnum {
+		switch v1 := v.(type) {
+		case int32:
+			v = NewDynamicEnum(t.Ref.Enum, v1)
+
+		case string:
+			e, err := ParseDynamicEnum(t.Ref.Enum, v1)
+			if err != nil {
+				return err
+			}
+			v = e
+		}
+	}
+
+	// Check value
+	if err := checkValue(t, v); err != nil {
+		return err
+	}
+
+	// Write value
+	switch t.Kind {
+	case model.KindBool:
+		return w.Bool(v.(bool))
+	case model.KindByte:
+		return w.Byte(v.(byte))
+
+	case model.KindInt16:
+		return w.Int16(v.(int16))
+	case model.KindInt32:
+		return w.Int32(v.(int32))
+	case model.KindInt64:
+		return w.Int64(v.(int64))
+
+	case model.KindUint16:
+		return w.Uint16(v.(uint16))
+	case model.KindUint32:
+		return w.Uint32(v.(uint32))
+	case model.KindUint64:
+		return w.Uint64(v.(uint64))
+
+	case model.KindFloat32:
+		return w.Float32(v.(float32))
+	case model.KindFloat64:
+		return w.Float64(v.(float64))
+
+	case model.KindBin64:
+		return w.Bin64(v.(bin.Bin64))
+	case model.KindBin128:
+		return w.Bin128(v.(bin.Bin128))
+	case model.KindBin256:
+		return w.Bin256(v.(bin.Bin256))
+
+	case model.KindBytes:
+		switch v := v.(type) {
+		case format.Bytes:
+			return w.Bytes(v)
+		default:
+			return w.Bytes(v.([]byte))
+		}
+	case model.KindString:
+		switch v := v.(type) {
+		case format.String:
+			return w.String(string(v))
+		default:
+			return w.String(v.(string))
+		}
+
+	case model.KindEnum:
+		return w.Int32(v.(DynamicEnum).number)
+
+	case model.KindStruct:
+		buf := alloc.AcquireBuffer()
+		defer buf.Free()
+
+		if _, err := encodeStruct(buf, v.(DynamicStruct)); err != nil {
+			return err
+		}
+		return w.Any(buf.Bytes())
+
+	case model.KindAny:
+		return w.Any(v.(types.Value))
+
+	case model.KindAnyMessage:
+		switch v := v.(type) {
+		case DynamicMessage:
+			return w.Any(v.msg.Raw())
+		default:
+			return w.Any(v.(types.Message).Raw())
+		}
+
+	case model.KindList:
+		return w.Any(v.(DynamicList).list.Raw())
+	case model.KindMessage:
+		return w.Any(v.(DynamicMessage).msg.Raw())
+	}
+
+	return fmt.Errorf("unsupported type %v", t.SourceName())
+}
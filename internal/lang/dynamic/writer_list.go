@@ -0,0 +1,124 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/writer"
+)
+
+// DynamicListWriter writes a list which element type is known only at runtime.
+type DynamicListWriter struct {
+	elem *model.Type
+	w    writer.ListWriter
+}
+
+// NewDynamicListWriterTo returns a new dynamic list writer which writes to the given writer.
+// Lists of builtin numbers and bins are packed the same way as in the generated code.
+func NewDynamicListWriterTo(elem *model.Type, w writer.ListWriter) DynamicListWriter {
+	if typ := packedType(elem); typ != format.TypeUndefined && w.Len() == 0 {
+		w.Pack(typ)
+	}
+
+	return DynamicListWriter{
+		elem: elem,
+		w:    w,
+	}
+}
+
+// Element returns the list element type.
+func (w DynamicListWriter) Element() *model.Type {
+	return w.elem
+}
+
+// Len returns the number of written elements.
+func (w DynamicListWriter) Len() int {
+	return w.w.Len()
+}
+
+// Add writes an element, the value must match the element type.
+// See [DynamicWriter.Set] for the value types.
+func (w DynamicListWriter) Add(v any) error {
+	if err := writeValue(w.w, w.elem, v); err != nil {
+		return fmt.Errorf("[%d]: %w", w.w.Len(), err)
+	}
+	return nil
+}
+
+// List begins a list element and returns a list writer.
+func (w DynamicListWriter) List() (DynamicListWriter, error) {
+	if err := checkKind(w.elem, model.KindList); err != nil {
+		return DynamicListWriter{}, err
+	}
+
+	lw := w.w.List()
+	return NewDynamicListWriterTo(w.elem.Element, lw), nil
+}
+
+// Message begins a message element and returns a message writer.
+func (w DynamicListWriter) Message() (DynamicWriter, error) {
+	if err := checkKind(w.elem, model.KindMessage); err != nil {
+		return DynamicWriter{}, err
+	}
+
+	mw := w.w.Message()
+	return NewDynamicWriterTo(w.elem.Ref.Message, mw), nil
+}
+
+// End ends the list.
+func (w DynamicListWriter) End() error {
+	return w.w.End()
+}
+
+// Build ends the list and returns it.
+func (w DynamicListWriter) Build() (_ DynamicList, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenDynamicListErr(w.elem, bytes)
+}
+
+// Unwrap returns the underlying list writer.
+func (w DynamicListWriter) Unwrap() writer.ListWriter {
+	return w.w
+}
+
+// private
+
+// packedType returns a packed element type of a builtin number or bin type, or undefined.
+func packedType(t *model.Type) format.Type {
+	switch t.Kind {
+	case model.KindInt16:
+		return format.TypeInt16
+	case model.KindInt32:
+		return format.TypeInt32
+	case model.KindInt64:
+		return format.TypeInt64
+
+	case model.KindUint16:
+		return format.TypeUint16
+	case model.KindUint32:
+		return format.TypeUint32
+	case model.KindUint64:
+		return format.TypeUint64
+
+	case model.KindFloat32:
+		return format.TypeFloat32
+	case model.KindFloat64:
+		return format.TypeFloat64
+
+	case model.KindBin64:
+		return format.TypeBin64
+	case model.KindBin128:
+		return format.TypeBin128
+	case model.KindBin256:
+		return format.TypeBin256
+	}
+	return format.TypeUndefined
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/tests/pkg1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicWriter__should_write_message(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	def := pkg.DefinitionNames["Message"]
+	w := NewDynamicWriter(def.Message)
+
+	require.NoError(t, w.Bool("bool", true))
+	require.NoError(t, w.Int16("int16", 16))
+	require.NoError(t, w.Int64("int64", 64))
+	require.NoError(t, w.Uint32("uint32", 32))
+	require.NoError(t, w.Float64("float64", 1.5))
+	require.NoError(t, w.Bin64("bin64", bin.Int64(1)))
+	require.NoError(t, w.String("string", "hello"))
+	require.NoError(t, w.Bytes("bytes1", []byte("world")))
+	require.NoError(t, w.Enum("enum1", "TWO"))
+
+	s := NewDynamicStruct(pkg.DefinitionNames["Struct"].Struct)
+	require.NoError(t, s.Set("key", int32(1)))
+	require.NoError(t, s.Set("value", int32(2)))
+	require.NoError(t, w.Struct("struct1", s))
+
+	sub, err := w.Message("submessage")
+	require.NoError(t, err)
+	require.NoError(t, sub.String("value", "sub"))
+	require.NoError(t, sub.End())
+
+	ints, err := w.List("ints")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ints.Add(int64(i)))
+	}
+	require.NoError(t, ints.End())
+
+	subs, err := w.List("submessages")
+	require.NoError(t, err)
+	for _, v := range []string{"a", "b"} {
+		sub, err := subs.Message()
+		require.NoError(t, err)
+		require.NoError(t, sub.String("value", v))
+		require.NoError(t, sub.End())
+	}
+	require.NoError(t, subs.End())
+
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	// Read using generated code
+	msg := pkg1.NewMessage(m.Unwrap())
+	assert.Equal(t, true, msg.Bool())
+	assert.Equal(t, int16(16), msg.Int16())
+	assert.Equal(t, int64(64), msg.Int64())
+	assert.Equal(t, uint32(32), msg.Uint32())
+	assert.Equal(t, 1.5, msg.Float64())
+	assert.Equal(t, bin.Int64(1), msg.Bin64())
+	assert.Equal(t, "hello", msg.String().Unwrap())
+	assert.Equal(t, []byte("world"), msg.Bytes1().Unwrap())
+	assert.Equal(t, pkg1.Enum_Two, msg.Enum1())
+	assert.Equal(t, pkg1.Struct{Key: 1, Value: 2}, msg.Struct1())
+	assert.Equal(t, "sub", msg.Submessage().Value().Unwrap())
+	assert.Equal(t, 3, msg.Ints().Len())
+	assert.Equal(t, int64(2), msg.Ints().Get(2))
+	assert.Equal(t, "b", msg.Submessages().Get(1).Value().Unwrap())
+}
+
+func TestDynamicWriter__should_copy_nested_values(t *testing.T) {
+	src := testMessage(t)
+
+	sub, err := src.Message("submessage")
+	require.NoError(t, err)
+	ints, err := src.List("ints")
+	require.NoError(t, err)
+
+	w := NewDynamicWriter(src.Definition())
+	require.NoError(t, w.Set("submessage", sub))
+	require.NoError(t, w.Set("ints", ints))
+
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	sub1, err := m.Message("submessage")
+	require.NoError(t, err)
+	v, err := sub1.String("value")
+	require.NoError(t, err)
+	assert.Equal(t, "value 000", v.Unwrap())
+
+	ints1, err := m.List("ints")
+	require.NoError(t, err)
+	assert.Equal(t, 10, ints1.Len())
+}
+
+func TestDynamicWriter__should_return_error_on_unknown_field(t *testing.T) {
+	def := testDefinition(t, "Message")
+	w := NewDynamicWriter(def.Message)
+
+	err := w.Int64("unknown", 1)
+	assert.Error(t, err)
+}
+
+func TestDynamicWriter__should_return_error_on_invalid_value(t *testing.T) {
+	def := testDefinition(t, "Message")
+	w := NewDynamicWriter(def.Message)
+
+	err := w.Int32("int64", 1)
+	assert.Error(t, err)
+
+	err = w.Set("string", 123)
+	assert.Error(t, err)
+
+	err = w.Enum("enum1", "UNKNOWN")
+	assert.Error(t, err)
+
+	_, err = w.Message("ints")
+	assert.Error(t, err)
+}
+
+func TestDynamicListWriter__should_return_error_on_invalid_element(t *testing.T) {
+	def := testDefinition(t, "Message")
+	w := NewDynamicWriter(def.Message)
+
+	list, err := w.List("strings")
+	require.NoError(t, err)
+
+	err = list.Add(int64(1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package export exports compiled packages as Protocol Buffers proto3 files
+// or JSON Schema documents.
+//
+// Proto files are written into package directories, i.e. "api/users/users.proto"
+// with the "api.users" proto package. Tags are mapped to field numbers, structs to messages,
+// channel methods to streaming RPCs.
+//
+// JSON Schema documents are named by package ids with slashes replaced by dots,
+// i.e. "api.users.schema.json", and describe the JSON mapping of messages, structs and enums
+// in "$defs", see the dynamic package.
+//
+// Packages are exported with their transitive imports, lossy mappings are reported as warnings.
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Format is an export format.
+type Format string
+
+const (
+	FormatProto      Format = "proto"
+	FormatJSONSchema Format = "jsonschema"
+)
+
+// Options specify the export options.
+type Options struct {
+	Format Format
+}
+
+// File is an exported file.
+type File struct {
+	Name    string // File name relative to the output directory
+	Content []byte
+}
+
+// Warning is a lossy mapping of a spec construct.
+type Warning struct {
+	Package string // Package id
+	Message string
+}
+
+// String returns "package: message".
+func (w Warning) String() string {
+	return fmt.Sprintf("%v: %v", w.Package, w.Message)
+}
+
+// Export exports packages and their imports.
+func Export(pkgs []*model.Package, opts Options) ([]File, []Warning, error) {
+	all := model.Transitive(pkgs)
+
+	var files []File
+	var warnings []Warning
+	for _, pkg := range all {
+		var file File
+		var ws []Warning
+		var err error
+
+		switch opts.Format {
+		case FormatProto:
+			file, ws, err = exportProto(pkg)
+		case FormatJSONSchema:
+			file, ws, err = exportJSONSchema(pkg)
+		default:
+			return nil, nil, fmt.Errorf("unknown export format %q", opts.Format)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, file)
+		warnings = append(warnings, ws...)
+	}
+	return files, warnings, nil
+}
+
+// Write exports packages and writes the files into an output directory.
+func Write(pkgs []*model.Package, out string, opts Options) ([]Warning, error) {
+	files, warnings, err := Export(pkgs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(out, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, file.Content, 0666); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
+}
+
+// private
+
+// definitions returns package definitions and generated request/response messages.
+func definitions(pkg *model.Package) []*model.Definition {
+	var defs []*model.Definition
+	for _, def := range pkg.Definitions {
+		defs = append(defs, def)
+		if def.Type != model.DefinitionService {
+			continue
+		}
+
+		for _, m := range def.Service.Methods {
+			if m.Request != nil && m.Request.Ref.Message.Generated {
+				defs = append(defs, m.Request.Ref)
+			}
+			if m.Response != nil && m.Response.Ref.Message.Generated {
+				defs = append(defs, m.Response.Ref)
+			}
+		}
+	}
+	return defs
+}
+
+// idParts returns package id parts without empty, "." and ".." parts.
+func idParts(id string) []string {
+	var parts []string
+	for _, part := range strings.Split(id, "/") {
+		switch part {
+		case "", ".", "..":
+			continue
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return []string{"package"}
+	}
+	return parts
+}
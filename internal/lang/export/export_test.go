@@ -0,0 +1,218 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExport(t *testing.T, pkg *model.Package, format Format) (map[string]string, []string) {
+	files, warnings, err := Export([]*model.Package{pkg}, Options{Format: format})
+	require.NoError(t, err)
+
+	result := make(map[string]string, len(files))
+	for _, file := range files {
+		result[file.Name] = string(file.Content)
+	}
+
+	msgs := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		msgs = append(msgs, w.String())
+	}
+	return result, msgs
+}
+
+// Export
+
+func TestExport__should_export_packages_and_imports(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+	files, _ := testExport(t, pkg, FormatProto)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"pkg1/pkg1.proto",
+		"pkg2/pkg2.proto",
+		"pkg3/pkg3a/pkg3a.proto",
+		"pkg4/pkg4.proto",
+	}, names)
+}
+
+func TestExport__should_return_error_when_unknown_format(t *testing.T) {
+	pkg := langtest.Package(t, "pkg2")
+
+	_, _, err := Export([]*model.Package{pkg}, Options{Format: "xml"})
+	assert.EqualError(t, err, `unknown export format "xml"`)
+}
+
+// Proto
+
+func TestExport_Proto__should_export_definitions(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	files, _ := testExport(t, pkg, FormatProto)
+	proto := files["pkg1/pkg1.proto"]
+
+	assert.Contains(t, proto, "syntax = \"proto3\";\n\npackage pkg1;\n\nimport \"pkg2/pkg2.proto\";\n")
+	assert.Contains(t, proto, "enum Enum {\n  UNDEFINED = 0;\n  ONE = 1;\n")
+	assert.Contains(t, proto, "  uint32 byte = 2;\n")
+	assert.Contains(t, proto, "  fixed64 bin64 = 40;\n")
+	assert.Contains(t, proto, "  bytes bin256 = 42;\n")
+	assert.Contains(t, proto, "  pkg2.Submessage submessage1 = 63;\n")
+	assert.Contains(t, proto, "  repeated Struct structs = 73;\n")
+	assert.Contains(t, proto, "message Struct {\n  int32 key = 1;\n  int32 value = 2;\n}")
+}
+
+func TestExport_Proto__should_export_channel_methods_as_streaming_rpcs(t *testing.T) {
+	pkg := langtest.Source(t, `
+message Request {
+    id int64 1;
+}
+
+message In {
+    a int64 1;
+}
+
+message Out {
+    b int64 1;
+}
+
+service Service {
+    // Method doc comment.
+    method(Request) Out;
+    empty();
+    upload() (<-In);
+    download(Request) (Out->);
+    exchange() (<-In, Out->);
+    call(Request) (<-In) Out;
+}
+`)
+	files, warnings := testExport(t, pkg, FormatProto)
+	proto := files["test/test.proto"]
+
+	assert.Contains(t, proto, `import "google/protobuf/empty.proto";`)
+	assert.Contains(t, proto, `service Service {
+  // Method doc comment.
+  rpc Method(Request) returns (Out);
+  rpc Empty(google.protobuf.Empty) returns (google.protobuf.Empty);
+  rpc Upload(stream In) returns (google.protobuf.Empty);
+  rpc Download(Request) returns (stream Out);
+  rpc Exchange(stream In) returns (stream Out);
+  rpc Call(stream ServiceCallInput) returns (Out);
+}`)
+	assert.Contains(t, proto, `message ServiceCallInput {
+  oneof input {
+    Request request = 1;
+    In in = 2;
+  }
+}`)
+	assert.Equal(t, []string{
+		"test: method Service.call request and input channel are exported as a stream of ServiceCallInput",
+	}, warnings)
+}
+
+func TestExport_Proto__should_prefix_conflicting_enum_values(t *testing.T) {
+	pkg := langtest.Source(t, `
+enum Color {
+    UNKNOWN = 0;
+    RED = 1;
+}
+
+enum UserStatus {
+    UNKNOWN = 0;
+    ACTIVE = 1;
+}
+`)
+	files, warnings := testExport(t, pkg, FormatProto)
+	proto := files["test/test.proto"]
+
+	assert.Contains(t, proto, "enum Color {\n  COLOR_UNKNOWN = 0;\n  RED = 1;\n}")
+	assert.Contains(t, proto, "enum UserStatus {\n  USER_STATUS_UNKNOWN = 0;\n  ACTIVE = 1;\n}")
+	assert.Equal(t, []string{
+		"test: enum value Color.UNKNOWN conflicts with another enum, exported as COLOR_UNKNOWN",
+		"test: enum value UserStatus.UNKNOWN conflicts with another enum, exported as USER_STATUS_UNKNOWN",
+	}, warnings)
+}
+
+func TestExport_Proto__should_warn_about_lossy_mappings(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	_, warnings := testExport(t, pkg, FormatProto)
+
+	assert.Contains(t, warnings, "pkg1: field Message.bin256 type bin256 is exported as bytes")
+	assert.Contains(t, warnings, "pkg1: field Message.any type any is exported as bytes")
+	assert.Contains(t, warnings, "pkg1: field Message.message1 type message is exported as bytes")
+}
+
+func TestExport_Proto__should_skip_subservice_methods(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+	files, warnings := testExport(t, pkg, FormatProto)
+	proto := files["pkg4/pkg4.proto"]
+
+	assert.NotContains(t, proto, "rpc Subservice(")
+	assert.Contains(t, proto, "service Subservice {")
+	assert.Contains(t, warnings, "pkg4: method Service.subservice returns subservice Subservice, skipped")
+	assert.Contains(t, warnings, "pkg4: subservice Subservice is exported as a service")
+}
+
+// JSON Schema
+
+func TestExport_JSONSchema__should_export_definitions(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	files, _ := testExport(t, pkg, FormatJSONSchema)
+
+	var schema struct {
+		ID   string                     `json:"$id"`
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	err := json.Unmarshal([]byte(files["pkg1.schema.json"]), &schema)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pkg1.schema.json", schema.ID)
+	assert.JSONEq(t, `{
+		"type": "string",
+		"enum": ["UNDEFINED", "ONE", "TWO", "THREE", "TEN"]
+	}`, string(schema.Defs["Enum"]))
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"key": {"type": "integer", "minimum": -2147483648, "maximum": 2147483647},
+			"value": {"type": "integer", "minimum": -2147483648, "maximum": 2147483647}
+		},
+		"additionalProperties": false
+	}`, string(schema.Defs["Struct"]))
+
+	var msg struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	err = json.Unmarshal(schema.Defs["Message"], &msg)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"type": "string", "pattern": "^[0-9a-f]{16}-[0-9a-f]{16}$"}`,
+		string(msg.Properties["bin128"]))
+	assert.JSONEq(t, `{"type": "string", "contentEncoding": "base64"}`,
+		string(msg.Properties["bytes1"]))
+	assert.JSONEq(t, `{"$ref": "pkg2.schema.json#/$defs/Submessage"}`,
+		string(msg.Properties["submessage1"]))
+	assert.JSONEq(t, `{"type": "array", "items": {"$ref": "#/$defs/Struct"}}`,
+		string(msg.Properties["structs"]))
+	assert.JSONEq(t, `{}`, string(msg.Properties["any"]))
+}
+
+func TestExport_JSONSchema__should_warn_about_schema_less_values(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	_, warnings := testExport(t, pkg, FormatJSONSchema)
+
+	assert.Equal(t, []string{
+		"pkg1: field Message.message1 type message is exported as a schema-less value",
+		"pkg1: field Message.any type any is exported as a schema-less value",
+	}, warnings)
+}
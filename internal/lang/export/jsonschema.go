@@ -0,0 +1,231 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// object is a json object with ordered members.
+type object []member
+
+type member struct {
+	key   string
+	value any
+}
+
+func (o *object) set(key string, value any) {
+	*o = append(*o, member{key, value})
+}
+
+// MarshalJSON returns a json object with members in the insertion order.
+func (o object) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// schemaWriter writes a package json schema.
+type schemaWriter struct {
+	pkg      *model.Package
+	warnings []Warning
+}
+
+func exportJSONSchema(pkg *model.Package) (File, []Warning, error) {
+	w := &schemaWriter{pkg: pkg}
+
+	defs := object{}
+	for _, def := range definitions(pkg) {
+		if def.Type == model.DefinitionService {
+			continue
+		}
+		defs.set(def.Name, w.definition(def))
+	}
+
+	doc := object{}
+	doc.set("$schema", jsonSchemaDraft)
+	doc.set("$id", schemaFileName(pkg.ID))
+	doc.set("title", pkg.ID)
+	doc.set("$defs", defs)
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return File{}, nil, err
+	}
+	content = append(content, '\n')
+
+	file := File{Name: schemaFileName(pkg.ID), Content: content}
+	return file, w.warnings, nil
+}
+
+// definitions
+
+func (w *schemaWriter) definition(def *model.Definition) object {
+	s := object{}
+	if def.Doc != "" {
+		s.set("description", def.Doc)
+	}
+
+	switch def.Type {
+	case model.DefinitionEnum:
+		names := make([]string, 0, len(def.Enum.Values))
+		for _, v := range def.Enum.Values {
+			names = append(names, v.Name)
+		}
+		s.set("type", "string")
+		s.set("enum", names)
+
+	case model.DefinitionMessage:
+		props := object{}
+		for _, f := range def.Message.Fields.List {
+			doc := f.Doc
+			if def.Message.Generated {
+				doc = ""
+			}
+			props.set(f.Name, w.field(def, f.Name, f.Type, doc))
+		}
+		s.set("type", "object")
+		s.set("properties", props)
+
+	case model.DefinitionStruct:
+		props := object{}
+		for _, f := range def.Struct.Fields.Values() {
+			props.set(f.Name, w.field(def, f.Name, f.Type, f.Doc))
+		}
+		s.set("type", "object")
+		s.set("properties", props)
+		s.set("additionalProperties", false)
+	}
+	return s
+}
+
+func (w *schemaWriter) field(def *model.Definition, name string, typ *model.Type, doc string) object {
+	s := w.type_(def, name, typ)
+	if doc != "" {
+		s.set("description", doc)
+	}
+	return s
+}
+
+// types
+
+func (w *schemaWriter) type_(def *model.Definition, field string, typ *model.Type) object {
+	s := object{}
+
+	switch typ.Kind {
+	case model.KindBool:
+		s.set("type", "boolean")
+
+	case model.KindByte:
+		setInteger(&s, 0, math.MaxUint8)
+	case model.KindInt16:
+		setInteger(&s, math.MinInt16, math.MaxInt16)
+	case model.KindInt32:
+		setInteger(&s, math.MinInt32, math.MaxInt32)
+	case model.KindInt64:
+		s.set("type", "integer")
+	case model.KindUint16:
+		setInteger(&s, 0, math.MaxUint16)
+	case model.KindUint32:
+		setInteger(&s, 0, math.MaxUint32)
+	case model.KindUint64:
+		s.set("type", "integer")
+		s.set("minimum", 0)
+
+	case model.KindBin64:
+		setHex(&s, 1)
+	case model.KindBin128:
+		setHex(&s, 2)
+	case model.KindBin256:
+		setHex(&s, 4)
+
+	case model.KindFloat32, model.KindFloat64:
+		s.set("type", "number")
+
+	case model.KindBytes:
+		s.set("type", "string")
+		s.set("contentEncoding", "base64")
+	case model.KindString:
+		s.set("type", "string")
+
+	case model.KindList:
+		s.set("type", "array")
+		s.set("items", w.type_(def, field, typ.Element))
+
+	case model.KindEnum, model.KindMessage, model.KindStruct:
+		s.set("$ref", w.ref(typ.Ref))
+
+	default:
+		w.warnf("field %v.%v type %v is exported as a schema-less value", def.Name, field, typ.SourceName())
+	}
+	return s
+}
+
+// ref returns a definition reference, i.e. "#/$defs/User" or "api.users.schema.json#/$defs/User".
+func (w *schemaWriter) ref(def *model.Definition) string {
+	ref := "#/$defs/" + def.Name
+	if def.Package == w.pkg {
+		return ref
+	}
+	return schemaFileName(def.Package.ID) + ref
+}
+
+// util
+
+func (w *schemaWriter) warnf(format string, args ...any) {
+	w.warnings = append(w.warnings, Warning{
+		Package: w.pkg.ID,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func setInteger(s *object, min int64, max int64) {
+	s.set("type", "integer")
+	s.set("minimum", min)
+	s.set("maximum", max)
+}
+
+// setHex sets a pattern of lower-case hex strings with dash-separated 8-byte parts.
+func setHex(s *object, parts int) {
+	group := "[0-9a-f]{16}"
+	pattern := "^" + strings.Repeat(group+"-", parts-1) + group + "$"
+
+	s.set("type", "string")
+	s.set("pattern", pattern)
+}
+
+// schemaFileName returns a json schema file name, i.e. "api.users.schema.json" for "api/users".
+func schemaFileName(id string) string {
+	return strings.Join(idParts(id), ".") + ".schema.json"
+}
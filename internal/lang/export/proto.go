@@ -0,0 +1,432 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+const (
+	protoEmpty       = "google.protobuf.Empty"
+	protoEmptyImport = "google/protobuf/empty.proto"
+)
+
+// protoScalars maps builtin spec types to proto types.
+var protoScalars = map[model.Kind]string{
+	model.KindBool:    "bool",
+	model.KindByte:    "uint32",
+	model.KindInt16:   "int32",
+	model.KindInt32:   "int32",
+	model.KindInt64:   "int64",
+	model.KindUint16:  "uint32",
+	model.KindUint32:  "uint32",
+	model.KindUint64:  "uint64",
+	model.KindBin64:   "fixed64",
+	model.KindFloat32: "float",
+	model.KindFloat64: "double",
+	model.KindBytes:   "bytes",
+	model.KindString:  "string",
+}
+
+// protoWriter writes a package proto file.
+type protoWriter struct {
+	pkg *model.Package
+	b   bytes.Buffer
+
+	imports    map[string]struct{}
+	enumValues map[*model.EnumValue]string // Renamed enum values
+	warnings   []Warning
+}
+
+func exportProto(pkg *model.Package) (File, []Warning, error) {
+	w := &protoWriter{
+		pkg:        pkg,
+		imports:    make(map[string]struct{}),
+		enumValues: make(map[*model.EnumValue]string),
+	}
+	w.scopeEnumValues()
+
+	for _, def := range definitions(pkg) {
+		w.definition(def)
+	}
+
+	// Write header with imports
+	out := &bytes.Buffer{}
+	out.WriteString("// Code generated by spec export. DO NOT EDIT.\n\n")
+	out.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(out, "package %v;\n\n", protoPackage(pkg.ID))
+
+	if len(w.imports) > 0 {
+		imports := make([]string, 0, len(w.imports))
+		for imp := range w.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+
+		for _, imp := range imports {
+			fmt.Fprintf(out, "import %q;\n", imp)
+		}
+		out.WriteString("\n")
+	}
+
+	out.Write(bytes.TrimRight(w.b.Bytes(), "\n"))
+	out.WriteString("\n")
+
+	file := File{Name: protoFileName(pkg.ID), Content: out.Bytes()}
+	return file, w.warnings, nil
+}
+
+// scopeEnumValues prefixes enum values which conflict with values of other enums,
+// proto enum values are scoped by the package, not by the enum.
+func (w *protoWriter) scopeEnumValues() {
+	counts := make(map[string]int)
+	for _, def := range w.pkg.Definitions {
+		if def.Type != model.DefinitionEnum {
+			continue
+		}
+		for _, v := range def.Enum.Values {
+			counts[v.Name]++
+		}
+	}
+
+	for _, def := range w.pkg.Definitions {
+		if def.Type != model.DefinitionEnum {
+			continue
+		}
+
+		prefix := upperSnakeCase(def.Name) + "_"
+		for _, v := range def.Enum.Values {
+			if counts[v.Name] < 2 {
+				continue
+			}
+
+			name := prefix + v.Name
+			w.enumValues[v] = name
+			w.warnf("enum value %v.%v conflicts with another enum, exported as %v",
+				def.Name, v.Name, name)
+		}
+	}
+}
+
+// definitions
+
+func (w *protoWriter) definition(def *model.Definition) {
+	switch def.Type {
+	case model.DefinitionEnum:
+		w.enum(def)
+	case model.DefinitionMessage:
+		w.message(def)
+	case model.DefinitionStruct:
+		w.struct_(def)
+	case model.DefinitionService:
+		w.service(def)
+	}
+}
+
+func (w *protoWriter) enum(def *model.Definition) {
+	w.doc("", def.Doc)
+	fmt.Fprintf(&w.b, "enum %v {\n", def.Name)
+
+	// Proto3 requires the zero value to be the first one
+	values := slices.Clone(def.Enum.Values)
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Number == 0 && values[j].Number != 0
+	})
+
+	for _, v := range values {
+		name := v.Name
+		if renamed, ok := w.enumValues[v]; ok {
+			name = renamed
+		}
+
+		w.doc("  ", v.Doc)
+		fmt.Fprintf(&w.b, "  %v = %d;\n", name, v.Number)
+	}
+	w.b.WriteString("}\n\n")
+}
+
+func (w *protoWriter) message(def *model.Definition) {
+	w.doc("", def.Doc)
+	fmt.Fprintf(&w.b, "message %v {\n", def.Name)
+
+	for _, f := range def.Message.Fields.List {
+		if f.Tag >= 19000 && f.Tag <= 19999 {
+			w.warnf("field %v.%v tag %d is reserved by protobuf, skipped", def.Name, f.Name, f.Tag)
+			continue
+		}
+
+		typ := w.fieldType(def, f.Name, f.Type)
+		if !def.Message.Generated {
+			w.doc("  ", f.Doc)
+		}
+		fmt.Fprintf(&w.b, "  %v %v = %d;\n", typ, f.Name, f.Tag)
+	}
+	w.b.WriteString("}\n\n")
+}
+
+func (w *protoWriter) struct_(def *model.Definition) {
+	w.doc("", def.Doc)
+	fmt.Fprintf(&w.b, "message %v {\n", def.Name)
+
+	for i, f := range def.Struct.Fields.Values() {
+		typ := w.fieldType(def, f.Name, f.Type)
+		w.doc("  ", f.Doc)
+		fmt.Fprintf(&w.b, "  %v %v = %d;\n", typ, f.Name, i+1)
+	}
+	w.b.WriteString("}\n\n")
+}
+
+// service
+
+func (w *protoWriter) service(def *model.Definition) {
+	srv := def.Service
+	if srv.Sub {
+		w.warnf("subservice %v is exported as a service", def.Name)
+	}
+
+	w.doc("", def.Doc)
+	fmt.Fprintf(&w.b, "service %v {\n", def.Name)
+
+	var streams []protoStream
+	for _, m := range srv.Methods {
+		sig, ss, ok := w.method(def, m)
+		if !ok {
+			continue
+		}
+
+		w.doc("  ", m.Doc)
+		fmt.Fprintf(&w.b, "  rpc %v;\n", sig)
+		streams = append(streams, ss...)
+	}
+	w.b.WriteString("}\n\n")
+
+	for _, s := range streams {
+		w.stream(s)
+	}
+}
+
+// protoStream is a stream message which wraps a request or a response and channel messages,
+// the request is sent before the input messages, the response after the output messages.
+type protoStream struct {
+	name    string
+	oneof   string
+	first   string // First oneof field name
+	firstT  string
+	second  string // Second oneof field name
+	secondT string
+}
+
+func (w *protoWriter) stream(s protoStream) {
+	fmt.Fprintf(&w.b, "message %v {\n", s.name)
+	fmt.Fprintf(&w.b, "  oneof %v {\n", s.oneof)
+	fmt.Fprintf(&w.b, "    %v %v = 1;\n", s.firstT, s.first)
+	fmt.Fprintf(&w.b, "    %v %v = 2;\n", s.secondT, s.second)
+	w.b.WriteString("  }\n}\n\n")
+}
+
+// method returns an rpc signature and stream messages,
+// or false when the method has no proto equivalent.
+func (w *protoWriter) method(def *model.Definition, m *model.Method) (string, []protoStream, bool) {
+	name := def.Name + "." + m.Name
+	prefix := def.Name + upperCamelCase(m.Name)
+
+	if m.Subservice != nil {
+		w.warnf("method %v returns subservice %v, skipped", name, m.Subservice.SourceName())
+		return "", nil, false
+	}
+
+	ch := m.Channel
+	if ch == nil {
+		ch = &model.MethodChannel{}
+	}
+
+	var streams []protoStream
+	addStream := func(s protoStream, what string) bool {
+		if _, ok := w.pkg.DefinitionNames[s.name]; ok {
+			w.warnf("method %v stream message %v conflicts with a definition, skipped", name, s.name)
+			return false
+		}
+
+		streams = append(streams, s)
+		w.warnf("method %v %v are exported as a stream of %v", name, what, s.name)
+		return true
+	}
+
+	// Input
+	var input string
+	switch {
+	case m.Request != nil && ch.In != nil:
+		s := protoStream{
+			name:    prefix + "Input",
+			oneof:   "input",
+			first:   "request",
+			firstT:  w.typeRef(m.Request),
+			second:  "in",
+			secondT: w.typeRef(ch.In),
+		}
+		if !addStream(s, "request and input channel") {
+			return "", nil, false
+		}
+		input = "stream " + s.name
+
+	case ch.In != nil:
+		input = "stream " + w.typeRef(ch.In)
+	case m.Request != nil:
+		input = w.typeRef(m.Request)
+	default:
+		input = w.empty()
+	}
+
+	// Output
+	var output string
+	switch {
+	case m.Response != nil && ch.Out != nil:
+		s := protoStream{
+			name:    prefix + "Output",
+			oneof:   "output",
+			first:   "out",
+			firstT:  w.typeRef(ch.Out),
+			second:  "response",
+			secondT: w.typeRef(m.Response),
+		}
+		if !addStream(s, "output channel and response") {
+			return "", nil, false
+		}
+		output = "stream " + s.name
+
+	case ch.Out != nil:
+		output = "stream " + w.typeRef(ch.Out)
+	case m.Response != nil:
+		output = w.typeRef(m.Response)
+	default:
+		output = w.empty()
+	}
+
+	if m.Oneway {
+		w.warnf("oneway method %v is exported as a unary rpc", name)
+	}
+
+	sig := fmt.Sprintf("%v(%v) returns (%v)", upperCamelCase(m.Name), input, output)
+	return sig, streams, true
+}
+
+// types
+
+func (w *protoWriter) fieldType(def *model.Definition, field string, typ *model.Type) string {
+	if typ.Kind != model.KindList {
+		return w.valueType(def, field, typ)
+	}
+
+	if typ.Element.Kind == model.KindList {
+		w.warnf("field %v.%v type %v is exported as repeated bytes, nested lists are not supported",
+			def.Name, field, typ.SourceName())
+		return "repeated bytes"
+	}
+	return "repeated " + w.valueType(def, field, typ.Element)
+}
+
+func (w *protoWriter) valueType(def *model.Definition, field string, typ *model.Type) string {
+	if s, ok := protoScalars[typ.Kind]; ok {
+		return s
+	}
+
+	switch typ.Kind {
+	case model.KindEnum, model.KindMessage, model.KindStruct:
+		return w.typeRef(typ)
+	}
+
+	w.warnf("field %v.%v type %v is exported as bytes", def.Name, field, typ.SourceName())
+	return "bytes"
+}
+
+// typeRef returns a definition reference, adds an import for other packages.
+func (w *protoWriter) typeRef(typ *model.Type) string {
+	def := typ.Ref
+	if def.Package == w.pkg {
+		return def.Name
+	}
+
+	w.imports[protoFileName(def.Package.ID)] = struct{}{}
+	return protoPackage(def.Package.ID) + "." + def.Name
+}
+
+func (w *protoWriter) empty() string {
+	w.imports[protoEmptyImport] = struct{}{}
+	return protoEmpty
+}
+
+// util
+
+func (w *protoWriter) doc(indent string, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			fmt.Fprintf(&w.b, "%v//\n", indent)
+			continue
+		}
+		fmt.Fprintf(&w.b, "%v// %v\n", indent, line)
+	}
+}
+
+func (w *protoWriter) warnf(format string, args ...any) {
+	w.warnings = append(w.warnings, Warning{
+		Package: w.pkg.ID,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// protoPackage returns a proto package name, i.e. "api.users" for "api/users".
+func protoPackage(id string) string {
+	parts := idParts(id)
+	for i, part := range parts {
+		parts[i] = strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return '_'
+		}, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// protoFileName returns a proto file name, i.e. "api/users/users.proto" for "api/users".
+func protoFileName(id string) string {
+	parts := idParts(id)
+	return path.Join(path.Join(parts...), parts[len(parts)-1]+".proto")
+}
+
+// upperCamelCase converts "getUser" into "GetUser".
+func upperCamelCase(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// upperSnakeCase converts "UserStatus" into "USER_STATUS".
+func upperSnakeCase(s string) string {
+	b := &strings.Builder{}
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// noNewline is appended to a last line without a newline.
+const noNewline = "\n\\ No newline at end of file"
+
+// Diff returns a unified diff between two files, or nil when the files are equal.
+func Diff(oldName string, newName string, old []byte, new []byte) []byte {
+	if bytes.Equal(old, new) {
+		return nil
+	}
+
+	a := splitLines(old)
+	b := splitLines(new)
+	ops := diffLines(a, b)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "--- %v\n", oldName)
+	fmt.Fprintf(buf, "+++ %v\n", newName)
+
+	// Group operations into hunks with context
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		start := max(i-diffContext, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+
+			// Find next change within context
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				end = min(end+diffContext, len(ops))
+				break
+			}
+			end = next
+		}
+
+		writeHunk(buf, ops[start:end])
+		i = end
+	}
+	return buf.Bytes()
+}
+
+// private
+
+type diffOp struct {
+	kind byte // ' ', '-', '+'
+	line string
+	a, b int // line indexes in old and new files
+}
+
+// diffLines returns line operations using the linear space Myers diff.
+func diffLines(a []string, b []string) []diffOp {
+	d := &differ{
+		a:   a,
+		b:   b,
+		ops: make([]diffOp, 0, max(len(a), len(b))),
+	}
+	d.compare(0, len(a), 0, len(b))
+	return d.ops
+}
+
+// differ recursively splits files at middle snakes, see
+// "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers.
+type differ struct {
+	a, b   []string
+	ops    []diffOp
+	vf, vb []int // forward and backward furthest x by diagonals
+}
+
+// compare appends operations which transform a[a0:a1] into b[b0:b1].
+func (d *differ) compare(a0, a1, b0, b1 int) {
+	// Common prefix
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.equal(a0, b0)
+		a0++
+		b0++
+	}
+
+	// Common suffix, appended at the end
+	n := 0
+	for a0 < a1-n && b0 < b1-n && d.a[a1-n-1] == d.b[b1-n-1] {
+		n++
+	}
+	a1 -= n
+	b1 -= n
+
+	switch {
+	case a0 == a1:
+		for j := b0; j < b1; j++ {
+			d.ops = append(d.ops, diffOp{kind: '+', line: d.b[j], a: a0, b: j})
+		}
+	case b0 == b1:
+		for i := a0; i < a1; i++ {
+			d.ops = append(d.ops, diffOp{kind: '-', line: d.a[i], a: i, b: b0})
+		}
+	default:
+		// Both ranges are not empty, and differ at the first and last lines,
+		// so the edit distance is at least 2, and the halves are smaller.
+		x, y, u, v := d.middleSnake(a0, a1, b0, b1)
+		d.compare(a0, x, b0, y)
+		for i, j := x, y; i < u; i, j = i+1, j+1 {
+			d.equal(i, j)
+		}
+		d.compare(u, a1, v, b1)
+	}
+
+	for i := 0; i < n; i++ {
+		d.equal(a1+i, b1+i)
+	}
+}
+
+// middleSnake returns the start and the end of a middle snake of an optimal edit path.
+func (d *differ) middleSnake(a0, a1, b0, b1 int) (x0, y0, x1, y1 int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta&1 != 0
+
+	dmax := (n + m + 1) / 2
+	off := dmax + 1
+	size := 2*dmax + 3
+	if cap(d.vf) < size {
+		d.vf = make([]int, size)
+		d.vb = make([]int, size)
+	}
+	vf, vb := d.vf[:size], d.vb[:size]
+	vf[off+1] = 0
+	vb[off+1] = 0
+
+	for k := 0; k <= dmax; k++ {
+		// Forward
+		for diag := -k; diag <= k; diag += 2 {
+			var x int
+			if diag == -k || (diag != k && vf[off+diag-1] < vf[off+diag+1]) {
+				x = vf[off+diag+1]
+			} else {
+				x = vf[off+diag-1] + 1
+			}
+			y := x - diag
+
+			sx, sy := x, y
+			for x < n && y < m && d.a[a0+x] == d.b[b0+y] {
+				x++
+				y++
+			}
+			vf[off+diag] = x
+
+			if odd && diag >= delta-(k-1) && diag <= delta+(k-1) {
+				if x+vb[off+delta-diag] >= n {
+					return a0 + sx, b0 + sy, a0 + x, b0 + y
+				}
+			}
+		}
+
+		// Backward, in reversed coordinates
+		for diag := -k; diag <= k; diag += 2 {
+			var x int
+			if diag == -k || (diag != k && vb[off+diag-1] < vb[off+diag+1]) {
+				x = vb[off+diag+1]
+			} else {
+				x = vb[off+diag-1] + 1
+			}
+			y := x - diag
+
+			sx, sy := x, y
+			for x < n && y < m && d.a[a1-1-x] == d.b[b1-1-y] {
+				x++
+				y++
+			}
+			vb[off+diag] = x
+
+			if !odd && delta-diag >= -k && delta-diag <= k {
+				if x+vf[off+delta-diag] >= n {
+					return a1 - x, b1 - y, a1 - sx, b1 - sy
+				}
+			}
+		}
+	}
+
+	// Unreachable, the paths always overlap
+	panic("diff: no middle snake")
+}
+
+func (d *differ) equal(i, j int) {
+	d.ops = append(d.ops, diffOp{kind: ' ', line: d.a[i], a: i, b: j})
+}
+
+func writeHunk(buf *bytes.Buffer, ops []diffOp) {
+	a, b := ops[0].a, ops[0].b
+	na, nb := 0, 0
+	for _, op := range ops {
+		switch op.kind {
+		case ' ':
+			na++
+			nb++
+		case '-':
+			na++
+		case '+':
+			nb++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%v +%v @@\n", hunkRange(a, na), hunkRange(b, nb))
+
+	// Write deletions before insertions in each run of changes, as diff -u
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			writeOp(buf, ops[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(ops) && ops[end].kind != ' ' {
+			end++
+		}
+		for _, kind := range []byte{'-', '+'} {
+			for _, op := range ops[i:end] {
+				if op.kind == kind {
+					writeOp(buf, op)
+				}
+			}
+		}
+		i = end
+	}
+}
+
+func writeOp(buf *bytes.Buffer, op diffOp) {
+	buf.WriteByte(op.kind)
+	buf.WriteString(op.line)
+	buf.WriteByte('\n')
+}
+
+func hunkRange(start int, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+// splitLines splits a file into lines, a last line without a newline includes
+// a `\ No newline at end of file` marker, so that it differs from the same line with a newline.
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+
+	s := string(b)
+	eol := strings.HasSuffix(s, "\n")
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if !eol {
+		lines[len(lines)-1] += noNewline
+	}
+	return lines
+}
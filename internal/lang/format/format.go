@@ -0,0 +1,576 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package format prints spec files in a canonical layout.
+//
+// The formatter preserves comments and single blank lines inside definitions,
+// aligns field names, types and tags, sorts imports and normalizes method signatures.
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+const indentation = "    "
+
+// Source parses and formats a spec file source.
+func Source(src []byte) ([]byte, error) {
+	file, err := parser.New().Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	return Syntax(file), nil
+}
+
+// File parses and formats a spec file.
+func File(path string) ([]byte, error) {
+	file, err := parser.New().ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Syntax(file), nil
+}
+
+// Syntax formats a parsed spec file.
+func Syntax(file *syntax.File) []byte {
+	p := newPrinter(file.Comments)
+	p.file(file)
+	return p.bytes()
+}
+
+// private
+
+type printer struct {
+	out []string // output lines
+	sec section  // pending alignment section
+
+	comments []*syntax.Comment
+	next     int // next comment index
+	last     int // last printed source line, zero at block start
+}
+
+func newPrinter(comments []*syntax.Comment) *printer {
+	return &printer{comments: comments}
+}
+
+func (p *printer) bytes() []byte {
+	p.flush()
+
+	// Trim blank lines
+	lines := p.out
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	s := strings.Join(lines, "\n") + "\n"
+	return []byte(s)
+}
+
+// file
+
+func (p *printer) file(file *syntax.File) {
+	if file.ImportsLine > 0 {
+		p.imports(file)
+	}
+	if file.OptionsLine > 0 {
+		p.options(file)
+	}
+
+	for _, def := range file.Definitions {
+		p.chunk(def.Line)
+
+		switch def.Type {
+		case syntax.DefinitionEnum:
+			p.enum(def)
+		case syntax.DefinitionMessage:
+			p.message(def)
+		case syntax.DefinitionStruct:
+			p.struct_(def)
+		case syntax.DefinitionService:
+			p.service(def)
+		}
+	}
+
+	// Trailing comments
+	p.leading(0, -1)
+}
+
+// chunk begins a top-level chunk separated by a blank line, prints its leading comments.
+func (p *printer) chunk(line int) {
+	p.blank()
+	p.last = 0
+	p.leading(0, line)
+	p.space(line)
+}
+
+// imports
+
+type importItem struct {
+	imp     *syntax.Import
+	lead    []*syntax.Comment
+	trail   string
+	newline bool // blank line before the item in source
+}
+
+func (p *printer) imports(file *syntax.File) {
+	p.chunk(file.ImportsLine)
+	p.line(0, "import ("+p.trailing(file.ImportsLine, file.ImportsLine))
+	p.last = 0
+
+	// Collect items with comments
+	items := make([]importItem, 0, len(file.Imports))
+	last := 0
+	for _, imp := range file.Imports {
+		item := importItem{imp: imp}
+		item.lead = p.take(imp.Line)
+
+		start := imp.Line
+		if len(item.lead) > 0 {
+			start = item.lead[0].Line
+		}
+		item.newline = last > 0 && start > last+1
+		item.trail = p.trailing(imp.Line, imp.Line)
+		items = append(items, item)
+		last = imp.Line
+	}
+
+	// Sort items in groups separated by blank lines
+	for i := 0; i < len(items); {
+		j := i + 1
+		for j < len(items) && !items[j].newline {
+			j++
+		}
+
+		group := items[i:j]
+		newline := group[0].newline
+		group[0].newline = false
+		sort.SliceStable(group, func(a, b int) bool {
+			return group[a].imp.ID < group[b].imp.ID
+		})
+		group[0].newline = newline
+		i = j
+	}
+
+	// Print items
+	for _, item := range items {
+		if item.newline {
+			p.blank()
+		}
+		for _, c := range item.lead {
+			p.comment(1, c)
+		}
+
+		s := quote(item.imp.ID)
+		if item.imp.Alias != "" {
+			s = item.imp.Alias + " " + s
+		}
+		p.row(1, s, item.trail)
+	}
+
+	p.last = last
+	p.leading(1, file.ImportsEnd)
+	p.line(0, ")"+p.trailing(file.ImportsEnd, file.ImportsEnd))
+	p.last = file.ImportsEnd
+}
+
+// options
+
+func (p *printer) options(file *syntax.File) {
+	p.chunk(file.OptionsLine)
+	p.line(0, "options ("+p.trailing(file.OptionsLine, file.OptionsLine))
+	p.last = 0
+
+	for _, opt := range file.Options {
+		p.leading(1, opt.Line)
+		p.space(opt.Line)
+
+		p.row(1, opt.Name, "= "+quote(opt.Value), p.trailing(opt.Line, opt.Line))
+		p.last = opt.Line
+	}
+
+	p.leading(1, file.OptionsEnd)
+	p.line(0, ")"+p.trailing(file.OptionsEnd, file.OptionsEnd))
+	p.last = file.OptionsEnd
+}
+
+// definitions
+
+func (p *printer) enum(def *syntax.Definition) {
+	if p.begin(def, "enum", len(def.Enum.Values)) {
+		return
+	}
+
+	for _, v := range def.Enum.Values {
+		p.leading(1, v.Line)
+		p.space(v.Line)
+
+		p.row(1, v.Name, fmt.Sprintf("= %d;", v.Value), p.trailing(v.Line, v.Line))
+		p.last = v.Line
+	}
+
+	p.end(def)
+}
+
+func (p *printer) message(def *syntax.Definition) {
+	if p.begin(def, "message", len(def.Message.Fields)) {
+		return
+	}
+
+	for _, f := range def.Message.Fields {
+		p.leading(1, f.Line)
+		p.space(f.Line)
+
+		p.row(1, f.Name, f.Type.String(), fmt.Sprintf("%d;", f.Tag), p.trailing(f.Line, f.Line))
+		p.last = f.Line
+	}
+
+	p.end(def)
+}
+
+func (p *printer) struct_(def *syntax.Definition) {
+	keyword := "struct"
+	if def.Struct.Key {
+		keyword = "key struct"
+	}
+
+	if p.begin(def, keyword, len(def.Struct.Fields)) {
+		return
+	}
+
+	for _, f := range def.Struct.Fields {
+		p.leading(1, f.Line)
+		p.space(f.Line)
+
+		p.row(1, f.Name, f.Type.String()+";", p.trailing(f.Line, f.Line))
+		p.last = f.Line
+	}
+
+	p.end(def)
+}
+
+func (p *printer) service(def *syntax.Definition) {
+	keyword := "service"
+	if def.Service.Sub {
+		keyword = "subservice"
+	}
+
+	if p.begin(def, keyword, len(def.Service.Methods)) {
+		return
+	}
+
+	for _, m := range def.Service.Methods {
+		p.leading(1, m.Line)
+		p.space(m.Line)
+
+		p.method(m)
+		p.last = m.End
+	}
+
+	p.end(def)
+}
+
+// begin prints a definition header, returns true when the definition is empty and complete.
+func (p *printer) begin(def *syntax.Definition, keyword string, members int) bool {
+	header := keyword + " " + def.Name + " {"
+
+	// Empty definition
+	if members == 0 && !p.hasComments(def.End) {
+		p.line(0, header+"}"+p.trailing(def.Line, def.End))
+		p.last = def.End
+		return true
+	}
+
+	p.line(0, header+p.trailing(def.Line, def.Line))
+	p.last = 0
+	return false
+}
+
+func (p *printer) end(def *syntax.Definition) {
+	p.leading(1, def.End)
+	p.line(0, "}"+p.trailing(def.End, def.End))
+	p.last = def.End
+}
+
+// method
+
+func (p *printer) method(m *syntax.Method) {
+	multi := methodMultiline(m)
+	in, inFields := m.Input.(syntax.Fields)
+
+	// Input
+	s := m.Name + "("
+	switch {
+	case inFields && multi && len(in) > 0:
+		p.line(1, s+p.trailing(m.Line, m.Line))
+		p.fields(in)
+		s = ")"
+	case inFields:
+		s += fieldsString(in) + ")"
+	default:
+		typ := m.Input.(*syntax.Type)
+		s += typ.String() + ")"
+	}
+
+	// Oneway and channel
+	if m.Oneway {
+		s += " oneway"
+	}
+	if ch := m.Channel; ch != nil {
+		s += " (" + channelString(ch) + ")"
+	}
+
+	// Output
+	switch out := m.Output.(type) {
+	case *syntax.Type:
+		s += " " + out.String()
+	case syntax.Fields:
+		if multi && len(out) > 0 {
+			p.line(1, s+" (")
+			p.fields(out)
+			s = ")"
+		} else {
+			s += " (" + fieldsString(out) + ")"
+		}
+	}
+
+	s += ";"
+	if multi {
+		p.line(1, s+p.trailing(m.End, m.End))
+	} else {
+		p.row(1, s, p.trailing(m.Line, m.End))
+	}
+}
+
+func (p *printer) fields(fields syntax.Fields) {
+	p.last = 0
+
+	for _, f := range fields {
+		p.leading(2, f.Line)
+		p.space(f.Line)
+
+		p.row(2, f.Name, f.Type.String(), fmt.Sprintf("%d,", f.Tag), p.trailing(f.Line, f.Line))
+		p.last = f.Line
+	}
+
+	p.flush()
+}
+
+// methodMultiline returns true when method fields are not on the method line.
+func methodMultiline(m *syntax.Method) bool {
+	in, _ := m.Input.(syntax.Fields)
+	out, _ := m.Output.(syntax.Fields)
+
+	for _, f := range in {
+		if f.Line != m.Line {
+			return true
+		}
+	}
+	for _, f := range out {
+		if f.Line != m.Line {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldsString(fields syntax.Fields) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%v %v %d", f.Name, f.Type, f.Tag))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quote returns a string in double quotes, the string is already escaped by the parser.
+func quote(s string) string {
+	return `"` + s + `"`
+}
+
+func channelString(ch *syntax.MethodChannel) string {
+	switch {
+	case ch.In != nil && ch.Out != nil:
+		return fmt.Sprintf("<-%v, %v->", ch.In, ch.Out)
+	case ch.In != nil:
+		return fmt.Sprintf("<-%v", ch.In)
+	default:
+		return fmt.Sprintf("%v->", ch.Out)
+	}
+}
+
+// comments
+
+// leading prints comments before a line, or all remaining comments when line is negative.
+func (p *printer) leading(indent int, line int) {
+	for _, c := range p.take(line) {
+		p.comment(indent, c)
+	}
+}
+
+// comment prints a comment on its own line, preserves a blank line before it.
+func (p *printer) comment(indent int, c *syntax.Comment) {
+	p.space(c.Line)
+
+	// Keep single line comments in sections
+	lines := strings.Split(c.Text, "\n")
+	if len(lines) == 1 {
+		p.row(indent, c.Text)
+		p.last = c.Line
+		return
+	}
+
+	// Keep block comment lines as is
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if i == 0 {
+			p.line(indent, line)
+		} else {
+			p.out = append(p.out, line)
+		}
+	}
+	p.last = c.End()
+}
+
+// take returns the next comments before a line, or all remaining comments when line is negative.
+func (p *printer) take(line int) []*syntax.Comment {
+	start := p.next
+	for p.next < len(p.comments) {
+		c := p.comments[p.next]
+		if line >= 0 && c.Line >= line {
+			break
+		}
+		p.next++
+	}
+	return p.comments[start:p.next]
+}
+
+// trailing returns the next comments within lines joined by spaces and prefixed by a space.
+func (p *printer) trailing(from int, to int) string {
+	var parts []string
+	for p.next < len(p.comments) {
+		c := p.comments[p.next]
+		if c.Line < from || c.Line > to || strings.Contains(c.Text, "\n") {
+			break
+		}
+
+		parts = append(parts, strings.TrimSpace(c.Text))
+		p.next++
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return " " + strings.Join(parts, " ")
+}
+
+// hasComments returns true when there are comments before a line.
+func (p *printer) hasComments(line int) bool {
+	if p.next >= len(p.comments) {
+		return false
+	}
+	return p.comments[p.next].Line < line
+}
+
+// output
+
+// space prints a blank line when the source has blank lines between the last line and a line.
+func (p *printer) space(line int) {
+	if p.last > 0 && line > p.last+1 {
+		p.blank()
+	}
+}
+
+// blank prints a single blank line.
+func (p *printer) blank() {
+	p.flush()
+
+	if n := len(p.out); n > 0 && p.out[n-1] != "" {
+		p.out = append(p.out, "")
+	}
+}
+
+// line prints a line, flushes the pending section.
+func (p *printer) line(indent int, s string) {
+	p.flush()
+	p.out = append(p.out, strings.Repeat(indentation, indent)+s)
+}
+
+// row adds a row of aligned cells to the pending section, skips trailing empty cells.
+func (p *printer) row(indent int, cells ...string) {
+	for len(cells) > 0 && cells[len(cells)-1] == "" {
+		cells = cells[:len(cells)-1]
+	}
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+
+	if len(p.sec.rows) > 0 && p.sec.indent != indent {
+		p.flush()
+	}
+	p.sec.indent = indent
+	p.sec.rows = append(p.sec.rows, cells)
+}
+
+// flush prints the pending section.
+func (p *printer) flush() {
+	if len(p.sec.rows) == 0 {
+		return
+	}
+
+	lines := p.sec.format()
+	p.out = append(p.out, lines...)
+	p.sec = section{}
+}
+
+// section
+
+// section is a group of consecutive rows aligned in columns.
+type section struct {
+	indent int
+	rows   [][]string
+}
+
+func (s section) format() []string {
+	// Compute column widths, skip last cells
+	var widths []int
+	for _, row := range s.rows {
+		for i, cell := range row[:len(row)-1] {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	// Print rows
+	prefix := strings.Repeat(indentation, s.indent)
+	lines := make([]string, 0, len(s.rows))
+
+	for _, row := range s.rows {
+		b := strings.Builder{}
+		b.WriteString(prefix)
+
+		for i, cell := range row {
+			b.WriteString(cell)
+
+			if i < len(row)-1 {
+				pad := widths[i] - len(cell) + 1
+				b.WriteString(strings.Repeat(" ", pad))
+			}
+		}
+
+		lines = append(lines, b.String())
+	}
+	return lines
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package format
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFormat(t *testing.T, src string) string {
+	out, err := Source([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func testSpecFiles(t *testing.T) []string {
+	var files []string
+	for _, pattern := range []string{
+		"../parser/*.spec",
+		"../../tests/*/*.spec",
+		"../../tests/*/*/*.spec",
+		"../../../proto/*/*.spec",
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Fatal("no spec files")
+	}
+	return files
+}
+
+// Source
+
+func TestSource__should_align_fields(t *testing.T) {
+	src := `message Message {
+	a int32 1;
+	long_name   []string      2;
+
+	c pkg.Type 10;
+}
+`
+	exp := `message Message {
+    a         int32    1;
+    long_name []string 2;
+
+    c pkg.Type 10;
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_align_enum_values_and_struct_fields(t *testing.T) {
+	src := `enum Enum { UNDEFINED = 0; ONE = 1; }
+struct Struct { key int32; value   string; }`
+
+	exp := `enum Enum {
+    UNDEFINED = 0;
+    ONE       = 1;
+}
+
+struct Struct {
+    key   int32;
+    value string;
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_format_key_structs(t *testing.T) {
+	src := `key   struct Key { id bin128; time   int64; }`
+
+	exp := `key struct Key {
+    id   bin128;
+    time int64;
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_sort_imports(t *testing.T) {
+	src := `import (
+	"pkg3"
+	alias "pkg1"
+	"pkg2" // Comment
+
+	"b"
+	"a"
+)
+options (go_package="test" java_package = "java")
+`
+	exp := `import (
+    alias "pkg1"
+    "pkg2" // Comment
+    "pkg3"
+
+    "a"
+    "b"
+)
+
+options (
+    go_package   = "test"
+    java_package = "java"
+)
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_preserve_comments(t *testing.T) {
+	src := `// Copyright header
+
+// Message doc comment.
+message Message { // Header comment
+	// Field doc comment.
+	a int32 1; // Trailing comment
+	bb int32 2;
+	// Last comment
+}
+
+/* Block
+   comment */
+struct Empty {}
+
+// Final comment
+`
+	exp := `// Copyright header
+
+// Message doc comment.
+message Message { // Header comment
+    // Field doc comment.
+    a  int32 1; // Trailing comment
+    bb int32 2;
+    // Last comment
+}
+
+/* Block
+   comment */
+struct Empty {}
+
+// Final comment
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_normalize_method_signatures(t *testing.T) {
+	src := `service Service {
+	method1 ( a int64 1,b string 2 ) ( ok bool 1 ) ;
+	method2(Request)(<-In,Out->)Response;
+	method3(msg string 1)oneway;
+	method4(
+		a int64 1,
+		long_name string 2
+	) Response;
+}`
+
+	exp := `service Service {
+    method1(a int64 1, b string 2) (ok bool 1);
+    method2(Request) (<-In, Out->) Response;
+    method3(msg string 1) oneway;
+    method4(
+        a         int64  1,
+        long_name string 2,
+    ) Response;
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestSource__should_return_error_on_invalid_source(t *testing.T) {
+	_, err := Source([]byte(`message Message {`))
+	assert.Error(t, err)
+}
+
+func TestSource__should_be_idempotent(t *testing.T) {
+	for _, path := range testSpecFiles(t) {
+		out0, err := File(path)
+		if err != nil {
+			t.Fatal(path, err)
+		}
+
+		out1, err := Source(out0)
+		if err != nil {
+			t.Fatal(path, err)
+		}
+		assert.Equal(t, string(out0), string(out1), path)
+	}
+}
+
+func TestSource__should_preserve_all_comments(t *testing.T) {
+	for _, path := range testSpecFiles(t) {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := Source(src)
+		if err != nil {
+			t.Fatal(path, err)
+		}
+
+		for _, line := range strings.Split(string(src), "\n") {
+			i := strings.Index(line, "//")
+			if i < 0 {
+				continue
+			}
+
+			comment := strings.TrimSpace(line[i:])
+			assert.Contains(t, string(out), comment, path)
+		}
+	}
+}
+
+// Diff
+
+func TestDiff__should_return_nil_when_equal(t *testing.T) {
+	d := Diff("a", "b", []byte("a\nb\n"), []byte("a\nb\n"))
+	assert.Nil(t, d)
+}
+
+func TestDiff__should_return_unified_diff(t *testing.T) {
+	old := "a\nb\nc\n"
+	new := "a\nB\nc\nd\n"
+
+	d := Diff("old", "new", []byte(old), []byte(new))
+	exp := `--- old
++++ new
+@@ -1,3 +1,4 @@
+ a
+-b
++B
+ c
++d
+`
+	assert.Equal(t, exp, string(d))
+}
+
+func TestDiff__should_write_deletions_before_insertions(t *testing.T) {
+	d := Diff("old", "new", []byte("x\ny\nz\n"), []byte("p\nx\nq\nz\n"))
+	exp := `--- old
++++ new
+@@ -1,3 +1,4 @@
++p
+ x
+-y
++q
+ z
+`
+	assert.Equal(t, exp, string(d))
+}
+
+func TestDiff__should_mark_missing_newline_at_end_of_file(t *testing.T) {
+	d := Diff("old", "new", []byte("a\nb\n"), []byte("a\nb"))
+	exp := `--- old
++++ new
+@@ -1,2 +1,2 @@
+ a
+-b
++b
+\ No newline at end of file
+`
+	assert.Equal(t, exp, string(d))
+}
+
+func TestDiff__should_return_minimal_diff(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	random := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		ops := diffLines(a, b)
+
+		var a1, b1 []string
+		equal := 0
+		for _, op := range ops {
+			switch op.kind {
+			case ' ':
+				a1 = append(a1, op.line)
+				b1 = append(b1, op.line)
+				equal++
+			case '-':
+				a1 = append(a1, op.line)
+			case '+':
+				b1 = append(b1, op.line)
+			}
+		}
+
+		assert.Equal(t, strings.Join(a, ""), strings.Join(a1, ""))
+		assert.Equal(t, strings.Join(b, ""), strings.Join(b1, ""))
+		assert.Equal(t, testLCS(a, b), equal, "%v %v", a, b)
+	}
+}
+
+func TestDiff__should_diff_large_files(t *testing.T) {
+	var old, new strings.Builder
+	for i := 0; i < 100_000; i++ {
+		fmt.Fprintf(&old, "line %d\n", i)
+		if i%10_000 == 0 {
+			fmt.Fprintf(&new, "changed %d\n", i)
+			continue
+		}
+		fmt.Fprintf(&new, "line %d\n", i)
+	}
+
+	d := Diff("old", "new", []byte(old.String()), []byte(new.String()))
+	assert.Equal(t, 10, strings.Count(string(d), "\n+changed"))
+}
+
+// testLCS returns the longest common subsequence length.
+func testLCS(a []string, b []string) int {
+	prev := make([]int, len(b)+1)
+	next := make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				next[j] = prev[j+1] + 1
+			} else {
+				next[j] = max(prev[j], next[j+1])
+			}
+		}
+		prev, next = next, prev
+	}
+	return prev[0]
+}
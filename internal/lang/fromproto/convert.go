@@ -0,0 +1,536 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package fromproto
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/basecomplextech/spec/internal/lang/format"
+)
+
+// maxTag is the maximum spec message field tag.
+const maxTag = 1<<16 - 1
+
+// scalarTypes maps proto scalar types to spec types.
+var scalarTypes = map[string]string{
+	"double":   "float64",
+	"float":    "float32",
+	"int32":    "int32",
+	"int64":    "int64",
+	"uint32":   "uint32",
+	"uint64":   "uint64",
+	"sint32":   "int32",
+	"sint64":   "int64",
+	"fixed32":  "uint32",
+	"fixed64":  "uint64",
+	"sfixed32": "int32",
+	"sfixed64": "int64",
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "bytes",
+}
+
+// wrapperTypes maps google.protobuf wrappers to spec types.
+var wrapperTypes = map[string]string{
+	"DoubleValue": "float64",
+	"FloatValue":  "float32",
+	"Int64Value":  "int64",
+	"UInt64Value": "uint64",
+	"Int32Value":  "int32",
+	"UInt32Value": "uint32",
+	"BoolValue":   "bool",
+	"StringValue": "string",
+	"BytesValue":  "bytes",
+}
+
+const (
+	wellKnownPackage = "google.protobuf"
+	emptyType        = "google.protobuf.Empty"
+)
+
+// symbols
+
+// symbol is a message or an enum.
+type symbol struct {
+	file *protoFile
+	name string // Spec name, nested names are flattened
+	enum bool
+}
+
+// symbols holds messages and enums by full names, i.e. "acme.users.User.Status".
+type symbols map[string]*symbol
+
+func newSymbols(files []*protoFile) symbols {
+	s := make(symbols)
+	for _, file := range files {
+		for _, msg := range file.Messages {
+			s.addMessage(file, file.Package, "", msg)
+		}
+		for _, enum := range file.Enums {
+			s.addEnum(file, file.Package, "", enum)
+		}
+	}
+	return s
+}
+
+func (s symbols) addMessage(file *protoFile, scope string, prefix string, msg *protoMessage) {
+	full := joinName(scope, msg.Name)
+	name := prefix + msg.Name
+	s[full] = &symbol{file: file, name: name}
+
+	for _, nested := range msg.Messages {
+		s.addMessage(file, full, name, nested)
+	}
+	for _, enum := range msg.Enums {
+		s.addEnum(file, full, name, enum)
+	}
+}
+
+func (s symbols) addEnum(file *protoFile, scope string, prefix string, enum *protoEnum) {
+	s[joinName(scope, enum.Name)] = &symbol{file: file, name: prefix + enum.Name, enum: true}
+}
+
+// lookup resolves a type name in a scope, searches the scope and its parents.
+func (s symbols) lookup(scope string, name string) (string, *symbol, bool) {
+	if strings.HasPrefix(name, ".") {
+		full := name[1:]
+		sym, ok := s[full]
+		return full, sym, ok
+	}
+
+	for {
+		full := joinName(scope, name)
+		if sym, ok := s[full]; ok {
+			return full, sym, true
+		}
+		if scope == "" {
+			return "", nil, false
+		}
+
+		i := strings.LastIndex(scope, ".")
+		if i < 0 {
+			scope = ""
+		} else {
+			scope = scope[:i]
+		}
+	}
+}
+
+// converter
+
+// converter converts a proto file into a spec file.
+type converter struct {
+	file    *protoFile
+	symbols symbols
+
+	b       bytes.Buffer
+	imports map[string]string // Import aliases by package ids
+	issues  []Issue
+}
+
+func convertFile(file *protoFile, symbols symbols) (File, []Issue, error) {
+	c := &converter{
+		file:    file,
+		symbols: symbols,
+		imports: make(map[string]string),
+	}
+
+	body, err := c.body()
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	// Write imports before definitions
+	out := &bytes.Buffer{}
+	c.writeImports(out)
+	out.Write(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return File{}, nil, fmt.Errorf("%v: invalid converted spec: %w", file.Path, err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(file.Path), filepath.Ext(file.Path)) + ".spec"
+	result := File{
+		Path:    path.Join(packageID(file.Package), base),
+		Content: src,
+	}
+	return result, c.issues, nil
+}
+
+func (c *converter) body() ([]byte, error) {
+	for _, enum := range c.file.Enums {
+		c.enum("", enum)
+	}
+	for _, msg := range c.file.Messages {
+		if err := c.message(c.file.Package, "", msg); err != nil {
+			return nil, err
+		}
+	}
+	for _, srv := range c.file.Services {
+		if err := c.service(srv); err != nil {
+			return nil, err
+		}
+	}
+	return c.b.Bytes(), nil
+}
+
+func (c *converter) writeImports(out *bytes.Buffer) {
+	if len(c.imports) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(c.imports))
+	for id := range c.imports {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	out.WriteString("import (\n")
+	for _, id := range ids {
+		alias := c.imports[id]
+		if alias == path.Base(id) {
+			fmt.Fprintf(out, "    %q\n", id)
+		} else {
+			fmt.Fprintf(out, "    %v %q\n", alias, id)
+		}
+	}
+	out.WriteString(")\n\n")
+}
+
+// enum
+
+func (c *converter) enum(prefix string, enum *protoEnum) {
+	name := prefix + enum.Name
+	c.doc("", enum.Doc)
+	fmt.Fprintf(&c.b, "enum %v {\n", name)
+
+	numbers := make(map[int]string)
+	zero := false
+	for _, v := range enum.Values {
+		if v.Number < 0 {
+			c.issuef(v.Line, "negative enum value %v.%v = %d is not supported, skipped",
+				name, v.Name, v.Number)
+			continue
+		}
+		if prev, ok := numbers[v.Number]; ok {
+			c.issuef(v.Line, "enum alias %v.%v of %v is not supported, skipped", name, v.Name, prev)
+			continue
+		}
+		numbers[v.Number] = v.Name
+		zero = zero || v.Number == 0
+
+		c.doc("    ", v.Doc)
+		fmt.Fprintf(&c.b, "    %v = %d;\n", v.Name, v.Number)
+	}
+	if !zero {
+		c.issuef(enum.Line, "enum %v has no zero value, spec enums require one", name)
+	}
+
+	c.b.WriteString("}\n\n")
+}
+
+// message
+
+func (c *converter) message(scope string, prefix string, msg *protoMessage) error {
+	full := joinName(scope, msg.Name)
+	name := prefix + msg.Name
+
+	type entry struct {
+		name  string
+		key   string
+		value string
+	}
+	var entries []entry
+
+	c.doc("", msg.Doc)
+	fmt.Fprintf(&c.b, "message %v {\n", name)
+
+	for _, f := range msg.Fields {
+		if f.Number > maxTag {
+			c.issuef(f.Line, "field %v.%v number %d exceeds the maximum tag %d, skipped",
+				name, f.Name, f.Number, maxTag)
+			continue
+		}
+
+		typ, err := c.typeName(full, f.Type, f.Line)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case f.Map:
+			key, err := c.typeName(full, f.Key, f.Line)
+			if err != nil {
+				return err
+			}
+
+			e := entry{name: name + camelCase(f.Name) + "Entry", key: key, value: typ}
+			entries = append(entries, e)
+			typ = "[]" + e.name
+
+			c.issuef(f.Line, "map field %v.%v is converted to a list of %v messages",
+				name, f.Name, e.name)
+
+		case f.Repeated:
+			typ = "[]" + typ
+		}
+
+		c.doc("    ", f.Doc)
+		fmt.Fprintf(&c.b, "    %v %v %d;\n", f.Name, typ, f.Number)
+	}
+	c.b.WriteString("}\n\n")
+
+	// Map entries
+	for _, e := range entries {
+		fmt.Fprintf(&c.b, "message %v {\n    key %v 1;\n    value %v 2;\n}\n\n", e.name, e.key, e.value)
+	}
+
+	// Nested types
+	for _, enum := range msg.Enums {
+		c.enum(name, enum)
+	}
+	for _, nested := range msg.Messages {
+		if err := c.message(full, name, nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// service
+
+func (c *converter) service(srv *protoService) error {
+	c.doc("", srv.Doc)
+	fmt.Fprintf(&c.b, "service %v {\n", srv.Name)
+
+	for i, m := range srv.Methods {
+		if i > 0 {
+			c.b.WriteString("\n")
+		}
+
+		sig, ok, err := c.method(srv, m)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			continue
+		}
+
+		c.doc("    ", m.Doc)
+		fmt.Fprintf(&c.b, "    %v;\n", sig)
+	}
+
+	c.b.WriteString("}\n\n")
+	return nil
+}
+
+// method returns a method signature, or false when the method is not supported.
+func (c *converter) method(srv *protoService, m *protoMethod) (string, bool, error) {
+	scope := c.file.Package
+	input, inEmpty, ok, err := c.methodType(srv, m, scope, m.Input)
+	if err != nil || !ok {
+		return "", false, err
+	}
+	output, outEmpty, ok, err := c.methodType(srv, m, scope, m.Output)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	if (m.InputStream && inEmpty) || (m.OutputStream && outEmpty) {
+		c.issuef(m.Line, "method %v.%v streams google.protobuf.Empty, which has no equivalent, skipped",
+			srv.Name, m.Name)
+		return "", false, nil
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(lowerCamelCase(m.Name))
+
+	// Input
+	switch {
+	case m.InputStream || inEmpty:
+		b.WriteString("()")
+	default:
+		fmt.Fprintf(b, "(%v)", input)
+	}
+
+	// Channel
+	switch {
+	case m.InputStream && m.OutputStream:
+		fmt.Fprintf(b, " (<-%v, %v->)", input, output)
+	case m.InputStream:
+		fmt.Fprintf(b, " (<-%v)", input)
+	case m.OutputStream:
+		fmt.Fprintf(b, " (%v->)", output)
+	}
+
+	// Output
+	if !m.OutputStream && !outEmpty {
+		fmt.Fprintf(b, " %v", output)
+	}
+	return b.String(), true, nil
+}
+
+// methodType returns a method message type, or true when it is google.protobuf.Empty.
+func (c *converter) methodType(srv *protoService, m *protoMethod, scope string, name string) (
+	typ string, empty bool, ok bool, err error) {
+
+	full := strings.TrimPrefix(name, ".")
+	if full == emptyType {
+		return "", true, true, nil
+	}
+	if strings.HasPrefix(full, wellKnownPackage+".") {
+		c.issuef(m.Line, "method %v.%v uses %v, which is not a spec message, skipped",
+			srv.Name, m.Name, full)
+		return "", false, false, nil
+	}
+
+	typ, err = c.typeName(scope, name, m.Line)
+	if err != nil {
+		return "", false, false, err
+	}
+	return typ, false, true, nil
+}
+
+// types
+
+// typeName returns a spec type name, adds an import when the type is in another package.
+func (c *converter) typeName(scope string, name string, line int) (string, error) {
+	if typ, ok := scalarTypes[name]; ok {
+		return typ, nil
+	}
+
+	// Well-known types
+	full := strings.TrimPrefix(name, ".")
+	if short, ok := strings.CutPrefix(full, wellKnownPackage+"."); ok {
+		if typ, ok := wrapperTypes[short]; ok {
+			return typ, nil
+		}
+
+		c.issuef(line, "%v has no equivalent, converted to bytes", full)
+		return "bytes", nil
+	}
+
+	// Messages and enums
+	_, sym, ok := c.symbols.lookup(scope, name)
+	if !ok {
+		return "", fmt.Errorf("%v:%d: unknown type %v", c.file.Path, line, name)
+	}
+	if sym.file.Package == c.file.Package {
+		return sym.name, nil
+	}
+
+	alias := c.importAlias(sym.file.Package)
+	return alias + "." + sym.name, nil
+}
+
+// importAlias adds an import of a proto package and returns its alias.
+func (c *converter) importAlias(pkg string) string {
+	id := packageID(pkg)
+	if alias, ok := c.imports[id]; ok {
+		return alias
+	}
+
+	// Use a parent name with versions, i.e. "usersv1" for "acme/users/v1"
+	parts := strings.Split(id, "/")
+	alias := parts[len(parts)-1]
+	if isVersion(alias) && len(parts) > 1 {
+		alias = parts[len(parts)-2] + alias
+	}
+
+	// Make unique
+	base := alias
+	for i := 2; c.hasAlias(alias); i++ {
+		alias = fmt.Sprintf("%v%d", base, i)
+	}
+
+	c.imports[id] = alias
+	return alias
+}
+
+func (c *converter) hasAlias(alias string) bool {
+	for _, a := range c.imports {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// util
+
+func (c *converter) doc(indent string, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			fmt.Fprintf(&c.b, "%v//\n", indent)
+			continue
+		}
+		fmt.Fprintf(&c.b, "%v// %v\n", indent, line)
+	}
+}
+
+func (c *converter) issuef(line int, format string, args ...any) {
+	c.issues = append(c.issues, Issue{
+		File:    c.file.Path,
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// packageID returns a spec package id of a proto package, i.e. "acme/users/v1".
+func packageID(pkg string) string {
+	return strings.ReplaceAll(pkg, ".", "/")
+}
+
+func joinName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// isVersion returns true for "v1", "v2beta1", etc.
+func isVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && unicode.IsDigit(rune(s[1]))
+}
+
+// camelCase converts "user_id" into "UserId".
+func camelCase(s string) string {
+	b := &strings.Builder{}
+	upper := true
+	for _, r := range s {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// lowerCamelCase converts "GetUser" into "getUser", and "URLFetch" into "urlFetch".
+func lowerCamelCase(s string) string {
+	runes := []rune(s)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
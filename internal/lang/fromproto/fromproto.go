@@ -0,0 +1,162 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package fromproto converts Protocol Buffers proto3 files into spec files.
+//
+// Proto packages are mapped to spec packages by replacing dots with slashes,
+// i.e. "acme.users.v1" is written into "acme/users/v1/<file>.spec", so converted
+// packages import each other when the output directory is used as an import path.
+//
+// The conversion maps:
+//
+//   - messages and enums, nested types are flattened as "OuterInner"
+//   - field numbers to tags, repeated fields to lists
+//   - map fields to lists of generated key-value entry messages
+//   - oneof fields to plain message fields
+//   - unary methods to request/response methods, google.protobuf.Empty to no request/response
+//   - streaming methods to channel methods, "(<-In)", "(Out->)" or "(<-In, Out->)"
+//   - google.protobuf wrappers to scalar types, spec fields are optional anyway
+//
+// Constructs without an equivalent are reported as issues.
+package fromproto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Options specify the conversion options.
+type Options struct {
+	ImportPaths []string // Directories to search imported proto files, default to the current directory
+}
+
+// File is a converted spec file.
+type File struct {
+	Path    string // Path relative to the output directory, i.e. "acme/users/v1/users.spec"
+	Content []byte
+}
+
+// Issue is a proto construct which has no spec equivalent, or is converted with a loss.
+type Issue struct {
+	File    string
+	Line    int
+	Message string
+}
+
+// String returns "file:line: message".
+func (i Issue) String() string {
+	return fmt.Sprintf("%v:%d: %v", i.File, i.Line, i.Message)
+}
+
+// Convert parses proto files and their imports, and converts the files into spec files.
+// Imported files are only used to resolve types, they are not converted.
+func Convert(paths []string, opts Options) ([]File, []Issue, error) {
+	importPaths := opts.ImportPaths
+	if len(importPaths) == 0 {
+		importPaths = []string{"."}
+	}
+
+	l := &loader{
+		importPaths: importPaths,
+		files:       make(map[string]*protoFile),
+	}
+
+	// Load files and imports
+	inputs := make([]*protoFile, 0, len(paths))
+	for _, path := range paths {
+		file, err := l.load(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		inputs = append(inputs, file)
+	}
+
+	// Convert files
+	symbols := newSymbols(l.list)
+
+	var files []File
+	var issues []Issue
+	for _, file := range inputs {
+		issues = append(issues, file.issues...)
+
+		f, fileIssues, err := convertFile(file, symbols)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, f)
+		issues = append(issues, fileIssues...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return files, issues, nil
+}
+
+// loader
+
+// loader parses proto files and their imports.
+type loader struct {
+	importPaths []string
+	files       map[string]*protoFile // Files by absolute paths
+	list        []*protoFile
+}
+
+func (l *loader) load(path string) (*protoFile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if file, ok := l.files[abs]; ok {
+		return file, nil
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parseFile(path, string(src))
+	if err != nil {
+		return nil, err
+	}
+	l.files[abs] = file
+	l.list = append(l.list, file)
+
+	for _, imp := range file.Imports {
+		if err := l.loadImport(file, imp); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+func (l *loader) loadImport(file *protoFile, imp string) error {
+	for _, dir := range l.importPaths {
+		path := filepath.Join(dir, filepath.FromSlash(imp))
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			_, err := l.load(path)
+			return err
+		case !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+
+	// Well-known types are mapped without their files
+	if strings.HasPrefix(imp, "google/protobuf/") {
+		return nil
+	}
+	return fmt.Errorf("%v: import %q not found in import paths", file.Path, imp)
+}
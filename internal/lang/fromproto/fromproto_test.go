@@ -0,0 +1,262 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package fromproto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+	specparser "github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConvert(t *testing.T, paths ...string) (map[string]string, []string) {
+	files, issues, err := Convert(paths, Options{ImportPaths: []string{"testdata"}})
+	require.NoError(t, err)
+
+	result := make(map[string]string, len(files))
+	for _, file := range files {
+		result[file.Path] = string(file.Content)
+	}
+
+	msgs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msgs = append(msgs, issue.String())
+	}
+	return result, msgs
+}
+
+func testConvertSource(t *testing.T, src string) (string, []string, error) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.proto")
+	err := os.WriteFile(path, []byte(src), 0644)
+	require.NoError(t, err)
+
+	files, issues, err := Convert([]string{path}, Options{ImportPaths: []string{dir}})
+	if err != nil {
+		return "", nil, err
+	}
+	require.Len(t, files, 1)
+
+	msgs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msgs = append(msgs, strings.TrimPrefix(issue.String(), path+":"))
+	}
+	return string(files[0].Content), msgs, nil
+}
+
+func testCompile(t *testing.T, files map[string]string) {
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(path, []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	x := model.NewContext(specparser.New(), []string{dir})
+	for path := range files {
+		id := filepath.ToSlash(filepath.Dir(path))
+		_, err := x.Compile(id, filepath.Join(dir, filepath.Dir(path)))
+		require.NoError(t, err, path)
+	}
+}
+
+// Convert
+
+func TestConvert__should_convert_proto_files_into_compilable_spec_files(t *testing.T) {
+	files, _ := testConvert(t,
+		"testdata/acme/users/v1/users.proto",
+		"testdata/acme/common/v1/common.proto",
+	)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	assert.ElementsMatch(t, []string{
+		"acme/users/v1/users.spec",
+		"acme/common/v1/common.spec",
+	}, paths)
+
+	testCompile(t, files)
+}
+
+func TestConvert__should_convert_messages_and_nested_types(t *testing.T) {
+	files, _ := testConvert(t, "testdata/acme/users/v1/users.proto")
+	spec := files["acme/users/v1/users.spec"]
+
+	assert.Contains(t, spec, `import (
+    commonv1 "acme/common/v1"
+)`)
+	assert.Contains(t, spec, `// User is a user account.
+message User {
+    id         string            1;
+    // Display name
+    name       string            2;
+`)
+	assert.Contains(t, spec, "    status     UserStatus        3;\n")
+	assert.Contains(t, spec, "    emails     []string          4;\n")
+	assert.Contains(t, spec, "    addresses  []UserAddress     5;\n")
+	assert.Contains(t, spec, "    labels     []UserLabelsEntry 6;\n")
+	assert.Contains(t, spec, "    nickname   string            7;\n")
+	assert.Contains(t, spec, "    phone      string            11;\n")
+	assert.Contains(t, spec, `message UserLabelsEntry {
+    key   string 1;
+    value string 2;
+}`)
+	assert.Contains(t, spec, `// Status is a user status.
+enum UserStatus {`)
+	assert.Contains(t, spec, "message UserAddress {")
+	assert.Contains(t, spec, "    page commonv1.Page 1;")
+}
+
+func TestConvert__should_convert_streaming_methods_to_channels(t *testing.T) {
+	files, _ := testConvert(t, "testdata/acme/users/v1/users.proto")
+	spec := files["acme/users/v1/users.spec"]
+
+	assert.Contains(t, spec, `    // GetUser returns a user by id.
+    getUser(GetUserRequest) User;`)
+	assert.Contains(t, spec, "    deleteUser(GetUserRequest);")
+	assert.Contains(t, spec, "    listUsers(ListUsersRequest) (User->);")
+	assert.Contains(t, spec, "    upload() (<-Chunk) UploadResult;")
+	assert.Contains(t, spec, "    watch() (<-GetUserRequest, UserEvent->);")
+	assert.Contains(t, spec, "    ping();")
+}
+
+func TestConvert__should_report_constructs_without_equivalent(t *testing.T) {
+	_, issues := testConvert(t, "testdata/acme/users/v1/users.proto")
+
+	assert.Equal(t, []string{
+		"testdata/acme/users/v1/users.proto:25: " +
+			"reserved 10 in message User has no equivalent, skipped",
+		"testdata/acme/users/v1/users.proto:32: " +
+			"map field User.labels is converted to a list of UserLabelsEntry messages",
+		"testdata/acme/users/v1/users.proto:34: " +
+			"google.protobuf.Timestamp has no equivalent, converted to bytes",
+		"testdata/acme/users/v1/users.proto:36: " +
+			"oneof User.contact has no equivalent, its fields are added to the message",
+	}, issues)
+}
+
+func TestConvert__should_report_reserved_tags_and_names(t *testing.T) {
+	spec, issues, err := testConvertSource(t, `
+syntax = "proto3";
+package test;
+
+message Msg {
+    reserved 2, 15, 9 to 11;
+    reserved "foo", "bar";
+    string a = 1;
+}
+
+enum Color {
+    UNDEFINED = 0;
+    reserved 5 to max;
+}
+`)
+	require.NoError(t, err)
+
+	assert.NotContains(t, spec, "reserved")
+	assert.Equal(t, []string{
+		"6: reserved 2, 15, 9 to 11 in message Msg has no equivalent, skipped",
+		"7: reserved \"foo\", \"bar\" in message Msg has no equivalent, skipped",
+		"13: reserved 5 to max in enum Color has no equivalent, skipped",
+	}, issues)
+}
+
+func TestConvert__should_return_error_when_import_not_found(t *testing.T) {
+	_, _, err := testConvertSource(t, `
+syntax = "proto3";
+package test;
+import "missing/missing.proto";
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `import "missing/missing.proto" not found in import paths`)
+}
+
+func TestConvert__should_return_error_when_unknown_type(t *testing.T) {
+	_, _, err := testConvertSource(t, `
+syntax = "proto3";
+package test;
+
+message Msg {
+    Unknown field = 1;
+}
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "test.proto:6: unknown type Unknown")
+}
+
+func TestConvert__should_report_unsupported_enum_values(t *testing.T) {
+	spec, issues, err := testConvertSource(t, `
+syntax = "proto3";
+package test;
+
+enum Color {
+    option allow_alias = true;
+    RED = 1;
+    CRIMSON = 1;
+    INVALID = -1;
+}
+`)
+	require.NoError(t, err)
+
+	assert.Contains(t, spec, "    RED = 1;\n}")
+	assert.Equal(t, []string{
+		"5: enum Color has no zero value, spec enums require one",
+		"8: enum alias Color.CRIMSON of RED is not supported, skipped",
+		"9: negative enum value Color.INVALID = -1 is not supported, skipped",
+	}, issues)
+}
+
+func TestConvert__should_skip_fields_with_too_large_numbers(t *testing.T) {
+	spec, issues, err := testConvertSource(t, `
+syntax = "proto3";
+package test;
+
+message Msg {
+    string a = 1;
+    string b = 100000;
+}
+`)
+	require.NoError(t, err)
+
+	assert.NotContains(t, spec, "100000")
+	assert.Equal(t, []string{
+		"7: field Msg.b number 100000 exceeds the maximum tag 65535, skipped",
+	}, issues)
+}
+
+func TestConvert__should_report_proto2_syntax(t *testing.T) {
+	_, issues, err := testConvertSource(t, `
+syntax = "proto2";
+package test;
+
+message Msg {
+    required string a = 1;
+}
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`2: syntax "proto2" is converted as proto3`,
+		"6: required field in message Msg is converted to an optional field",
+	}, issues)
+}
+
+// util
+
+func TestLowerCamelCase(t *testing.T) {
+	assert.Equal(t, "getUser", lowerCamelCase("GetUser"))
+	assert.Equal(t, "urlFetch", lowerCamelCase("URLFetch"))
+	assert.Equal(t, "id", lowerCamelCase("ID"))
+	assert.Equal(t, "get", lowerCamelCase("get"))
+}
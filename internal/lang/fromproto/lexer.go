@@ -0,0 +1,225 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package fromproto
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string // Unquoted string, identifier, number or symbol
+	line int
+}
+
+// comment is a line or block comment without comment markers.
+type comment struct {
+	line    int // First line
+	endLine int // Last line
+	text    string
+	inline  bool // Comment follows a token on the same line
+}
+
+// lexer splits a proto source into tokens and collects comments.
+type lexer struct {
+	src  []rune
+	pos  int
+	line int
+	last int // Line of the last token
+
+	comments []comment
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: []rune(src), line: 1}
+}
+
+// tokens returns all tokens up to and including EOF.
+func (l *lexer) tokens() ([]token, error) {
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skip(); err != nil {
+		return token{}, err
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line}, nil
+	}
+
+	line := l.line
+	l.last = line
+	c := l.src[l.pos]
+
+	switch {
+	case c == '_' || unicode.IsLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && isIdentRune(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: string(l.src[start:l.pos]), line: line}, nil
+
+	case unicode.IsDigit(c) || (c == '.' && l.pos+1 < len(l.src) && unicode.IsDigit(l.src[l.pos+1])):
+		return l.number(line), nil
+
+	case c == '"' || c == '\'':
+		return l.string(line)
+	}
+
+	l.pos++
+	return token{kind: tokenSymbol, text: string(c), line: line}, nil
+}
+
+// skip skips whitespace and collects comments.
+func (l *lexer) skip() error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+
+		case unicode.IsSpace(c):
+			l.pos++
+
+		case c == '/' && l.peek(1) == '/':
+			start := l.pos + 2
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+			l.addComment(l.line, l.line, string(l.src[start:l.pos]))
+
+		case c == '/' && l.peek(1) == '*':
+			line := l.line
+			l.pos += 2
+			start := l.pos
+			for l.pos < len(l.src) && !(l.src[l.pos] == '*' && l.peek(1) == '/') {
+				if l.src[l.pos] == '\n' {
+					l.line++
+				}
+				l.pos++
+			}
+			if l.pos >= len(l.src) {
+				return fmt.Errorf("%d: unterminated comment", line)
+			}
+			text := string(l.src[start:l.pos])
+			l.pos += 2
+			l.addComment(line, l.line, text)
+
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *lexer) addComment(line int, endLine int, text string) {
+	lines := strings.Split(text, "\n")
+	for i, s := range lines {
+		s = strings.TrimSpace(s)
+		s = strings.TrimPrefix(s, "*")
+		lines[i] = strings.TrimSpace(s)
+	}
+	text = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	l.comments = append(l.comments, comment{
+		line:    line,
+		endLine: endLine,
+		text:    text,
+		inline:  l.last == line,
+	})
+}
+
+func (l *lexer) number(line int) token {
+	start := l.pos
+	kind := tokenInt
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '.' || ((c == 'e' || c == 'E') && !l.isHex(start)):
+			kind = tokenFloat
+			if (c == 'e' || c == 'E') && (l.peek(1) == '-' || l.peek(1) == '+') {
+				l.pos++
+			}
+		case c == 'x' || c == 'X' || unicode.IsDigit(c) || unicode.Is(unicode.ASCII_Hex_Digit, c):
+		default:
+			return token{kind: kind, text: string(l.src[start:l.pos]), line: line}
+		}
+		l.pos++
+	}
+	return token{kind: kind, text: string(l.src[start:l.pos]), line: line}
+}
+
+func (l *lexer) isHex(start int) bool {
+	return l.pos-start >= 2 && l.src[start] == '0' && (l.src[start+1] == 'x' || l.src[start+1] == 'X')
+}
+
+func (l *lexer) string(line int) (token, error) {
+	quote := l.src[l.pos]
+	l.pos++
+
+	b := &strings.Builder{}
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return token{}, fmt.Errorf("%d: unterminated string", line)
+		}
+
+		c := l.src[l.pos]
+		l.pos++
+
+		switch {
+		case c == quote:
+			return token{kind: tokenString, text: b.String(), line: line}, nil
+		case c == '\\' && l.pos < len(l.src):
+			e := l.src[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			default:
+				b.WriteRune(e)
+			}
+		default:
+			b.WriteRune(c)
+		}
+	}
+}
+
+func (l *lexer) peek(n int) rune {
+	if l.pos+n >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+n]
+}
+
+func isIdentRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
@@ -0,0 +1,787 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package fromproto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// protoFile is a parsed proto file.
+type protoFile struct {
+	Path     string
+	Syntax   string // "proto3", "proto2" or empty
+	Package  string
+	Imports  []string
+	Messages []*protoMessage
+	Enums    []*protoEnum
+	Services []*protoService
+
+	issues []Issue // Unsupported constructs
+}
+
+type protoMessage struct {
+	Name     string
+	Line     int
+	Doc      string
+	Fields   []*protoField
+	Messages []*protoMessage
+	Enums    []*protoEnum
+}
+
+type protoField struct {
+	Name     string
+	Type     string // Scalar or message type, value type in maps
+	Key      string // Key type in maps
+	Number   int
+	Repeated bool
+	Map      bool
+	Oneof    string // Oneof name
+	Line     int
+	Doc      string
+}
+
+type protoEnum struct {
+	Name   string
+	Line   int
+	Doc    string
+	Values []*protoEnumValue
+}
+
+type protoEnumValue struct {
+	Name   string
+	Number int
+	Line   int
+	Doc    string
+}
+
+type protoService struct {
+	Name    string
+	Line    int
+	Doc     string
+	Methods []*protoMethod
+}
+
+type protoMethod struct {
+	Name         string
+	Input        string
+	Output       string
+	InputStream  bool
+	OutputStream bool
+	Line         int
+	Doc          string
+}
+
+// parser is a recursive descent proto parser.
+type parser struct {
+	file   *protoFile
+	tokens []token
+	pos    int
+
+	docs    map[int]string // Doc comments by the lines of the following declarations
+	inlines map[int]string // Inline comments by their lines
+}
+
+// parseFile parses a proto source.
+func parseFile(path string, src string) (*protoFile, error) {
+	l := newLexer(src)
+	tokens, err := l.tokens()
+	if err != nil {
+		return nil, fmt.Errorf("%v:%w", path, err)
+	}
+
+	p := &parser{
+		file:   &protoFile{Path: path},
+		tokens: tokens,
+		docs:   docComments(l.comments),
+	}
+	p.inlines = inlineComments(l.comments)
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("%v:%w", path, err)
+	}
+	return p.file, nil
+}
+
+// docComments returns doc comments by the lines following them,
+// adjacent line comments are joined.
+func docComments(comments []comment) map[int]string {
+	docs := make(map[int]string)
+
+	for i := 0; i < len(comments); i++ {
+		c := comments[i]
+		if c.inline {
+			continue
+		}
+
+		lines := []string{c.text}
+		end := c.endLine
+		for i+1 < len(comments) && !comments[i+1].inline && comments[i+1].line == end+1 {
+			i++
+			end = comments[i].endLine
+			lines = append(lines, comments[i].text)
+		}
+		docs[end+1] = strings.Join(lines, "\n")
+	}
+	return docs
+}
+
+// inlineComments returns inline comments by their lines.
+func inlineComments(comments []comment) map[int]string {
+	inlines := make(map[int]string)
+	for _, c := range comments {
+		if c.inline {
+			inlines[c.line] = c.text
+		}
+	}
+	return inlines
+}
+
+// doc returns a doc comment of a declaration, or its inline comment.
+func (p *parser) doc(line int) string {
+	if doc, ok := p.docs[line]; ok {
+		return doc
+	}
+	return p.inlines[line]
+}
+
+func (p *parser) parse() error {
+	for !p.at(tokenEOF, "") {
+		if p.accept(";") {
+			continue
+		}
+
+		t := p.peek()
+		switch t.text {
+		case "syntax", "edition":
+			p.pos++
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			s, err := p.expectKind(tokenString)
+			if err != nil {
+				return err
+			}
+			p.file.Syntax = s.text
+			if t.text == "edition" || s.text != "proto3" {
+				p.issuef(t.line, "%v %q is converted as proto3", t.text, s.text)
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+
+		case "package":
+			p.pos++
+			name, err := p.fullIdent()
+			if err != nil {
+				return err
+			}
+			p.file.Package = name
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+
+		case "import":
+			p.pos++
+			p.accept("public")
+			p.accept("weak")
+			s, err := p.expectKind(tokenString)
+			if err != nil {
+				return err
+			}
+			p.file.Imports = append(p.file.Imports, s.text)
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+
+		case "message":
+			msg, err := p.message()
+			if err != nil {
+				return err
+			}
+			p.file.Messages = append(p.file.Messages, msg)
+
+		case "enum":
+			enum, err := p.enum()
+			if err != nil {
+				return err
+			}
+			p.file.Enums = append(p.file.Enums, enum)
+
+		case "service":
+			srv, err := p.service()
+			if err != nil {
+				return err
+			}
+			p.file.Services = append(p.file.Services, srv)
+
+		case "extend":
+			p.issuef(t.line, "extend is not supported, skipped")
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+
+		default:
+			return p.errorf(t, "unexpected %q", t.text)
+		}
+	}
+	return nil
+}
+
+// message
+
+func (p *parser) message() (*protoMessage, error) {
+	start := p.next() // message
+	name, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &protoMessage{
+		Name: name.text,
+		Line: start.line,
+		Doc:  p.docs[start.line],
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		if err := p.messageElement(msg, ""); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+func (p *parser) messageElement(msg *protoMessage, oneof string) error {
+	if p.accept(";") {
+		return nil
+	}
+
+	t := p.peek()
+	switch t.text {
+	case "message":
+		if oneof != "" {
+			break
+		}
+		nested, err := p.message()
+		if err != nil {
+			return err
+		}
+		msg.Messages = append(msg.Messages, nested)
+		return nil
+
+	case "enum":
+		if oneof != "" {
+			break
+		}
+		enum, err := p.enum()
+		if err != nil {
+			return err
+		}
+		msg.Enums = append(msg.Enums, enum)
+		return nil
+
+	case "oneof":
+		if oneof != "" {
+			break
+		}
+		p.pos++
+		name, err := p.expectKind(tokenIdent)
+		if err != nil {
+			return err
+		}
+		if err := p.expect("{"); err != nil {
+			return err
+		}
+
+		p.issuef(t.line, "oneof %v.%v has no equivalent, its fields are added to the message",
+			msg.Name, name.text)
+		for !p.accept("}") {
+			if err := p.messageElement(msg, name.text); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "option":
+		return p.skipStatement()
+
+	case "reserved":
+		return p.reserved("message " + msg.Name)
+
+	case "extensions", "extend":
+		p.issuef(t.line, "%v in message %v is not supported, skipped", t.text, msg.Name)
+		return p.skipStatement()
+
+	case "map":
+		if p.peekAt(1).text == "<" {
+			return p.mapField(msg)
+		}
+	}
+
+	return p.field(msg, oneof)
+}
+
+func (p *parser) field(msg *protoMessage, oneof string) error {
+	start := p.peek()
+	field := &protoField{
+		Oneof: oneof,
+		Line:  start.line,
+		Doc:   p.doc(start.line),
+	}
+
+	switch start.text {
+	case "repeated":
+		p.pos++
+		field.Repeated = true
+	case "optional":
+		p.pos++
+	case "required":
+		p.pos++
+		p.issuef(start.line, "required field in message %v is converted to an optional field", msg.Name)
+	}
+
+	if p.peek().text == "group" {
+		p.issuef(start.line, "group in message %v is not supported, skipped", msg.Name)
+		return p.skipStatement()
+	}
+
+	typ, err := p.typeName()
+	if err != nil {
+		return err
+	}
+	field.Type = typ
+
+	return p.fieldRest(msg, field)
+}
+
+func (p *parser) mapField(msg *protoMessage) error {
+	start := p.next() // map
+	field := &protoField{
+		Map:  true,
+		Line: start.line,
+		Doc:  p.doc(start.line),
+	}
+
+	if err := p.expect("<"); err != nil {
+		return err
+	}
+	key, err := p.typeName()
+	if err != nil {
+		return err
+	}
+	if err := p.expect(","); err != nil {
+		return err
+	}
+	value, err := p.typeName()
+	if err != nil {
+		return err
+	}
+	if err := p.expect(">"); err != nil {
+		return err
+	}
+
+	field.Key = key
+	field.Type = value
+	return p.fieldRest(msg, field)
+}
+
+// fieldRest parses a field name, number and options.
+func (p *parser) fieldRest(msg *protoMessage, field *protoField) error {
+	name, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return err
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	number, err := p.integer()
+	if err != nil {
+		return err
+	}
+	if err := p.skipOptions(); err != nil {
+		return err
+	}
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	field.Name = name.text
+	field.Number = number
+	msg.Fields = append(msg.Fields, field)
+	return nil
+}
+
+// enum
+
+func (p *parser) enum() (*protoEnum, error) {
+	start := p.next() // enum
+	name, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	enum := &protoEnum{
+		Name: name.text,
+		Line: start.line,
+		Doc:  p.docs[start.line],
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		if p.accept(";") {
+			continue
+		}
+
+		t := p.peek()
+		switch t.text {
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+			continue
+		case "reserved":
+			if err := p.reserved("enum " + enum.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		name, err := p.expectKind(tokenIdent)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("="); err != nil {
+			return nil, err
+		}
+		number, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.skipOptions(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+
+		enum.Values = append(enum.Values, &protoEnumValue{
+			Name:   name.text,
+			Number: number,
+			Line:   name.line,
+			Doc:    p.doc(name.line),
+		})
+	}
+	return enum, nil
+}
+
+// service
+
+func (p *parser) service() (*protoService, error) {
+	start := p.next() // service
+	name, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &protoService{
+		Name: name.text,
+		Line: start.line,
+		Doc:  p.docs[start.line],
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		if p.accept(";") {
+			continue
+		}
+
+		t := p.peek()
+		switch t.text {
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		case "rpc":
+			method, err := p.method()
+			if err != nil {
+				return nil, err
+			}
+			srv.Methods = append(srv.Methods, method)
+		default:
+			return nil, p.errorf(t, "unexpected %q in service %v", t.text, srv.Name)
+		}
+	}
+	return srv, nil
+}
+
+func (p *parser) method() (*protoMethod, error) {
+	start := p.next() // rpc
+	name, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &protoMethod{
+		Name: name.text,
+		Line: start.line,
+		Doc:  p.docs[start.line],
+	}
+
+	m.Input, m.InputStream, err = p.methodType()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectIdent("returns"); err != nil {
+		return nil, err
+	}
+	m.Output, m.OutputStream, err = p.methodType()
+	if err != nil {
+		return nil, err
+	}
+
+	// Options block or semicolon
+	if p.at(tokenSymbol, "{") {
+		if err := p.skipBlock(); err != nil {
+			return nil, err
+		}
+		p.accept(";")
+		return m, nil
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (p *parser) methodType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+
+	stream := false
+	if p.peek().text == "stream" && p.peekAt(1).text != ")" {
+		p.pos++
+		stream = true
+	}
+
+	typ, err := p.typeName()
+	if err != nil {
+		return "", false, err
+	}
+	if err := p.expect(")"); err != nil {
+		return "", false, err
+	}
+	return typ, stream, nil
+}
+
+// util
+
+// typeName parses a possibly fully qualified type name, i.e. ".foo.Bar".
+func (p *parser) typeName() (string, error) {
+	prefix := ""
+	if p.accept(".") {
+		prefix = "."
+	}
+
+	name, err := p.fullIdent()
+	if err != nil {
+		return "", err
+	}
+	return prefix + name, nil
+}
+
+func (p *parser) fullIdent() (string, error) {
+	t, err := p.expectKind(tokenIdent)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{t.text}
+	for p.accept(".") {
+		t, err := p.expectKind(tokenIdent)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, t.text)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func (p *parser) integer() (int, error) {
+	neg := p.accept("-")
+
+	t, err := p.expectKind(tokenInt)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseInt(t.text, 0, 64)
+	if err != nil {
+		return 0, p.errorf(t, "invalid integer %q", t.text)
+	}
+	if neg {
+		n = -n
+	}
+	return int(n), nil
+}
+
+// skipOptions skips field options in brackets.
+func (p *parser) skipOptions() error {
+	if !p.at(tokenSymbol, "[") {
+		return nil
+	}
+	return p.skipBalanced("[", "]")
+}
+
+// skipStatement skips a statement up to a semicolon, or a block.
+func (p *parser) skipStatement() error {
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "unexpected end of file")
+		case t.kind == tokenSymbol && t.text == ";":
+			p.pos++
+			return nil
+		case t.kind == tokenSymbol && t.text == "{":
+			return p.skipBlock()
+		}
+		p.pos++
+	}
+}
+
+// reserved reports and skips a reserved statement, spec has no reserved tags or names.
+func (p *parser) reserved(owner string) error {
+	start := p.next() // reserved
+
+	var b strings.Builder
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "unexpected end of file")
+		case t.kind == tokenSymbol && t.text == ";":
+			p.issuef(start.line, "reserved %v in %v has no equivalent, skipped", b.String(), owner)
+			return nil
+		case t.kind == tokenSymbol && t.text == ",":
+			b.WriteString(",")
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		if t.kind == tokenString {
+			b.WriteString(strconv.Quote(t.text))
+		} else {
+			b.WriteString(t.text)
+		}
+	}
+}
+
+func (p *parser) skipBlock() error {
+	return p.skipBalanced("{", "}")
+}
+
+func (p *parser) skipBalanced(open string, close string) error {
+	depth := 0
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "unexpected end of file, expected %q", close)
+		case t.kind != tokenSymbol:
+		case t.text == open:
+			depth++
+		case t.text == close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *parser) peek() token {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) at(kind tokenKind, text string) bool {
+	t := p.peek()
+	return t.kind == kind && (text == "" || t.text == text)
+}
+
+func (p *parser) accept(symbol string) bool {
+	if p.at(tokenSymbol, symbol) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(symbol string) error {
+	if p.accept(symbol) {
+		return nil
+	}
+	t := p.peek()
+	return p.errorf(t, "expected %q, got %q", symbol, t.text)
+}
+
+func (p *parser) expectIdent(ident string) error {
+	if p.at(tokenIdent, ident) {
+		p.pos++
+		return nil
+	}
+	t := p.peek()
+	return p.errorf(t, "expected %q, got %q", ident, t.text)
+}
+
+func (p *parser) expectKind(kind tokenKind) (token, error) {
+	t := p.peek()
+	if t.kind != kind {
+		return t, p.errorf(t, "unexpected %q", t.text)
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("%d: %v", t.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) issuef(line int, format string, args ...any) {
+	p.file.issues = append(p.file.issues, Issue{
+		File:    p.file.Path,
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
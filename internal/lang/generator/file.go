@@ -32,10 +32,13 @@ func (w *fileWriter) file(file *model.File) error {
 	w.line(`"github.com/basecomplextech/baselibrary/status"`)
 	w.line(`"github.com/basecomplextech/spec"`)
 
-	if !w.skipRPC {
+	if !w.opts.SkipRPC {
 		w.line(`"github.com/basecomplextech/spec/rpc"`)
 		w.line(`"github.com/basecomplextech/spec/proto/prpc"`)
 	}
+	if fileHasKeys(file) {
+		w.line(`"github.com/basecomplextech/spec/key"`)
+	}
 
 	for _, imp := range file.Imports {
 		pkg := importPackage(imp)
@@ -54,7 +57,7 @@ func (w *fileWriter) file(file *model.File) error {
 	w.line(`_ pools.Pool[any]`)
 	w.line(`_ ref.Ref`)
 
-	if !w.skipRPC {
+	if !w.opts.SkipRPC {
 		w.line(`_ rpc.Client`)
 		w.line(`_ prpc.Request`)
 	}
@@ -84,7 +87,7 @@ func (w *fileWriter) definitions(file *model.File) error {
 				return err
 			}
 		case model.DefinitionService:
-			if w.skipRPC {
+			if w.opts.SkipRPC {
 				continue
 			}
 
@@ -108,7 +111,7 @@ func (w *fileWriter) definitions(file *model.File) error {
 	}
 
 	// Service impls
-	if !w.skipRPC {
+	if !w.opts.SkipRPC {
 		for _, def := range file.Definitions {
 			if def.Type != model.DefinitionService {
 				continue
@@ -126,6 +129,17 @@ func (w *fileWriter) definitions(file *model.File) error {
 				return err
 			}
 		}
+
+		if w.opts.Mocks {
+			for _, def := range file.Definitions {
+				if def.Type != model.DefinitionService {
+					continue
+				}
+				if err := w.mock(def); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -154,6 +168,10 @@ func (w *fileWriter) clientImpl(def *model.Definition) error {
 	return newClientImplWriter(w.writer).clientImpl(def)
 }
 
+func (w *fileWriter) mock(def *model.Definition) error {
+	return newMockWriter(w.writer).mock(def)
+}
+
 func (w *fileWriter) service(def *model.Definition) error {
 	return newServiceWriter(w.writer).service(def)
 }
@@ -161,3 +179,13 @@ func (w *fileWriter) service(def *model.Definition) error {
 func (w *fileWriter) serviceImpl(def *model.Definition) error {
 	return newServiceImplWriter(w.writer).serviceImpl(def)
 }
+
+// fileHasKeys returns true if the file has key structs.
+func fileHasKeys(file *model.File) bool {
+	for _, def := range file.Definitions {
+		if def.Type == model.DefinitionStruct && def.Struct.Key {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@
 package generator
 
 import (
+	"bytes"
 	"go/format"
 	"os"
 	"path/filepath"
@@ -17,75 +18,111 @@ const (
 	OptionPackage = "go_package"
 )
 
+type Options struct {
+	SkipRPC bool // Skip generating RPC code
+	Mocks   bool // Generate mock clients and fake services
+}
+
 type Generator interface {
-	// Package generates a go package.
+	// Package generates a go package, only files with changed content are written.
 	Package(pkg *model.Package, out string) error
+
+	// Files generates go package files in memory without writing them.
+	Files(pkg *model.Package, out string) ([]File, error)
+}
+
+// File is a generated file.
+type File struct {
+	Path    string // Output file path
+	Content []byte
 }
 
 // New returns a new generator.
-func New(skipRPC bool) Generator {
-	return newGenerator(skipRPC)
+func New(opts Options) Generator {
+	return newGenerator(opts)
 }
 
 type generator struct {
-	skipRPC bool
+	opts Options
 }
 
-func newGenerator(skipRPC bool) *generator {
-	return &generator{skipRPC: skipRPC}
+func newGenerator(opts Options) *generator {
+	return &generator{opts: opts}
 }
 
-// Package generates a go package.
+// Package generates a go package, only files with changed content are written.
 func (g *generator) Package(pkg *model.Package, out string) error {
-	for _, file := range pkg.Files {
-		if err := g.file(file, out); err != nil {
+	files, err := g.Files(pkg, out)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := WriteFile(file); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (g *generator) file(file *model.File, out string) error {
-	// Generate file
-	w := newWriter(g.skipRPC)
-	if err := w.file(file); err != nil {
-		return err
-	}
-	bytes := w.b.Bytes()
-
-	// Format file
-	bytes, err := format.Source(bytes)
-	if err != nil {
-		return err
+// Files generates go package files in memory without writing them.
+func (g *generator) Files(pkg *model.Package, out string) ([]File, error) {
+	files := make([]File, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		f, err := g.file(file, out)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
 	}
-
-	// Create file
-	return g.createFile(file, out, bytes)
+	return files, nil
 }
 
-// private
-
-func (g *generator) createFile(file *model.File, out string, bytes []byte) error {
-	filename := filenameWithoutExt(file.Name) + "_generated.go"
-	path := filepath.Join(out, filename)
+// WriteFile writes a generated file, skips the file when its content has not changed
+// to keep modification times for build tools.
+func WriteFile(file File) error {
+	if old, err := os.ReadFile(file.Path); err == nil && bytes.Equal(old, file.Content) {
+		return nil
+	}
 
-	dir := filepath.Dir(path)
+	dir := filepath.Dir(file.Path)
 	if err := os.MkdirAll(dir, 0777); err != nil {
 		return err
 	}
 
-	f, err := os.Create(path)
+	f, err := os.Create(file.Path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	if _, err = f.Write(bytes); err != nil {
+	if _, err = f.Write(file.Content); err != nil {
 		return err
 	}
 	return f.Sync()
 }
 
+// private
+
+func (g *generator) file(file *model.File, out string) (File, error) {
+	// Generate file
+	w := newWriter(g.opts)
+	if err := w.file(file); err != nil {
+		return File{}, err
+	}
+	bytes := w.b.Bytes()
+
+	// Format file
+	bytes, err := format.Source(bytes)
+	if err != nil {
+		return File{}, err
+	}
+
+	filename := filenameWithoutExt(file.Name) + "_generated.go"
+	path := filepath.Join(out, filename)
+	return File{Path: path, Content: bytes}, nil
+}
+
 // filenameWithoutExt returns a filename without an extension.
 func filenameWithoutExt(name string) string {
 	ext := filepath.Ext(name)
@@ -5,6 +5,8 @@
 package generator
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/basecomplextech/spec/internal/lang/compiler"
@@ -16,7 +18,7 @@ func TestGenerator_Package__should_generate_go_package(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	g := newGenerator(false /* do not skip rpc */)
+	g := newGenerator(Options{Mocks: true})
 
 	names := []string{"pkg1", "pkg2", "pkg3/pkg3a", "pkg4"}
 	for _, name := range names {
@@ -31,3 +33,33 @@ func TestGenerator_Package__should_generate_go_package(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerator_Files__should_generate_files_without_writing(t *testing.T) {
+	opts := compiler.Options{ImportPath: []string{"../../tests"}}
+	c, err := compiler.New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGenerator(Options{})
+
+	pkg, err := c.Compile("../../tests/pkg2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := t.TempDir()
+	files, err := g.Files(pkg, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+	if path := filepath.Join(out, "submessage_generated.go"); files[0].Path != path {
+		t.Fatalf("unexpected path %v", files[0].Path)
+	}
+	if _, err := os.Stat(files[0].Path); !os.IsNotExist(err) {
+		t.Fatalf("file written: %v", err)
+	}
+}
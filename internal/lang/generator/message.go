@@ -76,6 +76,13 @@ func (w *messageWriter) parse_method(def *model.Definition) error {
 	w.linef(`return %v{msg}, size, err`, def.Name)
 	w.linef(`}`)
 	w.line()
+
+	w.linef(`func Parse%vWithOptions(b []byte, opts spec.ParseOptions) (_ %v, size int, err error) {`,
+		def.Name, def.Name)
+	w.linef(`msg, size, err := spec.ParseMessageWithOptions(b, opts)`)
+	w.linef(`return %v{msg}, size, err`, def.Name)
+	w.linef(`}`)
+	w.line()
 	return nil
 }
 
@@ -0,0 +1,420 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+type mockWriter struct {
+	*writer
+}
+
+func newMockWriter(w *writer) *mockWriter {
+	return &mockWriter{w}
+}
+
+func (w *mockWriter) mock(def *model.Definition) error {
+	if err := w.client(def); err != nil {
+		return err
+	}
+	if err := w.channels(def); err != nil {
+		return err
+	}
+	if err := w.fake(def); err != nil {
+		return err
+	}
+	return nil
+}
+
+// client
+
+func (w *mockWriter) client(def *model.Definition) error {
+	name := mockClient_name(def)
+	iface := clientIface_name(def)
+
+	w.linef(`// %v is a mock %v with per-method functions, it records all calls.`, name, iface)
+	w.line(`// Methods without functions return status.Unsupported, or preset subservice calls`)
+	w.line(`// and channels when set.`)
+	w.linef(`type %v struct {`, name)
+	w.line(`rpc.MockCalls`)
+	w.line()
+
+	// Funcs
+	for _, m := range def.Service.Methods {
+		w.writef(`%vFunc func`, toUpperCamelCase(m.Name))
+		w.mockMethod_input(m)
+		w.mockMethod_output(m)
+		w.line()
+	}
+
+	// Presets
+	presets := false
+	for _, m := range def.Service.Methods {
+		if m.Subservice == nil && m.Channel == nil {
+			continue
+		}
+		if !presets {
+			presets = true
+			w.line()
+		}
+
+		switch {
+		case m.Subservice != nil:
+			w.linef(`%vCall *%v`, toUpperCamelCase(m.Name), mockClient_typeName(m.Subservice))
+		case m.Channel != nil:
+			w.linef(`%vChannel *%v`, toUpperCamelCase(m.Name), mockChannel_name(m))
+		}
+	}
+
+	w.line(`}`)
+	w.line()
+	w.linef(`var _ %v = (*%v)(nil)`, iface, name)
+	w.line()
+
+	// Methods
+	for _, m := range def.Service.Methods {
+		if err := w.clientMethod(def, m); err != nil {
+			return err
+		}
+	}
+
+	w.linef(`func (m *%v) Unwrap() rpc.Client {`, name)
+	w.line(`return nil`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *mockWriter) clientMethod(def *model.Definition, m *model.Method) error {
+	name := mockClient_name(def)
+	methodName := toUpperCamelCase(m.Name)
+
+	w.writef(`func (m *%v) %v`, name, methodName)
+	w.mockMethod_input(m)
+	w.mockMethod_output(m)
+	w.line(`{`)
+
+	// Record call
+	if m.Request != nil {
+		w.linef(`m.AddCall("%v", req_)`, methodName)
+	} else {
+		w.linef(`m.AddCall("%v", nil)`, methodName)
+	}
+
+	// Call func
+	w.linef(`if m.%vFunc != nil {`, methodName)
+	switch {
+	case m.Subservice != nil && m.Request != nil:
+		w.linef(`return m.%vFunc(req_)`, methodName)
+	case m.Subservice != nil:
+		w.linef(`return m.%vFunc()`, methodName)
+	case m.Request != nil:
+		w.linef(`return m.%vFunc(ctx, req_)`, methodName)
+	default:
+		w.linef(`return m.%vFunc(ctx)`, methodName)
+	}
+	w.line(`}`)
+
+	// Return preset or unsupported
+	msg := fmt.Sprintf("%v.%v is not mocked", name, methodName)
+	switch {
+	case m.Subservice != nil:
+		w.linef(`if m.%vCall != nil {`, methodName)
+		w.linef(`return m.%vCall`, methodName)
+		w.line(`}`)
+		w.linef(`return %v(status.Unsupported(%q))`, clientImplNewErr(m.Subservice), msg)
+
+	case m.Channel != nil:
+		w.linef(`if m.%vChannel != nil {`, methodName)
+		w.linef(`return m.%vChannel, status.OK`, methodName)
+		w.line(`}`)
+		w.linef(`return nil, status.Unsupported(%q)`, msg)
+
+	case m.Response != nil:
+		w.linef(`return nil, status.Unsupported(%q)`, msg)
+
+	default:
+		w.linef(`return status.Unsupported(%q)`, msg)
+	}
+
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *mockWriter) mockMethod_input(m *model.Method) {
+	ctx := "ctx async.Context"
+	if m.Subservice != nil {
+		ctx = ""
+	}
+
+	switch {
+	case m.Request == nil:
+		w.writef(`(%v) `, ctx)
+	case ctx == "":
+		w.writef(`(req_ %v) `, typeName(m.Request))
+	default:
+		w.writef(`(%v, req_ %v) `, ctx, typeName(m.Request))
+	}
+}
+
+func (w *mockWriter) mockMethod_output(m *model.Method) {
+	switch {
+	default:
+		w.write(`status.Status`)
+
+	case m.Subservice != nil:
+		typeName := typeName(m.Subservice)
+		w.writef(`%vCall`, typeName)
+
+	case m.Channel != nil:
+		name := clientChannel_name(m)
+		w.writef(`(%v, status.Status)`, name)
+
+	case m.Response != nil:
+		typeName := typeName(m.Response)
+		w.writef(`(ref.R[%v], status.Status)`, typeName)
+	}
+}
+
+// channels
+
+func (w *mockWriter) channels(def *model.Definition) error {
+	for _, m := range def.Service.Methods {
+		if m.Channel == nil {
+			continue
+		}
+
+		if err := w.channel(def, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *mockWriter) channel(def *model.Definition, m *model.Method) error {
+	name := mockChannel_name(m)
+	iface := clientChannel_name(m)
+	in := m.Channel.In
+	out := m.Channel.Out
+
+	w.linef(`// %v is a fake %v with per-method functions, it records all calls.`, name, iface)
+	w.line(`// By default, the channel appends sent messages to Sent, receives messages from Messages,`)
+	w.line(`// and returns Result and Status as its response.`)
+	w.linef(`type %v struct {`, name)
+	w.line(`rpc.MockCalls`)
+	w.line()
+
+	// Funcs
+	if in != nil {
+		typeName := typeName(in)
+		w.linef(`SendFunc func(ctx async.Context, msg %v) status.Status`, typeName)
+		w.line(`SendEndFunc func(ctx async.Context) status.Status`)
+	}
+	if out != nil {
+		typeName := typeName(out)
+		w.linef(`ReceiveFunc func(ctx async.Context) (%v, status.Status)`, typeName)
+		w.linef(`ReceiveAsyncFunc func(ctx async.Context) (%v, bool, status.Status)`, typeName)
+	}
+	if m.Response != nil {
+		typeName := typeName(m.Response)
+		w.linef(`ResponseFunc func(ctx async.Context) (%v, status.Status)`, typeName)
+	} else {
+		w.line(`ResponseFunc func(ctx async.Context) status.Status`)
+	}
+	w.line()
+
+	// State
+	if in != nil {
+		typeName := typeName(in)
+		w.linef(`Sent []%v // Sent messages`, typeName)
+		w.line(`SentEnd bool // SendEnd called`)
+	}
+	if out != nil {
+		typeName := typeName(out)
+		w.linef(`Messages []%v // Messages to receive, ends with status.End`, typeName)
+	}
+	if m.Response != nil {
+		typeName := typeName(m.Response)
+		w.linef(`Result %v // Response result`, typeName)
+	}
+	w.line(`Status status.Status // Response status, defaults to status.OK`)
+	w.line(`Freed bool // Free called`)
+	w.line(`}`)
+	w.line()
+	w.linef(`var _ %v = (*%v)(nil)`, iface, name)
+	w.line()
+
+	// Send methods
+	if in != nil {
+		typeName := typeName(in)
+		w.linef(`func (ch *%v) Send(ctx async.Context, msg %v) status.Status {`, name, typeName)
+		w.line(`ch.AddCall("Send", msg)`)
+		w.line(`if ch.SendFunc != nil {`)
+		w.line(`return ch.SendFunc(ctx, msg)`)
+		w.line(`}`)
+		w.line()
+		w.line(`ch.Sent = append(ch.Sent, msg)`)
+		w.line(`return status.OK`)
+		w.line(`}`)
+		w.line()
+
+		w.linef(`func (ch *%v) SendEnd(ctx async.Context) status.Status {`, name)
+		w.line(`ch.AddCall("SendEnd", nil)`)
+		w.line(`if ch.SendEndFunc != nil {`)
+		w.line(`return ch.SendEndFunc(ctx)`)
+		w.line(`}`)
+		w.line()
+		w.line(`ch.SentEnd = true`)
+		w.line(`return status.OK`)
+		w.line(`}`)
+		w.line()
+	}
+
+	// Receive methods
+	if out != nil {
+		typeName := typeName(out)
+		w.linef(`func (ch *%v) Receive(ctx async.Context) (%v, status.Status) {`, name, typeName)
+		w.line(`ch.AddCall("Receive", nil)`)
+		w.line(`if ch.ReceiveFunc != nil {`)
+		w.line(`return ch.ReceiveFunc(ctx)`)
+		w.line(`}`)
+		w.line()
+		w.line(`if len(ch.Messages) == 0 {`)
+		w.linef(`return %v{}, status.End`, typeName)
+		w.line(`}`)
+		w.line()
+		w.line(`msg := ch.Messages[0]`)
+		w.line(`ch.Messages = ch.Messages[1:]`)
+		w.line(`return msg, status.OK`)
+		w.line(`}`)
+		w.line()
+
+		w.linef(`func (ch *%v) ReceiveAsync(ctx async.Context) (%v, bool, status.Status) {`, name, typeName)
+		w.line(`ch.AddCall("ReceiveAsync", nil)`)
+		w.line(`if ch.ReceiveAsyncFunc != nil {`)
+		w.line(`return ch.ReceiveAsyncFunc(ctx)`)
+		w.line(`}`)
+		w.line()
+		w.line(`if len(ch.Messages) == 0 {`)
+		w.linef(`return %v{}, false, status.End`, typeName)
+		w.line(`}`)
+		w.line()
+		w.line(`msg := ch.Messages[0]`)
+		w.line(`ch.Messages = ch.Messages[1:]`)
+		w.line(`return msg, true, status.OK`)
+		w.line(`}`)
+		w.line()
+
+		w.linef(`func (ch *%v) ReceiveWait() <-chan struct{} {`, name)
+		w.line(`return rpc.MockWait()`)
+		w.line(`}`)
+		w.line()
+	}
+
+	// Response method
+	if m.Response != nil {
+		typeName := typeName(m.Response)
+		w.linef(`func (ch *%v) Response(ctx async.Context) (%v, status.Status) {`, name, typeName)
+		w.line(`ch.AddCall("Response", nil)`)
+		w.line(`if ch.ResponseFunc != nil {`)
+		w.line(`return ch.ResponseFunc(ctx)`)
+		w.line(`}`)
+		w.line()
+		w.line(`if ch.Status.Code == status.CodeNone {`)
+		w.line(`return ch.Result, status.OK`)
+		w.line(`}`)
+		w.line(`return ch.Result, ch.Status`)
+		w.line(`}`)
+		w.line()
+	} else {
+		w.linef(`func (ch *%v) Response(ctx async.Context) status.Status {`, name)
+		w.line(`ch.AddCall("Response", nil)`)
+		w.line(`if ch.ResponseFunc != nil {`)
+		w.line(`return ch.ResponseFunc(ctx)`)
+		w.line(`}`)
+		w.line()
+		w.line(`if ch.Status.Code == status.CodeNone {`)
+		w.line(`return status.OK`)
+		w.line(`}`)
+		w.line(`return ch.Status`)
+		w.line(`}`)
+		w.line()
+	}
+
+	// Free method
+	w.linef(`func (ch *%v) Free() {`, name)
+	w.line(`ch.Freed = true`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// fake
+
+func (w *mockWriter) fake(def *model.Definition) error {
+	name := fake_name(def)
+	sw := newServiceWriter(w.writer)
+
+	w.linef(`// %v is a %v base which returns status.Unsupported from all methods.`, name, def.Name)
+	w.line(`// Embed it into a struct and override the required methods.`)
+	w.linef(`type %v struct{}`, name)
+	w.line()
+	w.linef(`var _ %v = %v{}`, def.Name, name)
+	w.line()
+
+	for _, m := range def.Service.Methods {
+		w.writef(`func (%v) `, name)
+		if err := sw.method_input(def, m); err != nil {
+			return err
+		}
+		if err := sw.method_output(def, m); err != nil {
+			return err
+		}
+		w.line(`{`)
+
+		msg := fmt.Sprintf("%v.%v is not implemented", def.Name, toUpperCamelCase(m.Name))
+		if m.Response != nil {
+			w.linef(`return nil, status.Unsupported(%q)`, msg)
+		} else {
+			w.linef(`return status.Unsupported(%q)`, msg)
+		}
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
+
+// util
+
+func clientIface_name(def *model.Definition) string {
+	if def.Service.Sub {
+		return fmt.Sprintf("%vCall", def.Name)
+	}
+	return fmt.Sprintf("%vClient", def.Name)
+}
+
+func mockClient_name(def *model.Definition) string {
+	return "Mock" + clientIface_name(def)
+}
+
+func mockClient_typeName(typ *model.Type) string {
+	name := mockClient_name(typ.Ref)
+	if typ.Import != nil {
+		return fmt.Sprintf("%v.%v", typ.ImportName, name)
+	}
+	return name
+}
+
+func mockChannel_name(m *model.Method) string {
+	return "Mock" + clientChannel_name(m)
+}
+
+func fake_name(def *model.Definition) string {
+	return "Fake" + def.Name
+}
@@ -31,6 +31,12 @@ func (w *structWriter) struct_(def *model.Definition) error {
 	if err := w.encode_method(def); err != nil {
 		return err
 	}
+	if !def.Struct.Key {
+		return nil
+	}
+	if err := w.key(def); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -141,6 +147,144 @@ func (w *structWriter) encode_method(def *model.Definition) error {
 	return nil
 }
 
+// key
+
+func (w *structWriter) key(def *model.Definition) error {
+	if err := w.key_func(def); err != nil {
+		return err
+	}
+	if err := w.append_key_func(def); err != nil {
+		return err
+	}
+	if err := w.decode_key_func(def); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *structWriter) key_func(def *model.Definition) error {
+	w.linef(`// %vKey returns an order-preserving key.`, def.Name)
+	w.linef(`func %vKey(s %v) []byte {`, def.Name, def.Name)
+	w.linef(`return Append%vKey(nil, s)`, def.Name)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *structWriter) append_key_func(def *model.Definition) error {
+	w.linef(`// Append%vKey appends an order-preserving key.`, def.Name)
+	w.linef(`func Append%vKey(b []byte, s %v) []byte {`, def.Name, def.Name)
+
+	fields := def.Struct.Fields.Values()
+	for _, field := range fields {
+		fieldName := structFieldName(field)
+		typ := field.Type
+
+		switch typ.Kind {
+		case model.KindEnum:
+			w.linef(`b = key.AppendInt32(b, int32(s.%v))`, fieldName)
+		case model.KindStruct:
+			w.linef(`b = %v(b, s.%v)`, keyFunc(typ, "Append", "Key"), fieldName)
+		default:
+			w.linef(`b = key.Append%v(b, s.%v)`, keyKindName(typ.Kind), fieldName)
+		}
+	}
+
+	w.line(`return b`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *structWriter) decode_key_func(def *model.Definition) error {
+	w.linef(`// Decode%vKey decodes an order-preserving key, returns the struct and the number of read bytes.`,
+		def.Name)
+	w.linef(`func Decode%vKey(b []byte) (s %v, size int, err error) {`, def.Name, def.Name)
+	w.line(`var n int`)
+	w.line()
+
+	fields := def.Struct.Fields.Values()
+	for _, field := range fields {
+		fieldName := structFieldName(field)
+		typ := field.Type
+
+		switch typ.Kind {
+		case model.KindEnum:
+			w.linef(`var %v int32`, "v"+fieldName)
+			w.linef(`%v, n, err = key.DecodeInt32(b[size:])`, "v"+fieldName)
+		case model.KindStruct:
+			w.linef(`s.%v, n, err = %v(b[size:])`, fieldName, keyFunc(typ, "Decode", "Key"))
+		default:
+			w.linef(`s.%v, n, err = key.Decode%v(b[size:])`, fieldName, keyKindName(typ.Kind))
+		}
+
+		w.line(`if err != nil {
+			return
+		}`)
+		if typ.Kind == model.KindEnum {
+			w.linef(`s.%v = %v(%v)`, fieldName, typeName(typ), "v"+fieldName)
+		}
+		w.line(`size += n`)
+		w.line()
+	}
+
+	w.line(`return s, size, nil`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// keyFunc returns a key function name for a struct type, i.e. "pkg.AppendStructKey".
+func keyFunc(typ *model.Type, prefix string, suffix string) string {
+	name := prefix + typ.Name + suffix
+	if typ.Import != nil {
+		return typ.ImportName + "." + name
+	}
+	return name
+}
+
+// keyKindName returns a key package function suffix for a builtin kind, i.e. "Int64".
+func keyKindName(kind model.Kind) string {
+	switch kind {
+	case model.KindBool:
+		return "Bool"
+	case model.KindByte:
+		return "Byte"
+
+	case model.KindInt16:
+		return "Int16"
+	case model.KindInt32:
+		return "Int32"
+	case model.KindInt64:
+		return "Int64"
+
+	case model.KindUint16:
+		return "Uint16"
+	case model.KindUint32:
+		return "Uint32"
+	case model.KindUint64:
+		return "Uint64"
+
+	case model.KindFloat32:
+		return "Float32"
+	case model.KindFloat64:
+		return "Float64"
+
+	case model.KindBin64:
+		return "Bin64"
+	case model.KindBin128:
+		return "Bin128"
+	case model.KindBin256:
+		return "Bin256"
+
+	case model.KindBytes:
+		return "Bytes"
+	case model.KindString:
+		return "String"
+	}
+	return ""
+}
+
 func structFieldName(field *model.StructField) string {
 	return toUpperCamelCase(field.Name)
 }
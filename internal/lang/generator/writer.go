@@ -15,14 +15,14 @@ import (
 type writer struct {
 	b bytes.Buffer
 
-	skipRPC bool
+	opts Options
 }
 
-func newWriter(skipRPC bool) *writer {
+func newWriter(opts Options) *writer {
 	return &writer{
 		b: bytes.Buffer{},
 
-		skipRPC: skipRPC,
+		opts: opts,
 	}
 }
 
@@ -0,0 +1,180 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package graph builds the import graph between packages and the reference graph
+// between definitions, and reports definitions which are not reachable from roots.
+//
+// Definitions are identified by package ids and names, i.e. "api/users.User".
+// Generated request/response messages are not nodes, their field types are
+// referenced by services.
+package graph
+
+import (
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Graph is an import graph between packages and a reference graph between definitions.
+type Graph struct {
+	Packages    []*Package    `json:"packages"`
+	Definitions []*Definition `json:"definitions"`
+}
+
+// Package is a package node.
+type Package struct {
+	ID      string   `json:"id"`
+	Imports []string `json:"imports"` // Imported package ids
+}
+
+// Definition is a definition node.
+type Definition struct {
+	ID         string   `json:"id"` // Package id and name as "pkg.Name"
+	Package    string   `json:"package"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"` // Enum, message, struct, service or subservice
+	File       string   `json:"file"`
+	Line       int      `json:"line"`
+	References []string `json:"references"` // Referenced definition ids
+
+	def *model.Definition
+}
+
+// Build returns a graph of packages and their transitive imports.
+func Build(pkgs []*model.Package) *Graph {
+	g := &Graph{}
+
+	for _, pkg := range model.Transitive(pkgs) {
+		g.Packages = append(g.Packages, &Package{
+			ID:      pkg.ID,
+			Imports: imports(pkg),
+		})
+
+		for _, def := range pkg.Definitions {
+			g.Definitions = append(g.Definitions, &Definition{
+				ID:         definitionID(def),
+				Package:    pkg.ID,
+				Name:       def.Name,
+				Type:       definitionType(def),
+				File:       def.File.Path,
+				Line:       def.Line,
+				References: references(def),
+
+				def: def,
+			})
+		}
+	}
+	return g
+}
+
+// private
+
+// imports returns sorted ids of packages imported by package files.
+func imports(pkg *model.Package) []string {
+	seen := make(map[string]struct{})
+	result := []string{}
+
+	for _, file := range pkg.Files {
+		for _, imp := range file.Imports {
+			if imp.Package == nil {
+				continue
+			}
+			if _, ok := seen[imp.Package.ID]; ok {
+				continue
+			}
+
+			seen[imp.Package.ID] = struct{}{}
+			result = append(result, imp.Package.ID)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// references returns sorted ids of definitions referenced by a definition.
+func references(def *model.Definition) []string {
+	r := &referenceSet{seen: make(map[string]struct{}), ids: []string{}}
+
+	switch def.Type {
+	case model.DefinitionMessage:
+		for _, f := range def.Message.Fields.List {
+			r.addType(f.Type)
+		}
+
+	case model.DefinitionStruct:
+		for _, f := range def.Struct.Fields.Values() {
+			r.addType(f.Type)
+		}
+
+	case model.DefinitionService:
+		for _, m := range def.Service.Methods {
+			r.addMessage(m.Request)
+			r.addMessage(m.Response)
+			if m.Channel != nil {
+				r.addType(m.Channel.In)
+				r.addType(m.Channel.Out)
+			}
+			r.addType(m.Subservice)
+		}
+	}
+
+	sort.Strings(r.ids)
+	return r.ids
+}
+
+type referenceSet struct {
+	seen map[string]struct{}
+	ids  []string
+}
+
+// addMessage adds a message reference, or the field types of a generated message.
+func (r *referenceSet) addMessage(typ *model.Type) {
+	if typ == nil {
+		return
+	}
+
+	msg := typ.Ref.Message
+	if !msg.Generated {
+		r.addType(typ)
+		return
+	}
+
+	for _, f := range msg.Fields.List {
+		r.addType(f.Type)
+	}
+}
+
+func (r *referenceSet) addType(typ *model.Type) {
+	switch {
+	case typ == nil:
+		return
+	case typ.Element != nil:
+		r.addType(typ.Element)
+		return
+	case typ.Ref == nil:
+		return
+	}
+
+	id := definitionID(typ.Ref)
+	if _, ok := r.seen[id]; ok {
+		return
+	}
+
+	r.seen[id] = struct{}{}
+	r.ids = append(r.ids, id)
+}
+
+// definitionID returns "pkg.Name".
+func definitionID(def *model.Definition) string {
+	return def.Package.ID + "." + def.Name
+}
+
+// definitionType returns a definition type, or "subservice" for subservices.
+func definitionType(def *model.Definition) string {
+	if def.Type == model.DefinitionService && def.Service.Sub {
+		return "subservice"
+	}
+	return string(def.Type)
+}
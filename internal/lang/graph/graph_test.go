@@ -0,0 +1,203 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `
+enum Status {
+    UNKNOWN = 0;
+}
+
+enum Unused {
+    UNKNOWN = 0;
+}
+
+struct Point {
+    x int32;
+    y int32;
+}
+
+message User {
+    status Status 1;
+    points []Point 2;
+}
+
+message Event {
+    user User 1;
+}
+
+message Orphan {
+    event Event 1;
+}
+
+service Users {
+    get(id int64 1) (user User 1);
+    users(id int64 1) UserService;
+}
+
+subservice UserService {
+    events() (Event->);
+}
+
+subservice Detached {
+    get() Orphan;
+}
+`
+
+func testDefinition(g *Graph, id string) *Definition {
+	for _, def := range g.Definitions {
+		if def.ID == id {
+			return def
+		}
+	}
+	return nil
+}
+
+// Build
+
+func TestBuild__should_build_import_graph(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+	g := Build([]*model.Package{pkg})
+
+	imports := make(map[string][]string)
+	for _, p := range g.Packages {
+		imports[p.ID] = p.Imports
+	}
+
+	assert.Equal(t, map[string][]string{
+		"pkg1":       {"pkg2"},
+		"pkg2":       {"pkg3/pkg3a"},
+		"pkg3/pkg3a": {},
+		"pkg4":       {"pkg1", "pkg2"},
+	}, imports)
+}
+
+func TestBuild__should_build_reference_graph(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+	g := Build([]*model.Package{pkg})
+
+	user := testDefinition(g, "test.User")
+	require.NotNil(t, user)
+	assert.Equal(t, "message", user.Type)
+	assert.Equal(t, []string{"test.Point", "test.Status"}, user.References)
+
+	users := testDefinition(g, "test.Users")
+	require.NotNil(t, users)
+	assert.Equal(t, []string{"test.User", "test.UserService"}, users.References)
+
+	sub := testDefinition(g, "test.UserService")
+	require.NotNil(t, sub)
+	assert.Equal(t, "subservice", sub.Type)
+	assert.Equal(t, []string{"test.Event"}, sub.References)
+}
+
+func TestBuild__should_skip_generated_messages(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+	g := Build([]*model.Package{pkg})
+
+	assert.Nil(t, testDefinition(g, "test.UsersGetRequest"))
+	assert.Nil(t, testDefinition(g, "test.UsersGetResponse"))
+}
+
+// FindUnused
+
+func TestFindUnused__should_return_definitions_unreachable_from_services(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+
+	unused, err := FindUnused([]*model.Package{pkg}, nil)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(unused))
+	for _, u := range unused {
+		ids = append(ids, u.ID)
+	}
+	assert.Equal(t, []string{"test.Unused", "test.Orphan", "test.Detached"}, ids)
+	assert.Equal(t, fmt.Sprintf("%v:%d: message test.Orphan is unused", unused[1].File, unused[1].Line),
+		unused[1].String())
+}
+
+func TestFindUnused__should_return_definitions_unreachable_from_roots(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+
+	unused, err := FindUnused([]*model.Package{pkg}, []string{"test.Event", "Status"})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(unused))
+	for _, u := range unused {
+		ids = append(ids, u.ID)
+	}
+	assert.Equal(t, []string{
+		"test.Unused",
+		"test.Orphan",
+		"test.Users",
+		"test.UserService",
+		"test.Detached",
+	}, ids)
+}
+
+func TestFindUnused__should_not_report_imported_packages(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+
+	unused, err := FindUnused([]*model.Package{pkg}, nil)
+	require.NoError(t, err)
+
+	for _, u := range unused {
+		assert.Equal(t, "pkg4", u.Package, u.ID)
+	}
+}
+
+func TestFindUnused__should_return_error_when_root_not_found(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+
+	_, err := FindUnused([]*model.Package{pkg}, []string{"test.Missing"})
+	assert.EqualError(t, err, "root type not found: test.Missing")
+}
+
+// Output
+
+func TestWriteImportsDOT__should_write_import_graph(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+	g := Build([]*model.Package{pkg})
+
+	buf := &bytes.Buffer{}
+	err := WriteImportsDOT(buf, g)
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph imports {
+  node [shape=box];
+  "pkg1";
+  "pkg2";
+  "pkg3/pkg3a";
+  "pkg1" -> "pkg2";
+  "pkg2" -> "pkg3/pkg3a";
+}
+`, buf.String())
+}
+
+func TestWriteDefinitionsDOT__should_write_reference_graph(t *testing.T) {
+	pkg := langtest.Source(t, testSchema)
+	g := Build([]*model.Package{pkg})
+
+	buf := &bytes.Buffer{}
+	err := WriteDefinitionsDOT(buf, g)
+	require.NoError(t, err)
+
+	dot := buf.String()
+	assert.Contains(t, dot, "  subgraph cluster_0 {\n    label = \"test\";\n")
+	assert.Contains(t, dot, "    \"test.Status\" [label=\"Status\", shape=ellipse];\n")
+	assert.Contains(t, dot, "    \"test.User\" [label=\"User\", shape=box];\n")
+	assert.Contains(t, dot, "  \"test.User\" -> \"test.Point\";\n")
+	assert.Contains(t, dot, "  \"test.Users\" -> \"test.UserService\";\n")
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes a graph as a json object.
+func WriteJSON(w io.Writer, g *Graph) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteImportsDOT writes the package import graph in the Graphviz DOT format.
+func WriteImportsDOT(w io.Writer, g *Graph) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph imports {\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, pkg := range g.Packages {
+		fmt.Fprintf(b, "  %v;\n", strconv.Quote(pkg.ID))
+	}
+	for _, pkg := range g.Packages {
+		for _, imp := range pkg.Imports {
+			fmt.Fprintf(b, "  %v -> %v;\n", strconv.Quote(pkg.ID), strconv.Quote(imp))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// WriteDefinitionsDOT writes the definition reference graph in the Graphviz DOT format,
+// definitions are clustered by packages.
+func WriteDefinitionsDOT(w io.Writer, g *Graph) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph definitions {\n")
+
+	for i, pkg := range g.Packages {
+		fmt.Fprintf(b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(b, "    label = %v;\n", strconv.Quote(pkg.ID))
+
+		for _, def := range g.Definitions {
+			if def.Package != pkg.ID {
+				continue
+			}
+			fmt.Fprintf(b, "    %v [label=%v, shape=%v];\n",
+				strconv.Quote(def.ID), strconv.Quote(def.Name), shape(def.Type))
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, def := range g.Definitions {
+		for _, ref := range def.References {
+			fmt.Fprintf(b, "  %v -> %v;\n", strconv.Quote(def.ID), strconv.Quote(ref))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// WriteUnusedText writes unused definitions as "file:line: type pkg.Name is unused" lines.
+func WriteUnusedText(w io.Writer, unused []Unused) error {
+	for _, u := range unused {
+		if _, err := fmt.Fprintln(w, u.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteUnusedJSON writes unused definitions as a json array.
+func WriteUnusedJSON(w io.Writer, unused []Unused) error {
+	if unused == nil {
+		unused = []Unused{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(unused)
+}
+
+// private
+
+func shape(typ string) string {
+	switch typ {
+	case "enum":
+		return "ellipse"
+	case "struct":
+		return "box, style=rounded"
+	case "service", "subservice":
+		return "component"
+	}
+	return "box"
+}
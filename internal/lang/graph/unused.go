@@ -0,0 +1,134 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Unused is a definition which is not reachable from roots.
+type Unused struct {
+	ID      string `json:"id"`
+	Package string `json:"package"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+}
+
+// String returns "file:line: type pkg.Name is unused".
+func (u Unused) String() string {
+	return fmt.Sprintf("%v:%d: %v %v is unused", u.File, u.Line, u.Type, u.ID)
+}
+
+// FindUnused returns package definitions which are not reachable from roots.
+//
+// Roots are type names as "Name" or "pkg.Name", where pkg is a package id or name.
+// Services are roots when no roots are given, subservices are not.
+// Imported packages are traversed, but their definitions are not reported.
+func FindUnused(pkgs []*model.Package, roots []string) ([]Unused, error) {
+	g := Build(pkgs)
+
+	ids := make(map[string]*Definition, len(g.Definitions))
+	for _, def := range g.Definitions {
+		ids[def.ID] = def
+	}
+
+	// Roots
+	var queue []*Definition
+	if len(roots) == 0 {
+		for _, def := range g.Definitions {
+			if def.Type == string(model.DefinitionService) {
+				queue = append(queue, def)
+			}
+		}
+	} else {
+		for _, root := range roots {
+			def, err := lookupRoot(pkgs, root)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, ids[definitionID(def)])
+		}
+	}
+
+	// Traverse references
+	reached := make(map[string]struct{})
+	for len(queue) > 0 {
+		def := queue[0]
+		queue = queue[1:]
+
+		if _, ok := reached[def.ID]; ok {
+			continue
+		}
+		reached[def.ID] = struct{}{}
+
+		for _, id := range def.References {
+			if ref, ok := ids[id]; ok {
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	// Report unreached definitions in packages
+	reported := make(map[string]struct{}, len(pkgs))
+	for _, pkg := range pkgs {
+		reported[pkg.ID] = struct{}{}
+	}
+
+	result := []Unused{}
+	for _, def := range g.Definitions {
+		if _, ok := reported[def.Package]; !ok {
+			continue
+		}
+		if _, ok := reached[def.ID]; ok {
+			continue
+		}
+
+		result = append(result, Unused{
+			ID:      def.ID,
+			Package: def.Package,
+			Name:    def.Name,
+			Type:    def.Type,
+			File:    def.File,
+			Line:    def.Line,
+		})
+	}
+	return result, nil
+}
+
+// private
+
+// lookupRoot returns a definition by "Name" or "pkg.Name" in packages.
+func lookupRoot(pkgs []*model.Package, root string) (*model.Definition, error) {
+	pkgName, name := "", root
+	if i := strings.LastIndex(root, "."); i >= 0 {
+		pkgName, name = root[:i], root[i+1:]
+	}
+
+	var found *model.Definition
+	for _, pkg := range pkgs {
+		if pkgName != "" && pkgName != pkg.ID && pkgName != pkg.Name {
+			continue
+		}
+
+		def, ok := pkg.DefinitionNames[name]
+		if !ok {
+			continue
+		}
+		if found != nil && found != def {
+			return nil, fmt.Errorf("root type %q is ambiguous, use pkg.Name", root)
+		}
+		found = def
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("root type not found: %v", root)
+	}
+	return found, nil
+}
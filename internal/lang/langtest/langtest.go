@@ -0,0 +1,54 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package langtest provides shared helpers to compile schema packages in tests.
+package langtest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/stretchr/testify/require"
+)
+
+// Package compiles a test package from internal/tests, i.e. "pkg1" or "pkg3/pkg3a".
+func Package(t testing.TB, name string) *model.Package {
+	t.Helper()
+
+	dir := testsDir(t)
+	x := model.NewContext(parser.New(), []string{dir})
+
+	pkg, err := x.Compile(name, filepath.Join(dir, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return pkg
+}
+
+// Source compiles a single file source into a "test" package.
+func Source(t testing.TB, src string) *model.Package {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "test.spec"), []byte(src), 0644)
+	require.NoError(t, err)
+
+	x := model.NewContext(parser.New(), nil)
+	pkg, err := x.Compile("test", dir)
+	require.NoError(t, err)
+	return pkg
+}
+
+// private
+
+// testsDir returns an absolute path to internal/tests.
+func testsDir(t testing.TB) string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("langtest: no caller information")
+	}
+	return filepath.Join(filepath.Dir(file), "..", "..", "tests")
+}
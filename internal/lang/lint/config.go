@@ -0,0 +1,121 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a lint config, usually loaded from a yaml file:
+//
+//	rules:
+//	  service-doc: off
+//	  method-doc: error
+//	  tag-gap:
+//	    severity: error
+//	    max: 20
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig overrides a rule severity and options.
+type RuleConfig struct {
+	Severity Severity          // Empty for default severity
+	Options  map[string]string // Overridden options
+}
+
+// LoadConfig loads a yaml config from a file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseConfig parses a yaml config.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate returns an error when the config references unknown rules or options.
+func (c *Config) Validate() error {
+	for name, rc := range c.Rules {
+		rule, ok := Lookup(name)
+		if !ok {
+			return fmt.Errorf("unknown lint rule %q", name)
+		}
+
+		for opt := range rc.Options {
+			if _, ok := rule.Options[opt]; !ok {
+				return fmt.Errorf("unknown lint rule option %v.%v", name, opt)
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalYAML parses a rule config from a severity string or a mapping.
+func (c *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		sev, err := ParseSeverity(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		c.Severity = sev
+		return nil
+
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: rule option %q must be a scalar", value.Line, key.Value)
+			}
+
+			if key.Value == "severity" {
+				sev, err := ParseSeverity(value.Value)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", value.Line, err)
+				}
+				c.Severity = sev
+				continue
+			}
+
+			if c.Options == nil {
+				c.Options = make(map[string]string)
+			}
+			c.Options[key.Value] = value.Value
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: rule config must be a severity or a mapping", node.Line)
+}
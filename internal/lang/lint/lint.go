@@ -0,0 +1,108 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lint checks compiled spec packages against schema style rules.
+//
+// Rules are registered in a global registry, each rule has a default severity and options
+// which can be overridden in a config. Issues are suppressed by inline comments:
+//
+//	field int32 1; // spec-lint:ignore field-snake-case
+//
+//	// spec-lint:ignore field-snake-case, tag-gap
+//	field int32 100;
+//
+//	// spec-lint:ignore-file no-any
+//
+// A suppression comment without rule names suppresses all rules.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Severity is an issue severity.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// ParseSeverity parses a severity string.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case SeverityOff, SeverityInfo, SeverityWarning, SeverityError:
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown severity %q, expected off, info, warning or error", s)
+}
+
+// Issue is a rule violation.
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Message  string   `json:"message"`
+}
+
+// String returns an issue as "file:line: severity: message (rule)".
+func (i Issue) String() string {
+	return fmt.Sprintf("%v:%d: %v: %v (%v)", i.File, i.Line, i.Severity, i.Message, i.Rule)
+}
+
+// Run checks a package and returns sorted issues, the config may be nil.
+func Run(pkg *model.Package, config *Config) ([]Issue, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	for _, rule := range Rules() {
+		pass, ok := newPass(pkg, rule, config.Rules[rule.Name])
+		if !ok {
+			continue
+		}
+
+		rule.Check(pass)
+		issues = append(issues, pass.issues...)
+	}
+
+	issues = suppress(pkg, issues)
+	sortIssues(issues)
+	return issues, nil
+}
+
+// HasErrors returns true when issues contain errors.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// private
+
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch {
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		}
+		return a.Rule < b.Rule
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLint(t *testing.T, src string, config *Config) []Issue {
+	pkg := langtest.Source(t, src)
+
+	issues, err := Run(pkg, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issues
+}
+
+func testRules(issues []Issue) []string {
+	var rules []string
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+// Rules
+
+func TestRun__should_check_field_snake_case(t *testing.T) {
+	issues := testLint(t, `
+message Message {
+	good_name int32 1;
+	badName   int32 2;
+}
+
+struct Struct {
+	BadName int32;
+}
+`, nil)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, "field-snake-case", issues[0].Rule)
+	assert.Equal(t, 4, issues[0].Line)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+	assert.Equal(t, 8, issues[1].Line)
+}
+
+func TestRun__should_check_enums(t *testing.T) {
+	issues := testLint(t, `
+enum Enum1 {
+	UNDEFINED = 0;
+	one       = 1;
+}
+
+enum Enum2 {
+	NONE = 0;
+}
+`, nil)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, "enum-value-upper-case", issues[0].Rule)
+	assert.Equal(t, 4, issues[0].Line)
+	assert.Equal(t, "enum-zero-value", issues[1].Rule)
+	assert.Equal(t, 8, issues[1].Line)
+}
+
+func TestRun__should_check_tag_gaps(t *testing.T) {
+	issues := testLint(t, `
+message Message {
+	a int32 1;
+	b int32 5;
+	c int32 20;
+}
+`, nil)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, "tag-gap", issues[0].Rule)
+	assert.Equal(t, 5, issues[0].Line)
+	assert.Equal(t, SeverityWarning, issues[0].Severity)
+}
+
+func TestRun__should_check_service_and_method_docs(t *testing.T) {
+	issues := testLint(t, `
+// Service is documented.
+service Service {
+	// method1 is documented.
+	method1() ();
+	method2() ();
+}
+
+service Undocumented {}
+`, nil)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, "method-doc", issues[0].Rule)
+	assert.Equal(t, 6, issues[0].Line)
+	assert.Equal(t, "service-doc", issues[1].Rule)
+	assert.Equal(t, 9, issues[1].Line)
+}
+
+func TestRun__should_check_any_in_service_messages(t *testing.T) {
+	issues := testLint(t, `
+message Internal {
+	value any 1;
+}
+
+message Public {
+	value   []any      1;
+	message message    2;
+}
+
+// Service is documented.
+service Service {
+	// method is documented.
+	method(value any 1) Public;
+}
+`, nil)
+
+	require.Len(t, issues, 3)
+	assert.Equal(t, []string{"no-any", "no-any", "no-any"}, testRules(issues))
+	assert.Equal(t, 7, issues[0].Line)
+	assert.Equal(t, 8, issues[1].Line)
+	assert.Equal(t, 14, issues[2].Line)
+}
+
+// Config
+
+func TestRun__should_apply_config(t *testing.T) {
+	config, err := ParseConfig([]byte(`
+rules:
+  field-snake-case: off
+  tag-gap:
+    severity: error
+    max: 100
+  enum-zero-value:
+    name: NONE
+`))
+	require.NoError(t, err)
+
+	issues := testLint(t, `
+enum Enum {
+	NONE = 0;
+}
+
+message Message {
+	badName int32 1;
+	b       int32 50;
+}
+`, config)
+	assert.Empty(t, issues)
+}
+
+func TestParseConfig__should_return_error_on_unknown_rule(t *testing.T) {
+	_, err := ParseConfig([]byte("rules:\n  unknown: error\n"))
+	assert.Error(t, err)
+}
+
+func TestParseConfig__should_return_error_on_unknown_option(t *testing.T) {
+	_, err := ParseConfig([]byte("rules:\n  tag-gap:\n    unknown: 1\n"))
+	assert.Error(t, err)
+}
+
+func TestParseConfig__should_return_error_on_invalid_severity(t *testing.T) {
+	_, err := ParseConfig([]byte("rules:\n  tag-gap: fatal\n"))
+	assert.Error(t, err)
+}
+
+// Suppress
+
+func TestRun__should_suppress_issues(t *testing.T) {
+	issues := testLint(t, `
+message Message {
+	badName1 int32 1; // spec-lint:ignore field-snake-case
+
+	// spec-lint:ignore
+	badName2 int32 2;
+
+	// spec-lint:ignore tag-gap
+	badName3 int32 3;
+}
+`, nil)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, 9, issues[0].Line)
+}
+
+func TestRun__should_suppress_issues_in_file(t *testing.T) {
+	issues := testLint(t, `
+// spec-lint:ignore-file field-snake-case, enum-value-upper-case
+
+enum Enum {
+	UNDEFINED = 0;
+	one       = 1;
+}
+
+message Message {
+	badName int32 1;
+}
+`, nil)
+
+	assert.Empty(t, issues)
+}
+
+// Output
+
+func TestWriteJSON__should_write_empty_array(t *testing.T) {
+	b := &bytes.Buffer{}
+	require.NoError(t, WriteJSON(b, nil))
+	assert.Equal(t, "[]\n", b.String())
+}
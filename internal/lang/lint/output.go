@@ -0,0 +1,32 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteText writes issues as "file:line: severity: message (rule)" lines.
+func WriteText(w io.Writer, issues []Issue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintln(w, issue.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes issues as a json array.
+func WriteJSON(w io.Writer, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
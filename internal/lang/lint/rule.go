@@ -0,0 +1,145 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Rule is a lint rule.
+type Rule struct {
+	Name        string            // Rule name, i.e. "field-snake-case"
+	Description string            // Short description
+	Severity    Severity          // Default severity
+	Options     map[string]string // Default options
+	Check       func(p *Pass)     // Checks a package and reports issues
+}
+
+var registry = struct {
+	mu    sync.Mutex
+	rules map[string]*Rule
+}{
+	rules: make(map[string]*Rule),
+}
+
+// Register registers a rule, panics on a duplicate rule.
+func Register(rule *Rule) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.rules[rule.Name]; ok {
+		panic(fmt.Sprintf("duplicate lint rule %q", rule.Name))
+	}
+	registry.rules[rule.Name] = rule
+}
+
+// Lookup returns a rule by its name.
+func Lookup(name string) (*Rule, bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	rule, ok := registry.rules[name]
+	return rule, ok
+}
+
+// Rules returns registered rules sorted by name.
+func Rules() []*Rule {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	rules := make([]*Rule, 0, len(registry.rules))
+	for _, rule := range registry.rules {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// Pass
+
+// Pass is a single rule run over a package.
+type Pass struct {
+	Package *model.Package
+	Rule    *Rule
+
+	severity Severity
+	options  map[string]string
+	issues   []Issue
+}
+
+func newPass(pkg *model.Package, rule *Rule, config RuleConfig) (*Pass, bool) {
+	severity := rule.Severity
+	if config.Severity != "" {
+		severity = config.Severity
+	}
+	if severity == SeverityOff {
+		return nil, false
+	}
+
+	options := make(map[string]string, len(rule.Options)+len(config.Options))
+	for name, value := range rule.Options {
+		options[name] = value
+	}
+	for name, value := range config.Options {
+		options[name] = value
+	}
+
+	p := &Pass{
+		Package: pkg,
+		Rule:    rule,
+
+		severity: severity,
+		options:  options,
+	}
+	return p, true
+}
+
+// Option returns a rule option.
+func (p *Pass) Option(name string) string {
+	return p.options[name]
+}
+
+// IntOption returns an integer rule option, reports an issue and returns the default on invalid value.
+func (p *Pass) IntOption(name string, default_ int) int {
+	s, ok := p.options[name]
+	if !ok {
+		return default_
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		p.issues = append(p.issues, Issue{
+			Rule:     p.Rule.Name,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("invalid option %v=%q, expected integer", name, s),
+		})
+		return default_
+	}
+	return v
+}
+
+// Reportf reports an issue in a file line.
+func (p *Pass) Reportf(file *model.File, line int, format string, a ...any) {
+	path := ""
+	if file != nil {
+		path = file.Path
+	}
+
+	p.issues = append(p.issues, Issue{
+		Rule:     p.Rule.Name,
+		Severity: p.severity,
+		File:     path,
+		Line:     line,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+var (
+	snakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	upperCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+func init() {
+	Register(&Rule{
+		Name:        "field-snake-case",
+		Description: "Message and struct field names must be snake_case",
+		Severity:    SeverityError,
+		Check:       checkFieldSnakeCase,
+	})
+	Register(&Rule{
+		Name:        "enum-value-upper-case",
+		Description: "Enum value names must be UPPER_CASE",
+		Severity:    SeverityError,
+		Check:       checkEnumValueUpperCase,
+	})
+	Register(&Rule{
+		Name:        "enum-zero-value",
+		Description: "Enums must define a zero value with the configured name",
+		Severity:    SeverityError,
+		Options:     map[string]string{"name": "UNDEFINED"},
+		Check:       checkEnumZeroValue,
+	})
+	Register(&Rule{
+		Name:        "tag-gap",
+		Description: "Message field tags must not have gaps larger than max",
+		Severity:    SeverityWarning,
+		Options:     map[string]string{"max": "10"},
+		Check:       checkTagGap,
+	})
+	Register(&Rule{
+		Name:        "service-doc",
+		Description: "Services must have doc comments",
+		Severity:    SeverityWarning,
+		Check:       checkServiceDoc,
+	})
+	Register(&Rule{
+		Name:        "method-doc",
+		Description: "Service methods must have doc comments",
+		Severity:    SeverityWarning,
+		Check:       checkMethodDoc,
+	})
+	Register(&Rule{
+		Name:        "no-any",
+		Description: "Service requests, responses and channel messages must not use any types",
+		Severity:    SeverityWarning,
+		Check:       checkNoAny,
+	})
+}
+
+// field-snake-case
+
+func checkFieldSnakeCase(p *Pass) {
+	eachDefinition(p.Package, func(def *model.Definition) {
+		switch def.Type {
+		case model.DefinitionMessage:
+			for _, field := range def.Message.Fields.List {
+				if !snakeCase.MatchString(field.Name) {
+					p.Reportf(def.File, field.Line, "field %v.%v must be snake_case", def.Name, field.Name)
+				}
+			}
+
+		case model.DefinitionStruct:
+			for _, field := range def.Struct.Fields.Values() {
+				if !snakeCase.MatchString(field.Name) {
+					p.Reportf(def.File, field.Line, "field %v.%v must be snake_case", def.Name, field.Name)
+				}
+			}
+		}
+	})
+}
+
+// enum-value-upper-case
+
+func checkEnumValueUpperCase(p *Pass) {
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type != model.DefinitionEnum {
+			return
+		}
+
+		for _, v := range def.Enum.Values {
+			if !upperCase.MatchString(v.Name) {
+				p.Reportf(def.File, v.Line, "enum value %v.%v must be UPPER_CASE", def.Name, v.Name)
+			}
+		}
+	})
+}
+
+// enum-zero-value
+
+func checkEnumZeroValue(p *Pass) {
+	name := p.Option("name")
+
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type != model.DefinitionEnum {
+			return
+		}
+
+		v, ok := def.Enum.ValueNumbers[0]
+		switch {
+		case !ok:
+			p.Reportf(def.File, def.Line, "enum %v must define zero value %v", def.Name, name)
+		case name != "" && v.Name != name:
+			p.Reportf(def.File, v.Line, "enum %v zero value must be %v, got %v", def.Name, name, v.Name)
+		}
+	})
+}
+
+// tag-gap
+
+func checkTagGap(p *Pass) {
+	max := p.IntOption("max", 10)
+
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type != model.DefinitionMessage {
+			return
+		}
+
+		fields := append([]*model.Field(nil), def.Message.Fields.List...)
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].Tag < fields[j].Tag
+		})
+
+		prev := 0
+		for _, field := range fields {
+			if gap := field.Tag - prev; gap > max {
+				p.Reportf(def.File, field.Line, "field %v.%v tag %d has gap %d, max %d",
+					def.Name, field.Name, field.Tag, gap, max)
+			}
+			prev = field.Tag
+		}
+	})
+}
+
+// service-doc
+
+func checkServiceDoc(p *Pass) {
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type == model.DefinitionService && def.Doc == "" {
+			p.Reportf(def.File, def.Line, "service %v must have a doc comment", def.Name)
+		}
+	})
+}
+
+// method-doc
+
+func checkMethodDoc(p *Pass) {
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type != model.DefinitionService {
+			return
+		}
+
+		for _, m := range def.Service.Methods {
+			if m.Doc == "" {
+				p.Reportf(def.File, m.Line, "method %v.%v must have a doc comment", def.Name, m.Name)
+			}
+		}
+	})
+}
+
+// no-any
+
+func checkNoAny(p *Pass) {
+	// Collect messages used in service methods
+	var roots []*model.Type
+	eachDefinition(p.Package, func(def *model.Definition) {
+		if def.Type != model.DefinitionService {
+			return
+		}
+
+		for _, m := range def.Service.Methods {
+			roots = append(roots, m.Request, m.Response)
+			if m.Channel != nil {
+				roots = append(roots, m.Channel.In, m.Channel.Out)
+			}
+		}
+	})
+
+	// Walk messages in this package, imported packages are linted separately
+	visited := make(map[*model.Definition]struct{})
+	var walk func(typ *model.Type)
+	walk = func(typ *model.Type) {
+		if typ == nil {
+			return
+		}
+
+		switch typ.Kind {
+		case model.KindList:
+			walk(typ.Element)
+			return
+		case model.KindMessage:
+		default:
+			return
+		}
+
+		def := typ.Ref
+		if def.Package != p.Package {
+			return
+		}
+		if _, ok := visited[def]; ok {
+			return
+		}
+		visited[def] = struct{}{}
+
+		for _, field := range def.Message.Fields.List {
+			if isAny(field.Type) {
+				p.Reportf(def.File, field.Line, "field %v.%v must not use any types in a public api",
+					def.Name, field.Name)
+				continue
+			}
+			walk(field.Type)
+		}
+	}
+
+	for _, typ := range roots {
+		walk(typ)
+	}
+}
+
+func isAny(typ *model.Type) bool {
+	switch typ.Kind {
+	case model.KindAny, model.KindAnyMessage:
+		return true
+	case model.KindList:
+		return isAny(typ.Element)
+	}
+	return false
+}
+
+// util
+
+// eachDefinition calls a function for each definition in a package including generated ones.
+func eachDefinition(pkg *model.Package, fn func(def *model.Definition)) {
+	for _, file := range pkg.Files {
+		for _, def := range file.Definitions {
+			fn(def)
+		}
+	}
+}
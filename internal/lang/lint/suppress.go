@@ -0,0 +1,108 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+const (
+	ignorePrefix     = "spec-lint:ignore"
+	ignoreFilePrefix = "spec-lint:ignore-file"
+)
+
+// suppression suppresses rules in a line or in a whole file.
+type suppression struct {
+	line  int                 // Zero for a whole file
+	rules map[string]struct{} // Nil for all rules
+}
+
+func (s suppression) match(issue Issue) bool {
+	if s.line != 0 && s.line != issue.Line {
+		return false
+	}
+	if s.rules == nil {
+		return true
+	}
+
+	_, ok := s.rules[issue.Rule]
+	return ok
+}
+
+// suppress removes issues suppressed by inline comments.
+func suppress(pkg *model.Package, issues []Issue) []Issue {
+	files := make(map[string][]suppression, len(pkg.Files))
+	for _, file := range pkg.Files {
+		files[file.Path] = parseSuppressions(file)
+	}
+
+	result := issues[:0]
+outer:
+	for _, issue := range issues {
+		for _, s := range files[issue.File] {
+			if s.match(issue) {
+				continue outer
+			}
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+// parseSuppressions parses suppression comments in a file.
+//
+// A trailing comment suppresses issues in its line, a standalone comment
+// suppresses issues in the next line.
+func parseSuppressions(file *model.File) []suppression {
+	var result []suppression
+
+	for _, c := range file.Comments {
+		text := strings.TrimPrefix(c.Text, "//")
+		text = strings.TrimPrefix(text, "/*")
+		text = strings.TrimSuffix(text, "*/")
+		text = strings.TrimSpace(text)
+
+		var s suppression
+		switch {
+		case strings.HasPrefix(text, ignoreFilePrefix):
+			text = text[len(ignoreFilePrefix):]
+		case strings.HasPrefix(text, ignorePrefix):
+			text = text[len(ignorePrefix):]
+			s.line = c.End() + 1
+			if c.Trailing {
+				s.line = c.Line
+			}
+		default:
+			continue
+		}
+
+		// Skip other directives, i.e. "spec-lint:ignored"
+		if text != "" && text[0] != ' ' && text[0] != '\t' {
+			continue
+		}
+
+		s.rules = parseRuleNames(text)
+		result = append(result, s)
+	}
+	return result
+}
+
+// parseRuleNames parses comma or space separated rule names, returns nil when empty.
+func parseRuleNames(s string) map[string]struct{} {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil
+	}
+
+	rules := make(map[string]struct{}, len(fields))
+	for _, name := range fields {
+		rules[name] = struct{}{}
+	}
+	return rules
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// builtinTypes are builtin type names in completions.
+var builtinTypes = []string{
+	"any",
+	"bool",
+	"byte",
+	"int16",
+	"int32",
+	"int64",
+	"uint16",
+	"uint32",
+	"uint64",
+	"float32",
+	"float64",
+	"bin64",
+	"bin128",
+	"bin256",
+	"bytes",
+	"string",
+	"message",
+}
+
+// complete returns type names and import aliases at a protocol position,
+// or imported package types after an import alias.
+func (w *workspace) complete(path string, pos Position) []CompletionItem {
+	file := w.file(path)
+	if file == nil {
+		return nil
+	}
+
+	src := w.source(path)
+	prefix := prefixAt(src.line(pos.Line), pos.Character)
+
+	// Imported types
+	if i := strings.LastIndexByte(prefix, '.'); i >= 0 {
+		imp, ok := file.ImportMap[prefix[:i]]
+		if !ok || imp.Package == nil {
+			return nil
+		}
+		return definitionItems(imp.Package)
+	}
+
+	items := make([]CompletionItem, 0, len(builtinTypes))
+	for _, name := range builtinTypes {
+		items = append(items, CompletionItem{
+			Label: name,
+			Kind:  CompletionKindKeyword,
+		})
+	}
+
+	items = append(items, definitionItems(file.Package)...)
+	for _, imp := range file.Imports {
+		items = append(items, CompletionItem{
+			Label:  imp.Name,
+			Kind:   CompletionKindModule,
+			Detail: imp.ID,
+		})
+	}
+	return items
+}
+
+// definitionItems returns package definitions without generated messages, sorted by names.
+func definitionItems(pkg *model.Package) []CompletionItem {
+	var items []CompletionItem
+	for _, def := range pkg.Definitions {
+		if generated(def) {
+			continue
+		}
+
+		kind := CompletionKindClass
+		switch def.Type {
+		case model.DefinitionEnum:
+			kind = CompletionKindEnum
+		case model.DefinitionStruct:
+			kind = CompletionKindStruct
+		case model.DefinitionService:
+			kind = CompletionKindInterface
+		}
+
+		items = append(items, CompletionItem{
+			Label:  def.Name,
+			Kind:   kind,
+			Detail: string(def.Type),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lsp implements a language server for spec files over the Language Server Protocol.
+//
+// The server compiles packages of open documents on every change, publishes compile errors
+// as diagnostics, and answers definition, hover, completion, references and rename requests
+// using the last successfully compiled packages, so navigation keeps working while a file
+// is being edited.
+//
+// Documents are synchronized in full, references are searched in the packages of the
+// workspace root and of the open documents.
+package lsp
+
+import (
+	"io"
+	"path/filepath"
+)
+
+// Options specify language server options.
+type Options struct {
+	ImportPaths []string // Import paths, relative paths are resolved in the working directory
+}
+
+// Serve runs a language server over a reader and a writer, usually stdin and stdout,
+// until an exit notification or the end of input.
+func Serve(r io.Reader, w io.Writer, opts Options) error {
+	paths := make([]string, 0, len(opts.ImportPaths))
+	for _, path := range opts.ImportPaths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, abs)
+	}
+
+	s := newServer(r, w, paths)
+	return s.run()
+}
@@ -0,0 +1,547 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testShared = `// Status is a user status.
+enum Status {
+    UNDEFINED = 0;
+    ACTIVE    = 1;
+}
+
+// UserID is a user id.
+struct UserID {
+    value bin128;
+}
+`
+
+const testApp = `import (
+    "shared"
+)
+
+// User is a user.
+message User {
+    // User id.
+    id      shared.UserID 1;
+    status  shared.Status 2;
+    friends []User        3;
+}
+
+message Result {
+    ok bool 1;
+}
+
+service Users {
+    get(id shared.UserID 1) (user User 1);
+    put(User) Result;
+}
+`
+
+type testClient struct {
+	t    *testing.T
+	conn *conn
+	msgs chan map[string]json.RawMessage
+	id   int
+}
+
+type testWorkspace struct {
+	root   string
+	app    string // App file path
+	shared string // Shared file path
+}
+
+func testServer(t *testing.T) (*testClient, *testWorkspace) {
+	root := t.TempDir()
+	ws := &testWorkspace{
+		root:   root,
+		app:    filepath.Join(root, "app", "app.spec"),
+		shared: filepath.Join(root, "imports", "shared", "shared.spec"),
+	}
+	testWriteFile(t, ws.app, testApp)
+	testWriteFile(t, ws.shared, testShared)
+
+	inr, inw := io.Pipe()
+	outr, outw := io.Pipe()
+
+	opts := Options{ImportPaths: []string{filepath.Join(root, "imports")}}
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(inr, outw, opts)
+		outw.Close()
+	}()
+
+	c := &testClient{
+		t:    t,
+		conn: newConn(outr, inw),
+		msgs: make(chan map[string]json.RawMessage, 100),
+	}
+	go func() {
+		defer close(c.msgs)
+		for {
+			body, err := c.conn.readBody()
+			if err != nil {
+				return
+			}
+
+			var msg map[string]json.RawMessage
+			if err := json.Unmarshal(body, &msg); err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+
+	t.Cleanup(func() {
+		c.call("shutdown", nil, nil)
+		c.notify("exit", nil)
+		inw.Close()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Error("server did not exit")
+		}
+	})
+
+	c.call("initialize", &InitializeParams{RootURI: pathToURI(root)}, nil)
+	c.notify("initialized", struct{}{})
+	return c, ws
+}
+
+func testWriteFile(t *testing.T, path string, text string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	if err := c.conn.notify(method, params); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *testClient) call(method string, params any, result any) *rpcError {
+	c.id++
+	id := json.RawMessage(mustMarshal(c.id))
+
+	err := c.conn.write(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      &id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	for {
+		msg := c.next()
+		if _, ok := msg["method"]; ok {
+			continue
+		}
+		if string(msg["id"]) != string(id) {
+			continue
+		}
+
+		if raw, ok := msg["error"]; ok {
+			rerr := &rpcError{}
+			if err := json.Unmarshal(raw, rerr); err != nil {
+				c.t.Fatal(err)
+			}
+			return rerr
+		}
+		if result != nil {
+			if err := json.Unmarshal(msg["result"], result); err != nil {
+				c.t.Fatal(err)
+			}
+		}
+		return nil
+	}
+}
+
+// diagnostics returns the next published diagnostics for a file.
+func (c *testClient) diagnostics(path string) []Diagnostic {
+	for {
+		msg := c.next()
+		if string(msg["method"]) != `"textDocument/publishDiagnostics"` {
+			continue
+		}
+
+		var p PublishDiagnosticsParams
+		if err := json.Unmarshal(msg["params"], &p); err != nil {
+			c.t.Fatal(err)
+		}
+		if p.URI == pathToURI(path) {
+			return p.Diagnostics
+		}
+	}
+}
+
+func (c *testClient) next() map[string]json.RawMessage {
+	select {
+	case msg, ok := <-c.msgs:
+		if !ok {
+			c.t.Fatal("connection closed")
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timeout")
+	}
+	return nil
+}
+
+func (c *testClient) open(path string, text string) {
+	c.notify("textDocument/didOpen", &DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{
+			URI:     pathToURI(path),
+			Version: 1,
+			Text:    text,
+		},
+	})
+}
+
+func testPosition(path string, line int, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(path)},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func mustMarshal(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Diagnostics
+
+func TestServer__should_publish_compile_errors(t *testing.T) {
+	c, ws := testServer(t)
+
+	broken := `message Message {
+    a string  1;
+    b Unknown 2;
+}
+`
+	path := filepath.Join(ws.root, "broken", "broken.spec")
+	testWriteFile(t, path, broken)
+
+	c.open(path, broken)
+	diags := c.diagnostics(path)
+	require.Len(t, diags, 1)
+
+	d := diags[0]
+	assert.Equal(t, SeverityError, d.Severity)
+	assert.Equal(t, 2, d.Range.Start.Line)
+	assert.Contains(t, d.Message, "type not found: Unknown")
+
+	// Fix error
+	fixed := `message Message {
+    a string  1;
+    b string  2;
+}
+`
+	c.notify("textDocument/didChange", &DidChangeTextDocumentParams{
+		TextDocument:   TextDocumentIdentifier{URI: pathToURI(path)},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: fixed}},
+	})
+	diags = c.diagnostics(path)
+	assert.Empty(t, diags)
+}
+
+func TestServer__should_publish_syntax_errors(t *testing.T) {
+	c, ws := testServer(t)
+
+	broken := `message Message {
+    a string 1;
+    b string
+}
+`
+	path := filepath.Join(ws.root, "broken", "broken.spec")
+	testWriteFile(t, path, broken)
+
+	c.open(path, broken)
+	diags := c.diagnostics(path)
+	require.Len(t, diags, 1)
+	assert.Equal(t, 3, diags[0].Range.Start.Line)
+}
+
+// Definition
+
+func TestServer__should_return_imported_definition(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// id      shared.UserID 1;
+	var loc Location
+	rerr := c.call("textDocument/definition", testPosition(ws.app, 7, 20), &loc)
+	require.Nil(t, rerr)
+
+	assert.Equal(t, pathToURI(ws.shared), loc.URI)
+	assert.Equal(t, Range{
+		Start: Position{Line: 7, Character: 7},
+		End:   Position{Line: 7, Character: 13},
+	}, loc.Range)
+}
+
+func TestServer__should_return_local_definition(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// friends []User        3;
+	var loc Location
+	rerr := c.call("textDocument/definition", testPosition(ws.app, 9, 15), &loc)
+	require.Nil(t, rerr)
+
+	assert.Equal(t, pathToURI(ws.app), loc.URI)
+	assert.Equal(t, 5, loc.Range.Start.Line)
+	assert.Equal(t, 8, loc.Range.Start.Character)
+}
+
+// Hover
+
+func TestServer__should_return_definition_hover(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// put(User) Result;
+	var hover Hover
+	rerr := c.call("textDocument/hover", testPosition(ws.app, 18, 9), &hover)
+	require.Nil(t, rerr)
+
+	assert.Equal(t, "markdown", hover.Contents.Kind)
+	assert.Equal(t, "```spec\n"+
+		"message User {\n"+
+		"    id shared.UserID 1;\n"+
+		"    status shared.Status 2;\n"+
+		"    friends []User 3;\n"+
+		"}\n"+
+		"```\n"+
+		"\nUser is a user.\n", hover.Contents.Value)
+}
+
+func TestServer__should_return_field_hover(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// id      shared.UserID 1;
+	var hover Hover
+	rerr := c.call("textDocument/hover", testPosition(ws.app, 7, 5), &hover)
+	require.Nil(t, rerr)
+
+	assert.Equal(t, "```spec\nid shared.UserID 1;\n```\n\nIn message User\n\nUser id.\n",
+		hover.Contents.Value)
+}
+
+func TestServer__should_return_method_hover(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// get(id shared.UserID 1) (user User 1);
+	var hover Hover
+	rerr := c.call("textDocument/hover", testPosition(ws.app, 17, 5), &hover)
+	require.Nil(t, rerr)
+
+	assert.Contains(t, hover.Contents.Value, "get(id shared.UserID 1) (user User 1);")
+}
+
+// Completion
+
+func TestServer__should_complete_types_and_imports(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	var list CompletionList
+	rerr := c.call("textDocument/completion", testPosition(ws.app, 9, 14), &list)
+	require.Nil(t, rerr)
+
+	labels := make(map[string]CompletionItemKind)
+	for _, item := range list.Items {
+		labels[item.Label] = item.Kind
+	}
+	assert.Equal(t, CompletionKindKeyword, labels["string"])
+	assert.Equal(t, CompletionKindClass, labels["User"])
+	assert.Equal(t, CompletionKindInterface, labels["Users"])
+	assert.Equal(t, CompletionKindModule, labels["shared"])
+	assert.NotContains(t, labels, "UsersGetRequest")
+}
+
+func TestServer__should_complete_imported_types(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	// status  shared.|Status 2;
+	var list CompletionList
+	rerr := c.call("textDocument/completion", testPosition(ws.app, 8, 19), &list)
+	require.Nil(t, rerr)
+
+	var labels []string
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	assert.Equal(t, []string{"Status", "UserID"}, labels)
+}
+
+// References
+
+func TestServer__should_find_references_across_imports(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.shared, testShared)
+
+	params := &ReferenceParams{
+		TextDocumentPositionParams: testPosition(ws.shared, 7, 8),
+		Context:                    ReferenceContext{IncludeDeclaration: true},
+	}
+
+	var locs []Location
+	rerr := c.call("textDocument/references", params, &locs)
+	require.Nil(t, rerr)
+
+	type ref struct {
+		file string
+		line int
+		char int
+	}
+	var refs []ref
+	for _, loc := range locs {
+		refs = append(refs, ref{filepath.Base(uriToPath(loc.URI)), loc.Range.Start.Line, loc.Range.Start.Character})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].file < refs[j].file
+	})
+
+	assert.Equal(t, []ref{
+		{"app.spec", 7, 19},
+		{"app.spec", 17, 18},
+		{"shared.spec", 7, 7},
+	}, refs)
+}
+
+func TestServer__should_find_references_without_declaration(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	params := &ReferenceParams{
+		TextDocumentPositionParams: testPosition(ws.app, 5, 9),
+	}
+
+	var locs []Location
+	rerr := c.call("textDocument/references", params, &locs)
+	require.Nil(t, rerr)
+
+	var lines []int
+	for _, loc := range locs {
+		lines = append(lines, loc.Range.Start.Line)
+	}
+	assert.Equal(t, []int{9, 17, 18}, lines)
+}
+
+// Rename
+
+func TestServer__should_rename_definition(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	params := &RenameParams{
+		TextDocumentPositionParams: testPosition(ws.app, 5, 9),
+		NewName:                    "Account",
+	}
+
+	var edit WorkspaceEdit
+	rerr := c.call("textDocument/rename", params, &edit)
+	require.Nil(t, rerr)
+
+	edits := edit.Changes[pathToURI(ws.app)]
+	require.Len(t, edits, 4)
+	for _, e := range edits {
+		assert.Equal(t, "Account", e.NewText)
+		assert.Equal(t, 4, e.Range.End.Character-e.Range.Start.Character)
+	}
+}
+
+func TestServer__should_rename_field(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	params := &RenameParams{
+		TextDocumentPositionParams: testPosition(ws.app, 8, 6),
+		NewName:                    "state",
+	}
+
+	var edit WorkspaceEdit
+	rerr := c.call("textDocument/rename", params, &edit)
+	require.Nil(t, rerr)
+
+	assert.Equal(t, map[string][]TextEdit{
+		pathToURI(ws.app): {{
+			Range: Range{
+				Start: Position{Line: 8, Character: 4},
+				End:   Position{Line: 8, Character: 10},
+			},
+			NewText: "state",
+		}},
+	}, edit.Changes)
+}
+
+func TestServer__should_reject_existing_definition_name(t *testing.T) {
+	c, ws := testServer(t)
+	c.open(ws.app, testApp)
+
+	params := &RenameParams{
+		TextDocumentPositionParams: testPosition(ws.app, 5, 9),
+		NewName:                    "Result",
+	}
+
+	rerr := c.call("textDocument/rename", params, nil)
+	require.NotNil(t, rerr)
+	assert.Equal(t, "definition Result already exists", rerr.Message)
+}
+
+// Protocol
+
+func TestServer__should_reply_method_not_found(t *testing.T) {
+	c, _ := testServer(t)
+
+	rerr := c.call("textDocument/unknown", struct{}{}, nil)
+	require.NotNil(t, rerr)
+	assert.Equal(t, codeMethodNotFound, rerr.Code)
+}
+
+func TestConn_read__should_reject_content_length_above_max(t *testing.T) {
+	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", maxContentLength+1)
+	c := newConn(strings.NewReader(header), io.Discard)
+
+	_, err := c.read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds max length")
+}
+
+func TestConn_read__should_reject_negative_content_length(t *testing.T) {
+	c := newConn(strings.NewReader("Content-Length: -1\r\n\r\n"), io.Discard)
+
+	_, err := c.read()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid content length")
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reference is a symbol name occurrence in a file.
+type reference struct {
+	path string
+	rng  Range // Name range without a qualifier
+}
+
+// references returns a symbol declaration and references in the workspace packages.
+//
+// Fields, enum values, methods and imports are only referenced by their declarations.
+func (w *workspace) references(sym *symbol) []reference {
+	decl := w.location(sym)
+	refs := []reference{{
+		path: sym.file.Path,
+		rng:  decl.Range,
+	}}
+	if sym.kind != symbolDefinition {
+		return refs
+	}
+
+	// Packages are compiled separately, definitions are matched by package paths and names
+	seen := make(map[string]struct{})
+	target := sym.def
+
+	for _, dir := range w.packageDirs() {
+		pkg := w.pkg(dir)
+		if pkg == nil || !references(pkg, target) {
+			continue
+		}
+
+		for _, file := range pkg.Files {
+			if _, ok := seen[file.Path]; ok {
+				continue
+			}
+			seen[file.Path] = struct{}{}
+
+			refs = append(refs, w.fileReferences(file, target)...)
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		switch {
+		case a.path != b.path:
+			return a.path < b.path
+		case a.rng.Start.Line != b.rng.Start.Line:
+			return a.rng.Start.Line < b.rng.Start.Line
+		}
+		return a.rng.Start.Character < b.rng.Start.Character
+	})
+	return dedupReferences(refs)
+}
+
+// fileReferences returns references to a definition in a file.
+func (w *workspace) fileReferences(file *model.File, target *model.Definition) []reference {
+	src := w.source(file.Path)
+
+	var refs []reference
+	find := func(line int, last int, typ *model.Type) {
+		if typ == nil || !refersTo(typ, target) {
+			return
+		}
+		for typ.Kind == model.KindList {
+			typ = typ.Element
+		}
+
+		name := typ.SourceName()
+		for n := line - 1; n < last; n++ {
+			for i, tok := range tokenize(src.line(n)) {
+				// Skip field and method names
+				if n == line-1 && i == 0 {
+					continue
+				}
+				if tok.text != name {
+					continue
+				}
+
+				refs = append(refs, reference{
+					path: file.Path,
+					rng:  src.rangeOf(n, tok.nameStart(), tok.end),
+				})
+			}
+		}
+	}
+
+	for _, def := range file.Definitions {
+		switch def.Type {
+		case model.DefinitionMessage:
+			for _, f := range def.Message.Fields.List {
+				find(f.Line, f.Line, f.Type)
+			}
+
+		case model.DefinitionStruct:
+			for _, f := range def.Struct.Fields.Values() {
+				find(f.Line, f.Line, f.Type)
+			}
+
+		case model.DefinitionService:
+			for _, m := range def.Service.Methods {
+				last := methodEnd(src, m.Line)
+
+				// Inline fields are referenced in generated messages
+				if m.Request != nil && !m.Request.Ref.Message.Generated {
+					find(m.Line, last, m.Request)
+				}
+				if m.Response != nil && !m.Response.Ref.Message.Generated {
+					find(m.Line, last, m.Response)
+				}
+				if ch := m.Channel; ch != nil {
+					find(m.Line, last, ch.In)
+					find(m.Line, last, ch.Out)
+				}
+				find(m.Line, last, m.Subservice)
+			}
+		}
+	}
+	return refs
+}
+
+// rename returns edits to rename a symbol.
+func (w *workspace) rename(sym *symbol, name string) (*WorkspaceEdit, error) {
+	switch {
+	case !identifier.MatchString(name):
+		return nil, fmt.Errorf("invalid name %q", name)
+	case sym.kind == symbolImport:
+		return nil, fmt.Errorf("cannot rename import %v", sym.name)
+	case sym.kind == symbolMethod:
+		return nil, fmt.Errorf("cannot rename method %v, generated names depend on it", sym.name)
+	case sym.kind == symbolDefinition && generated(sym.def):
+		return nil, fmt.Errorf("cannot rename generated message %v", sym.name)
+	}
+
+	if sym.kind == symbolDefinition {
+		if _, ok := findDefinition(sym.def.Package, name); ok {
+			return nil, fmt.Errorf("definition %v already exists", name)
+		}
+	}
+
+	edit := &WorkspaceEdit{Changes: make(map[string][]TextEdit)}
+	for _, ref := range w.references(sym) {
+		uri := pathToURI(ref.path)
+		edit.Changes[uri] = append(edit.Changes[uri], TextEdit{
+			Range:   ref.rng,
+			NewText: name,
+		})
+	}
+	return edit, nil
+}
+
+// util
+
+// references returns true when a package is or imports a definition package.
+func references(pkg *model.Package, target *model.Definition) bool {
+	if samePackage(pkg, target.Package) {
+		return true
+	}
+
+	for _, file := range pkg.Files {
+		for _, imp := range file.Imports {
+			if imp.Package != nil && samePackage(imp.Package, target.Package) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func refersTo(typ *model.Type, target *model.Definition) bool {
+	if typ.Kind == model.KindList {
+		return refersTo(typ.Element, target)
+	}
+
+	ref := typ.Ref
+	if ref == nil {
+		return false
+	}
+	return ref.Name == target.Name && samePackage(ref.Package, target.Package)
+}
+
+func samePackage(a *model.Package, b *model.Package) bool {
+	if a == b {
+		return true
+	}
+
+	pa, err := filepath.Abs(a.Path)
+	if err != nil {
+		return false
+	}
+	pb, err := filepath.Abs(b.Path)
+	if err != nil {
+		return false
+	}
+	return pa == pb
+}
+
+// methodEnd returns a one-based line of a method semicolon.
+func methodEnd(src *source, line int) int {
+	for n := line - 1; n < len(src.lines); n++ {
+		text := src.line(n)
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i]
+		}
+		if strings.Contains(text, ";") {
+			return n + 1
+		}
+	}
+	return line
+}
+
+func dedupReferences(refs []reference) []reference {
+	result := refs[:0]
+	for _, ref := range refs {
+		if n := len(result); n > 0 && result[n-1] == ref {
+			continue
+		}
+		result = append(result, ref)
+	}
+	return result
+}
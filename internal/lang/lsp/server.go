@@ -0,0 +1,314 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+type server struct {
+	conn *conn
+	ws   *workspace
+
+	shutdown bool
+}
+
+type handler func(s *server, params json.RawMessage) (any, error)
+
+var handlers = map[string]handler{
+	"initialize":  (*server).initialize,
+	"initialized": (*server).initialized,
+	"shutdown":    (*server).shutdownRequest,
+
+	"textDocument/didOpen":   (*server).didOpen,
+	"textDocument/didChange": (*server).didChange,
+	"textDocument/didSave":   (*server).didSave,
+	"textDocument/didClose":  (*server).didClose,
+
+	"textDocument/definition": (*server).definition,
+	"textDocument/hover":      (*server).hover,
+	"textDocument/completion": (*server).completion,
+	"textDocument/references": (*server).references,
+	"textDocument/rename":     (*server).rename,
+}
+
+func newServer(r io.Reader, w io.Writer, importPaths []string) *server {
+	return &server{
+		conn: newConn(r, w),
+		ws:   newWorkspace(importPaths),
+	}
+}
+
+// run handles messages sequentially until an exit notification or the end of input.
+func (s *server) run() error {
+	for {
+		req, err := s.conn.read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			var rerr *rpcError
+			if errors.As(err, &rerr) {
+				if err := s.conn.reply(nil, nil, rerr); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit without shutdown")
+			}
+			return nil
+		}
+
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) handle(req *request) error {
+	h, ok := handlers[req.Method]
+	if !ok {
+		// Ignore unknown notifications, i.e. $/cancelRequest
+		if req.ID == nil {
+			return nil
+		}
+
+		err := &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+		return s.conn.reply(req.ID, nil, err)
+	}
+
+	result, err := h(s, req.Params)
+	if req.ID == nil {
+		return nil
+	}
+	return s.conn.reply(req.ID, result, err)
+}
+
+// lifecycle
+
+func (s *server) initialize(params json.RawMessage) (any, error) {
+	var p InitializeParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.RootURI != "":
+		s.ws.root = uriToPath(p.RootURI)
+	case p.RootPath != "":
+		s.ws.root = p.RootPath
+	}
+
+	result := &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    1,
+				Save:      true,
+			},
+			DefinitionProvider: true,
+			HoverProvider:      true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"."},
+			},
+			ReferencesProvider: true,
+			RenameProvider:     true,
+		},
+		ServerInfo: ServerInfo{Name: "spec"},
+	}
+	return result, nil
+}
+
+func (s *server) initialized(params json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+func (s *server) shutdownRequest(params json.RawMessage) (any, error) {
+	s.shutdown = true
+	return nil, nil
+}
+
+// documents
+
+func (s *server) didOpen(params json.RawMessage) (any, error) {
+	var p DidOpenTextDocumentParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	path := uriToPath(p.TextDocument.URI)
+	s.ws.docs[path] = p.TextDocument.Text
+	return nil, s.check(path)
+}
+
+func (s *server) didChange(params json.RawMessage) (any, error) {
+	var p DidChangeTextDocumentParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+
+	// Full document sync, the last change is the document text
+	path := uriToPath(p.TextDocument.URI)
+	s.ws.docs[path] = p.ContentChanges[len(p.ContentChanges)-1].Text
+	return nil, s.check(path)
+}
+
+func (s *server) didSave(params json.RawMessage) (any, error) {
+	var p DidSaveTextDocumentParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	path := uriToPath(p.TextDocument.URI)
+	return nil, s.check(path)
+}
+
+func (s *server) didClose(params json.RawMessage) (any, error) {
+	var p DidCloseTextDocumentParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	path := uriToPath(p.TextDocument.URI)
+	delete(s.ws.docs, path)
+	return nil, s.check(path)
+}
+
+// check compiles a document package and publishes diagnostics.
+func (s *server) check(path string) error {
+	diags := s.ws.check(filepath.Dir(path))
+	for path, list := range diags {
+		params := &PublishDiagnosticsParams{
+			URI:         pathToURI(path),
+			Diagnostics: list,
+		}
+		if err := s.conn.notify("textDocument/publishDiagnostics", params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// features
+
+func (s *server) definition(params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	sym, ok := s.ws.lookup(uriToPath(p.TextDocument.URI), p.Position)
+	if !ok {
+		return nil, nil
+	}
+	return s.ws.location(sym), nil
+}
+
+func (s *server) hover(params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	sym, ok := s.ws.lookup(uriToPath(p.TextDocument.URI), p.Position)
+	if !ok {
+		return nil, nil
+	}
+
+	hover := &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: sym.hover(),
+		},
+	}
+	return hover, nil
+}
+
+func (s *server) completion(params json.RawMessage) (any, error) {
+	var p TextDocumentPositionParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	items := s.ws.complete(uriToPath(p.TextDocument.URI), p.Position)
+	if items == nil {
+		items = []CompletionItem{}
+	}
+	return &CompletionList{Items: items}, nil
+}
+
+func (s *server) references(params json.RawMessage) (any, error) {
+	var p ReferenceParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	sym, ok := s.ws.lookup(uriToPath(p.TextDocument.URI), p.Position)
+	if !ok {
+		return []Location{}, nil
+	}
+
+	refs := s.ws.references(sym)
+	if !p.Context.IncludeDeclaration {
+		decl := s.ws.location(sym)
+		refs = removeReference(refs, reference{path: sym.file.Path, rng: decl.Range})
+	}
+
+	locs := make([]Location, 0, len(refs))
+	for _, ref := range refs {
+		locs = append(locs, Location{
+			URI:   pathToURI(ref.path),
+			Range: ref.rng,
+		})
+	}
+	return locs, nil
+}
+
+func (s *server) rename(params json.RawMessage) (any, error) {
+	var p RenameParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+
+	sym, ok := s.ws.lookup(uriToPath(p.TextDocument.URI), p.Position)
+	if !ok {
+		return nil, fmt.Errorf("no symbol at position")
+	}
+	return s.ws.rename(sym, p.NewName)
+}
+
+// util
+
+func unmarshal(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func removeReference(refs []reference, ref reference) []reference {
+	result := refs[:0]
+	for _, r := range refs {
+		if r != ref {
+			result = append(result, r)
+		}
+	}
+	return result
+}
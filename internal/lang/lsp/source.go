@@ -0,0 +1,205 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+// source is a file text split into lines.
+type source struct {
+	lines []string
+}
+
+func newSource(text string) *source {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return &source{lines: lines}
+}
+
+// line returns a zero-based line or an empty string.
+func (s *source) line(n int) string {
+	if n < 0 || n >= len(s.lines) {
+		return ""
+	}
+	return s.lines[n]
+}
+
+// tokenAt returns a token at a protocol position.
+func (s *source) tokenAt(pos Position) (token, bool) {
+	line := s.line(pos.Line)
+	offset := byteOffset(line, pos.Character)
+
+	for _, tok := range tokenize(line) {
+		if tok.start <= offset && offset <= tok.end {
+			return tok, true
+		}
+	}
+	return token{}, false
+}
+
+// rangeOf returns a protocol range of a byte range in a zero-based line.
+func (s *source) rangeOf(n int, start int, end int) Range {
+	line := s.line(n)
+	return Range{
+		Start: Position{Line: n, Character: utf16Column(line, start)},
+		End:   Position{Line: n, Character: utf16Column(line, end)},
+	}
+}
+
+// lineRange returns a range of a zero-based line without leading and trailing spaces.
+func (s *source) lineRange(n int) Range {
+	line := s.line(n)
+	start := len(line) - len(strings.TrimLeft(line, " \t"))
+	end := len(strings.TrimRight(line, " \t"))
+	if end < start {
+		end = start
+	}
+	return s.rangeOf(n, start, end)
+}
+
+// nameRange returns a range of the first identifier with a name in a zero-based line,
+// or the line range when not found.
+func (s *source) nameRange(n int, name string) Range {
+	for _, tok := range tokenize(s.line(n)) {
+		if tok.text == name {
+			return s.rangeOf(n, tok.start, tok.end)
+		}
+	}
+	return s.lineRange(n)
+}
+
+// token
+
+// token is an identifier in a line, qualified identifiers are single tokens, i.e. "pkg.Name".
+type token struct {
+	text  string
+	start int // Byte offset
+	end   int // Byte offset
+}
+
+// parts returns a qualifier and a name, the qualifier is empty in unqualified identifiers.
+func (t token) parts() (string, string) {
+	i := strings.LastIndexByte(t.text, '.')
+	if i < 0 {
+		return "", t.text
+	}
+	return t.text[:i], t.text[i+1:]
+}
+
+// nameStart returns a byte offset of the name in a qualified identifier.
+func (t token) nameStart() int {
+	return t.end - len(t.text[strings.LastIndexByte(t.text, '.')+1:])
+}
+
+// tokenize returns identifiers in a line, skips strings and comments.
+func tokenize(line string) []token {
+	var tokens []token
+
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return tokens
+
+		case c == '"':
+			end := strings.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				return tokens
+			}
+			i += end + 2
+
+		case isIdentStart(c):
+			start := i
+			for i < len(line) {
+				if isIdentPart(line[i]) {
+					i++
+					continue
+				}
+				if line[i] == '.' && i+1 < len(line) && isIdentStart(line[i+1]) {
+					i++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, token{text: line[start:i], start: start, end: i})
+
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// prefixAt returns an identifier prefix before a protocol position, i.e. "pkg.Na" in "pkg.Na|".
+func prefixAt(line string, character int) string {
+	offset := byteOffset(line, character)
+
+	start := offset
+	for start > 0 && (isIdentPart(line[start-1]) || line[start-1] == '.') {
+		start--
+	}
+	return line[start:offset]
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || ('0' <= c && c <= '9')
+}
+
+// columns
+
+// utf16Column converts a byte offset in a line into a UTF-16 column.
+func utf16Column(line string, offset int) int {
+	if offset > len(line) {
+		offset = len(line)
+	}
+
+	n := 0
+	for _, r := range line[:offset] {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// byteOffset converts a UTF-16 column in a line into a byte offset.
+func byteOffset(line string, column int) int {
+	n := 0
+	for i, r := range line {
+		if n >= column {
+			return i
+		}
+		n += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// uris
+
+// uriToPath converts a file uri into a path.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts a path into a file uri.
+func pathToURI(path string) string {
+	u := url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(path),
+	}
+	return u.String()
+}
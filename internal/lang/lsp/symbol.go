@@ -0,0 +1,289 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+type symbolKind int
+
+const (
+	symbolDefinition symbolKind = iota
+	symbolField                 // Message or struct field
+	symbolEnumValue
+	symbolMethod
+	symbolImport
+)
+
+// symbol is a named element declared in a spec file.
+type symbol struct {
+	kind symbolKind
+	name string
+	file *model.File
+	line int // Declaration line, one-based
+	doc  string
+
+	def    *model.Definition // Definition, or a field, value or method owner
+	typ    *model.Type       // Field type
+	tag    int               // Message field tag
+	number int               // Enum value number
+	method *model.Method
+	imp    *model.Import
+}
+
+// lookup returns a symbol declared or referenced at a protocol position.
+func (w *workspace) lookup(path string, pos Position) (*symbol, bool) {
+	file := w.file(path)
+	if file == nil {
+		return nil, false
+	}
+
+	src := w.source(path)
+	tok, ok := src.tokenAt(pos)
+	if !ok {
+		return nil, false
+	}
+
+	line := pos.Line + 1
+	qual, name := tok.parts()
+
+	// Qualified type or import alias
+	if qual != "" {
+		imp, ok := file.ImportMap[qual]
+		if !ok {
+			return nil, false
+		}
+
+		offset := byteOffset(src.line(pos.Line), pos.Character)
+		if offset < tok.nameStart() {
+			return importSymbol(imp), true
+		}
+
+		def, ok := findDefinition(imp.Package, name)
+		if !ok {
+			return nil, false
+		}
+		return definitionSymbol(def), true
+	}
+
+	// Declarations
+	if sym, ok := declarationAt(file, line, name); ok {
+		return sym, true
+	}
+
+	// Local type or import alias
+	if def, ok := findDefinition(file.Package, name); ok {
+		return definitionSymbol(def), true
+	}
+	if imp, ok := file.ImportMap[name]; ok {
+		return importSymbol(imp), true
+	}
+	return nil, false
+}
+
+// declarationAt returns a symbol declared at a line.
+func declarationAt(file *model.File, line int, name string) (*symbol, bool) {
+	for _, imp := range file.Imports {
+		if imp.Line == line && imp.Name == name {
+			return importSymbol(imp), true
+		}
+	}
+
+	for _, def := range file.Definitions {
+		if def.Line == line && def.Name == name && !generated(def) {
+			return definitionSymbol(def), true
+		}
+
+		switch def.Type {
+		case model.DefinitionEnum:
+			for _, v := range def.Enum.Values {
+				if v.Line == line && v.Name == name {
+					return &symbol{
+						kind:   symbolEnumValue,
+						name:   v.Name,
+						file:   file,
+						line:   v.Line,
+						doc:    v.Doc,
+						def:    def,
+						number: v.Number,
+					}, true
+				}
+			}
+
+		case model.DefinitionMessage:
+			// Includes inline fields of method requests and responses
+			for _, f := range def.Message.Fields.List {
+				if f.Line == line && f.Name == name {
+					return &symbol{
+						kind: symbolField,
+						name: f.Name,
+						file: file,
+						line: f.Line,
+						doc:  f.Doc,
+						def:  def,
+						typ:  f.Type,
+						tag:  f.Tag,
+					}, true
+				}
+			}
+
+		case model.DefinitionStruct:
+			for _, f := range def.Struct.Fields.Values() {
+				if f.Line == line && f.Name == name {
+					return &symbol{
+						kind: symbolField,
+						name: f.Name,
+						file: file,
+						line: f.Line,
+						doc:  f.Doc,
+						def:  def,
+						typ:  f.Type,
+					}, true
+				}
+			}
+
+		case model.DefinitionService:
+			for _, m := range def.Service.Methods {
+				if m.Line == line && m.Name == name {
+					return &symbol{
+						kind:   symbolMethod,
+						name:   m.Name,
+						file:   file,
+						line:   m.Line,
+						doc:    m.Doc,
+						def:    def,
+						method: m,
+					}, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func definitionSymbol(def *model.Definition) *symbol {
+	return &symbol{
+		kind: symbolDefinition,
+		name: def.Name,
+		file: def.File,
+		line: def.Line,
+		doc:  def.Doc,
+		def:  def,
+	}
+}
+
+func importSymbol(imp *model.Import) *symbol {
+	return &symbol{
+		kind: symbolImport,
+		name: imp.Name,
+		file: imp.File,
+		line: imp.Line,
+		imp:  imp,
+	}
+}
+
+// location returns a symbol declaration location.
+func (w *workspace) location(sym *symbol) Location {
+	// Imports without aliases and generated definitions point to whole lines
+	path := sym.file.Path
+	src := w.source(path)
+	return Location{
+		URI:   pathToURI(path),
+		Range: src.nameRange(sym.line-1, sym.name),
+	}
+}
+
+// hover
+
+// hover returns a markdown symbol description.
+func (sym *symbol) hover() string {
+	b := &strings.Builder{}
+	b.WriteString("```spec\n")
+
+	switch sym.kind {
+	case symbolDefinition:
+		writeDefinition(b, sym.def)
+	case symbolField:
+		if sym.def.Type == model.DefinitionStruct {
+			fmt.Fprintf(b, "%v %v;\n", sym.name, sym.typ.SourceName())
+		} else {
+			fmt.Fprintf(b, "%v %v %d;\n", sym.name, sym.typ.SourceName(), sym.tag)
+		}
+	case symbolEnumValue:
+		fmt.Fprintf(b, "%v = %d;\n", sym.name, sym.number)
+	case symbolMethod:
+		fmt.Fprintf(b, "%v;\n", sym.method.Signature())
+	case symbolImport:
+		fmt.Fprintf(b, "import %v %q\n", sym.imp.Name, sym.imp.ID)
+	}
+	b.WriteString("```\n")
+
+	if sym.kind != symbolDefinition && sym.kind != symbolImport {
+		fmt.Fprintf(b, "\nIn %v %v\n", sym.def.Type, sym.def.Name)
+	}
+	if sym.doc != "" {
+		fmt.Fprintf(b, "\n%v\n", sym.doc)
+	}
+	return b.String()
+}
+
+func writeDefinition(b *strings.Builder, def *model.Definition) {
+	switch def.Type {
+	case model.DefinitionEnum:
+		fmt.Fprintf(b, "enum %v {\n", def.Name)
+		for _, v := range def.Enum.Values {
+			fmt.Fprintf(b, "    %v = %d;\n", v.Name, v.Number)
+		}
+
+	case model.DefinitionMessage:
+		fmt.Fprintf(b, "message %v {\n", def.Name)
+		for _, f := range def.Message.Fields.List {
+			fmt.Fprintf(b, "    %v %v %d;\n", f.Name, f.Type.SourceName(), f.Tag)
+		}
+
+	case model.DefinitionStruct:
+		keyword := "struct"
+		if def.Struct.Key {
+			keyword = "key struct"
+		}
+
+		fmt.Fprintf(b, "%v %v {\n", keyword, def.Name)
+		for _, f := range def.Struct.Fields.Values() {
+			fmt.Fprintf(b, "    %v %v;\n", f.Name, f.Type.SourceName())
+		}
+
+	case model.DefinitionService:
+		keyword := "service"
+		if def.Service.Sub {
+			keyword = "subservice"
+		}
+
+		fmt.Fprintf(b, "%v %v {\n", keyword, def.Name)
+		for _, m := range def.Service.Methods {
+			fmt.Fprintf(b, "    %v;\n", m.Signature())
+		}
+	}
+	b.WriteString("}\n")
+}
+
+// util
+
+// findDefinition returns a package definition including generated ones.
+func findDefinition(pkg *model.Package, name string) (*model.Definition, bool) {
+	for _, file := range pkg.Files {
+		if def, ok := file.DefinitionNames[name]; ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+func generated(def *model.Definition) bool {
+	return def.Type == model.DefinitionMessage && def.Message.Generated
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/module"
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// workspace holds open documents and the last successfully compiled packages.
+type workspace struct {
+	root        string   // Workspace root, empty when unknown
+	importPaths []string // Absolute import paths
+	parser      parser.Parser
+
+	docs      map[string]string         // Open document texts by paths
+	packages  map[string]*model.Package // Last compiled packages by directories
+	published map[string][]string       // Files with diagnostics by package directories
+}
+
+func newWorkspace(importPaths []string) *workspace {
+	return &workspace{
+		importPaths: importPaths,
+		parser:      parser.New(),
+
+		docs:      make(map[string]string),
+		packages:  make(map[string]*model.Package),
+		published: make(map[string][]string),
+	}
+}
+
+// source returns an open document or a file source.
+func (w *workspace) source(path string) *source {
+	text, ok := w.docs[path]
+	if !ok {
+		b, _ := os.ReadFile(path)
+		text = string(b)
+	}
+	return newSource(text)
+}
+
+// compile compiles a package from a directory with open documents,
+// caches and returns the package on success.
+func (w *workspace) compile(dir string) (*model.Package, error) {
+	p := &overlayParser{Parser: w.parser, docs: w.docs}
+	x := model.NewContext(p, w.importPaths)
+
+	id := w.packageID(dir)
+	resolver, err := module.Discover(dir)
+	if err != nil {
+		return nil, err
+	}
+	if resolver != nil {
+		x.Resolver = resolver
+		if mid, ok := resolver.PackageID(dir); ok {
+			id = mid
+		}
+	}
+
+	pkg, err := x.Compile(id, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	w.packages[dir] = pkg
+	return pkg, nil
+}
+
+// pkg returns the last compiled package in a directory, compiles it when not compiled yet.
+func (w *workspace) pkg(dir string) *model.Package {
+	pkg, ok := w.packages[dir]
+	if ok {
+		return pkg
+	}
+
+	pkg, _ = w.compile(dir)
+	return pkg
+}
+
+// file returns the last compiled file at a path.
+func (w *workspace) file(path string) *model.File {
+	pkg := w.pkg(filepath.Dir(path))
+	if pkg == nil {
+		return nil
+	}
+	return pkg.FileNames[filepath.Base(path)]
+}
+
+// packageID returns a package id relative to an import path, or a directory name.
+func (w *workspace) packageID(dir string) string {
+	for _, path := range w.importPaths {
+		rel, err := filepath.Rel(path, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(dir)
+}
+
+// packageDirs returns directories with spec files in the workspace root and with open documents.
+func (w *workspace) packageDirs() []string {
+	dirs := make(map[string]struct{})
+	for path := range w.docs {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+
+	if w.root != "" {
+		filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return nil
+			case d.IsDir():
+				name := d.Name()
+				if path != w.root && (strings.HasPrefix(name, ".") || name == "node_modules") {
+					return filepath.SkipDir
+				}
+			case filepath.Ext(path) == ".spec":
+				dirs[filepath.Dir(path)] = struct{}{}
+			}
+			return nil
+		})
+	}
+
+	result := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// diagnostics
+
+// check compiles a package in a directory and returns diagnostics by file paths,
+// including empty diagnostics for files with previously published diagnostics.
+func (w *workspace) check(dir string) map[string][]Diagnostic {
+	result := make(map[string][]Diagnostic)
+	for _, path := range w.published[dir] {
+		result[path] = []Diagnostic{}
+	}
+	delete(w.published, dir)
+
+	_, err := w.compile(dir)
+	if err == nil {
+		return result
+	}
+
+	path, line, column := syntax.Position(err)
+	if path == "" {
+		path = w.firstFile(dir)
+	}
+	if path == "" {
+		return result
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	src := w.source(path)
+	rng := src.lineRange(max(line-1, 0))
+	if column > 0 {
+		rng.Start.Character = utf16Column(src.line(line-1), column-1)
+	}
+
+	result[path] = []Diagnostic{{
+		Range:    rng,
+		Severity: SeverityError,
+		Source:   "spec",
+		Message:  err.Error(),
+	}}
+	w.published[dir] = []string{path}
+	return result
+}
+
+// firstFile returns the first spec file in a directory to report errors without positions.
+func (w *workspace) firstFile(dir string) string {
+	paths, _ := listFiles(dir, w.docs)
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
+}
+
+// overlay parser
+
+// overlayParser parses open documents instead of files on disk.
+type overlayParser struct {
+	parser.Parser
+	docs map[string]string
+}
+
+func (p *overlayParser) ParseFile(path string) (*syntax.File, error) {
+	text, ok := p.docs[path]
+	if !ok {
+		return p.Parser.ParseFile(path)
+	}
+	return p.Parser.ParseSource(path, text)
+}
+
+func (p *overlayParser) ParseDirectory(dir string) ([]*syntax.File, error) {
+	paths, err := listFiles(dir, p.docs)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*syntax.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := p.ParseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// listFiles returns sorted spec files in a directory, including open unsaved documents.
+func listFiles(dir string, docs map[string]string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.spec"))
+	if err != nil {
+		return nil, err
+	}
+
+	for path := range docs {
+		if filepath.Dir(path) != dir || filepath.Ext(path) != ".spec" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
@@ -16,10 +16,17 @@ import (
 type Context struct {
 	Parser      parser.Parser
 	ImportPaths []string // import paths
+	Resolver    Resolver // module resolver, nil when packages are not in a module
 
 	Packages map[string]*Package // compiled packages by ids
 }
 
+// Resolver resolves package ids into directories, i.e. in module requirements.
+type Resolver interface {
+	// Resolve returns a package directory, or false when the package is not found.
+	Resolve(id string) (string, bool, error)
+}
+
 // NewContext returns a new package context.
 func NewContext(parser parser.Parser, importPaths []string) *Context {
 	return &Context{
@@ -61,6 +68,17 @@ func (x *Context) getPackage(id string) (*Package, error) {
 		return x.compile(id, p)
 	}
 
+	// Try to resolve package in module
+	if x.Resolver != nil {
+		p, ok, err := x.Resolver.Resolve(id)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			return x.compile(id, p)
+		}
+	}
+
 	return nil, fmt.Errorf("package not found: %v", id)
 }
 
@@ -100,13 +118,9 @@ func (x *Context) compileFiles(id string, path string, files []*syntax.File) (*P
 	x.Packages[id] = pkg
 
 	// Resolve, compile, validate
-	if err := pkg.resolve(); err != nil {
-		return nil, err
-	}
-	if err := pkg.compile(); err != nil {
-		return nil, err
-	}
-	if err := pkg.validate(); err != nil {
+	if err := x.compilePackage(pkg); err != nil {
+		// Remove failed package, so that it is not returned by next compilations
+		delete(x.Packages, id)
 		return nil, err
 	}
 
@@ -114,3 +128,13 @@ func (x *Context) compileFiles(id string, path string, files []*syntax.File) (*P
 	pkg.Compiling = false
 	return pkg, nil
 }
+
+func (x *Context) compilePackage(pkg *Package) error {
+	if err := pkg.resolve(); err != nil {
+		return err
+	}
+	if err := pkg.compile(); err != nil {
+		return err
+	}
+	return pkg.validate()
+}
@@ -16,6 +16,8 @@ type Definition struct {
 
 	Name string
 	Type DefinitionType
+	Line int    // Source line, method line in generated definitions
+	Doc  string // Doc comment without comment markers
 
 	Enum    *Enum
 	Message *Message
@@ -35,6 +37,7 @@ func parseDefinition(pkg *Package, file *File, pdef *syntax.Definition) (*Defini
 
 		Name: pdef.Name,
 		Type: typ,
+		Line: pdef.Line,
 	}
 
 	if err := def.parse(pdef); err != nil {
@@ -0,0 +1,98 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// parseDocs sets doc comments from comment lines directly above definitions, fields and methods.
+func (f *File) parseDocs() {
+	for _, def := range f.Definitions {
+		def.Doc = f.doc(def.Line)
+
+		switch def.Type {
+		case DefinitionEnum:
+			for _, v := range def.Enum.Values {
+				v.Doc = f.doc(v.Line)
+			}
+
+		case DefinitionMessage:
+			f.parseFieldDocs(def.Message.Fields)
+
+		case DefinitionStruct:
+			for _, field := range def.Struct.Fields.Values() {
+				field.Doc = f.doc(field.Line)
+			}
+
+		case DefinitionService:
+			for _, m := range def.Service.Methods {
+				m.Doc = f.doc(m.Line)
+				f.parseFieldDocs(m._InputFields)
+				f.parseFieldDocs(m._OutputFields)
+			}
+		}
+	}
+}
+
+func (f *File) parseFieldDocs(fields *Fields) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		field.Doc = f.doc(field.Line)
+	}
+}
+
+// doc returns a doc comment which ends on the line before a line.
+func (f *File) doc(line int) string {
+	if line == 0 {
+		return ""
+	}
+
+	// Find last comment before line
+	i := len(f.Comments) - 1
+	for i >= 0 && f.Comments[i].Line >= line {
+		i--
+	}
+	if i < 0 {
+		return ""
+	}
+
+	// Collect adjacent comments
+	var lines []string
+	next := line
+	for ; i >= 0; i-- {
+		c := f.Comments[i]
+		if c.Trailing || c.End() != next-1 {
+			break
+		}
+
+		lines = append(commentLines(c), lines...)
+		next = c.Line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentLines returns comment text lines without comment markers.
+func commentLines(c *syntax.Comment) []string {
+	text := c.Text
+	if strings.HasPrefix(text, "//") {
+		text = strings.TrimPrefix(text, "//")
+		text = strings.TrimPrefix(text, " ")
+		return []string{strings.TrimRight(text, " \t")}
+	}
+
+	text = strings.TrimPrefix(text, "/*")
+	text = strings.TrimSuffix(text, "*/")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
@@ -57,20 +57,20 @@ func (e *Enum) parseValues(penum *syntax.Enum) error {
 func (e *Enum) parseValue(pval *syntax.EnumValue) error {
 	val, err := parseEnumValue(e, pval)
 	if err != nil {
-		return fmt.Errorf("%v.%v: %w", e.Def.Name, pval.Name, err)
+		return errorAt("", pval.Line, fmt.Errorf("%v.%v: %w", e.Def.Name, pval.Name, err))
 	}
 
 	// Check name
 	_, ok := e.ValueNames[val.Name]
 	if ok {
-		return fmt.Errorf("%v.%v: duplicate enum value", e.Def.Name, val.Name)
+		return errorAt("", val.Line, fmt.Errorf("%v.%v: duplicate enum value", e.Def.Name, val.Name))
 	}
 
 	// Check number
 	_, ok = e.ValueNumbers[val.Number]
 	if ok {
-		return fmt.Errorf("%v.%v: duplicate enum value number, number=%v",
-			e.Def.Name, val.Name, val.Number)
+		return errorAt("", val.Line, fmt.Errorf("%v.%v: duplicate enum value number, number=%v",
+			e.Def.Name, val.Name, val.Number))
 	}
 
 	// Add value
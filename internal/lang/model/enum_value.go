@@ -11,6 +11,8 @@ type EnumValue struct {
 
 	Name   string
 	Number int
+	Line   int    // Source line
+	Doc    string // Doc comment without comment markers
 }
 
 func parseEnumValue(enum *Enum, pval *syntax.EnumValue) (*EnumValue, error) {
@@ -18,6 +20,7 @@ func parseEnumValue(enum *Enum, pval *syntax.EnumValue) (*EnumValue, error) {
 		Enum:   enum,
 		Name:   pval.Name,
 		Number: pval.Value,
+		Line:   pval.Line,
 	}
 	return v, nil
 }
@@ -24,6 +24,8 @@ type File struct {
 
 	Definitions     []*Definition
 	DefinitionNames map[string]*Definition
+
+	Comments []*syntax.Comment // Source comments
 }
 
 func newFile(pkg *Package, pfile *syntax.File) (*File, error) {
@@ -38,6 +40,8 @@ func newFile(pkg *Package, pfile *syntax.File) (*File, error) {
 		ImportMap:       make(map[string]*Import),
 		OptionMap:       make(map[string]*Option),
 		DefinitionNames: make(map[string]*Definition),
+
+		Comments: pfile.Comments,
 	}
 
 	if err := f.parseImports(pfile); err != nil {
@@ -49,6 +53,7 @@ func newFile(pkg *Package, pfile *syntax.File) (*File, error) {
 	if err := f.parseDefinitions(pfile); err != nil {
 		return nil, err
 	}
+	f.parseDocs()
 	return f, nil
 }
 
@@ -76,12 +81,12 @@ func (f *File) parseImports(pfile *syntax.File) error {
 func (f *File) parseImport(pimp *syntax.Import) error {
 	imp, err := newImport(f, pimp)
 	if err != nil {
-		return fmt.Errorf("%v: %w", f.Path, err)
+		return errorAt(f.Path, pimp.Line, fmt.Errorf("%v: %w", f.Path, err))
 	}
 
 	_, ok := f.ImportMap[imp.Name]
 	if ok {
-		return fmt.Errorf("%v: duplicate import %q", f.Path, imp.Name)
+		return errorAt(f.Path, imp.Line, fmt.Errorf("%v: duplicate import %q", f.Path, imp.Name))
 	}
 
 	f.Imports = append(f.Imports, imp)
@@ -130,7 +135,7 @@ func (f *File) parseDefinitions(pfile *syntax.File) error {
 func (f *File) parseDefinition(pdef *syntax.Definition) error {
 	def, err := parseDefinition(f.Package, f, pdef)
 	if err != nil {
-		return fmt.Errorf("%v: %w", f.Path, err)
+		return errorAt(f.Path, pdef.Line, fmt.Errorf("%v: %w", f.Path, err))
 	}
 
 	return f.add(def)
@@ -141,7 +146,7 @@ func (f *File) parseDefinition(pdef *syntax.Definition) error {
 func (f *File) resolveImports() error {
 	for _, imp := range f.Imports {
 		if err := imp.resolve(); err != nil {
-			return fmt.Errorf("%v: %w", f.Name, err)
+			return errorAt(f.Path, imp.Line, fmt.Errorf("%v: %w", f.Name, err))
 		}
 	}
 	return nil
@@ -150,7 +155,7 @@ func (f *File) resolveImports() error {
 func (f *File) resolve() error {
 	for _, def := range f.Definitions {
 		if err := def.resolve(f); err != nil {
-			return fmt.Errorf("%v: %w", f.Name, err)
+			return errorAt(f.Path, def.Line, fmt.Errorf("%v: %w", f.Name, err))
 		}
 	}
 	return nil
@@ -161,7 +166,7 @@ func (f *File) resolve() error {
 func (f *File) compile() error {
 	for _, def := range f.Definitions {
 		if err := def.compile(); err != nil {
-			return fmt.Errorf("%v: %w", f.Path, err)
+			return errorAt(f.Path, def.Line, fmt.Errorf("%v: %w", f.Path, err))
 		}
 	}
 	return nil
@@ -172,7 +177,7 @@ func (f *File) compile() error {
 func (f *File) validate() error {
 	for _, def := range f.Definitions {
 		if err := def.validate(); err != nil {
-			return fmt.Errorf("%v: %w", f.Path, err)
+			return errorAt(f.Path, def.Line, fmt.Errorf("%v: %w", f.Path, err))
 		}
 	}
 	return nil
@@ -183,7 +188,7 @@ func (f *File) validate() error {
 func (f *File) add(def *Definition) error {
 	_, ok := f.DefinitionNames[def.Name]
 	if ok {
-		return fmt.Errorf("%v: duplicate definition %q", f.Path, def.Name)
+		return errorAt(f.Path, def.Line, fmt.Errorf("%v: duplicate definition %q", f.Path, def.Name))
 	}
 
 	f.Definitions = append(f.Definitions, def)
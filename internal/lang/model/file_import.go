@@ -18,6 +18,7 @@ type Import struct {
 	ID      string   // full id
 	Name    string   // name or alias
 	Package *Package // resolved imported package
+	Line    int      // Source line
 
 	Resolved bool
 }
@@ -37,6 +38,7 @@ func newImport(file *File, pimp *syntax.Import) (*Import, error) {
 		File: file,
 		ID:   pimp.ID,
 		Name: name,
+		Line: pimp.Line,
 	}
 	return imp, nil
 }
@@ -14,6 +14,8 @@ type Field struct {
 	Name string
 	Tag  int
 	Type *Type
+	Line int    // Source line
+	Doc  string // Doc comment without comment markers
 }
 
 func newField(pfield *syntax.Field) (*Field, error) {
@@ -31,13 +33,14 @@ func newField(pfield *syntax.Field) (*Field, error) {
 		Name: pfield.Name,
 		Tag:  pfield.Tag,
 		Type: type_,
+		Line: pfield.Line,
 	}
 	return f, nil
 }
 
 func (f *Field) resolve(file *File) error {
 	if err := f.Type.resolve(file); err != nil {
-		return fmt.Errorf("%v: %w", f.Name, err)
+		return errorAt("", f.Line, fmt.Errorf("%v: %w", f.Name, err))
 	}
 	return nil
 }
@@ -48,7 +51,7 @@ func (f *Field) resolved() error {
 		return nil
 	}
 	if ref.Type == DefinitionService {
-		return fmt.Errorf("invalid field %q: service type not allowed", f.Name)
+		return errorAt("", f.Line, fmt.Errorf("invalid field %q: service type not allowed", f.Name))
 	}
 	return nil
 }
@@ -71,17 +74,18 @@ func newFields(pfields []*syntax.Field) (*Fields, error) {
 	for _, pfield := range pfields {
 		field, err := newField(pfield)
 		if err != nil {
-			return nil, fmt.Errorf("invalid field %q: %w", pfield.Name, err)
+			return nil, errorAt("", pfield.Line, fmt.Errorf("invalid field %q: %w", pfield.Name, err))
 		}
 
 		_, ok := fields.Tags[field.Tag]
 		if ok {
-			return nil, fmt.Errorf("invalid field %q: duplicate tag %d", field.Name, field.Tag)
+			return nil, errorAt("", field.Line,
+				fmt.Errorf("invalid field %q: duplicate tag %d", field.Name, field.Tag))
 		}
 
 		_, ok = fields.Names[field.Name]
 		if ok {
-			return nil, fmt.Errorf("duplicate field %q", field.Name)
+			return nil, errorAt("", field.Line, fmt.Errorf("duplicate field %q", field.Name))
 		}
 
 		fields.List = append(fields.List, field)
@@ -6,6 +6,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/basecomplextech/spec/internal/lang/syntax"
 )
@@ -27,7 +28,9 @@ type Method struct {
 
 	Name   string
 	Type   MethodType
-	Oneway bool // Oneway method
+	Oneway bool   // Oneway method
+	Line   int    // Source line
+	Doc    string // Doc comment without comment markers
 
 	Request    *Type // Message type
 	Response   *Type // Message type
@@ -48,6 +51,7 @@ func parseMethod(pkg *Package, file *File, service *Service, pm *syntax.Method)
 
 		Name:   pm.Name,
 		Oneway: pm.Oneway,
+		Line:   pm.Line,
 	}
 
 	if err := m.parseInput(pm); err != nil {
@@ -62,6 +66,39 @@ func parseMethod(pkg *Package, file *File, service *Service, pm *syntax.Method)
 	return m, nil
 }
 
+// Signature returns a method signature as written in a source file,
+// generated requests and responses are inlined.
+func (m *Method) Signature() string {
+	b := &strings.Builder{}
+	b.WriteString(m.Name)
+	b.WriteString("(")
+	b.WriteString(methodTypeSignature(m.Request))
+	b.WriteString(")")
+
+	if ch := m.Channel; ch != nil {
+		var parts []string
+		if ch.In != nil {
+			parts = append(parts, "<-"+ch.In.SourceName())
+		}
+		if ch.Out != nil {
+			parts = append(parts, ch.Out.SourceName()+"->")
+		}
+		fmt.Fprintf(b, " (%v)", strings.Join(parts, ", "))
+	}
+
+	switch {
+	case m.Oneway:
+		b.WriteString(" oneway")
+	case m.Subservice != nil:
+		b.WriteString(" " + m.Subservice.SourceName())
+	case m.Response != nil && m.Response.Ref.Message.Generated:
+		fmt.Fprintf(b, " (%v)", methodTypeSignature(m.Response))
+	case m.Response != nil:
+		b.WriteString(" " + m.Response.SourceName())
+	}
+	return b.String()
+}
+
 // parse
 
 func (m *Method) parse(pm *syntax.Method) error {
@@ -288,6 +325,7 @@ func generateMethodRequest(m *Method, fields *Fields) (*Type, error) {
 	if err != nil {
 		return nil, err
 	}
+	def.Line = m.Line
 
 	// Return type
 	typ := newTypeRef(def)
@@ -305,8 +343,28 @@ func generateMethodResponse(m *Method, fields *Fields) (*Type, error) {
 	if err != nil {
 		return nil, err
 	}
+	def.Line = m.Line
 
 	// Return type
 	typ := newTypeRef(def)
 	return typ, nil
 }
+
+// util
+
+// methodTypeSignature returns a request/response type name or inlined generated message fields.
+func methodTypeSignature(typ *Type) string {
+	if typ == nil {
+		return ""
+	}
+	if !typ.Ref.Message.Generated {
+		return typ.SourceName()
+	}
+
+	fields := typ.Ref.Message.Fields.List
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%v %v %d", f.Name, f.Type.SourceName(), f.Tag))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -7,6 +7,7 @@ package model
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/basecomplextech/spec/internal/lang/syntax"
 )
@@ -62,6 +63,39 @@ func parsePackage(ctx *Context, id string, path string, pfiles []*syntax.File) (
 	return pkg, nil
 }
 
+// Transitive returns packages and their transitive imports sorted by ids.
+func Transitive(pkgs []*Package) []*Package {
+	seen := make(map[string]*Package)
+
+	var add func(pkg *Package)
+	add = func(pkg *Package) {
+		if _, ok := seen[pkg.ID]; ok {
+			return
+		}
+		seen[pkg.ID] = pkg
+
+		for _, file := range pkg.Files {
+			for _, imp := range file.Imports {
+				if imp.Package != nil {
+					add(imp.Package)
+				}
+			}
+		}
+	}
+	for _, pkg := range pkgs {
+		add(pkg)
+	}
+
+	result := make([]*Package, 0, len(seen))
+	for _, pkg := range seen {
+		result = append(result, pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
 func (p *Package) lookupType(name string) (*Definition, bool) {
 	def, ok := p.DefinitionNames[name]
 	return def, ok
@@ -109,7 +143,8 @@ func (p *Package) parseDefinitions() error {
 		for _, def := range file.Definitions {
 			_, ok := p.DefinitionNames[def.Name]
 			if ok {
-				return fmt.Errorf("%v: duplicate definition %q", file.Path, def.Name)
+				return errorAt(file.Path, def.Line,
+					fmt.Errorf("%v: duplicate definition %q", file.Path, def.Name))
 			}
 
 			p.Definitions = append(p.Definitions, def)
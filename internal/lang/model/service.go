@@ -50,12 +50,12 @@ func (s *Service) parseMethods(ps *syntax.Service) error {
 func (s *Service) parseMethod(pm *syntax.Method) error {
 	method, err := parseMethod(s.Package, s.File, s, pm)
 	if err != nil {
-		return fmt.Errorf("%v.%v: %w", s.Def.Name, pm.Name, err)
+		return errorAt("", pm.Line, fmt.Errorf("%v.%v: %w", s.Def.Name, pm.Name, err))
 	}
 
 	_, ok := s.MethodNames[method.Name]
 	if ok {
-		return fmt.Errorf("%v.%v: duplicate method", s.Def.Name, pm.Name)
+		return errorAt("", pm.Line, fmt.Errorf("%v.%v: duplicate method", s.Def.Name, pm.Name))
 	}
 
 	s.Methods = append(s.Methods, method)
@@ -68,7 +68,7 @@ func (s *Service) parseMethod(pm *syntax.Method) error {
 func (s *Service) resolve(file *File) error {
 	for _, m := range s.Methods {
 		if err := m.resolve(file); err != nil {
-			return fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err)
+			return errorAt("", m.Line, fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err))
 		}
 	}
 	return nil
@@ -79,7 +79,7 @@ func (s *Service) resolve(file *File) error {
 func (s *Service) compile() error {
 	for _, m := range s.Methods {
 		if err := m.compile(); err != nil {
-			return fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err)
+			return errorAt("", m.Line, fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err))
 		}
 	}
 	return nil
@@ -15,6 +15,7 @@ type Struct struct {
 	Package *Package
 	File    *File
 	Def     *Definition
+	Key     bool // Key struct
 
 	Fields collect.OrderedMap[string, *StructField]
 }
@@ -24,6 +25,7 @@ func parseStruct(pkg *Package, file *File, def *Definition, ps *syntax.Struct) (
 		Package: pkg,
 		File:    file,
 		Def:     def,
+		Key:     ps.Key,
 
 		Fields: collect.NewOrderedMap[string, *StructField](),
 	}
@@ -48,12 +50,12 @@ func (s *Struct) parseFields(ps *syntax.Struct) error {
 func (s *Struct) parseField(pfield *syntax.StructField) error {
 	field, err := parseStructField(s, pfield)
 	if err != nil {
-		return fmt.Errorf("%v.%v: %w", s.Def.Name, pfield.Name, err)
+		return errorAt("", pfield.Line, fmt.Errorf("%v.%v: %w", s.Def.Name, pfield.Name, err))
 	}
 
 	_, ok := s.Fields.Get(field.Name)
 	if ok {
-		return fmt.Errorf("%v.%v: duplicate field", s.Def.Name, field.Name)
+		return errorAt("", field.Line, fmt.Errorf("%v.%v: duplicate field", s.Def.Name, field.Name))
 	}
 
 	s.Fields.Put(field.Name, field)
@@ -14,6 +14,8 @@ type StructField struct {
 	Struct *Struct
 	Name   string
 	Type   *Type
+	Line   int    // Source line
+	Doc    string // Doc comment without comment markers
 }
 
 func parseStructField(str *Struct, pfield *syntax.StructField) (*StructField, error) {
@@ -26,6 +28,7 @@ func parseStructField(str *Struct, pfield *syntax.StructField) (*StructField, er
 		Struct: str,
 		Name:   pfield.Name,
 		Type:   typ,
+		Line:   pfield.Line,
 	}
 	return f, nil
 }
@@ -34,7 +37,7 @@ func parseStructField(str *Struct, pfield *syntax.StructField) (*StructField, er
 
 func (f *StructField) resolve(file *File) error {
 	if err := f.Type.resolve(file); err != nil {
-		return fmt.Errorf("%v: %w", f.Name, err)
+		return errorAt("", f.Line, fmt.Errorf("%v: %w", f.Name, err))
 	}
 	return nil
 }
@@ -47,7 +50,7 @@ func (f *StructField) compile() error {
 		return nil
 	}
 	if ref.Type == DefinitionService {
-		return fmt.Errorf("%v: service type not allowed", f.Name)
+		return errorAt("", f.Line, fmt.Errorf("%v: service type not allowed", f.Name))
 	}
 	return nil
 }
@@ -56,6 +59,9 @@ func (f *StructField) compile() error {
 
 func (f *StructField) validate() error {
 	t := f.Type
+	if f.Struct.Key {
+		return f.validateKey()
+	}
 
 	switch {
 	case t.builtin():
@@ -66,6 +72,29 @@ func (f *StructField) validate() error {
 		return nil
 	}
 
-	return fmt.Errorf("%v: structs support only value types or other structs, actual=%v",
-		f.Name, t.Kind)
+	return errorAt("", f.Line, fmt.Errorf(
+		"%v: structs support only value types or other structs, actual=%v", f.Name, t.Kind))
+}
+
+func (f *StructField) validateKey() error {
+	t := f.Type
+
+	switch {
+	case t.Kind == KindAny || t.Kind == KindAnyMessage:
+		break
+	case t.builtin():
+		return nil
+	case t.Kind == KindEnum:
+		return nil
+	case t.Kind == KindStruct:
+		if t.Ref.Struct.Key {
+			return nil
+		}
+		return errorAt("", f.Line, fmt.Errorf(
+			"%v: key structs support only other key structs, actual=%v", f.Name, t.Name))
+	}
+
+	return errorAt("", f.Line, fmt.Errorf(
+		"%v: key structs support only scalar types, strings, bytes, enums or key structs, actual=%v",
+		f.Name, t.Kind))
 }
@@ -121,6 +121,17 @@ func newTypeRef(def *Definition) *Type {
 	return t
 }
 
+// SourceName returns a type name as written in a source file, i.e. "[]pkg.Type".
+func (t *Type) SourceName() string {
+	switch {
+	case t.Kind == KindList:
+		return "[]" + t.Element.SourceName()
+	case t.ImportName != "":
+		return t.ImportName + "." + t.Name
+	}
+	return t.Name
+}
+
 func (t *Type) builtin() bool {
 	_, ok := builtin[t.Kind]
 	return ok
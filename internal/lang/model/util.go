@@ -4,7 +4,11 @@
 
 package model
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
 
 func toUpperCamelCase(s string) string {
 	parts := strings.Split(s, "_")
@@ -15,3 +19,8 @@ func toUpperCamelCase(s string) string {
 	}
 	return strings.Join(parts, "")
 }
+
+// errorAt adds a source position to an error, the error message does not change.
+func errorAt(path string, line int, err error) error {
+	return &syntax.Error{Path: path, Line: line, Err: err}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package module implements spec.mod manifests and module-aware import resolution.
+//
+// A manifest declares a module path and its dependencies, the syntax follows go.mod:
+//
+//	module github.com/example/api
+//
+//	require (
+//	    github.com/example/common v1.2.0
+//	    github.com/example/events
+//	)
+//
+//	replace github.com/example/events => ../events
+//
+// Requirement versions may be omitted, then they are read from go.mod in the module root.
+// Imports are resolved in the module itself, in replaced directories, in the vendor directory
+// and in the Go module cache.
+package module
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Filename is a manifest file name.
+const Filename = "spec.mod"
+
+// Manifest is a parsed spec.mod file.
+type Manifest struct {
+	Path    string // Manifest file path
+	Module  string // Module path
+	Require []Require
+	Replace []Replace
+}
+
+// Require is a module requirement.
+type Require struct {
+	Path    string
+	Version string // Empty when taken from go.mod
+	Line    int
+}
+
+// Replace replaces a required module with a local directory.
+type Replace struct {
+	Path string
+	Dir  string // Directory relative to the module root
+	Line int
+}
+
+// Root returns the module root directory.
+func (m *Manifest) Root() string {
+	return filepath.Dir(m.Path)
+}
+
+// Find searches for a manifest in a directory and its parents, returns an empty path when not found.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, Filename)
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			return path, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load loads a manifest from a file.
+func Load(path string) (*Manifest, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v:%w", path, err)
+	}
+	m.Path = path
+	return m, nil
+}
+
+// Parse parses a manifest, errors are prefixed with line numbers.
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	block := "" // Current block directive
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		n := i + 1
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Block
+		if block != "" {
+			if len(fields) == 1 && fields[0] == ")" {
+				block = ""
+				continue
+			}
+			if err := m.directive(n, block, fields); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Directive
+		if len(fields) == 2 && fields[1] == "(" {
+			switch fields[0] {
+			case "require", "replace":
+				block = fields[0]
+				continue
+			}
+			return nil, fmt.Errorf("%d: unknown block %q", n, fields[0])
+		}
+		if err := m.directive(n, fields[0], fields[1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case block != "":
+		return nil, fmt.Errorf("%d: unterminated %v block", len(lines), block)
+	case m.Module == "":
+		return nil, fmt.Errorf("1: missing module directive")
+	}
+	return m, nil
+}
+
+// private
+
+func (m *Manifest) directive(line int, name string, args []string) error {
+	switch name {
+	case "module":
+		if len(args) != 1 {
+			return fmt.Errorf("%d: expected module path", line)
+		}
+		if m.Module != "" {
+			return fmt.Errorf("%d: duplicate module directive", line)
+		}
+		m.Module = args[0]
+
+	case "require":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("%d: expected module path and optional version", line)
+		}
+
+		req := Require{Path: args[0], Line: line}
+		if len(args) == 2 {
+			req.Version = args[1]
+		}
+		for _, r := range m.Require {
+			if r.Path == req.Path {
+				return fmt.Errorf("%d: duplicate requirement %v", line, req.Path)
+			}
+		}
+		m.Require = append(m.Require, req)
+
+	case "replace":
+		if len(args) != 3 || args[1] != "=>" {
+			return fmt.Errorf("%d: expected module path => directory", line)
+		}
+		m.Replace = append(m.Replace, Replace{Path: args[0], Dir: args[2], Line: line})
+
+	default:
+		return fmt.Errorf("%d: unknown directive %q", line, name)
+	}
+	return nil
+}
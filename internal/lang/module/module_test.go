@@ -0,0 +1,251 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWriteFile(t *testing.T, path string, text string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testResolver(t *testing.T, root string, manifest string) *Resolver {
+	testWriteFile(t, filepath.Join(root, Filename), manifest)
+
+	r, err := Discover(root)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	return r
+}
+
+// Parse
+
+func TestParse__should_parse_manifest(t *testing.T) {
+	src := `// Schemas
+module github.com/example/api
+
+require github.com/example/common v1.2.0
+require (
+    github.com/example/events // version from go.mod
+    github.com/example/Users v0.1.0
+)
+
+replace github.com/example/events => ../events
+`
+	m, err := Parse([]byte(src))
+	require.NoError(t, err)
+
+	assert.Equal(t, "github.com/example/api", m.Module)
+	assert.Equal(t, []Require{
+		{Path: "github.com/example/common", Version: "v1.2.0", Line: 4},
+		{Path: "github.com/example/events", Line: 6},
+		{Path: "github.com/example/Users", Version: "v0.1.0", Line: 7},
+	}, m.Require)
+	assert.Equal(t, []Replace{
+		{Path: "github.com/example/events", Dir: "../events", Line: 10},
+	}, m.Replace)
+}
+
+func TestParse__should_return_error_with_line(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{"require a v1\n", "1: missing module directive"},
+		{"module a\nmodule b\n", "2: duplicate module directive"},
+		{"module a\nrequire (\n  b v1\n", "4: unterminated require block"},
+		{"module a\nreplace b ../b\n", "2: expected module path => directory"},
+		{"module a\nexclude b v1\n", `2: unknown directive "exclude"`},
+		{"module a\nrequire b v1\nrequire b v2\n", "3: duplicate requirement b"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.src))
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+// Find
+
+func TestFind__should_find_manifest_in_parent_directories(t *testing.T) {
+	root := t.TempDir()
+	testWriteFile(t, filepath.Join(root, Filename), "module example\n")
+
+	dir := filepath.Join(root, "a", "b")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	path, err := Find(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, Filename), path)
+}
+
+// Resolve
+
+func TestResolver__should_resolve_module_packages(t *testing.T) {
+	root := t.TempDir()
+	r := testResolver(t, root, "module github.com/example/api\n")
+	testWriteFile(t, filepath.Join(root, "users", "users.spec"), "message User {}\n")
+
+	dir, ok, err := r.Resolve("github.com/example/api/users")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(root, "users"), dir)
+
+	id, ok := r.PackageID(dir)
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/example/api/users", id)
+
+	_, ok, err = r.Resolve("github.com/example/api/unknown")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestResolver__should_resolve_replaced_modules(t *testing.T) {
+	root := t.TempDir()
+	r := testResolver(t, filepath.Join(root, "api"), `module github.com/example/api
+require github.com/example/common
+replace github.com/example/common => ../common
+`)
+	testWriteFile(t, filepath.Join(root, "common", "ids", "ids.spec"), "struct ID { v int64; }\n")
+
+	dir, ok, err := r.Resolve("github.com/example/common/ids")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(root, "common", "ids"), dir)
+	assert.Empty(t, r.Unused())
+}
+
+func TestResolver__should_resolve_vendored_modules(t *testing.T) {
+	root := t.TempDir()
+	r := testResolver(t, root, "module api\nrequire github.com/example/common v1.0.0\n")
+
+	vendor := filepath.Join(root, "vendor", "github.com", "example", "common", "ids")
+	testWriteFile(t, filepath.Join(vendor, "ids.spec"), "struct ID { v int64; }\n")
+
+	dir, ok, err := r.Resolve("github.com/example/common/ids")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, vendor, dir)
+}
+
+func TestResolver__should_resolve_modules_in_go_module_cache(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("GOMODCACHE", cache)
+
+	root := t.TempDir()
+	testWriteFile(t, filepath.Join(root, "go.mod"), `module api
+
+require (
+	github.com/example/Common v1.3.0 // indirect
+)
+`)
+	r := testResolver(t, root, "module api\nrequire github.com/example/Common\n")
+
+	mod := filepath.Join(cache, "github.com", "example", "!common@v1.3.0")
+	testWriteFile(t, filepath.Join(mod, "ids", "ids.spec"), "struct ID { v int64; }\n")
+
+	dir, ok, err := r.Resolve("github.com/example/Common/ids")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(mod, "ids"), dir)
+}
+
+func TestResolver__should_resolve_packages_by_go_package(t *testing.T) {
+	root := t.TempDir()
+	r := testResolver(t, filepath.Join(root, "api"), `module github.com/example/api
+require github.com/example/common
+replace github.com/example/common => ../common
+`)
+	testWriteFile(t, filepath.Join(root, "common", "schema", "ids", "ids.spec"), `options (
+    go_package = "github.com/example/common/ids"
+)
+
+struct ID { v int64; }
+`)
+
+	dir, ok, err := r.Resolve("github.com/example/common/ids")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(root, "common", "schema", "ids"), dir)
+}
+
+func TestResolver__should_return_error_when_module_not_downloaded(t *testing.T) {
+	t.Setenv("GOMODCACHE", t.TempDir())
+
+	root := t.TempDir()
+	r := testResolver(t, root, "module api\nrequire github.com/example/common v1.0.0\n")
+
+	_, _, err := r.Resolve("github.com/example/common/ids")
+	assert.EqualError(t, err,
+		"module github.com/example/common@v1.0.0 not found in go module cache, run go mod download")
+}
+
+// Tidy
+
+func TestTidy__should_return_unresolved_imports(t *testing.T) {
+	root := t.TempDir()
+	testWriteFile(t, filepath.Join(root, Filename), "module api\n")
+	testWriteFile(t, filepath.Join(root, "users", "users.spec"), `import (
+    "api/ids"
+    "github.com/example/missing"
+)
+
+message User {}
+`)
+	testWriteFile(t, filepath.Join(root, "ids", "ids.spec"), "struct ID { v int64; }\n")
+
+	m, err := Load(filepath.Join(root, Filename))
+	require.NoError(t, err)
+
+	result, err := Tidy(m, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"api/ids", "api/users"}, result.Packages)
+	require.Len(t, result.Errors, 1)
+
+	e := result.Errors[0]
+	assert.Equal(t, filepath.Join(root, "users", "users.spec"), e.File)
+	assert.Equal(t, 3, e.Line)
+	assert.Contains(t, e.Error(), "package not found: github.com/example/missing")
+}
+
+func TestTidy__should_return_unused_requirements(t *testing.T) {
+	root := t.TempDir()
+	testWriteFile(t, filepath.Join(root, "api", Filename), `module api
+require github.com/example/common
+require github.com/example/unused
+replace github.com/example/common => ../common
+replace github.com/example/unused => ../common
+`)
+	testWriteFile(t, filepath.Join(root, "api", "users", "users.spec"), `import (
+    "github.com/example/common/ids"
+)
+
+message User {
+    id ids.ID 1;
+}
+`)
+	testWriteFile(t, filepath.Join(root, "common", "ids", "ids.spec"), "struct ID { v int64; }\n")
+
+	m, err := Load(filepath.Join(root, "api", Filename))
+	require.NoError(t, err)
+
+	result, err := Tidy(m, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []Require{{Path: "github.com/example/unused", Line: 3}}, result.Unused)
+}
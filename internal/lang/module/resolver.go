@@ -0,0 +1,337 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package module
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+)
+
+// OptionGoPackage maps spec packages to go packages.
+const OptionGoPackage = "go_package"
+
+// Resolver resolves package ids into directories in a module and its requirements.
+//
+// An id is resolved in a module directory by its path relative to the module path,
+// or by a package with a matching go_package option when no such directory exists.
+type Resolver struct {
+	manifest *Manifest
+	root     string
+	modCache string // Go module cache, empty when unknown
+	parser   parser.Parser
+
+	versions map[string]string            // Requirement versions from go.mod
+	indexes  map[string]map[string]string // Package directories by go_package by module directories
+	used     map[string]struct{}          // Used requirement paths
+}
+
+// NewResolver returns a resolver for a manifest.
+func NewResolver(m *Manifest) *Resolver {
+	r := &Resolver{
+		manifest: m,
+		root:     m.Root(),
+		modCache: moduleCache(),
+		parser:   parser.New(),
+
+		indexes: make(map[string]map[string]string),
+		used:    make(map[string]struct{}),
+	}
+	r.versions = readGoVersions(filepath.Join(r.root, "go.mod"))
+	return r
+}
+
+// Discover returns a resolver for a manifest in a directory or its parents, or nil when not found.
+func Discover(dir string) (*Resolver, error) {
+	path, err := Find(dir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+
+	m, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(m), nil
+}
+
+// Manifest returns the resolver manifest.
+func (r *Resolver) Manifest() *Manifest {
+	return r.manifest
+}
+
+// PackageID returns a package id of a directory in the module, i.e. "github.com/example/api/users".
+func (r *Resolver) PackageID(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(r.root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	if rel == "." {
+		return r.manifest.Module, true
+	}
+	return path.Join(r.manifest.Module, filepath.ToSlash(rel)), true
+}
+
+// Resolve returns a package directory, or false when no module provides the package.
+func (r *Resolver) Resolve(id string) (string, bool, error) {
+	// Module package
+	if rel, ok := trimModule(id, r.manifest.Module); ok {
+		return r.resolveIn(r.root, id, rel)
+	}
+
+	// Required package
+	req, rel, ok := r.lookupRequire(id)
+	if !ok {
+		return "", false, nil
+	}
+
+	dir, err := r.moduleDir(req)
+	if err != nil {
+		return "", false, err
+	}
+
+	dir, ok, err = r.resolveIn(dir, id, rel)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	r.used[req.Path] = struct{}{}
+	return dir, true, nil
+}
+
+// Unused returns requirements which have not been used to resolve packages.
+func (r *Resolver) Unused() []Require {
+	var unused []Require
+	for _, req := range r.manifest.Require {
+		if _, ok := r.used[req.Path]; !ok {
+			unused = append(unused, req)
+		}
+	}
+	return unused
+}
+
+// private
+
+func (r *Resolver) resolveIn(moduleDir string, id string, rel string) (string, bool, error) {
+	dir := filepath.Join(moduleDir, filepath.FromSlash(rel))
+	if hasSpecFiles(dir) {
+		return dir, true, nil
+	}
+
+	index, err := r.index(moduleDir)
+	if err != nil {
+		return "", false, err
+	}
+
+	dir, ok := index[id]
+	return dir, ok, nil
+}
+
+func (r *Resolver) lookupRequire(id string) (Require, string, bool) {
+	var best Require
+	var bestRel string
+	found := false
+
+	for _, req := range r.manifest.Require {
+		rel, ok := trimModule(id, req.Path)
+		if !ok {
+			continue
+		}
+		if !found || len(req.Path) > len(best.Path) {
+			best, bestRel, found = req, rel, true
+		}
+	}
+	return best, bestRel, found
+}
+
+// moduleDir returns a required module directory.
+func (r *Resolver) moduleDir(req Require) (string, error) {
+	// Replaced
+	for _, rep := range r.manifest.Replace {
+		if rep.Path == req.Path {
+			dir := rep.Dir
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(r.root, dir)
+			}
+			return dir, nil
+		}
+	}
+
+	// Vendored
+	vendor := filepath.Join(r.root, "vendor", filepath.FromSlash(req.Path))
+	if isDir(vendor) {
+		return vendor, nil
+	}
+
+	// Module cache
+	version := req.Version
+	if version == "" {
+		version = r.versions[req.Path]
+	}
+	if version == "" {
+		return "", fmt.Errorf("%v:%d: no version for module %v in spec.mod or go.mod",
+			r.manifest.Path, req.Line, req.Path)
+	}
+	if r.modCache == "" {
+		return "", fmt.Errorf("module %v@%v: go module cache not found", req.Path, version)
+	}
+
+	dir := filepath.Join(r.modCache, escapePath(req.Path)+"@"+escapePath(version))
+	if !isDir(dir) {
+		return "", fmt.Errorf("module %v@%v not found in go module cache, run go mod download",
+			req.Path, version)
+	}
+	return dir, nil
+}
+
+// index returns package directories by go_package options in a module directory.
+func (r *Resolver) index(moduleDir string) (map[string]string, error) {
+	index, ok := r.indexes[moduleDir]
+	if ok {
+		return index, nil
+	}
+
+	index = make(map[string]string)
+	err := filepath.WalkDir(moduleDir, func(p string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir():
+			if p != moduleDir && (skipDir(d.Name()) || isModule(p)) {
+				return filepath.SkipDir
+			}
+			return nil
+		case filepath.Ext(p) != ".spec":
+			return nil
+		}
+
+		// Skip invalid files, they are reported when compiled
+		file, err := r.parser.ParseFile(p)
+		if err != nil {
+			return nil
+		}
+		for _, opt := range file.Options {
+			if opt.Name == OptionGoPackage {
+				if _, ok := index[opt.Value]; !ok {
+					index[opt.Value] = filepath.Dir(p)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.indexes[moduleDir] = index
+	return index, nil
+}
+
+// util
+
+// trimModule returns a package path relative to a module path.
+func trimModule(id string, module string) (string, bool) {
+	switch {
+	case id == module:
+		return "", true
+	case strings.HasPrefix(id, module+"/"):
+		return id[len(module)+1:], true
+	}
+	return "", false
+}
+
+// escapePath escapes upper case letters in module paths and versions as in the go module cache.
+func escapePath(s string) string {
+	b := &strings.Builder{}
+	for _, c := range s {
+		if unicode.IsUpper(c) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(c))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// moduleCache returns the go module cache directory.
+func moduleCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// readGoVersions returns required module versions from a go.mod file.
+func readGoVersions(path string) map[string]string {
+	versions := make(map[string]string)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return versions
+	}
+
+	block := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case block && fields[0] == ")":
+			block = false
+		case block && len(fields) >= 2:
+			versions[fields[0]] = fields[1]
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			block = true
+		case fields[0] == "require" && len(fields) >= 3:
+			versions[fields[1]] = fields[2]
+		}
+	}
+	return versions
+}
+
+func hasSpecFiles(dir string) bool {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.spec"))
+	return len(paths) > 0
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// isModule returns true when a directory contains a manifest, nested modules are skipped.
+func isModule(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, Filename))
+	return err == nil
+}
+
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || name == "node_modules" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package module
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// TidyResult is a module check result.
+type TidyResult struct {
+	Packages []string  // Package ids in the module
+	Errors   []Error   // Unresolved imports and compile errors
+	Unused   []Require // Unused requirements, empty when there are errors
+}
+
+// Error is a package error.
+type Error struct {
+	Package string // Package id
+	File    string // File path, empty when unknown
+	Line    int    // Line, zero when unknown
+	Err     error
+}
+
+// Error returns "file:line: error", or "package: error" without a position.
+func (e Error) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%v:%d: %v", e.File, e.Line, e.Err)
+	case e.File != "":
+		return fmt.Sprintf("%v: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%v: %v", e.Package, e.Err)
+}
+
+// Tidy compiles all packages in a module and verifies that their imports resolve.
+//
+// Imports are resolved in the import paths first, then in the module and its requirements.
+// Unused requirements are only reported when all packages compile.
+func Tidy(m *Manifest, importPaths []string) (*TidyResult, error) {
+	r := NewResolver(m)
+
+	dirs, err := packageDirs(r.root)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TidyResult{}
+	p := parser.New()
+
+	for _, dir := range dirs {
+		id, _ := r.PackageID(dir)
+		result.Packages = append(result.Packages, id)
+
+		x := model.NewContext(p, importPaths)
+		x.Resolver = r
+
+		if _, err := x.Compile(id, dir); err != nil {
+			path, line, _ := syntax.Position(err)
+			result.Errors = append(result.Errors, Error{
+				Package: id,
+				File:    path,
+				Line:    line,
+				Err:     err,
+			})
+		}
+	}
+
+	if len(result.Errors) == 0 {
+		result.Unused = r.Unused()
+	}
+	return result, nil
+}
+
+// packageDirs returns sorted directories with spec files in a module.
+func packageDirs(root string) ([]string, error) {
+	var dirs []string
+	seen := make(map[string]struct{})
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir():
+			if path != root && (skipDir(d.Name()) || isModule(path)) {
+				return filepath.SkipDir
+			}
+			return nil
+		case filepath.Ext(path) != ".spec":
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if _, ok := seen[dir]; !ok {
+			seen[dir] = struct{}{}
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
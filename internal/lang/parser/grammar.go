@@ -11,6 +11,10 @@ import (
 
 type yySymType struct {
 	yys int
+	// Positions
+	line int // line of the first token
+	end  int // line of the last token, used in import/option blocks
+
 	// Tokens
 	ident   string
 	bool    bool
@@ -58,16 +62,17 @@ type yySymType struct {
 const ANY = 57346
 const ENUM = 57347
 const IMPORT = 57348
-const MESSAGE = 57349
-const ONEWAY = 57350
-const OPTIONS = 57351
-const STRUCT = 57352
-const SERVICE = 57353
-const SUBSERVICE = 57354
-const IDENT = 57355
-const INTEGER = 57356
-const STRING = 57357
-const METHOD_OUTPUT = 57358
+const KEY = 57349
+const MESSAGE = 57350
+const ONEWAY = 57351
+const OPTIONS = 57352
+const STRUCT = 57353
+const SERVICE = 57354
+const SUBSERVICE = 57355
+const IDENT = 57356
+const INTEGER = 57357
+const STRING = 57358
+const METHOD_OUTPUT = 57359
 
 var yyToknames = [...]string{
 	"$end",
@@ -76,6 +81,7 @@ var yyToknames = [...]string{
 	"ANY",
 	"ENUM",
 	"IMPORT",
+	"KEY",
 	"MESSAGE",
 	"ONEWAY",
 	"OPTIONS",
@@ -111,133 +117,141 @@ var yyExca = [...]int8{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 98,
-	18, 24,
-	27, 24,
-	28, 24,
-	-2, 1,
-	-1, 99,
-	18, 26,
-	27, 26,
+	-1, 106,
+	19, 26,
 	28, 26,
+	29, 26,
+	-2, 1,
+	-1, 107,
+	19, 28,
+	28, 28,
+	29, 28,
 	-2, 3,
-	-1, 100,
-	18, 27,
-	27, 27,
-	28, 27,
-	-2, 5,
+	-1, 108,
+	19, 29,
+	28, 29,
+	29, 29,
+	-2, 7,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 212
+const yyLast = 243
 
 var yyAct = [...]uint8{
-	63, 112, 111, 102, 64, 91, 110, 103, 43, 99,
-	55, 48, 100, 139, 50, 51, 52, 53, 98, 67,
-	130, 129, 68, 128, 129, 65, 138, 127, 66, 128,
-	125, 67, 113, 115, 68, 65, 119, 123, 126, 121,
-	66, 106, 105, 115, 44, 124, 104, 65, 67, 60,
-	56, 68, 93, 88, 113, 62, 77, 66, 39, 38,
-	37, 95, 71, 74, 74, 36, 35, 81, 80, 89,
-	44, 78, 82, 47, 67, 48, 49, 68, 50, 51,
-	52, 53, 45, 66, 76, 86, 40, 109, 94, 96,
-	142, 97, 141, 75, 33, 107, 114, 94, 108, 31,
-	47, 122, 48, 49, 120, 50, 51, 52, 53, 45,
-	47, 117, 48, 49, 84, 50, 51, 52, 53, 45,
-	72, 116, 8, 131, 6, 134, 133, 136, 137, 135,
-	69, 140, 47, 67, 48, 49, 68, 50, 51, 52,
-	53, 45, 66, 5, 3, 57, 34, 132, 47, 65,
-	48, 49, 58, 50, 51, 52, 53, 45, 99, 85,
-	48, 100, 79, 50, 51, 52, 53, 98, 25, 16,
-	24, 17, 87, 23, 18, 19, 20, 67, 30, 29,
-	68, 28, 27, 26, 118, 61, 66, 1, 101, 92,
-	90, 83, 73, 15, 14, 54, 70, 13, 42, 12,
-	41, 59, 11, 7, 10, 4, 21, 32, 2, 9,
-	22, 46,
+	69, 120, 119, 110, 70, 99, 118, 111, 46, 147,
+	59, 61, 107, 51, 52, 53, 108, 138, 55, 56,
+	57, 58, 106, 137, 50, 51, 52, 53, 54, 71,
+	55, 56, 57, 58, 48, 146, 121, 123, 73, 73,
+	73, 135, 74, 74, 74, 90, 47, 136, 72, 72,
+	72, 127, 66, 117, 62, 71, 84, 71, 136, 137,
+	133, 115, 60, 123, 121, 131, 129, 77, 134, 81,
+	81, 78, 87, 132, 114, 113, 47, 85, 89, 112,
+	96, 50, 51, 52, 53, 54, 77, 55, 56, 57,
+	58, 48, 94, 68, 42, 41, 102, 104, 39, 105,
+	38, 88, 82, 37, 122, 102, 116, 50, 51, 52,
+	53, 54, 128, 55, 56, 57, 58, 48, 26, 83,
+	25, 43, 35, 24, 150, 149, 130, 33, 79, 125,
+	124, 139, 92, 142, 141, 144, 145, 143, 8, 148,
+	50, 51, 52, 53, 54, 6, 55, 56, 57, 58,
+	48, 63, 95, 36, 140, 93, 50, 51, 52, 53,
+	54, 75, 55, 56, 57, 58, 48, 40, 73, 86,
+	73, 73, 74, 101, 74, 74, 32, 64, 72, 31,
+	72, 72, 103, 29, 28, 27, 30, 71, 5, 3,
+	97, 50, 51, 52, 53, 54, 126, 55, 56, 57,
+	58, 48, 107, 51, 52, 53, 108, 67, 55, 56,
+	57, 58, 106, 16, 1, 19, 17, 109, 100, 18,
+	20, 21, 98, 91, 80, 15, 14, 76, 13, 45,
+	12, 44, 65, 11, 7, 10, 4, 22, 34, 2,
+	9, 23, 49,
 }
 
 var yyPact = [...]int16{
-	138, -1000, 134, 107, -1000, 105, -1000, 164, -1000, 155,
-	-1000, -1000, -1000, -1000, -1000, -1000, 170, 169, 168, 166,
-	165, 81, -1000, -1000, -1000, 131, 43, 42, 37, 36,
-	35, -1000, -1000, 67, -1000, -1000, 144, -1000, -1000, -1000,
-	130, 128, 30, -1000, 129, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 106, 96, 69, -1000, -1000, -1000,
-	65, 32, 144, 148, -1000, 47, 45, -1000, -1000, -1000,
-	-1000, 129, -1000, -1000, 97, -1000, 145, -1000, -1000, -1000,
-	173, 159, 28, 44, 154, 21, -1000, -1000, -1000, -1000,
-	17, 16, 70, -1000, -1000, 5, 103, 93, 45, -1000,
-	-1000, 10, -1000, 129, -1000, -1000, -1000, -1000, 14, 144,
-	83, 19, 12, -1, -4, -9, -1000, -1000, -1000, 144,
-	133, -1000, -1000, -1000, 15, -1000, 27, 129, -2, -16,
-	129, -1000, -1000, 74, -7, 72, 2, -1000, -1000, -1000,
-	-1000, -1000, -1000,
+	183, -1000, 178, 127, -1000, 120, -1000, 208, -1000, 104,
+	-1000, -1000, -1000, -1000, -1000, -1000, 171, 170, 169, 175,
+	165, 162, 108, -1000, -1000, -1000, 137, 79, 76, 74,
+	153, 71, 70, -1000, -1000, 101, -1000, -1000, 187, -1000,
+	38, -1000, -1000, 135, 152, 67, -1000, 166, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 136,
+	-1000, 103, 77, -1000, -1000, -1000, 99, 31, 187, 154,
+	-1000, 50, 78, -1000, -1000, -1000, -1000, 166, 20, -1000,
+	-1000, 114, -1000, 140, -1000, -1000, -1000, 167, 138, 54,
+	-1000, 164, 198, 53, -1000, -1000, -1000, -1000, 49, 48,
+	35, -1000, -1000, 8, 111, 110, 78, -1000, -1000, 24,
+	-1000, 166, -1000, -1000, -1000, -1000, 40, 187, 107, 46,
+	41, 12, 30, -13, -1000, -1000, -1000, 187, 139, -1000,
+	-1000, -1000, 34, -1000, 36, 166, 6, -21, 166, -1000,
+	-1000, 106, -6, 105, 19, -1000, -1000, -1000, -1000, -1000,
+	-1000,
 }
 
 var yyPgo = [...]uint8{
-	0, 211, 7, 210, 209, 208, 207, 206, 205, 0,
-	4, 204, 203, 202, 201, 200, 199, 8, 198, 197,
-	196, 195, 194, 193, 10, 192, 191, 190, 5, 189,
-	2, 1, 3, 188, 6, 187, 185, 184,
+	0, 242, 7, 241, 240, 239, 238, 237, 236, 0,
+	4, 235, 234, 233, 232, 231, 230, 8, 229, 228,
+	227, 10, 226, 225, 11, 224, 223, 222, 5, 218,
+	2, 1, 3, 217, 6, 214, 207, 196,
 }
 
 var yyR1 = [...]int8{
 	0, 2, 2, 1, 1, 1, 1, 1, 1, 1,
-	35, 3, 3, 4, 4, 5, 5, 8, 8, 7,
-	7, 6, 9, 9, 10, 10, 10, 10, 11, 11,
-	11, 11, 11, 12, 12, 13, 14, 15, 15, 16,
-	17, 18, 18, 18, 19, 20, 21, 21, 22, 23,
-	24, 24, 25, 25, 25, 25, 25, 26, 26, 27,
-	28, 28, 29, 29, 29, 29, 30, 30, 31, 31,
-	34, 33, 33, 33, 32, 37, 37, 36, 36,
+	1, 1, 35, 3, 3, 4, 4, 5, 5, 8,
+	8, 7, 7, 6, 9, 9, 10, 10, 10, 10,
+	11, 11, 11, 11, 11, 12, 12, 13, 14, 15,
+	15, 16, 17, 18, 18, 18, 19, 19, 20, 21,
+	21, 22, 23, 24, 24, 25, 25, 25, 25, 25,
+	26, 26, 27, 28, 28, 29, 29, 29, 29, 30,
+	30, 31, 31, 34, 33, 33, 33, 32, 37, 37,
+	36, 36,
 }
 
 var yyR2 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	3, 1, 2, 0, 2, 0, 4, 0, 4, 0,
-	2, 3, 1, 3, 1, 3, 1, 1, 1, 1,
-	1, 1, 1, 0, 2, 5, 4, 0, 2, 6,
-	3, 0, 1, 3, 5, 3, 0, 2, 5, 5,
-	0, 2, 3, 4, 4, 4, 5, 3, 3, 1,
-	1, 3, 3, 3, 5, 5, 3, 3, 3, 3,
-	2, 0, 1, 3, 3, 0, 1, 0, 1,
+	1, 1, 3, 1, 2, 0, 2, 0, 4, 0,
+	4, 0, 2, 3, 1, 3, 1, 3, 1, 1,
+	1, 1, 1, 1, 1, 0, 2, 5, 4, 0,
+	2, 6, 3, 0, 1, 3, 5, 6, 3, 0,
+	2, 5, 5, 0, 2, 3, 4, 4, 4, 5,
+	3, 3, 1, 1, 3, 3, 3, 5, 5, 3,
+	3, 3, 3, 2, 0, 1, 3, 3, 0, 1,
+	0, 1,
 }
 
 var yyChk = [...]int16{
-	-1000, -35, -5, 6, -8, 9, 17, -12, 17, -4,
-	-11, -13, -16, -19, -22, -23, 5, 7, 10, 11,
-	12, -7, -3, 18, 15, 13, 13, 13, 13, 13,
-	13, 18, -6, 13, 15, 23, 23, 23, 23, 23,
-	19, -15, -18, -17, -2, 13, -1, 4, 6, 7,
-	9, 10, 11, 12, -21, -24, -24, 15, 24, -14,
-	-2, -36, 25, -9, -10, 20, 13, 4, 7, 24,
-	-20, -2, 24, -25, -2, 24, 19, 24, -17, 14,
-	21, 22, -9, -26, 17, 14, -10, 13, 25, 25,
-	-27, -28, -29, 8, -10, 17, -10, -34, 13, 4,
-	7, -33, -32, -2, 25, 25, 25, 25, -28, 17,
-	-34, -30, -31, 27, -9, 28, 18, 18, -37, 26,
-	-9, 25, 18, 18, 26, 18, 26, 28, 27, 28,
-	29, -32, 14, -31, -9, -30, -9, -9, 28, 29,
-	-9, 18, 18,
+	-1000, -35, -5, 6, -8, 10, 18, -12, 18, -4,
+	-11, -13, -16, -19, -22, -23, 5, 8, 11, 7,
+	12, 13, -7, -3, 19, 16, 14, 14, 14, 14,
+	11, 14, 14, 19, -6, 14, 16, 24, 24, 24,
+	14, 24, 24, 20, -15, -18, -17, -2, 14, -1,
+	4, 5, 6, 7, 8, 10, 11, 12, 13, -21,
+	24, -24, -24, 16, 25, -14, -2, -36, 26, -9,
+	-10, 21, 14, 4, 8, 25, -20, -2, -21, 25,
+	-25, -2, 25, 20, 25, -17, 15, 22, 23, -9,
+	25, -26, 18, 15, -10, 14, 26, 26, -27, -28,
+	-29, 9, -10, 18, -10, -34, 14, 4, 8, -33,
+	-32, -2, 26, 26, 26, 26, -28, 18, -34, -30,
+	-31, 28, -9, 29, 19, 19, -37, 27, -9, 26,
+	19, 19, 27, 19, 27, 29, 28, 29, 30, -32,
+	15, -31, -9, -30, -9, -9, 29, 30, -9, 19,
+	19,
 }
 
 var yyDef = [...]int8{
-	15, -2, 17, 0, 33, 0, 13, 10, 19, 0,
-	34, 28, 29, 30, 31, 32, 0, 0, 0, 0,
-	0, 0, 14, 16, 11, 0, 0, 0, 0, 0,
-	0, 18, 20, 0, 12, 37, 41, 46, 50, 50,
-	0, 0, 77, 42, 0, 1, 2, 3, 4, 5,
-	6, 7, 8, 9, 0, 0, 0, 21, 35, 38,
-	0, 0, 78, 0, 22, 0, 24, 26, 27, 44,
-	47, 0, 48, 51, 0, 49, 0, 39, 43, 40,
-	0, 0, 0, 0, 71, 0, 23, 25, 45, 52,
-	0, 0, 0, 59, 60, 71, 0, 0, -2, -2,
-	-2, 75, 72, 0, 36, 53, 54, 55, 0, 71,
-	0, 0, 0, 0, 0, 0, 57, 58, 70, 76,
-	0, 56, 61, 62, 0, 63, 0, 0, 0, 0,
-	0, 73, 74, 0, 0, 0, 0, 66, 67, 68,
-	69, 64, 65,
+	17, -2, 19, 0, 35, 0, 15, 12, 21, 0,
+	36, 30, 31, 32, 33, 34, 0, 0, 0, 0,
+	0, 0, 0, 16, 18, 13, 0, 0, 0, 0,
+	0, 0, 0, 20, 22, 0, 14, 39, 43, 49,
+	0, 53, 53, 0, 0, 80, 44, 0, 1, 2,
+	3, 4, 5, 6, 7, 8, 9, 10, 11, 0,
+	49, 0, 0, 23, 37, 40, 0, 0, 81, 0,
+	24, 0, 26, 28, 29, 46, 50, 0, 0, 51,
+	54, 0, 52, 0, 41, 45, 42, 0, 0, 0,
+	47, 0, 74, 0, 25, 27, 48, 55, 0, 0,
+	0, 62, 63, 74, 0, 0, -2, -2, -2, 78,
+	75, 0, 38, 56, 57, 58, 0, 74, 0, 0,
+	0, 0, 0, 0, 60, 61, 73, 79, 0, 59,
+	64, 65, 0, 66, 0, 0, 0, 0, 0, 76,
+	77, 0, 0, 0, 0, 69, 70, 71, 72, 67,
+	68,
 }
 
 var yyTok1 = [...]int8{
@@ -245,20 +259,20 @@ var yyTok1 = [...]int8{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	17, 18, 3, 3, 26, 28, 22, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 25,
-	27, 19, 29, 3, 3, 3, 3, 3, 3, 3,
+	18, 19, 3, 3, 27, 29, 23, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 26,
+	28, 20, 30, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 20, 3, 21, 3, 3, 3, 3, 3, 3,
+	3, 21, 3, 22, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 23, 3, 24,
+	3, 3, 3, 24, 3, 25,
 }
 
 var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
-	12, 13, 14, 15, 16,
+	12, 13, 14, 15, 16, 17,
 }
 
 var yyTok3 = [...]int8{
@@ -618,54 +632,70 @@ yydefault:
 	case 4:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "import"
+			yyVAL.ident = "enum"
 		}
 	case 5:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "message"
+			yyVAL.ident = "import"
 		}
 	case 6:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "options"
+			yyVAL.ident = "key"
 		}
 	case 7:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "struct"
+			yyVAL.ident = "message"
 		}
 	case 8:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "service"
+			yyVAL.ident = "options"
 		}
 	case 9:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "subservice"
+			yyVAL.ident = "struct"
 		}
 	case 10:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.ident = "service"
+		}
+	case 11:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.ident = "subservice"
+		}
+	case 12:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			file := &syntax.File{
 				Imports:     yyDollar[1].imports,
 				Options:     yyDollar[2].options,
 				Definitions: yyDollar[3].definitions,
+
+				ImportsLine: yyDollar[1].line,
+				ImportsEnd:  yyDollar[1].end,
+				OptionsLine: yyDollar[2].line,
+				OptionsEnd:  yyDollar[2].end,
 			}
 			setLexerResult(yylex, file)
 		}
-	case 11:
+	case 13:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("import ", yyDollar[1].string)
 			}
 			yyVAL.import_ = &syntax.Import{
-				ID: trimString(yyDollar[1].string),
+				ID:   trimString(yyDollar[1].string),
+				Line: yyDollar[1].line,
 			}
 		}
-	case 12:
+	case 14:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -674,14 +704,15 @@ yydefault:
 			yyVAL.import_ = &syntax.Import{
 				Alias: yyDollar[1].ident,
 				ID:    trimString(yyDollar[2].string),
+				Line:  yyDollar[1].line,
 			}
 		}
-	case 13:
+	case 15:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.imports = nil
 		}
-	case 14:
+	case 16:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -689,38 +720,46 @@ yydefault:
 			}
 			yyVAL.imports = append(yyVAL.imports, yyDollar[2].import_)
 		}
-	case 15:
+	case 17:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.imports = nil
+			yyVAL.line = 0
+			yyVAL.end = 0
 		}
-	case 16:
+	case 18:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("imports", yyDollar[3].imports)
 			}
 			yyVAL.imports = append(yyVAL.imports, yyDollar[3].imports...)
+			yyVAL.line = yyDollar[1].line
+			yyVAL.end = yyDollar[4].line
 		}
-	case 17:
+	case 19:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.options = nil
+			yyVAL.line = 0
+			yyVAL.end = 0
 		}
-	case 18:
+	case 20:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("options", yyDollar[3].options)
 			}
 			yyVAL.options = append(yyVAL.options, yyDollar[3].options...)
+			yyVAL.line = yyDollar[1].line
+			yyVAL.end = yyDollar[4].line
 		}
-	case 19:
+	case 21:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.options = nil
 		}
-	case 20:
+	case 22:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -728,7 +767,7 @@ yydefault:
 			}
 			yyVAL.options = append(yyVAL.options, yyDollar[2].option)
 		}
-	case 21:
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -737,9 +776,10 @@ yydefault:
 			yyVAL.option = &syntax.Option{
 				Name:  yyDollar[1].ident,
 				Value: trimString(yyDollar[3].string),
+				Line:  yyDollar[1].line,
 			}
 		}
-	case 22:
+	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -747,7 +787,7 @@ yydefault:
 			}
 			yyVAL.type_ = yyDollar[1].type_
 		}
-	case 23:
+	case 25:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -758,7 +798,7 @@ yydefault:
 				Element: yyDollar[3].type_,
 			}
 		}
-	case 24:
+	case 26:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -769,7 +809,7 @@ yydefault:
 				Name: yyDollar[1].ident,
 			}
 		}
-	case 25:
+	case 27:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -781,7 +821,7 @@ yydefault:
 				Import: yyDollar[1].ident,
 			}
 		}
-	case 26:
+	case 28:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -792,7 +832,7 @@ yydefault:
 				Name: "any",
 			}
 		}
-	case 27:
+	case 29:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -803,12 +843,12 @@ yydefault:
 				Name: "message",
 			}
 		}
-	case 33:
+	case 35:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.definitions = nil
 		}
-	case 34:
+	case 36:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -816,7 +856,7 @@ yydefault:
 			}
 			yyVAL.definitions = append(yyVAL.definitions, yyDollar[2].definition)
 		}
-	case 35:
+	case 37:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -825,13 +865,15 @@ yydefault:
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionEnum,
 				Name: yyDollar[2].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[5].line,
 
 				Enum: &syntax.Enum{
 					Values: yyDollar[4].enum_values,
 				},
 			}
 		}
-	case 36:
+	case 38:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -840,14 +882,15 @@ yydefault:
 			yyVAL.enum_value = &syntax.EnumValue{
 				Name:  yyDollar[1].ident,
 				Value: yyDollar[3].integer,
+				Line:  yyDollar[1].line,
 			}
 		}
-	case 37:
+	case 39:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.enum_values = nil
 		}
-	case 38:
+	case 40:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -855,7 +898,7 @@ yydefault:
 			}
 			yyVAL.enum_values = append(yyVAL.enum_values, yyDollar[2].enum_value)
 		}
-	case 39:
+	case 41:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
@@ -864,13 +907,15 @@ yydefault:
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionMessage,
 				Name: yyDollar[2].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[6].line,
 
 				Message: &syntax.Message{
 					Fields: yyDollar[4].fields,
 				},
 			}
 		}
-	case 40:
+	case 42:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -880,14 +925,15 @@ yydefault:
 				Name: yyDollar[1].ident,
 				Type: yyDollar[2].type_,
 				Tag:  yyDollar[3].integer,
+				Line: yyDollar[1].line,
 			}
 		}
-	case 41:
+	case 43:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.fields = nil
 		}
-	case 42:
+	case 44:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -895,7 +941,7 @@ yydefault:
 			}
 			yyVAL.fields = []*syntax.Field{yyDollar[1].field}
 		}
-	case 43:
+	case 45:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -903,7 +949,7 @@ yydefault:
 			}
 			yyVAL.fields = append(yyVAL.fields, yyDollar[3].field)
 		}
-	case 44:
+	case 46:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -912,13 +958,33 @@ yydefault:
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionStruct,
 				Name: yyDollar[2].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[5].line,
 
 				Struct: &syntax.Struct{
 					Fields: yyDollar[4].struct_fields,
 				},
 			}
 		}
-	case 45:
+	case 47:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("key struct", yyDollar[3].ident, yyDollar[5].struct_fields)
+			}
+			yyVAL.definition = &syntax.Definition{
+				Type: syntax.DefinitionStruct,
+				Name: yyDollar[3].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[6].line,
+
+				Struct: &syntax.Struct{
+					Key:    true,
+					Fields: yyDollar[5].struct_fields,
+				},
+			}
+		}
+	case 48:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -927,14 +993,15 @@ yydefault:
 			yyVAL.struct_field = &syntax.StructField{
 				Name: yyDollar[1].ident,
 				Type: yyDollar[2].type_,
+				Line: yyDollar[1].line,
 			}
 		}
-	case 46:
+	case 49:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.struct_fields = nil
 		}
-	case 47:
+	case 50:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -942,7 +1009,7 @@ yydefault:
 			}
 			yyVAL.struct_fields = append(yyVAL.struct_fields, yyDollar[2].struct_field)
 		}
-	case 48:
+	case 51:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -951,13 +1018,15 @@ yydefault:
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionService,
 				Name: yyDollar[2].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[5].line,
 
 				Service: &syntax.Service{
 					Methods: yyDollar[4].methods,
 				},
 			}
 		}
-	case 49:
+	case 52:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -966,6 +1035,8 @@ yydefault:
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionService,
 				Name: yyDollar[2].ident,
+				Line: yyDollar[1].line,
+				End:  yyDollar[5].line,
 
 				Service: &syntax.Service{
 					Sub:     true,
@@ -973,17 +1044,17 @@ yydefault:
 				},
 			}
 		}
-	case 50:
+	case 53:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.methods = nil
 		}
-	case 51:
+	case 54:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.methods = append(yyDollar[1].methods, yyDollar[2].method)
 		}
-	case 52:
+	case 55:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -992,9 +1063,11 @@ yydefault:
 			yyVAL.method = &syntax.Method{
 				Name:  yyDollar[1].ident,
 				Input: yyDollar[2].method_input,
+				Line:  yyDollar[1].line,
+				End:   yyDollar[3].line,
 			}
 		}
-	case 53:
+	case 56:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -1004,9 +1077,11 @@ yydefault:
 				Name:   yyDollar[1].ident,
 				Input:  yyDollar[2].method_input,
 				Oneway: true,
+				Line:   yyDollar[1].line,
+				End:    yyDollar[4].line,
 			}
 		}
-	case 54:
+	case 57:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -1016,9 +1091,11 @@ yydefault:
 				Name:   yyDollar[1].ident,
 				Input:  yyDollar[2].method_input,
 				Output: yyDollar[3].method_output,
+				Line:   yyDollar[1].line,
+				End:    yyDollar[4].line,
 			}
 		}
-	case 55:
+	case 58:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -1028,9 +1105,11 @@ yydefault:
 				Name:    yyDollar[1].ident,
 				Input:   yyDollar[2].method_input,
 				Channel: yyDollar[3].method_channel,
+				Line:    yyDollar[1].line,
+				End:     yyDollar[4].line,
 			}
 		}
-	case 56:
+	case 59:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -1041,9 +1120,11 @@ yydefault:
 				Input:   yyDollar[2].method_input,
 				Channel: yyDollar[3].method_channel,
 				Output:  yyDollar[4].method_output,
+				Line:    yyDollar[1].line,
+				End:     yyDollar[5].line,
 			}
 		}
-	case 57:
+	case 60:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1051,7 +1132,7 @@ yydefault:
 			}
 			yyVAL.method_input = yyDollar[2].type_
 		}
-	case 58:
+	case 61:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1059,7 +1140,7 @@ yydefault:
 			}
 			yyVAL.method_input = yyDollar[2].fields
 		}
-	case 59:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1067,7 +1148,7 @@ yydefault:
 			}
 			yyVAL.bool = true
 		}
-	case 60:
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1075,7 +1156,7 @@ yydefault:
 			}
 			yyVAL.method_output = yyDollar[1].type_
 		}
-	case 61:
+	case 64:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1083,7 +1164,7 @@ yydefault:
 			}
 			yyVAL.method_output = yyDollar[2].fields
 		}
-	case 62:
+	case 65:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1094,7 +1175,7 @@ yydefault:
 				In: yyDollar[2].type_,
 			}
 		}
-	case 63:
+	case 66:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1105,7 +1186,7 @@ yydefault:
 				Out: yyDollar[2].type_,
 			}
 		}
-	case 64:
+	case 67:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -1117,38 +1198,38 @@ yydefault:
 				Out: yyDollar[4].type_,
 			}
 		}
-	case 65:
+	case 68:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel syntax, expected (<-%v, %v->), got (%v->, <-%v)",
 				yyDollar[4].type_, yyDollar[2].type_, yyDollar[2].type_, yyDollar[4].type_)
 		}
-	case 66:
+	case 69:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.type_ = yyDollar[3].type_
 		}
-	case 67:
+	case 70:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel in syntax, expected <-%v, got %v<-",
 				yyDollar[1].type_, yyDollar[1].type_)
 		}
-	case 68:
+	case 71:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.type_ = yyDollar[1].type_
 		}
-	case 69:
+	case 72:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel out syntax, expected %v->, got ->%v",
 				yyDollar[3].type_, yyDollar[3].type_)
 		}
-	case 70:
+	case 73:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -1156,12 +1237,12 @@ yydefault:
 			}
 			yyVAL.fields = yyDollar[1].fields
 		}
-	case 71:
+	case 74:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.fields = nil
 		}
-	case 72:
+	case 75:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1169,7 +1250,7 @@ yydefault:
 			}
 			yyVAL.fields = []*syntax.Field{yyDollar[1].field}
 		}
-	case 73:
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1177,7 +1258,7 @@ yydefault:
 			}
 			yyVAL.fields = append(yyDollar[1].fields, yyDollar[3].field)
 		}
-	case 74:
+	case 77:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1187,21 +1268,22 @@ yydefault:
 				Name: yyDollar[1].ident,
 				Type: yyDollar[2].type_,
 				Tag:  yyDollar[3].integer,
+				Line: yyDollar[1].line,
 			}
 		}
-	case 75:
+	case 78:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 		}
-	case 76:
+	case 79:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 		}
-	case 77:
+	case 80:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 		}
-	case 78:
+	case 81:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 		}
@@ -11,6 +11,10 @@ import (
 
 // union defines yySymType body.
 %union {
+	// Positions
+	line int // line of the first token
+	end  int // line of the last token, used in import/option blocks
+
 	// Tokens
 	ident   string
 	bool	bool
@@ -59,6 +63,7 @@ import (
 %token ANY
 %token ENUM
 %token IMPORT
+%token KEY
 %token MESSAGE
 %token ONEWAY
 %token OPTIONS
@@ -145,15 +150,25 @@ field_name:
 		$$ = $1
 	};
 
+// keyword is a keyword allowed as a field name. All definition keywords are allowed,
+// so that fields can be named after definition kinds, i.e. plugin Definition.enum.
 keyword:
 	ANY
 	{
 		$$ = "any"
 	}
+	| ENUM
+	{
+		$$ = "enum"
+	}
     | IMPORT
     {
         $$ = "import"
     }
+	| KEY
+	{
+		$$ = "key"
+	}
 	| MESSAGE
     {
         $$ = "message"
@@ -183,6 +198,11 @@ file: imports options definitions
 			Imports:     $1,
 			Options:     $2,
 			Definitions: $3,
+
+			ImportsLine: $<line>1,
+			ImportsEnd:  $<end>1,
+			OptionsLine: $<line>2,
+			OptionsEnd:  $<end>2,
 		}
 		setLexerResult(yylex, file)
 	};
@@ -196,7 +216,8 @@ import:
 			fmt.Println("import ", $1)
 		}
 		$$ = &syntax.Import{
-			ID: trimString($1),
+			ID:   trimString($1),
+			Line: $<line>1,
 		}
 	}
 	| IDENT STRING
@@ -207,6 +228,7 @@ import:
 		$$ = &syntax.Import{
 			Alias: $1,
 			ID:    trimString($2),
+			Line:  $<line>1,
 		}
 	};
 
@@ -227,6 +249,8 @@ imports:
 	// Empty
 	{ 
 		$$ = nil
+		$<line>$ = 0
+		$<end>$ = 0
 	}
 	| IMPORT '(' import_list ')'
 	{
@@ -234,6 +258,8 @@ imports:
 			fmt.Println("imports", $3)
 		}
 		$$ = append($$, $3...)
+		$<line>$ = $<line>1
+		$<end>$ = $<line>4
 	};
 
 // options
@@ -242,6 +268,8 @@ options:
 	// Empty
 	{ 
 		$$ = nil
+		$<line>$ = 0
+		$<end>$ = 0
 	}
 	| OPTIONS '(' option_list ')'
 	{
@@ -249,6 +277,8 @@ options:
 			fmt.Println("options", $3)
 		}
 		$$ = append($$, $3...)
+		$<line>$ = $<line>1
+		$<end>$ = $<line>4
 	};
 
 option_list:
@@ -273,6 +303,7 @@ option:
 		$$ = &syntax.Option{
 			Name:  $1,
 			Value: trimString($3),
+			Line:  $<line>1,
 		}
 	};
 
@@ -374,6 +405,8 @@ enum: ENUM IDENT '{' enum_values '}'
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionEnum,
 			Name: $2,
+			Line: $<line>1,
+			End:  $<line>5,
 
 			Enum: &syntax.Enum{
 				Values: $4,
@@ -389,6 +422,7 @@ enum_value: field_name '=' INTEGER ';'
 		$$ = &syntax.EnumValue{
 			Name: $1,
 			Value: $3,
+			Line: $<line>1,
 		}
 	};
 
@@ -416,6 +450,8 @@ message: MESSAGE IDENT '{' fields semi_opt '}'
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionMessage,
 			Name: $2,
+			Line: $<line>1,
+			End:  $<line>6,
 
 			Message: &syntax.Message{
 				Fields: $4,
@@ -432,6 +468,7 @@ field: field_name type INTEGER
 			Name: $1,
 			Type: $2,
 			Tag: $3,
+			Line: $<line>1,
 		}
 	};
 
@@ -466,11 +503,30 @@ struct: STRUCT IDENT '{' struct_fields '}'
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionStruct,
 			Name: $2,
+			Line: $<line>1,
+			End:  $<line>5,
 
 			Struct: &syntax.Struct{
 				Fields: $4,
 			},
 		}
+	}
+	| KEY STRUCT IDENT '{' struct_fields '}'
+	{
+		if debugParser {
+			fmt.Println("key struct", $3, $5)
+		}
+		$$ = &syntax.Definition{
+			Type: syntax.DefinitionStruct,
+			Name: $3,
+			Line: $<line>1,
+			End:  $<line>6,
+
+			Struct: &syntax.Struct{
+				Key:    true,
+				Fields: $5,
+			},
+		}
 	};
 
 struct_field: field_name type ';'
@@ -481,6 +537,7 @@ struct_field: field_name type ';'
 		$$ = &syntax.StructField{
 			Name: $1,
 			Type: $2,
+			Line: $<line>1,
 		}
 	};
 
@@ -507,6 +564,8 @@ service: SERVICE IDENT '{' methods '}'
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionService,
 			Name: $2,
+			Line: $<line>1,
+			End:  $<line>5,
 
 			Service: &syntax.Service{
 				Methods: $4,
@@ -523,6 +582,8 @@ subservice: SUBSERVICE IDENT '{' methods '}'
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionService,
 			Name: $2,
+			Line: $<line>1,
+			End:  $<line>5,
 
 			Service: &syntax.Service{
 				Sub: true,
@@ -554,6 +615,8 @@ method:
 		$$ = &syntax.Method{
 			Name: $1,
 			Input: $2,
+			Line: $<line>1,
+			End: $<line>3,
 		}
 	}
 	| field_name method_input method_oneway ';'
@@ -565,6 +628,8 @@ method:
 			Name: $1,
 			Input: $2,
 			Oneway: true,
+			Line: $<line>1,
+			End: $<line>4,
 		}
 	}
 	| field_name method_input method_output ';'
@@ -576,6 +641,8 @@ method:
 			Name: $1,
 			Input: $2,
 			Output: $3,
+			Line: $<line>1,
+			End: $<line>4,
 		}
 	}
 	| field_name method_input method_channel ';'
@@ -587,6 +654,8 @@ method:
 			Name: $1,
 			Input: $2,
 			Channel: $3,
+			Line: $<line>1,
+			End: $<line>4,
 		}
 	}
 	| field_name method_input method_channel method_output ';'
@@ -599,6 +668,8 @@ method:
 			Input: $2,
 			Channel: $3,
 			Output: $4,
+			Line: $<line>1,
+			End: $<line>5,
 		}
 	};
 
@@ -747,6 +818,7 @@ method_field:
 			Name: $1,
 			Type: $2,
 			Tag: $3,
+			Line: $<line>1,
 		}
 	};
 
@@ -8,6 +8,7 @@ var keywords = map[string]int{
 	"any":        ANY,
 	"enum":       ENUM,
 	"import":     IMPORT,
+	"key":        KEY,
 	"message":    MESSAGE,
 	"oneway":     ONEWAY,
 	"options":    OPTIONS,
@@ -5,6 +5,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -24,14 +25,17 @@ var _ yyLexer = &lexer{}
 type lexer struct {
 	s *scanner.Scanner
 
-	file *syntax.File // used by yyParser to return result
-	err  error        // parse error
+	file     *syntax.File      // used by yyParser to return result
+	comments []*syntax.Comment // scanned comments
+	line     int               // last token line
+	err      error             // parse error
 }
 
 func newLexer(filename string, src io.Reader) *lexer {
 	s := &scanner.Scanner{}
 	s.Init(src)
 	s.Filename = filename
+	s.Mode &^= scanner.SkipComments
 	return &lexer{s: s}
 }
 
@@ -45,6 +49,10 @@ func (l *lexer) Lex(lval *yySymType) int {
 		// Scan next token
 		token := l.s.Scan()
 		text := l.s.TokenText()
+		lval.line = l.s.Position.Line
+		if token != scanner.Comment {
+			l.line = lval.line
+		}
 
 		// Return on eof
 		if token == scanner.EOF {
@@ -106,6 +114,12 @@ func (l *lexer) Lex(lval *yySymType) int {
 			if debugLexer {
 				fmt.Printf("COMMENT %v %v %v\n", l.s.Position, token, text)
 			}
+
+			l.comments = append(l.comments, &syntax.Comment{
+				Text:     text,
+				Line:     l.s.Position.Line,
+				Trailing: l.line == l.s.Position.Line,
+			})
 			continue
 
 		default:
@@ -121,12 +135,12 @@ func (l *lexer) Lex(lval *yySymType) int {
 }
 
 func (l *lexer) Error(s string) {
-	l.err = fmt.Errorf("%v %v", l.s.Position, s)
+	l.err = fmt.Errorf("%v %w", l.s.Position, l.errorAt(errors.New(s)))
 }
 
 func yyLexError(l yyLexer, err error) int {
 	ll := l.(*lexer)
-	ll.err = fmt.Errorf("%v %w", ll.s.Position, err)
+	ll.err = fmt.Errorf("%v %w", ll.s.Position, ll.errorAt(err))
 	return ERROR
 }
 
@@ -135,6 +149,17 @@ func yyLexErrorf(l yyLexer, format string, a ...any) int {
 	return yyLexError(l, err)
 }
 
+// errorAt wraps an error with the last token position.
+func (l *lexer) errorAt(err error) error {
+	pos := l.s.Position
+	return &syntax.Error{
+		Path:   pos.Filename,
+		Line:   pos.Line,
+		Column: pos.Column,
+		Err:    err,
+	}
+}
+
 // util
 
 func trimString(s string) string {
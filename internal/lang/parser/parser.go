@@ -19,6 +19,7 @@ import (
 
 type Parser interface {
 	Parse(s string) (*syntax.File, error)
+	ParseSource(path string, s string) (*syntax.File, error)
 	ParseFile(path string) (*syntax.File, error)
 	ParseDirectory(path string) ([]*syntax.File, error)
 }
@@ -39,6 +40,12 @@ func (p *parser) Parse(s string) (*syntax.File, error) {
 	return p.parse("", src)
 }
 
+// ParseSource parses a file source, the path is used in the file and in error positions.
+func (p *parser) ParseSource(path string, s string) (*syntax.File, error) {
+	src := strings.NewReader(s)
+	return p.parse(path, src)
+}
+
 func (p *parser) ParseFile(path string) (*syntax.File, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -95,5 +102,6 @@ func (p *parser) parse(filename string, src io.Reader) (*syntax.File, error) {
 
 	file := lexer.file
 	file.Path = filename
+	file.Comments = lexer.comments
 	return file, nil
 }
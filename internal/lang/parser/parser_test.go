@@ -219,6 +219,30 @@ func TestParser_Parse__should_parse_empty_message(t *testing.T) {
 	assert.Len(t, def.Message.Fields, 0)
 }
 
+func TestParser_Parse__should_parse_keyword_field_names(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage {
+	enum	int32	1;
+	message	string	2;
+	struct	string	3;
+	service	string	4;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Len(t, file.Definitions, 1)
+	def := file.Definitions[0]
+	require.Len(t, def.Message.Fields, 4)
+
+	assert.Equal(t, "enum", def.Message.Fields[0].Name)
+	assert.Equal(t, "message", def.Message.Fields[1].Name)
+	assert.Equal(t, "struct", def.Message.Fields[2].Name)
+	assert.Equal(t, "service", def.Message.Fields[3].Name)
+}
+
 // struct
 
 func TestParser_Parse__should_parse_struct(t *testing.T) {
@@ -264,6 +288,30 @@ func TestParser_Parse__should_parse_empty_struct(t *testing.T) {
 	assert.Len(t, str.Struct.Fields, 0)
 }
 
+func TestParser_Parse__should_parse_key_struct(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+key struct TestKey {
+	key		bin128;
+	time	int64;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Len(t, file.Definitions, 1)
+	def := file.Definitions[0]
+
+	assert.Equal(t, "TestKey", def.Name)
+	require.Equal(t, syntax.DefinitionStruct, def.Type)
+	assert.True(t, def.Struct.Key)
+	require.Len(t, def.Struct.Fields, 2)
+
+	assert.Equal(t, "key", def.Struct.Fields[0].Name)
+	assert.Equal(t, "time", def.Struct.Fields[1].Name)
+}
+
 // type
 
 func TestParser_Parse__should_parse_base_type(t *testing.T) {
@@ -567,3 +615,42 @@ func TestParser_Parse__should_return_error_when_invalid_channel_syntax(t *testin
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), `invalid channel out syntax, expected Msg->, got ->Msg`)
 }
+
+// comments
+
+func TestParser_Parse__should_parse_comments_and_lines(t *testing.T) {
+	p := newParser()
+	s := `// Header comment
+
+// Message comment
+message Message {
+	field int32 1; // Field comment
+}
+
+service Service {
+	method(
+		a int32 1,
+	);
+}`
+
+	file, err := p.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Len(t, file.Comments, 3)
+	assert.Equal(t, "// Header comment", file.Comments[0].Text)
+	assert.Equal(t, 1, file.Comments[0].Line)
+	assert.Equal(t, 3, file.Comments[1].Line)
+	assert.Equal(t, 5, file.Comments[2].Line)
+
+	msg := file.Definitions[0]
+	assert.Equal(t, 4, msg.Line)
+	assert.Equal(t, 6, msg.End)
+	assert.Equal(t, 5, msg.Message.Fields[0].Line)
+
+	method := file.Definitions[1].Service.Methods[0]
+	assert.Equal(t, 9, method.Line)
+	assert.Equal(t, 11, method.End)
+	assert.Equal(t, 10, method.Input.(syntax.Fields)[0].Line)
+}
@@ -0,0 +1,465 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/collect"
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/proto/pplugin"
+)
+
+// DecodeRequest decodes a plugin request and returns a package with resolved imports.
+func DecodeRequest(b []byte) (_ *model.Package, parameter string, err error) {
+	req, _, err := pplugin.ParseRequest(b)
+	if err != nil {
+		return nil, "", err
+	}
+
+	d := newDecoder()
+
+	// Decode packages
+	pkg, err := d.package_(req.Package())
+	if err != nil {
+		return nil, "", err
+	}
+
+	imports := req.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if _, err := d.package_(imports.Get(i)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Resolve imports and types
+	if err := d.resolve(); err != nil {
+		return nil, "", err
+	}
+
+	parameter = req.Parameter().Clone()
+	return pkg, parameter, nil
+}
+
+type decoder struct {
+	ctx   *model.Context
+	types []pendingType
+}
+
+// pendingType is a reference type which is resolved after all packages are decoded.
+type pendingType struct {
+	file     *model.File
+	typ      *model.Type
+	package_ string
+}
+
+func newDecoder() *decoder {
+	return &decoder{
+		ctx: model.NewContext(nil, nil),
+	}
+}
+
+// package
+
+func (d *decoder) package_(p pplugin.Package) (*model.Package, error) {
+	pkg := &model.Package{
+		Context: d.ctx,
+
+		ID:   p.Id().Clone(),
+		Name: p.Name().Clone(),
+		Path: p.Path().Clone(),
+
+		FileNames:       make(map[string]*model.File),
+		OptionNames:     make(map[string]*model.Option),
+		DefinitionNames: make(map[string]*model.Definition),
+	}
+
+	if _, ok := d.ctx.Packages[pkg.ID]; ok {
+		return nil, fmt.Errorf("duplicate package %q", pkg.ID)
+	}
+	d.ctx.Packages[pkg.ID] = pkg
+
+	// Files
+	files := p.Files()
+	for i := 0; i < files.Len(); i++ {
+		file := d.file(pkg, files.Get(i))
+		pkg.Files = append(pkg.Files, file)
+		pkg.FileNames[file.Name] = file
+	}
+
+	// Options
+	pkg.Options = d.options(p.Options())
+	for _, opt := range pkg.Options {
+		pkg.OptionNames[opt.Name] = opt
+	}
+
+	// Definitions
+	defs := p.Definitions()
+	for i := 0; i < defs.Len(); i++ {
+		if err := d.definition(pkg, defs.Get(i)); err != nil {
+			return nil, fmt.Errorf("%v: %w", pkg.ID, err)
+		}
+	}
+	return pkg, nil
+}
+
+func (d *decoder) file(pkg *model.Package, f pplugin.File) *model.File {
+	file := &model.File{
+		Package: pkg,
+		Name:    f.Name().Clone(),
+		Path:    f.Path().Clone(),
+
+		ImportMap:       make(map[string]*model.Import),
+		OptionMap:       make(map[string]*model.Option),
+		DefinitionNames: make(map[string]*model.Definition),
+	}
+
+	imports := f.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		imp := imports.Get(i)
+		imp1 := &model.Import{
+			File: file,
+			ID:   imp.Id().Clone(),
+			Name: imp.Name().Clone(),
+		}
+
+		file.Imports = append(file.Imports, imp1)
+		file.ImportMap[imp1.Name] = imp1
+	}
+
+	file.Options = d.options(f.Options())
+	for _, opt := range file.Options {
+		file.OptionMap[opt.Name] = opt
+	}
+	return file
+}
+
+func (d *decoder) options(list spec.MessageList[pplugin.Option]) []*model.Option {
+	result := make([]*model.Option, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		opt := list.Get(i)
+		result = append(result, &model.Option{
+			Name:  opt.Name().Clone(),
+			Value: opt.Value().Clone(),
+		})
+	}
+	return result
+}
+
+// definition
+
+func (d *decoder) definition(pkg *model.Package, p pplugin.Definition) error {
+	name := p.Name().Clone()
+
+	file, ok := pkg.FileNames[p.File().Unwrap()]
+	if !ok {
+		return fmt.Errorf("%v: file not found %q", name, p.File().Unwrap())
+	}
+
+	def := &model.Definition{
+		Package: pkg,
+		File:    file,
+		Name:    name,
+	}
+
+	var err error
+	generated := false
+
+	switch p.Type() {
+	case pplugin.DefinitionType_Enum:
+		def.Type = model.DefinitionEnum
+		def.Enum = d.enum(def, p.Enum())
+
+	case pplugin.DefinitionType_Message:
+		def.Type = model.DefinitionMessage
+		def.Message, err = d.message(def, p.Message())
+		generated = p.Message().Generated()
+
+	case pplugin.DefinitionType_Struct:
+		def.Type = model.DefinitionStruct
+		def.Struct, err = d.struct_(def, p.Struct())
+
+	case pplugin.DefinitionType_Service:
+		def.Type = model.DefinitionService
+		def.Service, err = d.service(def, p.Service())
+
+	default:
+		return fmt.Errorf("%v: unsupported definition type %v", name, p.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %w", name, err)
+	}
+
+	// Add to file, and to package when not generated
+	if _, ok := file.DefinitionNames[name]; ok {
+		return fmt.Errorf("%v: duplicate definition", name)
+	}
+	file.Definitions = append(file.Definitions, def)
+	file.DefinitionNames[name] = def
+
+	if !generated {
+		pkg.Definitions = append(pkg.Definitions, def)
+		pkg.DefinitionNames[name] = def
+	}
+	return nil
+}
+
+func (d *decoder) enum(def *model.Definition, p pplugin.Enum) *model.Enum {
+	enum := &model.Enum{
+		Package: def.Package,
+		File:    def.File,
+		Def:     def,
+
+		ValueNames:   make(map[string]*model.EnumValue),
+		ValueNumbers: make(map[int]*model.EnumValue),
+	}
+
+	values := p.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		val := &model.EnumValue{
+			Enum:   enum,
+			Name:   v.Name().Clone(),
+			Number: int(v.Number()),
+		}
+
+		enum.Values = append(enum.Values, val)
+		enum.ValueNames[val.Name] = val
+		enum.ValueNumbers[val.Number] = val
+	}
+	return enum
+}
+
+func (d *decoder) message(def *model.Definition, p pplugin.Message) (*model.Message, error) {
+	msg := &model.Message{
+		Package: def.Package,
+		File:    def.File,
+		Def:     def,
+
+		Fields: &model.Fields{
+			Names: make(map[string]*model.Field),
+			Tags:  make(map[int]*model.Field),
+		},
+		Generated: p.Generated(),
+	}
+
+	fields := p.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+
+		typ, err := d.type_(def.File, f.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		field := &model.Field{
+			Name: f.Name().Clone(),
+			Tag:  int(f.Tag()),
+			Type: typ,
+		}
+
+		msg.Fields.List = append(msg.Fields.List, field)
+		msg.Fields.Names[field.Name] = field
+		msg.Fields.Tags[field.Tag] = field
+	}
+	return msg, nil
+}
+
+func (d *decoder) struct_(def *model.Definition, p pplugin.Struct) (*model.Struct, error) {
+	str := &model.Struct{
+		Package: def.Package,
+		File:    def.File,
+		Def:     def,
+		Key:     p.Key(),
+
+		Fields: collect.NewOrderedMap[string, *model.StructField](),
+	}
+
+	fields := p.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+
+		typ, err := d.type_(def.File, f.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		field := &model.StructField{
+			Struct: str,
+			Name:   f.Name().Clone(),
+			Type:   typ,
+		}
+		str.Fields.Put(field.Name, field)
+	}
+	return str, nil
+}
+
+func (d *decoder) service(def *model.Definition, p pplugin.Service) (*model.Service, error) {
+	srv := &model.Service{
+		Package: def.Package,
+		File:    def.File,
+		Def:     def,
+		Sub:     p.Sub(),
+
+		MethodNames: make(map[string]*model.Method),
+	}
+
+	methods := p.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		m, err := d.method(srv, methods.Get(i))
+		if err != nil {
+			return nil, err
+		}
+
+		srv.Methods = append(srv.Methods, m)
+		srv.MethodNames[m.Name] = m
+	}
+	return srv, nil
+}
+
+func (d *decoder) method(srv *model.Service, p pplugin.Method) (*model.Method, error) {
+	m := &model.Method{
+		Package: srv.Package,
+		File:    srv.File,
+		Service: srv,
+
+		Name: p.Name().Clone(),
+		Type: decodeMethodType(p.Type()),
+	}
+	m.Oneway = m.Type == model.MethodType_Oneway
+
+	var err error
+	if m.Request, err = d.typeOpt(srv.File, p.HasRequest(), p.Request()); err != nil {
+		return nil, err
+	}
+	if m.Response, err = d.typeOpt(srv.File, p.HasResponse(), p.Response()); err != nil {
+		return nil, err
+	}
+	if m.Subservice, err = d.typeOpt(srv.File, p.HasSubservice(), p.Subservice()); err != nil {
+		return nil, err
+	}
+
+	if p.HasChannelIn() || p.HasChannelOut() {
+		m.Channel = &model.MethodChannel{}
+
+		if m.Channel.In, err = d.typeOpt(srv.File, p.HasChannelIn(), p.ChannelIn()); err != nil {
+			return nil, err
+		}
+		if m.Channel.Out, err = d.typeOpt(srv.File, p.HasChannelOut(), p.ChannelOut()); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func decodeMethodType(t pplugin.MethodType) model.MethodType {
+	switch t {
+	case pplugin.MethodType_Request:
+		return model.MethodType_Request
+	case pplugin.MethodType_Oneway:
+		return model.MethodType_Oneway
+	case pplugin.MethodType_Channel:
+		return model.MethodType_Channel
+	case pplugin.MethodType_Subservice:
+		return model.MethodType_Subservice
+	}
+	return model.MethodType_Undefined
+}
+
+// type
+
+func (d *decoder) type_(file *model.File, p pplugin.Type) (*model.Type, error) {
+	kind := model.Kind(p.Kind())
+	if kind <= model.KindUndefined || kind > model.KindService {
+		return nil, fmt.Errorf("unsupported type kind %d", kind)
+	}
+
+	t := &model.Type{
+		Kind:       kind,
+		Name:       p.Name().Clone(),
+		ImportName: p.Import().Clone(),
+	}
+
+	switch kind {
+	case model.KindList:
+		elem, err := d.type_(file, p.Element())
+		if err != nil {
+			return nil, err
+		}
+		t.Element = elem
+
+	case model.KindEnum, model.KindMessage, model.KindStruct, model.KindService:
+		d.types = append(d.types, pendingType{
+			file:     file,
+			typ:      t,
+			package_: p.Package().Clone(),
+		})
+	}
+	return t, nil
+}
+
+func (d *decoder) typeOpt(file *model.File, ok bool, p pplugin.Type) (*model.Type, error) {
+	if !ok {
+		return nil, nil
+	}
+	return d.type_(file, p)
+}
+
+// resolve
+
+func (d *decoder) resolve() error {
+	// Resolve imports
+	for _, pkg := range d.ctx.Packages {
+		for _, file := range pkg.Files {
+			for _, imp := range file.Imports {
+				p, ok := d.ctx.Packages[imp.ID]
+				if !ok {
+					return fmt.Errorf("%v: package not found %q", file.Path, imp.ID)
+				}
+
+				imp.Package = p
+				imp.Resolved = true
+			}
+		}
+	}
+
+	// Resolve types
+	for _, pt := range d.types {
+		t := pt.typ
+
+		pkg, ok := d.ctx.Packages[pt.package_]
+		if !ok {
+			return fmt.Errorf("%v: package not found %q", pt.file.Path, pt.package_)
+		}
+
+		def, ok := lookupDefinition(pkg, t.Name)
+		if !ok {
+			return fmt.Errorf("%v: type not found %v", pt.file.Path, t.Name)
+		}
+
+		t.Ref = def
+		if t.ImportName != "" {
+			t.Import = pt.file.ImportMap[t.ImportName]
+		}
+	}
+	return nil
+}
+
+// lookupDefinition returns a package or generated definition by a name.
+func lookupDefinition(pkg *model.Package, name string) (*model.Definition, bool) {
+	def, ok := pkg.DefinitionNames[name]
+	if ok {
+		return def, true
+	}
+
+	for _, file := range pkg.Files {
+		def, ok := file.DefinitionNames[name]
+		if ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
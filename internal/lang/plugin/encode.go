@@ -0,0 +1,298 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/proto/pplugin"
+)
+
+// EncodeRequest encodes a plugin request with a package and its transitive imports.
+func EncodeRequest(pkg *model.Package, parameter string) ([]byte, error) {
+	w := pplugin.NewRequestWriter()
+	if err := encodePackage(w.Package(), pkg); err != nil {
+		return nil, err
+	}
+
+	imports := w.Imports()
+	for _, imp := range importedPackages(pkg) {
+		if err := encodePackage(imports.Add(), imp); err != nil {
+			return nil, err
+		}
+	}
+	if err := imports.End(); err != nil {
+		return nil, err
+	}
+
+	w.Parameter(parameter)
+
+	req, err := w.Build()
+	if err != nil {
+		return nil, err
+	}
+	return req.Unwrap().Raw(), nil
+}
+
+// importedPackages returns transitively imported packages in a deterministic order.
+func importedPackages(pkg *model.Package) []*model.Package {
+	var result []*model.Package
+	seen := map[*model.Package]struct{}{pkg: {}}
+
+	var visit func(p *model.Package)
+	visit = func(p *model.Package) {
+		for _, file := range p.Files {
+			for _, imp := range file.Imports {
+				if _, ok := seen[imp.Package]; ok {
+					continue
+				}
+
+				seen[imp.Package] = struct{}{}
+				result = append(result, imp.Package)
+				visit(imp.Package)
+			}
+		}
+	}
+
+	visit(pkg)
+	return result
+}
+
+// package
+
+func encodePackage(w pplugin.PackageWriter, pkg *model.Package) error {
+	w.Id(pkg.ID)
+	w.Name(pkg.Name)
+	w.Path(pkg.Path)
+
+	// Files
+	files := w.Files()
+	for _, file := range pkg.Files {
+		if err := encodeFile(files.Add(), file); err != nil {
+			return err
+		}
+	}
+	if err := files.End(); err != nil {
+		return err
+	}
+
+	// Options
+	if err := encodeOptions(w.Options(), pkg.Options); err != nil {
+		return err
+	}
+
+	// Definitions, including generated ones
+	defs := w.Definitions()
+	for _, file := range pkg.Files {
+		for _, def := range file.Definitions {
+			if err := encodeDefinition(defs.Add(), def); err != nil {
+				return err
+			}
+		}
+	}
+	if err := defs.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeFile(w pplugin.FileWriter, file *model.File) error {
+	w.Name(file.Name)
+	w.Path(file.Path)
+
+	imports := w.Imports()
+	for _, imp := range file.Imports {
+		w1 := imports.Add()
+		w1.Id(imp.ID)
+		w1.Name(imp.Name)
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := imports.End(); err != nil {
+		return err
+	}
+
+	if err := encodeOptions(w.Options(), file.Options); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeOptions(w spec.MessageListWriter[pplugin.OptionWriter], options []*model.Option) error {
+	for _, opt := range options {
+		w1 := w.Add()
+		w1.Name(opt.Name)
+		w1.Value(opt.Value)
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	return w.End()
+}
+
+// definition
+
+func encodeDefinition(w pplugin.DefinitionWriter, def *model.Definition) error {
+	w.Name(def.Name)
+	w.File(def.File.Name)
+
+	var err error
+	switch def.Type {
+	case model.DefinitionEnum:
+		w.Type(pplugin.DefinitionType_Enum)
+		err = encodeEnum(w.Enum(), def.Enum)
+	case model.DefinitionMessage:
+		w.Type(pplugin.DefinitionType_Message)
+		err = encodeMessage(w.Message(), def.Message)
+	case model.DefinitionStruct:
+		w.Type(pplugin.DefinitionType_Struct)
+		err = encodeStruct(w.Struct(), def.Struct)
+	case model.DefinitionService:
+		w.Type(pplugin.DefinitionType_Service)
+		err = encodeService(w.Service(), def.Service)
+	}
+	if err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeEnum(w pplugin.EnumWriter, enum *model.Enum) error {
+	values := w.Values()
+	for _, val := range enum.Values {
+		w1 := values.Add()
+		w1.Name(val.Name)
+		w1.Number(int32(val.Number))
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := values.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeMessage(w pplugin.MessageWriter, msg *model.Message) error {
+	fields := w.Fields()
+	for _, field := range msg.Fields.List {
+		w1 := fields.Add()
+		w1.Name(field.Name)
+		w1.Tag(int32(field.Tag))
+		if err := encodeType(w1.Type(), field.Type); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := fields.End(); err != nil {
+		return err
+	}
+
+	w.Generated(msg.Generated)
+	return w.End()
+}
+
+func encodeStruct(w pplugin.StructWriter, str *model.Struct) error {
+	fields := w.Fields()
+	for _, field := range str.Fields.Values() {
+		w1 := fields.Add()
+		w1.Name(field.Name)
+		if err := encodeType(w1.Type(), field.Type); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := fields.End(); err != nil {
+		return err
+	}
+
+	w.Key(str.Key)
+	return w.End()
+}
+
+func encodeService(w pplugin.ServiceWriter, srv *model.Service) error {
+	w.Sub(srv.Sub)
+
+	methods := w.Methods()
+	for _, method := range srv.Methods {
+		if err := encodeMethod(methods.Add(), method); err != nil {
+			return err
+		}
+	}
+	if err := methods.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeMethod(w pplugin.MethodWriter, m *model.Method) error {
+	w.Name(m.Name)
+	w.Type(encodeMethodType(m.Type))
+
+	if err := encodeTypeOpt(w.Request, m.Request); err != nil {
+		return err
+	}
+	if err := encodeTypeOpt(w.Response, m.Response); err != nil {
+		return err
+	}
+	if err := encodeTypeOpt(w.Subservice, m.Subservice); err != nil {
+		return err
+	}
+
+	if ch := m.Channel; ch != nil {
+		if err := encodeTypeOpt(w.ChannelIn, ch.In); err != nil {
+			return err
+		}
+		if err := encodeTypeOpt(w.ChannelOut, ch.Out); err != nil {
+			return err
+		}
+	}
+	return w.End()
+}
+
+func encodeMethodType(t model.MethodType) pplugin.MethodType {
+	switch t {
+	case model.MethodType_Request:
+		return pplugin.MethodType_Request
+	case model.MethodType_Oneway:
+		return pplugin.MethodType_Oneway
+	case model.MethodType_Channel:
+		return pplugin.MethodType_Channel
+	case model.MethodType_Subservice:
+		return pplugin.MethodType_Subservice
+	}
+	return pplugin.MethodType_Undefined
+}
+
+// type
+
+func encodeType(w pplugin.TypeWriter, t *model.Type) error {
+	w.Kind(pplugin.Kind(t.Kind))
+	w.Name(t.Name)
+	w.Import(t.ImportName)
+
+	if t.Ref != nil {
+		w.Package(t.Ref.Package.ID)
+	}
+	if t.Element != nil {
+		if err := encodeType(w.Element(), t.Element); err != nil {
+			return err
+		}
+	}
+	return w.End()
+}
+
+// encodeTypeOpt encodes a type if it is not nil.
+func encodeTypeOpt(field func() pplugin.TypeWriter, t *model.Type) error {
+	if t == nil {
+		return nil
+	}
+	return encodeType(field(), t)
+}
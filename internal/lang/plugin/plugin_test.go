@@ -0,0 +1,124 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/langtest"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Request
+
+func TestRequest__should_encode_decode_package(t *testing.T) {
+	pkg := langtest.Package(t, "pkg1")
+
+	b, err := EncodeRequest(pkg, "param")
+	require.NoError(t, err)
+
+	pkg1, param, err := DecodeRequest(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, "param", param)
+	assert.Equal(t, pkg.ID, pkg1.ID)
+	assert.Equal(t, pkg.Name, pkg1.Name)
+	assert.Len(t, pkg1.Files, len(pkg.Files))
+	assert.Len(t, pkg1.Definitions, len(pkg.Definitions))
+	assert.Equal(t, pkg.Options[0].Value, pkg1.OptionNames["go_package"].Value)
+
+	for _, def := range pkg.Definitions {
+		def1 := pkg1.DefinitionNames[def.Name]
+		require.NotNil(t, def1, def.Name)
+		assert.Equal(t, def.Type, def1.Type)
+		assert.Equal(t, def.File.Name, def1.File.Name)
+	}
+
+	// Enum
+	enum := pkg1.DefinitionNames["Enum"].Enum
+	assert.Equal(t, 10, enum.ValueNames["TEN"].Number)
+
+	// Struct
+	str := pkg1.DefinitionNames["Struct"].Struct
+	assert.Equal(t, []string{"key", "value"}, str.Fields.Keys())
+	assert.False(t, str.Key)
+
+	// Key struct
+	key := pkg1.DefinitionNames["Record"].Struct
+	assert.True(t, key.Key)
+
+	// Message with imported types
+	msg := pkg1.DefinitionNames["Message"].Message
+	field := msg.Fields.Get("submessages1")
+	require.NotNil(t, field)
+	assert.Equal(t, 75, field.Tag)
+	assert.Equal(t, model.KindList, field.Type.Kind)
+
+	elem := field.Type.Element
+	assert.Equal(t, model.KindMessage, elem.Kind)
+	assert.Equal(t, "pkg2", elem.Ref.Package.ID)
+	assert.Equal(t, "pkg2", elem.Import.Name)
+	assert.Same(t, elem.Import.Package, elem.Ref.Package)
+}
+
+func TestRequest__should_encode_decode_services(t *testing.T) {
+	pkg := langtest.Package(t, "pkg4")
+
+	b, err := EncodeRequest(pkg, "")
+	require.NoError(t, err)
+
+	pkg1, _, err := DecodeRequest(b)
+	require.NoError(t, err)
+
+	srv := pkg.DefinitionNames["Service"].Service
+	srv1 := pkg1.DefinitionNames["Service"].Service
+	require.Len(t, srv1.Methods, len(srv.Methods))
+
+	for i, m := range srv.Methods {
+		m1 := srv1.Methods[i]
+		assert.Equal(t, m.Name, m1.Name)
+		assert.Equal(t, m.Type, m1.Type)
+		assert.Equal(t, m.Oneway, m1.Oneway)
+		assert.Equal(t, m.Channel != nil, m1.Channel != nil)
+	}
+
+	// Generated request
+	m := srv1.MethodNames["method2"]
+	req := m.Request.Ref
+	assert.True(t, req.Message.Generated)
+	assert.Same(t, req, m.Request.Ref.File.DefinitionNames[req.Name])
+	assert.Nil(t, pkg1.DefinitionNames[req.Name])
+
+	// Subservice
+	m = srv1.MethodNames["subservice"]
+	assert.True(t, m.Subservice.Ref.Service.Sub)
+}
+
+// Response
+
+func TestResponse__should_encode_decode_files(t *testing.T) {
+	files := []File{
+		{Name: "a.txt", Content: []byte("a")},
+		{Name: "dir/b.txt", Content: []byte("b")},
+	}
+
+	b, err := EncodeResponse(files, nil)
+	require.NoError(t, err)
+
+	files1, err := DecodeResponse(b)
+	require.NoError(t, err)
+	assert.Equal(t, files, files1)
+}
+
+func TestResponse__should_return_plugin_error(t *testing.T) {
+	b, err := EncodeResponse(nil, errors.New("test error"))
+	require.NoError(t, err)
+
+	_, err = DecodeResponse(b)
+	assert.EqualError(t, err, "test error")
+}
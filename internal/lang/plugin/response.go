@@ -0,0 +1,70 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/basecomplextech/spec/proto/pplugin"
+)
+
+// File is a file generated by a plugin.
+type File struct {
+	Name    string // Relative file path
+	Content []byte
+}
+
+// EncodeResponse encodes a plugin response with generated files or an error.
+func EncodeResponse(files []File, genErr error) ([]byte, error) {
+	w := pplugin.NewResponseWriter()
+
+	list := w.Files()
+	for _, file := range files {
+		w1 := list.Add()
+		w1.Name(file.Name)
+		w1.Content(file.Content)
+		if err := w1.End(); err != nil {
+			return nil, err
+		}
+	}
+	if err := list.End(); err != nil {
+		return nil, err
+	}
+
+	if genErr != nil {
+		w.Error(genErr.Error())
+	}
+
+	resp, err := w.Build()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Unwrap().Raw(), nil
+}
+
+// DecodeResponse decodes a plugin response and returns generated files,
+// or a plugin error.
+func DecodeResponse(b []byte) ([]File, error) {
+	resp, _, err := pplugin.ParseResponse(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg := resp.Error().Unwrap(); msg != "" {
+		return nil, errors.New(msg)
+	}
+
+	list := resp.Files()
+	files := make([]File, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		file := list.Get(i)
+		files = append(files, File{
+			Name:    file.Name().Clone(),
+			Content: bytes.Clone(file.Content().Unwrap()),
+		})
+	}
+	return files, nil
+}
@@ -17,6 +17,8 @@ const (
 type Definition struct {
 	Type DefinitionType
 	Name string
+	Line int // Line of the definition keyword
+	End  int // Line of the closing brace
 
 	Enum    *Enum
 	Message *Message
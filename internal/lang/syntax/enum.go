@@ -11,4 +11,5 @@ type Enum struct {
 type EnumValue struct {
 	Name  string
 	Value int
+	Line  int
 }
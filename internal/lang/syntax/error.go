@@ -0,0 +1,49 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+import "errors"
+
+// Error is an error at a source position.
+//
+// The error message is the wrapped error message, positions are added to error chains
+// for tools and do not change messages.
+type Error struct {
+	Path   string // File path, empty when unknown
+	Line   int    // Line, zero when unknown
+	Column int    // Column, zero when unknown
+	Err    error
+}
+
+// Error returns the wrapped error message.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Position returns the most specific source position in an error chain.
+//
+// The path is taken from the innermost error with a path, the line and column are taken
+// from the innermost error after it, so that errors in imported packages point to their files.
+func Position(err error) (path string, line int, column int) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		e, ok := err.(*Error)
+		if !ok {
+			continue
+		}
+
+		if e.Path != "" {
+			path, line, column = e.Path, 0, 0
+		}
+		if e.Line > 0 {
+			line, column = e.Line, e.Column
+		}
+	}
+	return path, line, column
+}
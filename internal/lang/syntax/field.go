@@ -8,6 +8,7 @@ type Field struct {
 	Name string
 	Type *Type
 	Tag  int
+	Line int
 }
 
 type Fields []*Field
@@ -4,11 +4,20 @@
 
 package syntax
 
+import "strings"
+
 type File struct {
 	Path        string
 	Imports     []*Import
 	Options     []*Option
 	Definitions []*Definition
+	Comments    []*Comment // Comments in source order
+
+	// Lines of import/option blocks, zero when absent
+	ImportsLine int
+	ImportsEnd  int
+	OptionsLine int
+	OptionsEnd  int
 }
 
 // Import
@@ -16,6 +25,7 @@ type File struct {
 type Import struct {
 	ID    string
 	Alias string
+	Line  int
 }
 
 // Option
@@ -23,4 +33,18 @@ type Import struct {
 type Option struct {
 	Name  string
 	Value string
+	Line  int
+}
+
+// Comment
+
+type Comment struct {
+	Text     string // Comment text including // or /* */
+	Line     int
+	Trailing bool // Comment follows a token on the same line
+}
+
+// End returns the last line of the comment.
+func (c *Comment) End() int {
+	return c.Line + strings.Count(c.Text, "\n")
 }
@@ -11,6 +11,8 @@ type Service struct {
 
 type Method struct {
 	Name string
+	Line int // Line of the method name
+	End  int // Line of the closing semicolon
 
 	Input   MethodInput
 	Output  MethodOutput
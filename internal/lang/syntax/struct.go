@@ -5,10 +5,12 @@
 package syntax
 
 type Struct struct {
+	Key    bool // Key struct
 	Fields []*StructField
 }
 
 type StructField struct {
 	Name string
 	Type *Type
+	Line int
 }
@@ -0,0 +1,54 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+func (w *writer) enum(def *lang.Definition) error {
+	name := def.Name()
+	values := def.Enum().Values()
+
+	w.linef(`// %v`, name)
+	w.line()
+
+	// Values
+	w.linef(`export const %v = {`, name)
+	for _, v := range values {
+		w.linef(`	%v: %d,`, toUpperCamelCase(v.Name()), v.Number())
+	}
+	w.line(`} as const;`)
+	w.line()
+	w.linef(`export type %v = (typeof %v)[keyof typeof %v];`, name, name, name)
+	w.line()
+
+	// Decode/encode
+	w.linef(`export function decode%v(b: Uint8Array): [%v, number] {`, name, name)
+	w.line(`	const [v, n] = spec.decodeInt32(b);`)
+	w.linef(`	return [v as %v, n];`, name)
+	w.line(`}`)
+	w.line()
+
+	w.linef(`export function encode%vTo(b: spec.Buffer, v: %v): number {`, name, name)
+	w.line(`	return spec.encodeInt32(b, v);`)
+	w.line(`}`)
+	w.line()
+
+	// String
+	w.linef(`export function %vString(v: %v): string {`, lowerFirst(name), name)
+	w.line(`	switch (v) {`)
+	for _, v := range values {
+		w.linef(`		case %v.%v:`, name, toUpperCamelCase(v.Name()))
+		w.linef(`			return %q;`, strings.ToLower(v.Name()))
+	}
+	w.line(`	}`)
+	w.line(`	return "";`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+func (w *writer) message(def *lang.Definition) error {
+	name := def.Name()
+
+	w.linef(`// %v`, name)
+	w.line()
+	w.linef(`export class %v {`, name)
+	w.line(`	static readonly Empty = new ` + name + `(spec.Message.Empty);`)
+	w.line()
+	w.line(`	readonly #msg: spec.Message;`)
+	w.line()
+	w.line(`	constructor(msg: spec.Message) {`)
+	w.line(`		this.#msg = msg;`)
+	w.line(`	}`)
+	w.line()
+
+	// Open/parse
+	w.line(`	// open opens a message, returns an empty message on invalid data.`)
+	w.linef(`	static open(b: Uint8Array): %v {`, name)
+	w.linef(`		return new %v(spec.Message.open(b));`, name)
+	w.line(`	}`)
+	w.line()
+	w.line(`	// parse parses a message, returns the message and its size, throws on invalid data.`)
+	w.linef(`	static parse(b: Uint8Array): [%v, number] {`, name)
+	w.line(`		const [msg, size] = spec.Message.parse(b);`)
+	w.linef(`		return [new %v(msg), size];`, name)
+	w.line(`	}`)
+	w.line()
+
+	// Fields
+	fields := def.Message().Fields()
+	for _, field := range fields {
+		if err := w.messageField(field); err != nil {
+			return fmt.Errorf("%v: %w", field.Name(), err)
+		}
+	}
+
+	// Has fields
+	for _, field := range fields {
+		w.linef(`	has%v(): boolean {`, toUpperCamelCase(field.Name()))
+		w.linef(`		return this.#msg.has(%d);`, field.Tag())
+		w.line(`	}`)
+		w.line()
+	}
+
+	// Methods
+	w.line(`	isEmpty(): boolean {`)
+	w.line(`		return this.#msg.isEmpty();`)
+	w.line(`	}`)
+	w.line()
+	w.line(`	unwrap(): spec.Message {`)
+	w.line(`		return this.#msg;`)
+	w.line(`	}`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *writer) messageField(field *lang.Field) error {
+	fname := toLowerCamelCase(field.Name())
+	typ := field.Type()
+	tag := field.Tag()
+
+	tname, err := typeName(typ)
+	if err != nil {
+		return err
+	}
+
+	w.linef(`	%v(): %v {`, fname, tname)
+
+	switch typ.Kind() {
+	case lang.KindAny:
+		w.linef(`		return this.#msg.field(%d) ?? spec.Value.Empty;`, tag)
+	case lang.KindAnyMessage:
+		w.linef(`		return this.#msg.message(%d);`, tag)
+
+	case lang.KindBool,
+		lang.KindByte,
+		lang.KindInt16,
+		lang.KindInt32,
+		lang.KindInt64,
+		lang.KindUint16,
+		lang.KindUint32,
+		lang.KindUint64,
+		lang.KindBin64,
+		lang.KindBin128,
+		lang.KindBin256,
+		lang.KindFloat32,
+		lang.KindFloat64,
+		lang.KindBytes,
+		lang.KindString:
+		method := typ.Kind().String()
+		w.linef(`		return this.#msg.%v(%d);`, method, tag)
+
+	case lang.KindEnum,
+		lang.KindStruct:
+		decode, err := typeDecodeFunc(typ)
+		if err != nil {
+			return err
+		}
+		zero, err := typeZero(typ)
+		if err != nil {
+			return err
+		}
+		w.linef(`		return this.#msg.decode(%d, %v, %v);`, tag, decode, zero)
+
+	case lang.KindMessage:
+		w.linef(`		return new %v(this.#msg.message(%d));`, tname, tag)
+
+	case lang.KindList:
+		decode, err := typeDecodeFunc(typ.Element())
+		if err != nil {
+			return err
+		}
+
+		switch typ.Element().Kind() {
+		case lang.KindMessage, lang.KindAnyMessage:
+			w.linef(`		return new spec.MessageList(this.#msg.list(%d), %v);`, tag, decode)
+		default:
+			w.linef(`		return new spec.ValueList(this.#msg.list(%d), %v);`, tag, decode)
+		}
+
+	default:
+		return fmt.Errorf("unsupported type %v", typ)
+	}
+
+	w.line(`	}`)
+	w.line()
+	return nil
+}
+
+// writer
+
+func (w *writer) messageWriter(def *lang.Definition) error {
+	name := def.Name()
+	wname := name + "Writer"
+
+	w.linef(`// %v`, wname)
+	w.line()
+	w.linef(`export class %v {`, wname)
+	w.line(`	readonly #w: spec.MessageWriter;`)
+	w.line()
+	w.line(`	// constructor returns a writer which writes to a message writer,`)
+	w.line(`	// or begins a new message when no writer is given.`)
+	w.line(`	constructor(w?: spec.MessageWriter) {`)
+	w.line(`		this.#w = w ?? new spec.MessageWriter();`)
+	w.line(`	}`)
+	w.line()
+
+	// Fields
+	for _, field := range def.Message().Fields() {
+		if err := w.messageWriterField(field); err != nil {
+			return fmt.Errorf("%v: %w", field.Name(), err)
+		}
+	}
+
+	// Methods
+	w.linef(`	merge(msg: %v): void {`, name)
+	w.line(`		this.#w.copy(msg.unwrap());`)
+	w.line(`	}`)
+	w.line()
+	w.line(`	end(): void {`)
+	w.line(`		this.#w.end();`)
+	w.line(`	}`)
+	w.line()
+	w.linef(`	build(): %v {`, name)
+	w.line(`		const b = this.#w.build();`)
+	w.linef(`		return %v.parse(b)[0];`, name)
+	w.line(`	}`)
+	w.line()
+	w.line(`	unwrap(): spec.MessageWriter {`)
+	w.line(`		return this.#w;`)
+	w.line(`	}`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *writer) messageWriterField(field *lang.Field) error {
+	fname := toLowerCamelCase(field.Name())
+	cname := "copy" + toUpperCamelCase(field.Name())
+	typ := field.Type()
+	tag := field.Tag()
+
+	switch typ.Kind() {
+	case lang.KindAny:
+		w.linef(`	%v(): spec.FieldWriter {`, fname)
+		w.linef(`		return this.#w.field(%d);`, tag)
+		w.line(`	}`)
+		w.line()
+		w.linef(`	%v(v: spec.Value): void {`, cname)
+		w.linef(`		this.#w.field(%d).any(v.raw);`, tag)
+		w.line(`	}`)
+
+	case lang.KindAnyMessage:
+		w.linef(`	%v(): spec.MessageWriter {`, fname)
+		w.linef(`		return this.#w.field(%d).message();`, tag)
+		w.line(`	}`)
+		w.line()
+		w.linef(`	%v(v: spec.Message): void {`, cname)
+		w.linef(`		this.#w.field(%d).any(v.raw);`, tag)
+		w.line(`	}`)
+
+	case lang.KindBool,
+		lang.KindByte,
+		lang.KindInt16,
+		lang.KindInt32,
+		lang.KindInt64,
+		lang.KindUint16,
+		lang.KindUint32,
+		lang.KindUint64,
+		lang.KindBin64,
+		lang.KindBin128,
+		lang.KindBin256,
+		lang.KindFloat32,
+		lang.KindFloat64,
+		lang.KindBytes,
+		lang.KindString:
+		tname, err := typeName(typ)
+		if err != nil {
+			return err
+		}
+
+		method := typ.Kind().String()
+		w.linef(`	%v(v: %v): void {`, fname, tname)
+		w.linef(`		this.#w.field(%d).%v(v);`, tag, method)
+		w.line(`	}`)
+
+	case lang.KindEnum,
+		lang.KindStruct:
+		tname, err := typeName(typ)
+		if err != nil {
+			return err
+		}
+		encode, err := typeEncodeFunc(typ)
+		if err != nil {
+			return err
+		}
+
+		w.linef(`	%v(v: %v): void {`, fname, tname)
+		w.linef(`		this.#w.field(%d).write(v, %v);`, tag, encode)
+		w.line(`	}`)
+
+	case lang.KindMessage:
+		tname, err := typeName(typ)
+		if err != nil {
+			return err
+		}
+
+		w.linef(`	%v(): %vWriter {`, fname, tname)
+		w.linef(`		return new %vWriter(this.#w.field(%d).message());`, tname, tag)
+		w.line(`	}`)
+		w.line()
+		w.linef(`	%v(v: %v): void {`, cname, tname)
+		w.linef(`		this.#w.field(%d).any(v.unwrap().raw);`, tag)
+		w.line(`	}`)
+
+	case lang.KindList:
+		elem := typ.Element()
+		ename, err := typeName(elem)
+		if err != nil {
+			return err
+		}
+
+		switch elem.Kind() {
+		case lang.KindMessage:
+			w.linef(`	%v(): spec.MessageListWriter<%vWriter> {`, fname, ename)
+			w.linef(`		const list = this.#w.field(%d).list();`, tag)
+			w.linef(`		return new spec.MessageListWriter(list, (w) => new %vWriter(w));`, ename)
+			w.line(`	}`)
+
+		case lang.KindAnyMessage:
+			w.linef(`	%v(): spec.MessageListWriter<spec.MessageWriter> {`, fname)
+			w.linef(`		const list = this.#w.field(%d).list();`, tag)
+			w.line(`		return new spec.MessageListWriter(list, (w) => w);`)
+			w.line(`	}`)
+
+		default:
+			encode, err := typeEncodeFunc(elem)
+			if err != nil {
+				return err
+			}
+
+			w.linef(`	%v(): spec.ValueListWriter<%v> {`, fname, ename)
+			w.linef(`		const list = this.#w.field(%d).list();`, tag)
+			w.linef(`		return new spec.ValueListWriter(list, %v);`, encode)
+			w.line(`	}`)
+		}
+
+	default:
+		return fmt.Errorf("unsupported type %v", typ)
+	}
+
+	w.line()
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"github.com/basecomplextech/spec/lang"
+)
+
+func (w *writer) struct_(def *lang.Definition) error {
+	name := def.Name()
+	fields := def.Struct().Fields()
+
+	w.linef(`// %v`, name)
+	w.line()
+
+	// Interface
+	w.linef(`export interface %v {`, name)
+	for _, field := range fields {
+		tname, err := typeName(field.Type())
+		if err != nil {
+			return err
+		}
+		w.linef(`	%v: %v;`, toLowerCamelCase(field.Name()), tname)
+	}
+	w.line(`}`)
+	w.line()
+
+	// New
+	w.linef(`export function new%v(): %v {`, name, name)
+	w.line(`	return {`)
+	for _, field := range fields {
+		zero, err := typeZero(field.Type())
+		if err != nil {
+			return err
+		}
+		w.linef(`		%v: %v,`, toLowerCamelCase(field.Name()), zero)
+	}
+	w.line(`	};`)
+	w.line(`}`)
+	w.line()
+
+	// Open
+	w.linef(`export function open%v(b: Uint8Array): %v {`, name, name)
+	w.line(`	try {`)
+	w.linef(`		return decode%v(b)[0];`, name)
+	w.line(`	} catch {`)
+	w.linef(`		return new%v();`, name)
+	w.line(`	}`)
+	w.line(`}`)
+	w.line()
+
+	if err := w.structDecode(def); err != nil {
+		return err
+	}
+	return w.structEncode(def)
+}
+
+func (w *writer) structDecode(def *lang.Definition) error {
+	name := def.Name()
+	fields := def.Struct().Fields()
+
+	w.linef(`export function decode%v(b: Uint8Array): [%v, number] {`, name, name)
+	w.line(`	const [dataSize, size] = spec.decodeStruct(b);`)
+	w.linef(`	const s = new%v();`, name)
+	w.line(`	if (size === 0) {`)
+	w.line(`		return [s, 0];`)
+	w.line(`	}`)
+	w.line()
+	w.line(`	b = b.subarray(b.length - size);`)
+	w.line(`	let off = dataSize;`)
+	w.line(`	let n = 0;`)
+	w.line()
+	w.line(`	// Decode in reverse order`)
+
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := fields[i]
+
+		decode, err := typeDecodeFunc(field.Type())
+		if err != nil {
+			return err
+		}
+
+		w.linef(`	[s.%v, n] = %v(b.subarray(0, off));`, toLowerCamelCase(field.Name()), decode)
+		w.line(`	off -= n;`)
+	}
+
+	w.line(`	return [s, size];`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *writer) structEncode(def *lang.Definition) error {
+	name := def.Name()
+	fields := def.Struct().Fields()
+
+	w.linef(`export function encode%vTo(b: spec.Buffer, s: %v): number {`, name, name)
+	w.line(`	let dataSize = 0;`)
+
+	for _, field := range fields {
+		encode, err := typeEncodeFunc(field.Type())
+		if err != nil {
+			return err
+		}
+		w.linef(`	dataSize += %v(b, s.%v);`, encode, toLowerCamelCase(field.Name()))
+	}
+
+	w.line(`	return dataSize + spec.encodeStruct(b, dataSize);`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Cross-check script, run by tsgen tests.
+//
+// Usage:
+//
+//	node check.ts read < message.bin > message.json
+//	node check.ts write [big] > message.bin
+
+import { readFileSync } from "node:fs";
+import * as pkg1 from "./tests/pkg1/pkg1_generated.ts";
+import * as pkg2 from "./tests/pkg2/pkg2_generated.ts";
+
+function hex(b: Uint8Array): string {
+	return Array.from(b, (v) => v.toString(16).padStart(2, "0")).join("");
+}
+
+function fromHex(s: string): Uint8Array {
+	const b = new Uint8Array(s.length / 2);
+	for (let i = 0; i < b.length; i++) {
+		b[i] = parseInt(s.slice(i * 2, i * 2 + 2), 16);
+	}
+	return b;
+}
+
+// read
+
+function readMessage(m: pkg1.Message): object {
+	const message1: Record<string, number> = {};
+	const msg1 = m.message1();
+	for (let i = 0; i < msg1.fields(); i++) {
+		const tag = msg1.tagAt(i);
+		message1[String(tag)] = msg1.int32(tag);
+	}
+
+	return {
+		bool: m.bool(),
+		byte: m.byte(),
+		int16: m.int16(),
+		int32: m.int32(),
+		int64: m.int64().toString(),
+		uint16: m.uint16(),
+		uint32: m.uint32(),
+		uint64: m.uint64().toString(),
+		float32: m.float32(),
+		float64: m.float64(),
+		bin64: hex(m.bin64()),
+		bin128: hex(m.bin128()),
+		bin256: hex(m.bin256()),
+		string: m.string(),
+		bytes1: hex(m.bytes1()),
+		message1: message1,
+		enum1: pkg1.enumString(m.enum1()),
+		struct1: m.struct1(),
+		submessage: readSubmessage(m.submessage()),
+		submessage1: readSubmessage1(m.submessage1()),
+		ints: m.ints().values().map((v) => v.toString()),
+		strings: m.strings().values(),
+		structs: m.structs().values(),
+		submessages: m.submessages().values().map(readSubmessage),
+		submessages1: m.submessages1().values().map(readSubmessage1),
+		any: m.any().int32(),
+		hasAny: m.hasAny(),
+	};
+}
+
+function readSubmessage(m: pkg1.Submessage): object {
+	const result: Record<string, unknown> = { value: m.value() };
+	if (m.hasNext()) {
+		result.next = readSubmessage(m.next());
+	}
+	return result;
+}
+
+function readSubmessage1(m: pkg2.Submessage): object {
+	return { key: m.key(), value: m.value() };
+}
+
+// write
+
+function writeMessage(big: boolean): Uint8Array {
+	const n = big ? 300 : 10;
+	const w = new pkg1.MessageWriter();
+
+	w.bool(true);
+	w.byte(255);
+	w.int16(-0x8000);
+	w.int32(0x7fffffff);
+	w.int64(-(1n << 63n));
+	w.uint16(0xffff);
+	w.uint32(0xffffffff);
+	w.uint64((1n << 64n) - 1n);
+	w.float32(3.4028234663852886e38);
+	w.float64(-1.5);
+	w.bin64(fromHex("0000000000000001"));
+	w.bin128(fromHex("00000000000000000000000000000002"));
+	w.bin256(fromHex("0000000000000000000000000000000000000000000000000000000000000003"));
+	w.string(big ? "x".repeat(70000) : "hello, world");
+	w.bytes1(new TextEncoder().encode("goodbye, world"));
+
+	// Unsorted tags
+	const msg1 = w.message1();
+	msg1.field(3).int32(3);
+	msg1.field(1).int32(1);
+	msg1.field(2).int32(2);
+	msg1.end();
+
+	w.enum1(pkg1.Enum.Two);
+	w.struct1({ key: 1, value: -1 });
+
+	const sub = w.submessage();
+	sub.value("value 000");
+	const next = sub.next();
+	next.value("value 001");
+	next.end();
+	sub.end();
+
+	const sub1 = w.submessage1();
+	sub1.key("key 000");
+	sub1.value({ x: 1, y: -1 });
+	sub1.end();
+
+	const ints = w.ints();
+	for (let i = 0; i < n; i++) {
+		ints.add(BigInt(i * 1000 - 5000));
+	}
+	ints.end();
+
+	const strings = w.strings();
+	for (let i = 0; i < n; i++) {
+		strings.add(`hello, world ${String(i).padStart(3, "0")}`);
+	}
+	strings.end();
+
+	const structs = w.structs();
+	for (let i = 0; i < n; i++) {
+		structs.add({ key: i, value: -i });
+	}
+	structs.end();
+
+	const subs = w.submessages();
+	for (let i = 0; i < n; i++) {
+		const s = subs.add();
+		s.value(`value ${String(i).padStart(3, "0")}`);
+		s.end();
+	}
+	subs.end();
+
+	const subs1 = w.submessages1();
+	for (let i = 0; i < n; i++) {
+		const s = subs1.add();
+		s.key(`key ${String(i).padStart(3, "0")}`);
+		s.value({ x: i, y: -i });
+		s.end();
+	}
+	subs1.end();
+
+	w.any().int32(7);
+	return w.build().unwrap().raw;
+}
+
+// main
+
+const mode = process.argv[2];
+switch (mode) {
+	case "read": {
+		const b = new Uint8Array(readFileSync(0));
+		const [m] = pkg1.Message.parse(b);
+		process.stdout.write(JSON.stringify(readMessage(m)));
+		break;
+	}
+
+	case "write": {
+		const b = writeMessage(process.argv[3] === "big");
+		process.stdout.write(hex(b));
+		break;
+	}
+
+	default:
+		throw new Error(`unknown mode ${mode}`);
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package tsgen generates TypeScript code from spec packages.
+//
+// The generator emits one "<package>_generated.ts" file per package with enums, structs,
+// read-only message views and message writers. The generated code depends on the TypeScript
+// runtime in the "ts" directory of this repository.
+//
+// Imported packages are referenced by relative paths computed from the package directories,
+// so imported packages must be generated into the same relative locations.
+package tsgen
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+const (
+	// DefaultRuntime is the default runtime module import path.
+	DefaultRuntime = "@basecomplextech/spec"
+
+	// DefaultExt is the default import extension of generated files.
+	DefaultExt = ".ts"
+)
+
+// Options specifies the generator options.
+type Options struct {
+	Runtime string // Runtime module import path, defaults to DefaultRuntime
+	Ext     string // Import extension of generated files, defaults to DefaultExt, "none" for none
+}
+
+// ParseOptions parses options from a plugin parameter as "runtime=./spec.ts,ext=.js".
+func ParseOptions(param string) (Options, error) {
+	var opts Options
+	if param == "" {
+		return opts, nil
+	}
+
+	for _, part := range strings.Split(param, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return opts, fmt.Errorf("invalid parameter %q, expected key=value", part)
+		}
+
+		switch key {
+		case "runtime":
+			opts.Runtime = value
+		case "ext":
+			opts.Ext = value
+		default:
+			return opts, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// Filename returns a generated file name for a package.
+func Filename(pkg *lang.Package) string {
+	return pkg.Name() + "_generated.ts"
+}
+
+// Generate generates a TypeScript file for a package.
+func Generate(pkg *lang.Package, opts Options) ([]lang.PluginFile, error) {
+	if opts.Runtime == "" {
+		opts.Runtime = DefaultRuntime
+	}
+	switch opts.Ext {
+	case "":
+		opts.Ext = DefaultExt
+	case "none":
+		opts.Ext = ""
+	}
+
+	w := newWriter(opts)
+	if err := w.package_(pkg); err != nil {
+		return nil, fmt.Errorf("%v: %w", pkg.ID(), err)
+	}
+
+	file := lang.PluginFile{
+		Name:    Filename(pkg),
+		Content: w.b.Bytes(),
+	}
+	return []lang.PluginFile{file}, nil
+}
+
+// internal
+
+func (w *writer) package_(pkg *lang.Package) error {
+	w.line(`// Code generated by spec-gen-ts. DO NOT EDIT.`)
+	w.line()
+
+	if err := w.imports(pkg); err != nil {
+		return err
+	}
+
+	for _, def := range definitions(pkg) {
+		if err := w.definition(def); err != nil {
+			return fmt.Errorf("%v: %w", def.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (w *writer) imports(pkg *lang.Package) error {
+	w.linef(`import * as spec from %q;`, w.opts.Runtime)
+
+	// Collect imports from all files, they are unique by names in a package
+	imports := make(map[string]*lang.Import)
+	for _, file := range pkg.Files() {
+		for _, imp := range file.Imports() {
+			imports[imp.Name()] = imp
+		}
+	}
+
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		imp := imports[name]
+
+		path, err := importPath(pkg, imp.Package(), w.opts.Ext)
+		if err != nil {
+			return err
+		}
+		w.linef(`import * as %v from %q;`, name, path)
+	}
+
+	w.line()
+	return nil
+}
+
+func (w *writer) definition(def *lang.Definition) error {
+	switch def.Type() {
+	case lang.DefinitionEnum:
+		return w.enum(def)
+	case lang.DefinitionMessage:
+		if err := w.message(def); err != nil {
+			return err
+		}
+		return w.messageWriter(def)
+	case lang.DefinitionStruct:
+		return w.struct_(def)
+	}
+
+	// Services are not supported yet
+	return nil
+}
+
+// definitions returns package definitions including generated ones in file order.
+func definitions(pkg *lang.Package) []*lang.Definition {
+	var result []*lang.Definition
+	for _, file := range pkg.Files() {
+		result = append(result, file.Definitions()...)
+	}
+	return result
+}
+
+// importPath returns a relative import path of a generated package file.
+func importPath(pkg *lang.Package, imp *lang.Package, ext string) (string, error) {
+	dir, err := filepath.Rel(pkg.Path(), imp.Path())
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve import path %q: %w", imp.ID(), err)
+	}
+
+	name := strings.TrimSuffix(Filename(imp), ".ts") + ext
+	path := filepath.ToSlash(filepath.Join(dir, name))
+	if !strings.HasPrefix(path, ".") {
+		path = "./" + path
+	}
+	return path, nil
+}
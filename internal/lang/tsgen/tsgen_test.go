@@ -0,0 +1,438 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/tests/pkg1"
+	"github.com/basecomplextech/spec/internal/tests/pkg2"
+	"github.com/basecomplextech/spec/internal/tests/pkg3/pkg3a"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNodeEnv specifies a node binary which supports type stripping, i.e. node 22.6+,
+// defaults to "node".
+const testNodeEnv = "SPEC_NODE"
+
+// testRequiredEnv fails typescript tests instead of skipping them when node is not available,
+// it is set in CI.
+const testRequiredEnv = "SPEC_TS_REQUIRED"
+
+func testCompile(t *testing.T, name string) *lang.Package {
+	pkg, err := lang.Compile("../../tests/"+name, []string{"../../tests"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+// testGenerate generates pkg1 and its imports into a temp directory with the runtime
+// and the check script, returns the directory.
+func testGenerate(t *testing.T) string {
+	dir := t.TempDir()
+	opts := Options{Runtime: filepath.Join(dir, "spec.ts")}
+
+	var generate func(pkg *lang.Package)
+	done := make(map[string]bool)
+
+	generate = func(pkg *lang.Package) {
+		rel, err := filepath.Rel("../../tests", pkg.Path())
+		require.NoError(t, err)
+		if done[rel] {
+			return
+		}
+		done[rel] = true
+
+		files, err := Generate(pkg, opts)
+		require.NoError(t, err)
+
+		for _, file := range files {
+			path := filepath.Join(dir, "tests", rel, file.Name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, file.Content, 0644))
+		}
+
+		for _, imp := range pkg.Imports() {
+			generate(imp)
+		}
+	}
+	generate(testCompile(t, "pkg1"))
+
+	testCopy(t, "../../../ts/spec.ts", filepath.Join(dir, "spec.ts"))
+	testCopy(t, "testdata/check.ts", filepath.Join(dir, "check.ts"))
+
+	pkgJSON := []byte(`{"type": "module"}`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), pkgJSON, 0644))
+	return dir
+}
+
+func testCopy(t *testing.T, src string, dst string) {
+	b, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, b, 0644))
+}
+
+// testNode returns a node binary or skips the test when node does not support type stripping,
+// fails the test instead when SPEC_TS_REQUIRED is set.
+func testNode(t *testing.T) string {
+	skip := t.Skipf
+	if os.Getenv(testRequiredEnv) != "" {
+		skip = t.Fatalf
+	}
+
+	node := os.Getenv(testNodeEnv)
+	if node == "" {
+		node = "node"
+	}
+
+	path, err := exec.LookPath(node)
+	if err != nil {
+		skip("node not found, set %v to run typescript tests", testNodeEnv)
+	}
+
+	dir := t.TempDir()
+	probe := filepath.Join(dir, "probe.ts")
+	require.NoError(t, os.WriteFile(probe, []byte("const x: number = 1;\n"), 0644))
+
+	cmd := exec.Command(path, "--experimental-strip-types", "--no-warnings", probe)
+	if err := cmd.Run(); err != nil {
+		version, _ := exec.Command(path, "--version").Output()
+		skip("node %v does not support type stripping, typescript tests require node 22.6+, "+
+			"set %v to a newer node", strings.TrimSpace(string(version)), testNodeEnv)
+	}
+	return path
+}
+
+func testRun(t *testing.T, node string, dir string, stdin []byte, args ...string) []byte {
+	args = append([]string{"--experimental-strip-types", "--no-warnings", "check.ts"}, args...)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command(node, args...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("%v: %s", err, stderr.Bytes())
+	}
+	return stdout.Bytes()
+}
+
+// testWriteMessage writes the same message as check.ts.
+func testWriteMessage(t *testing.T, big bool) []byte {
+	return testWriteMessageTo(t, pkg1.NewMessageWriter(), big, false)
+}
+
+// testWriteVarintMessage writes a message with mid-range integers encoded as varints.
+func testWriteVarintMessage(t *testing.T) []byte {
+	w := spec.NewWriter()
+	w.SetVarint(true)
+	return testWriteMessageTo(t, pkg1.NewMessageWriterTo(w.Message()), false, true)
+}
+
+func testWriteMessageTo(t *testing.T, w pkg1.MessageWriter, big bool, varint bool) []byte {
+	n := 10
+	str := "hello, world"
+	if big {
+		n = 300
+		str = strings.Repeat("x", 70000)
+	}
+
+	w.Bool(true)
+	w.Byte(255)
+	if varint {
+		w.Int16(-1000)
+		w.Int32(1000)
+		w.Int64(-100_000)
+		w.Uint16(1000)
+		w.Uint32(100_000)
+		w.Uint64(100_000)
+	} else {
+		w.Int16(math.MinInt16)
+		w.Int32(math.MaxInt32)
+		w.Int64(math.MinInt64)
+		w.Uint16(math.MaxUint16)
+		w.Uint32(math.MaxUint32)
+		w.Uint64(math.MaxUint64)
+	}
+	w.Float32(math.MaxFloat32)
+	w.Float64(-1.5)
+	w.Bin64(bin.Int64(1))
+	w.Bin128(bin.Int128(0, 2))
+	w.Bin256(bin.Int256(0, 0, 0, 3))
+	w.String(str)
+	w.Bytes1([]byte("goodbye, world"))
+
+	// Unsorted tags
+	msg1 := w.Message1()
+	msg1.Field(3).Int32(3)
+	msg1.Field(1).Int32(1)
+	msg1.Field(2).Int32(2)
+	require.NoError(t, msg1.End())
+
+	w.Enum1(pkg1.Enum_Two)
+	w.Struct1(pkg1.Struct{Key: 1, Value: -1})
+
+	sub := w.Submessage()
+	sub.Value("value 000")
+	next := sub.Next()
+	next.Value("value 001")
+	require.NoError(t, next.End())
+	require.NoError(t, sub.End())
+
+	sub1 := w.Submessage1()
+	sub1.Key("key 000")
+	sub1.Value(pkg3a.Value{X: 1, Y: -1})
+	require.NoError(t, sub1.End())
+
+	ints := w.Ints()
+	for i := 0; i < n; i++ {
+		ints.Add(int64(i*1000 - 5000))
+	}
+	require.NoError(t, ints.End())
+
+	strs := w.Strings()
+	for i := 0; i < n; i++ {
+		strs.Add(fmt.Sprintf("hello, world %03d", i))
+	}
+	require.NoError(t, strs.End())
+
+	structs := w.Structs()
+	for i := 0; i < n; i++ {
+		structs.Add(pkg1.Struct{Key: int32(i), Value: -int32(i)})
+	}
+	require.NoError(t, structs.End())
+
+	subs := w.Submessages()
+	for i := 0; i < n; i++ {
+		s := subs.Add()
+		s.Value(fmt.Sprintf("value %03d", i))
+		require.NoError(t, s.End())
+	}
+	require.NoError(t, subs.End())
+
+	subs1 := w.Submessages1()
+	for i := 0; i < n; i++ {
+		s := subs1.Add()
+		s.Key(fmt.Sprintf("key %03d", i))
+		s.Value(pkg3a.Value{X: int32(i), Y: -int32(i)})
+		require.NoError(t, s.End())
+	}
+	require.NoError(t, subs1.End())
+
+	w.Any().Int32(7)
+
+	m, err := w.Build()
+	require.NoError(t, err)
+	return m.Unwrap().Raw()
+}
+
+// testReadMessage reads a message into the same json as check.ts.
+func testReadMessage(t *testing.T, m pkg1.Message) any {
+	message1 := make(map[string]any)
+	msg1 := m.Message1()
+	for i := 0; i < msg1.Fields(); i++ {
+		tag, _ := msg1.TagAt(i)
+		message1[strconv.Itoa(int(tag))] = msg1.Int32(tag)
+	}
+
+	var readSubmessage func(m pkg1.Submessage) map[string]any
+	readSubmessage = func(m pkg1.Submessage) map[string]any {
+		result := map[string]any{"value": m.Value().Unwrap()}
+		if m.HasNext() {
+			result["next"] = readSubmessage(m.Next())
+		}
+		return result
+	}
+	readSubmessage1 := func(m pkg2.Submessage) map[string]any {
+		v := m.Value()
+		return map[string]any{
+			"key":   m.Key().Unwrap(),
+			"value": map[string]any{"x": v.X, "y": v.Y},
+		}
+	}
+
+	var ints, strs, structs, subs, subs1 []any
+	for _, v := range m.Ints().Values() {
+		ints = append(ints, strconv.FormatInt(v, 10))
+	}
+	for _, v := range m.Strings().Values() {
+		strs = append(strs, v.Unwrap())
+	}
+	for _, v := range m.Structs().Values() {
+		structs = append(structs, map[string]any{"key": v.Key, "value": v.Value})
+	}
+	for i := 0; i < m.Submessages().Len(); i++ {
+		subs = append(subs, readSubmessage(m.Submessages().Get(i)))
+	}
+	for i := 0; i < m.Submessages1().Len(); i++ {
+		subs1 = append(subs1, readSubmessage1(m.Submessages1().Get(i)))
+	}
+
+	bin64, bin128, bin256 := m.Bin64(), m.Bin128(), m.Bin256()
+	result := map[string]any{
+		"bool":         m.Bool(),
+		"byte":         m.Byte(),
+		"int16":        m.Int16(),
+		"int32":        m.Int32(),
+		"int64":        strconv.FormatInt(m.Int64(), 10),
+		"uint16":       m.Uint16(),
+		"uint32":       m.Uint32(),
+		"uint64":       strconv.FormatUint(m.Uint64(), 10),
+		"float32":      float64(m.Float32()),
+		"float64":      m.Float64(),
+		"bin64":        hex.EncodeToString(bin64.Marshal()),
+		"bin128":       hex.EncodeToString(bin128.Marshal()),
+		"bin256":       hex.EncodeToString(bin256.Marshal()),
+		"string":       m.String().Unwrap(),
+		"bytes1":       hex.EncodeToString(m.Bytes1()),
+		"message1":     message1,
+		"enum1":        m.Enum1().String(),
+		"struct1":      map[string]any{"key": m.Struct1().Key, "value": m.Struct1().Value},
+		"submessage":   readSubmessage(m.Submessage()),
+		"submessage1":  readSubmessage1(m.Submessage1()),
+		"ints":         ints,
+		"strings":      strs,
+		"structs":      structs,
+		"submessages":  subs,
+		"submessages1": subs1,
+		"any":          m.Any().Int32(),
+		"hasAny":       m.HasAny(),
+	}
+
+	// Normalize numbers
+	b, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var v any
+	require.NoError(t, json.Unmarshal(b, &v))
+	return v
+}
+
+// Generate
+
+func TestGenerate__should_generate_package_file(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	files, err := Generate(pkg, Options{})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	file := files[0]
+	content := string(file.Content)
+	assert.Equal(t, "pkg1_generated.ts", file.Name)
+	assert.Contains(t, content, `import * as spec from "@basecomplextech/spec";`)
+	assert.Contains(t, content, `import * as pkg2 from "../pkg2/pkg2_generated.ts";`)
+	assert.Contains(t, content, `export class Message {`)
+	assert.Contains(t, content, `export class MessageWriter {`)
+	assert.Contains(t, content, `export interface Struct {`)
+	assert.Contains(t, content, `export const Enum = {`)
+}
+
+func TestGenerate__should_apply_options(t *testing.T) {
+	pkg := testCompile(t, "pkg2")
+
+	opts, err := ParseOptions("runtime=./spec.js,ext=.js")
+	require.NoError(t, err)
+
+	files, err := Generate(pkg, opts)
+	require.NoError(t, err)
+
+	content := string(files[0].Content)
+	assert.Contains(t, content, `import * as spec from "./spec.js";`)
+	assert.Contains(t, content, `import * as pkg3a from "../pkg3/pkg3a/pkg3a_generated.js";`)
+}
+
+func TestParseOptions__should_return_error_on_unknown_parameter(t *testing.T) {
+	_, err := ParseOptions("runtime=./spec.ts,unknown=1")
+	assert.Error(t, err)
+}
+
+// Cross-check
+
+func TestTypeScript__should_read_go_message(t *testing.T) {
+	node := testNode(t)
+	dir := testGenerate(t)
+
+	for _, big := range []bool{false, true} {
+		b := testWriteMessage(t, big)
+		expected := testReadMessage(t, pkg1.OpenMessage(b))
+
+		out := testRun(t, node, dir, b, "read")
+
+		var actual any
+		require.NoError(t, json.Unmarshal(out, &actual))
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestTypeScript__should_read_go_varint_message(t *testing.T) {
+	node := testNode(t)
+	dir := testGenerate(t)
+
+	b := testWriteVarintMessage(t)
+	field := pkg1.OpenMessage(b).Unwrap().Field(12)
+	require.Equal(t, byte(format.TypeVarint), field[len(field)-1])
+
+	expected := testReadMessage(t, pkg1.OpenMessage(b))
+
+	out := testRun(t, node, dir, b, "read")
+
+	var actual any
+	require.NoError(t, json.Unmarshal(out, &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestTypeScript__should_write_same_bytes_as_go(t *testing.T) {
+	node := testNode(t)
+	dir := testGenerate(t)
+
+	for _, big := range []bool{false, true} {
+		expected := testWriteMessage(t, big)
+
+		args := []string{"write"}
+		if big {
+			args = append(args, "big")
+		}
+
+		out := testRun(t, node, dir, nil, args...)
+		actual, err := hex.DecodeString(string(out))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		// Check table types
+		typ := format.TypeMessage
+		if big {
+			typ = format.TypeBigMessage
+		}
+		assert.Equal(t, byte(typ), actual[len(actual)-1])
+
+		m := pkg1.OpenMessage(actual)
+		ints := spec.OpenList(m.Unwrap().FieldRaw(70))
+		assert.Equal(t, format.TypePackedList, format.Type(ints.Raw()[len(ints.Raw())-1]))
+
+		strs := spec.OpenList(m.Unwrap().FieldRaw(71))
+		assert.Equal(t, big, format.Type(strs.Raw()[len(strs.Raw())-1]) == format.TypeBigList)
+	}
+}
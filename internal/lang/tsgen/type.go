@@ -0,0 +1,228 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// typeName returns a TypeScript type name.
+func typeName(t *lang.Type) (string, error) {
+	switch t.Kind() {
+	case lang.KindAny:
+		return "spec.Value", nil
+
+	case lang.KindBool:
+		return "boolean", nil
+
+	case lang.KindByte,
+		lang.KindInt16,
+		lang.KindInt32,
+		lang.KindUint16,
+		lang.KindUint32,
+		lang.KindFloat32,
+		lang.KindFloat64:
+		return "number", nil
+
+	case lang.KindInt64,
+		lang.KindUint64:
+		return "bigint", nil
+
+	case lang.KindBin64:
+		return "spec.Bin64", nil
+	case lang.KindBin128:
+		return "spec.Bin128", nil
+	case lang.KindBin256:
+		return "spec.Bin256", nil
+
+	case lang.KindBytes:
+		return "Uint8Array", nil
+	case lang.KindString:
+		return "string", nil
+	case lang.KindAnyMessage:
+		return "spec.Message", nil
+
+	case lang.KindList:
+		elem := t.Element()
+		elemName, err := typeName(elem)
+		if err != nil {
+			return "", err
+		}
+
+		switch elem.Kind() {
+		case lang.KindMessage, lang.KindAnyMessage:
+			return fmt.Sprintf("spec.MessageList<%v>", elemName), nil
+		case lang.KindList:
+			return "", fmt.Errorf("nested lists are not supported")
+		}
+		return fmt.Sprintf("spec.ValueList<%v>", elemName), nil
+
+	case lang.KindEnum,
+		lang.KindMessage,
+		lang.KindStruct:
+		return typeRefName(t, t.Name()), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %v", t)
+}
+
+// typeRefName returns a name qualified with an import name when the type is imported.
+func typeRefName(t *lang.Type, name string) string {
+	if t.ImportName() == "" {
+		return name
+	}
+	return t.ImportName() + "." + name
+}
+
+// typeDecodeFunc returns a decode function which returns a value and its size.
+func typeDecodeFunc(t *lang.Type) (string, error) {
+	switch t.Kind() {
+	case lang.KindAny:
+		return "spec.decodeValue", nil
+
+	case lang.KindBool:
+		return "spec.decodeBool", nil
+	case lang.KindByte:
+		return "spec.decodeByte", nil
+
+	case lang.KindInt16:
+		return "spec.decodeInt16", nil
+	case lang.KindInt32:
+		return "spec.decodeInt32", nil
+	case lang.KindInt64:
+		return "spec.decodeInt64", nil
+
+	case lang.KindUint16:
+		return "spec.decodeUint16", nil
+	case lang.KindUint32:
+		return "spec.decodeUint32", nil
+	case lang.KindUint64:
+		return "spec.decodeUint64", nil
+
+	case lang.KindBin64:
+		return "spec.decodeBin64", nil
+	case lang.KindBin128:
+		return "spec.decodeBin128", nil
+	case lang.KindBin256:
+		return "spec.decodeBin256", nil
+
+	case lang.KindFloat32:
+		return "spec.decodeFloat32", nil
+	case lang.KindFloat64:
+		return "spec.decodeFloat64", nil
+
+	case lang.KindBytes:
+		return "spec.decodeBytes", nil
+	case lang.KindString:
+		return "spec.decodeString", nil
+	case lang.KindAnyMessage:
+		return "spec.decodeMessage", nil
+
+	case lang.KindEnum, lang.KindStruct:
+		return typeRefName(t, "decode"+t.Name()), nil
+	case lang.KindMessage:
+		return typeRefName(t, t.Name()) + ".parse", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %v", t)
+}
+
+// typeEncodeFunc returns an encode function which writes a value into a buffer.
+func typeEncodeFunc(t *lang.Type) (string, error) {
+	switch t.Kind() {
+	case lang.KindAny:
+		return "spec.encodeValue", nil
+
+	case lang.KindBool:
+		return "spec.encodeBool", nil
+	case lang.KindByte:
+		return "spec.encodeByte", nil
+
+	case lang.KindInt16:
+		return "spec.encodeInt16", nil
+	case lang.KindInt32:
+		return "spec.encodeInt32", nil
+	case lang.KindInt64:
+		return "spec.encodeInt64", nil
+
+	case lang.KindUint16:
+		return "spec.encodeUint16", nil
+	case lang.KindUint32:
+		return "spec.encodeUint32", nil
+	case lang.KindUint64:
+		return "spec.encodeUint64", nil
+
+	case lang.KindBin64:
+		return "spec.encodeBin64", nil
+	case lang.KindBin128:
+		return "spec.encodeBin128", nil
+	case lang.KindBin256:
+		return "spec.encodeBin256", nil
+
+	case lang.KindFloat32:
+		return "spec.encodeFloat32", nil
+	case lang.KindFloat64:
+		return "spec.encodeFloat64", nil
+
+	case lang.KindBytes:
+		return "spec.encodeBytes", nil
+	case lang.KindString:
+		return "spec.encodeString", nil
+	case lang.KindAnyMessage:
+		return "spec.encodeMessage", nil
+
+	case lang.KindEnum, lang.KindStruct:
+		return typeRefName(t, "encode"+t.Name()+"To"), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %v", t)
+}
+
+// typeZero returns a zero value expression.
+func typeZero(t *lang.Type) (string, error) {
+	switch t.Kind() {
+	case lang.KindAny:
+		return "spec.Value.Empty", nil
+
+	case lang.KindBool:
+		return "false", nil
+
+	case lang.KindByte,
+		lang.KindInt16,
+		lang.KindInt32,
+		lang.KindUint16,
+		lang.KindUint32,
+		lang.KindFloat32,
+		lang.KindFloat64:
+		return "0", nil
+
+	case lang.KindInt64,
+		lang.KindUint64:
+		return "0n", nil
+
+	case lang.KindBin64:
+		return "new Uint8Array(8)", nil
+	case lang.KindBin128:
+		return "new Uint8Array(16)", nil
+	case lang.KindBin256:
+		return "new Uint8Array(32)", nil
+
+	case lang.KindBytes:
+		return "new Uint8Array(0)", nil
+	case lang.KindString:
+		return `""`, nil
+	case lang.KindAnyMessage:
+		return "spec.Message.Empty", nil
+
+	case lang.KindEnum:
+		return fmt.Sprintf("0 as %v", typeRefName(t, t.Name())), nil
+	case lang.KindStruct:
+		return typeRefName(t, "new"+t.Name()) + "()", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %v", t)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package tsgen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type writer struct {
+	b bytes.Buffer
+
+	opts Options
+}
+
+func newWriter(opts Options) *writer {
+	return &writer{
+		b: bytes.Buffer{},
+
+		opts: opts,
+	}
+}
+
+func (w *writer) line(args ...string) {
+	w.write(args...)
+	w.b.WriteString("\n")
+}
+
+func (w *writer) linef(format string, args ...interface{}) {
+	w.writef(format, args...)
+	w.b.WriteString("\n")
+}
+
+func (w *writer) write(args ...string) {
+	for _, s := range args {
+		w.b.WriteString(s)
+	}
+}
+
+func (w *writer) writef(format string, args ...interface{}) {
+	if len(args) == 0 {
+		w.write(format)
+		return
+	}
+
+	s := fmt.Sprintf(format, args...)
+	w.b.WriteString(s)
+}
+
+// internal
+
+func toUpperCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		part = strings.ToLower(part)
+		part = strings.Title(part)
+		parts[i] = part
+	}
+
+	s1 := strings.Join(parts, "")
+	if strings.HasPrefix(s, "_") {
+		s1 = "_" + s1
+	}
+	if strings.HasSuffix(s, "_") {
+		s1 += "_"
+	}
+	return s1
+}
+
+func toLowerCamelCase(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	s = toUpperCamelCase(s)
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// lowerFirst lowers a leading uppercase run of a name, i.e. "HTTPMethod" => "httpMethod".
+func lowerFirst(s string) string {
+	r := []rune(s)
+	for i := 0; i < len(r); i++ {
+		if !unicode.IsUpper(r[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
@@ -59,3 +59,16 @@ struct ComplexStruct {
     bin256  bin256;
     string  string;
 }
+
+key struct Record {
+    tenant  bin128;
+    time    int64;
+    name    string;
+    enum    Enum;
+    version Version;
+}
+
+key struct Version {
+    major   uint32;
+    minor   uint32;
+}
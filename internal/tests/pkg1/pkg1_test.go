@@ -5,11 +5,13 @@
 package pkg1
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"testing"
 
 	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -108,3 +110,112 @@ func TestParseMessage__should_parse_message(t *testing.T) {
 	assert.Equal(t, len(b), n)
 	assert.Equal(t, m, m1)
 }
+
+func TestToJSON__should_round_trip_message(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Write(NewMessageWriter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := m.Unwrap().Raw()
+
+	data, err := spec.ToJSON(spec.Value(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := spec.FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, []byte(v))
+}
+
+func TestFormatText__should_round_trip_message(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Write(NewMessageWriter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := m.Unwrap().Raw()
+
+	s, err := spec.FormatText(spec.Value(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := spec.NewWriter()
+	defer w.Free()
+
+	b1, err := spec.ParseText(w, []byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, b, b1)
+}
+
+func TestParseMessageWithOptions__should_return_error_when_limit_exceeded(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Write(NewMessageWriter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := m.Unwrap().Raw()
+
+	_, _, err = ParseMessageWithOptions(b, spec.ParseOptions{MaxDepth: 3})
+	assert.NoError(t, err)
+
+	_, _, err = ParseMessageWithOptions(b, spec.ParseOptions{MaxDepth: 1})
+	assert.ErrorIs(t, err, spec.ErrParseLimit)
+}
+
+func TestRecordKey__should_decode_key(t *testing.T) {
+	r := Record{
+		Tenant:  bin.Random128(),
+		Time:    -123,
+		Name:    "hello\x00world",
+		Enum:    Enum_Two,
+		Version: Version{Major: 1, Minor: 2},
+	}
+	b := RecordKey(r)
+
+	r1, n, err := DecodeRecordKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, r, r1)
+	assert.Equal(t, len(b), n)
+}
+
+func TestRecordKey__should_preserve_order(t *testing.T) {
+	tenant := bin.Int128(1, 1)
+	records := []Record{
+		{Tenant: tenant, Time: -1, Name: "b"},
+		{Tenant: tenant, Time: 0, Name: ""},
+		{Tenant: tenant, Time: 0, Name: "a"},
+		{Tenant: tenant, Time: 0, Name: "a", Enum: Enum_One},
+		{Tenant: tenant, Time: 0, Name: "a", Enum: Enum_One, Version: Version{Major: 1}},
+		{Tenant: tenant, Time: 0, Name: "a", Enum: Enum_One, Version: Version{Major: 1, Minor: 1}},
+		{Tenant: tenant, Time: 0, Name: "ab"},
+		{Tenant: bin.Int128(1, 2), Time: math.MinInt64},
+	}
+
+	for i := 1; i < len(records); i++ {
+		prev := RecordKey(records[i-1])
+		next := RecordKey(records[i])
+		assert.Equal(t, -1, bytes.Compare(prev, next), i)
+	}
+}
+
+func TestNewValueListWriter__should_return_pack_error(t *testing.T) {
+	w := spec.NewWriter()
+	list := w.List()
+	list.Message() // pending element, the list cannot be packed
+
+	ints := spec.NewValueListWriter(list, spec.EncodeInt64)
+	assert.Error(t, ints.Add(1))
+	assert.Error(t, ints.End())
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package pkg4
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/logging"
+	"github.com/basecomplextech/baselibrary/ref"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRequest(t *testing.T, msg string) Request {
+	w := NewRequestWriter()
+	w.Msg(msg)
+
+	req, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// MockServiceClient
+
+func TestMockServiceClient__should_call_func_and_record_call(t *testing.T) {
+	ctx := async.NoContext()
+	req := testRequest(t, "hello")
+
+	client := &MockServiceClient{}
+	client.Method3Func = func(ctx async.Context, req Request) (ref.R[Response], status.Status) {
+		w := NewResponseWriter()
+		w.Msg(req.Msg().Unwrap())
+
+		resp, err := w.Build()
+		if err != nil {
+			return nil, status.WrapError(err)
+		}
+		return ref.NewNoop(resp), status.OK
+	}
+
+	resp, st := client.Method3(ctx, req)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	assert.Equal(t, "hello", resp.Unwrap().Msg().Unwrap())
+	assert.Equal(t, 1, client.CallCount("Method3"))
+
+	call, ok := client.LastCall("Method3")
+	assert.True(t, ok)
+	assert.Equal(t, req, call.Request)
+}
+
+func TestMockServiceClient__should_return_unsupported_when_not_mocked(t *testing.T) {
+	ctx := async.NoContext()
+	client := &MockServiceClient{}
+
+	st := client.Method(ctx)
+	assert.Equal(t, status.CodeUnsupported, st.Code)
+
+	_, st = client.Method3(ctx, testRequest(t, "hello"))
+	assert.Equal(t, status.CodeUnsupported, st.Code)
+
+	_, st = client.Method21(ctx, testRequest(t, "hello"))
+	assert.Equal(t, status.CodeUnsupported, st.Code)
+
+	calls := client.Calls()
+	assert.Equal(t, []string{"Method", "Method3", "Method21"}, []string{
+		calls[0].Method,
+		calls[1].Method,
+		calls[2].Method,
+	})
+}
+
+func TestMockServiceClient__should_chain_subservice_calls(t *testing.T) {
+	ctx := async.NoContext()
+
+	sub := &MockSubserviceCall{}
+	sub.HelloFunc = func(ctx async.Context, req SubserviceHelloRequest) (
+		ref.R[SubserviceHelloResponse], status.Status) {
+		w := NewSubserviceHelloResponseWriter()
+		w.Msg(req.Msg().Unwrap())
+
+		resp, err := w.Build()
+		if err != nil {
+			return nil, status.WrapError(err)
+		}
+		return ref.NewNoop(resp), status.OK
+	}
+	client := &MockServiceClient{SubserviceCall: sub}
+
+	w0 := NewServiceSubserviceRequestWriter()
+	w0.Id(bin.Int128(0, 123))
+	req0, err := w0.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w1 := NewSubserviceHelloRequestWriter()
+	w1.Msg("hello")
+	req1, err := w1.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, st := client.Subservice(req0).Hello(ctx, req1)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+
+	assert.Equal(t, "hello", resp.Unwrap().Msg().Unwrap())
+	assert.Equal(t, 1, client.CallCount("Subservice"))
+	assert.Equal(t, 1, sub.CallCount("Hello"))
+}
+
+func TestMockServiceClient__should_return_unsupported_subservice_call_when_not_mocked(t *testing.T) {
+	ctx := async.NoContext()
+	client := &MockServiceClient{}
+
+	_, st := client.Subservice(ServiceSubserviceRequest{}).Hello(ctx, SubserviceHelloRequest{})
+	assert.Equal(t, status.CodeUnsupported, st.Code)
+}
+
+// MockServiceMethod23ClientChannel
+
+func TestMockServiceClientChannel__should_send_and_receive_messages(t *testing.T) {
+	ctx := async.NoContext()
+
+	out := NewOutWriter()
+	out.A(1)
+	msg, err := out.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := &MockServiceMethod23ClientChannel{
+		Messages: []Out{msg},
+		Result:   testResponse(t, "done"),
+	}
+	client := &MockServiceClient{Method23Channel: ch}
+
+	ch1, st := client.Method23(ctx, testRequest(t, "hello"))
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	defer ch1.Free()
+
+	// Send
+	st = ch1.Send(ctx, In{})
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	st = ch1.SendEnd(ctx)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	assert.Len(t, ch.Sent, 1)
+	assert.True(t, ch.SentEnd)
+
+	// Receive
+	msg1, st := ch1.Receive(ctx)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	assert.Equal(t, int64(1), msg1.A())
+
+	_, st = ch1.Receive(ctx)
+	assert.Equal(t, status.End, st)
+
+	// Response
+	resp, st := ch1.Response(ctx)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	assert.Equal(t, "done", resp.Msg().Unwrap())
+}
+
+func testResponse(t *testing.T, msg string) Response {
+	w := NewResponseWriter()
+	w.Msg(msg)
+
+	resp, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// FakeService
+
+type testFakeService struct {
+	FakeService
+}
+
+func (s *testFakeService) Method(ctx rpc.Context) status.Status {
+	return status.OK
+}
+
+func TestFakeService__should_return_unsupported_for_unimplemented_methods(t *testing.T) {
+	ctx := async.NoContext()
+	logger := logging.TestLogger(t)
+	server := testServer(t, logger, &testFakeService{})
+	client := testClient(t, logger, server)
+
+	st := client.Method(ctx)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+
+	_, st = client.Method3(ctx, testRequest(t, "hello"))
+	assert.Equal(t, status.CodeUnsupported, st.Code)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package text
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/bin"
+)
+
+// Bool returns a bool literal.
+func (n *Node) Bool() (bool, error) {
+	if n.Kind != NodeBool {
+		return false, n.Errorf("expected bool, got %v", n)
+	}
+	return n.Text == "true", nil
+}
+
+// Int returns a decimal or hex integer literal.
+func (n *Node) Int(bits int) (int64, error) {
+	if n.Kind != NodeInt {
+		return 0, n.Errorf("expected integer, got %v", n)
+	}
+
+	v, err := strconv.ParseInt(n.Text, intBase(n.Text), bits)
+	if err != nil {
+		return 0, n.Errorf("invalid int%d %v", bits, n.Text)
+	}
+	return v, nil
+}
+
+// Uint returns a decimal or hex unsigned integer literal.
+func (n *Node) Uint(bits int) (uint64, error) {
+	if n.Kind != NodeInt {
+		return 0, n.Errorf("expected integer, got %v", n)
+	}
+
+	v, err := strconv.ParseUint(n.Text, intBase(n.Text), bits)
+	if err != nil {
+		return 0, n.Errorf("invalid uint%d %v", bits, n.Text)
+	}
+	return v, nil
+}
+
+// Float returns a float or integer literal, or nan, inf, -inf.
+func (n *Node) Float(bits int) (float64, error) {
+	switch n.Kind {
+	case NodeFloat, NodeInt:
+	case NodeIdent:
+		switch strings.TrimLeft(n.Text, "+-") {
+		case "nan", "inf":
+		default:
+			return 0, n.Errorf("expected float, got %v", n)
+		}
+	default:
+		return 0, n.Errorf("expected float, got %v", n)
+	}
+
+	v, err := strconv.ParseFloat(n.Text, bits)
+	if err != nil {
+		return 0, n.Errorf("invalid float%d %v", bits, n.Text)
+	}
+	return v, nil
+}
+
+// Bin64 returns a bin64 hex literal.
+func (n *Node) Bin64() (bin.Bin64, error) {
+	b, err := n.hexBin(bin.Len64)
+	if err != nil {
+		return bin.Bin64{}, err
+	}
+	return bin.Parse64(b)
+}
+
+// Bin128 returns a bin128 hex literal.
+func (n *Node) Bin128() (bin.Bin128, error) {
+	b, err := n.hexBin(bin.Len128)
+	if err != nil {
+		return bin.Bin128{}, err
+	}
+	return bin.Parse128(b)
+}
+
+// Bin256 returns a bin256 hex literal.
+func (n *Node) Bin256() (bin.Bin256, error) {
+	b, err := n.hexBin(bin.Len256)
+	if err != nil {
+		return bin.Bin256{}, err
+	}
+	return bin.Parse256(b)
+}
+
+// Bytes returns a string or hex literal as bytes.
+func (n *Node) Bytes() ([]byte, error) {
+	switch n.Kind {
+	case NodeString:
+		return []byte(n.Text), nil
+	case NodeInt:
+		if isHexLiteral(n.Text) {
+			return n.hex()
+		}
+	}
+	return nil, n.Errorf("expected string or hex bytes, got %v", n)
+}
+
+// String returns a short node description for error messages.
+func (n *Node) String() string {
+	switch n.Kind {
+	case NodeString:
+		return strconv.Quote(n.Text)
+	case NodeCall:
+		return n.Text + "(...)"
+	case NodeList:
+		return "list"
+	case NodeObject:
+		return "object"
+	}
+	return n.Text
+}
+
+// private
+
+func (n *Node) hex() ([]byte, error) {
+	b, err := hex.DecodeString(n.Text[2:])
+	if err != nil {
+		return nil, n.Errorf("invalid hex %v", n.Text)
+	}
+	return b, nil
+}
+
+// hexBin returns hex literal bytes of a bin size.
+func (n *Node) hexBin(size int) ([]byte, error) {
+	if n.Kind != NodeInt || !isHexLiteral(n.Text) {
+		return nil, n.Errorf("expected hex, got %v", n)
+	}
+
+	b, err := n.hex()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, n.Errorf("invalid bin%d %v, expected %d hex digits", size*8, n.Text, size*2)
+	}
+	return b, nil
+}
+
+func isHexLiteral(s string) bool {
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+}
+
+func intBase(s string) int {
+	if isHexLiteral(strings.TrimLeft(s, "+-")) {
+		return 0
+	}
+	return 10
+}
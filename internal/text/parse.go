@@ -0,0 +1,462 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package text implements a readable text format of spec values.
+//
+// Schema-free values are typed literals, int64 and float64 are untyped:
+//
+//	true, false
+//	5                                      int64
+//	1.5, 1.0, 1e10                         float64
+//	"hello\n"                              string with Go escapes
+//	byte(1), int16(-1), int32(5), uint16(1), uint32(1), uint64(1)
+//	varint(-1), uvarint(1)                 varint-encoded integers
+//	float32(1.5), float64(nan), float64(inf), float64(-inf)
+//	bin64(0x0123456789abcdef), bin128(0x...), bin256(0x...)
+//	bytes("hello"), bytes(0x68656c6c6f)
+//	[1, 2, 3]                              list
+//	{1: "a", 2: [1, 2]}                    message fields by tags
+//	struct(int32(1), "a")                  struct field values
+//	packed(1, 2, 3)                        packed list of fixed-size elements
+//
+// In the schema mode messages and structs are keyed by field names, i.e. {id: 1, name: "a"},
+// values are untyped literals or enum value names, see the dynamic package.
+//
+// Line comments start with "//", trailing commas are allowed.
+package text
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NodeKind is a text node kind.
+type NodeKind int
+
+const (
+	NodeUndefined NodeKind = iota
+	NodeBool               // true or false
+	NodeInt                // Integer literal, i.e. -5, 0x10
+	NodeFloat              // Float literal, i.e. 1.5, 1e10
+	NodeString             // String literal, text is unquoted
+	NodeIdent              // Identifier, i.e. an enum value or nan
+	NodeCall               // Typed literal, i.e. int32(5), struct(1, 2)
+	NodeList               // List [...]
+	NodeObject             // Message or struct {key: value, ...}
+)
+
+// Node is a parsed text value.
+type Node struct {
+	Kind NodeKind
+	Line int
+
+	Text   string   // Literal text, unquoted string, identifier or call name
+	Args   []*Node  // Call arguments or list elements
+	Fields []*Field // Object fields
+}
+
+// Field is an object field, the key is a tag or a field name.
+type Field struct {
+	Key   string
+	Line  int
+	Value *Node
+}
+
+// ParseNode parses a text value.
+func ParseNode(src []byte) (*Node, error) {
+	p := &parser{lexer: newLexer(string(src))}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %v after value", p.tok)
+	}
+	return node, nil
+}
+
+// Error is a text syntax error.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+// Error returns "line N: message".
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Msg)
+}
+
+// Errorf returns a node error.
+func (n *Node) Errorf(format string, args ...any) error {
+	return &Error{Line: n.Line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parser
+
+type parser struct {
+	*lexer
+	tok token
+}
+
+func (p *parser) next() (err error) {
+	p.tok, err = p.lexer.next()
+	return err
+}
+
+func (p *parser) expect(kind tokenKind) error {
+	if p.tok.kind != kind {
+		return p.errorf("expected %v, got %v", kind, p.tok)
+	}
+	return p.next()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &Error{Line: p.tok.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) value() (*Node, error) {
+	tok := p.tok
+	node := &Node{Line: tok.line, Text: tok.text}
+
+	switch tok.kind {
+	case tokenInt:
+		node.Kind = NodeInt
+	case tokenFloat:
+		node.Kind = NodeFloat
+	case tokenString:
+		node.Kind = NodeString
+
+	case tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.tok.kind == tokenLParen:
+			node.Kind = NodeCall
+			args, err := p.elements(tokenLParen, tokenRParen)
+			if err != nil {
+				return nil, err
+			}
+			node.Args = args
+		case tok.text == "true" || tok.text == "false":
+			node.Kind = NodeBool
+		default:
+			node.Kind = NodeIdent
+		}
+		return node, nil
+
+	case tokenLBracket:
+		node.Kind = NodeList
+		args, err := p.elements(tokenLBracket, tokenRBracket)
+		if err != nil {
+			return nil, err
+		}
+		node.Args = args
+		return node, nil
+
+	case tokenLBrace:
+		node.Kind = NodeObject
+		fields, err := p.fields()
+		if err != nil {
+			return nil, err
+		}
+		node.Fields = fields
+		return node, nil
+
+	default:
+		return nil, p.errorf("expected value, got %v", tok)
+	}
+
+	return node, p.next()
+}
+
+// elements parses comma-separated values in delimiters.
+func (p *parser) elements(open tokenKind, close tokenKind) ([]*Node, error) {
+	if err := p.expect(open); err != nil {
+		return nil, err
+	}
+
+	nodes := []*Node{}
+	for p.tok.kind != close {
+		node, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect(close); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// fields parses comma-separated "key: value" fields in braces.
+func (p *parser) fields() ([]*Field, error) {
+	if err := p.expect(tokenLBrace); err != nil {
+		return nil, err
+	}
+
+	fields := []*Field{}
+	for p.tok.kind != tokenRBrace {
+		key := p.tok
+		if key.kind != tokenInt && key.kind != tokenIdent {
+			return nil, p.errorf("expected field tag or name, got %v", key)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenColon); err != nil {
+			return nil, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, &Field{Key: key.text, Line: key.line, Value: value})
+
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect(tokenRBrace); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// lexer
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenIdent
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenLBrace
+	tokenRBrace
+	tokenComma
+	tokenColon
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:      "end of input",
+	tokenInt:      "integer",
+	tokenFloat:    "float",
+	tokenString:   "string",
+	tokenIdent:    "identifier",
+	tokenLParen:   "(",
+	tokenRParen:   ")",
+	tokenLBracket: "[",
+	tokenRBracket: "]",
+	tokenLBrace:   "{",
+	tokenRBrace:   "}",
+	tokenComma:    ",",
+	tokenColon:    ":",
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenInt, tokenFloat, tokenIdent:
+		return strconv.Quote(t.text)
+	case tokenString:
+		return "string " + strconv.Quote(t.text)
+	}
+	return t.kind.String()
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skip()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+
+	switch c {
+	case '(':
+		return l.punct(tokenLParen), nil
+	case ')':
+		return l.punct(tokenRParen), nil
+	case '[':
+		return l.punct(tokenLBracket), nil
+	case ']':
+		return l.punct(tokenRBracket), nil
+	case '{':
+		return l.punct(tokenLBrace), nil
+	case '}':
+		return l.punct(tokenRBrace), nil
+	case ',':
+		return l.punct(tokenComma), nil
+	case ':':
+		return l.punct(tokenColon), nil
+	case '"':
+		return l.string()
+	}
+
+	// Signed identifiers, i.e. -inf
+	if (c == '-' || c == '+') && l.pos+1 < len(l.src) && isLetter(l.src[l.pos+1]) {
+		l.pos++
+		l.scanIdent()
+		return token{kind: tokenIdent, text: l.src[start:l.pos], line: l.line}, nil
+	}
+
+	switch {
+	case c == '-' || c == '+' || c == '.' || isDigit(c):
+		return l.number(), nil
+	case isLetter(c):
+		l.scanIdent()
+		return token{kind: tokenIdent, text: l.src[start:l.pos], line: l.line}, nil
+	}
+	return token{}, &Error{Line: l.line, Msg: fmt.Sprintf("unexpected character %q", c)}
+}
+
+// skip skips whitespace and comments.
+func (l *lexer) skip() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) punct(kind tokenKind) token {
+	tok := token{kind: kind, text: l.src[l.pos : l.pos+1], line: l.line}
+	l.pos++
+	return tok
+}
+
+func (l *lexer) string() (token, error) {
+	start := l.pos
+	l.pos++
+
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+			continue
+		case '\n':
+			return token{}, &Error{Line: l.line, Msg: "newline in string"}
+		case '"':
+			l.pos++
+
+			s, err := strconv.Unquote(l.src[start:l.pos])
+			if err != nil {
+				return token{}, &Error{Line: l.line, Msg: "invalid string escape"}
+			}
+			return token{kind: tokenString, text: s, line: l.line}, nil
+		}
+		l.pos++
+	}
+	return token{}, &Error{Line: l.line, Msg: "unterminated string"}
+}
+
+// number scans an integer, hex or float literal.
+func (l *lexer) number() token {
+	start := l.pos
+	if c := l.src[l.pos]; c == '-' || c == '+' {
+		l.pos++
+	}
+
+	kind := tokenInt
+	if strings.HasPrefix(l.src[l.pos:], "0x") || strings.HasPrefix(l.src[l.pos:], "0X") {
+		l.pos += 2
+		for l.pos < len(l.src) && isHex(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: kind, text: l.src[start:l.pos], line: l.line}
+	}
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case isDigit(c):
+		case c == '.':
+			kind = tokenFloat
+		case c == 'e' || c == 'E':
+			kind = tokenFloat
+			if l.pos+1 < len(l.src) && (l.src[l.pos+1] == '-' || l.src[l.pos+1] == '+') {
+				l.pos++
+			}
+		default:
+			return token{kind: kind, text: l.src[start:l.pos], line: l.line}
+		}
+		l.pos++
+	}
+	return token{kind: kind, text: l.src[start:l.pos], line: l.line}
+}
+
+func (l *lexer) scanIdent() {
+	for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+		l.pos++
+	}
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
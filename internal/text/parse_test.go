@@ -0,0 +1,134 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/types"
+	"github.com/basecomplextech/spec/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testParse(t *testing.T, src string) types.Value {
+	w := writer.New(false)
+	t.Cleanup(w.Free)
+
+	b, err := Parse(w, []byte(src))
+	require.NoError(t, err)
+
+	v, _, err := types.ParseValue(b)
+	require.NoError(t, err)
+	return v
+}
+
+func testParseError(t *testing.T, src string) error {
+	w := writer.New(false)
+	defer w.Free()
+
+	_, err := Parse(w, []byte(src))
+	require.Error(t, err, src)
+	return err
+}
+
+// ParseNode
+
+func TestParseNode__should_parse_values(t *testing.T) {
+	node, err := ParseNode([]byte(`{
+    // Comment
+    id: 1,
+    name: "a",
+    kind: Enum,
+    list: [1.5, -inf, int32(0x10)],
+}`))
+	require.NoError(t, err)
+
+	require.Equal(t, NodeObject, node.Kind)
+	require.Len(t, node.Fields, 4)
+	assert.Equal(t, "id", node.Fields[0].Key)
+	assert.Equal(t, 3, node.Fields[0].Line)
+	assert.Equal(t, NodeInt, node.Fields[0].Value.Kind)
+	assert.Equal(t, NodeString, node.Fields[1].Value.Kind)
+	assert.Equal(t, NodeIdent, node.Fields[2].Value.Kind)
+
+	list := node.Fields[3].Value
+	require.Equal(t, NodeList, list.Kind)
+	require.Len(t, list.Args, 3)
+	assert.Equal(t, NodeFloat, list.Args[0].Kind)
+	assert.Equal(t, NodeIdent, list.Args[1].Kind)
+	assert.Equal(t, "-inf", list.Args[1].Text)
+	assert.Equal(t, NodeCall, list.Args[2].Kind)
+	assert.Equal(t, "int32", list.Args[2].Text)
+}
+
+func TestParseNode__should_return_syntax_errors(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{``, `line 1: expected value, got end of input`},
+		{`[1, 2`, `line 1: expected ], got end of input`},
+		{"{\n1 2}", `line 2: expected :, got "2"`},
+		{`{"a": 1}`, `line 1: expected field tag or name, got string "a"`},
+		{`"abc`, `line 1: unterminated string`},
+		{`1 2`, `line 1: unexpected "2" after value`},
+		{`@`, `line 1: unexpected character '@'`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseNode([]byte(tt.src))
+		assert.EqualError(t, err, tt.err, tt.src)
+	}
+}
+
+// Parse
+
+func TestParse__should_parse_untyped_literals(t *testing.T) {
+	v := testParse(t, `[true, 5, -0x10, 1.5, "a"]`)
+
+	l := v.List()
+	require.Equal(t, 5, l.Len())
+	assert.True(t, l.Get(0).Bool())
+	assert.Equal(t, int64(5), l.Get(1).Int64())
+	assert.Equal(t, int64(-16), l.Get(2).Int64())
+	assert.Equal(t, 1.5, l.Get(3).Float64())
+	assert.Equal(t, "a", l.Get(4).String().Unwrap())
+}
+
+func TestParse__should_parse_message_fields_in_text_order(t *testing.T) {
+	v := testParse(t, `{2: "b", 1: int32(1), 3: bytes(0x6869)}`)
+
+	m := v.Message()
+	assert.Equal(t, int32(1), m.Int32(1))
+	assert.Equal(t, "b", m.String(2).Unwrap())
+	assert.Equal(t, []byte("hi"), m.Bytes(3).Unwrap())
+
+	s, err := Format(v)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    2: \"b\",\n    1: int32(1),\n    3: bytes(\"hi\"),\n}", s)
+}
+
+func TestParse__should_return_value_errors(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`int16(40000)`, `line 1: invalid int16 40000`},
+		{`byte(-1)`, `line 1: invalid uint8 -1`},
+		{`int32(1, 2)`, `line 1: int32 expects one argument`},
+		{`bin64(0x01)`, `line 1: invalid bin64 0x01, expected 16 hex digits`},
+		{`uuid(1)`, `line 1: unknown type uuid`},
+		{`[Enum]`, `line 1: unexpected Enum, expected a typed literal`},
+		{"{\n1: 1,\n1: 2}", `line 3: duplicate field tag 1`},
+		{`{name: 1}`, `line 1: invalid field tag "name"`},
+		{`struct([1])`, `line 1: unexpected list, expected a typed literal`},
+	}
+
+	for _, tt := range tests {
+		err := testParseError(t, tt.src)
+		assert.EqualError(t, err, tt.err, tt.src)
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package text
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// indent is a nested value indentation.
+const indent = "    "
+
+// Format returns a canonical schema-free text of a value.
+//
+// Messages are printed on multiple lines with fields in the data order, lists are
+// printed on one line unless they contain lists or messages, see the package doc.
+func Format(b []byte) (string, error) {
+	p := &printer{}
+	if err := p.value(b, 0); err != nil {
+		return "", err
+	}
+	return p.String(), nil
+}
+
+// Print writes a canonical schema-free text of a value followed by a newline, see [Format].
+func Print(w io.Writer, b []byte) error {
+	s, err := Format(b)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, s+"\n")
+	return err
+}
+
+// FormatFloat64 returns a float64 literal which always has a fraction or an exponent,
+// or a typed literal for non-finite values, i.e. 1.0, float64(nan).
+func FormatFloat64(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "float64(nan)"
+	case math.IsInf(v, 1):
+		return "float64(inf)"
+	case math.IsInf(v, -1):
+		return "float64(-inf)"
+	}
+
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// internal
+
+type printer struct {
+	strings.Builder
+}
+
+func (p *printer) value(b []byte, depth int) error {
+	v, _, err := types.ParseValue(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ := v.Type(); typ {
+	case format.TypeTrue:
+		p.WriteString("true")
+	case format.TypeFalse:
+		p.WriteString("false")
+	case format.TypeByte:
+		p.typed("byte", strconv.FormatUint(uint64(v.Byte()), 10))
+
+	case format.TypeInt16:
+		p.typed("int16", strconv.FormatInt(int64(v.Int16()), 10))
+	case format.TypeInt32:
+		p.typed("int32", strconv.FormatInt(int64(v.Int32()), 10))
+	case format.TypeInt64:
+		p.WriteString(strconv.FormatInt(v.Int64(), 10))
+	case format.TypeVarint:
+		p.typed("varint", strconv.FormatInt(v.Int64(), 10))
+
+	case format.TypeUint16:
+		p.typed("uint16", strconv.FormatUint(uint64(v.Uint16()), 10))
+	case format.TypeUint32:
+		p.typed("uint32", strconv.FormatUint(uint64(v.Uint32()), 10))
+	case format.TypeUint64:
+		p.typed("uint64", strconv.FormatUint(v.Uint64(), 10))
+	case format.TypeUvarint:
+		p.typed("uvarint", strconv.FormatUint(v.Uint64(), 10))
+
+	case format.TypeFloat32:
+		p.typed("float32", formatFloat32(v.Float32()))
+	case format.TypeFloat64:
+		p.WriteString(FormatFloat64(v.Float64()))
+
+	case format.TypeBin64:
+		p.typed("bin64", "0x"+hex.EncodeToString(v.Bin64().Marshal()))
+	case format.TypeBin128:
+		p.typed("bin128", "0x"+hex.EncodeToString(v.Bin128().Marshal()))
+	case format.TypeBin256:
+		p.typed("bin256", "0x"+hex.EncodeToString(v.Bin256().Marshal()))
+
+	case format.TypeBytes:
+		p.typed("bytes", strconv.Quote(string(v.Bytes())))
+	case format.TypeString:
+		p.WriteString(strconv.Quote(string(v.String())))
+
+	case format.TypeList, format.TypeBigList, format.TypePackedList:
+		return p.list(v.List(), depth)
+	case format.TypeMessage, format.TypeBigMessage:
+		return p.message(v.Message(), depth)
+	case format.TypeStruct:
+		return p.struct_(v)
+
+	default:
+		return fmt.Errorf("format text: unsupported type %v", typ)
+	}
+	return nil
+}
+
+func (p *printer) typed(name string, s string) {
+	p.WriteString(name)
+	p.WriteByte('(')
+	p.WriteString(s)
+	p.WriteByte(')')
+}
+
+// list prints a list on one line, or on multiple lines when it contains lists or messages.
+func (p *printer) list(l types.List, depth int) error {
+	if l.Len() == 0 {
+		p.WriteString("[]")
+		return nil
+	}
+
+	if l.Packed() {
+		return p.packed(l, depth)
+	}
+
+	multiline := false
+	for i := 0; i < l.Len(); i++ {
+		switch l.Get(i).Type() {
+		case format.TypeList, format.TypeBigList, format.TypePackedList, format.TypeMessage, format.TypeBigMessage:
+			multiline = true
+		}
+	}
+
+	p.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		switch {
+		case multiline:
+			p.newline(depth + 1)
+		case i > 0:
+			p.WriteString(", ")
+		}
+
+		if err := p.value(l.GetBytes(i), depth+1); err != nil {
+			return err
+		}
+		if multiline {
+			p.WriteByte(',')
+		}
+	}
+	if multiline {
+		p.newline(depth)
+	}
+	p.WriteByte(']')
+	return nil
+}
+
+// message prints message fields in the data order, the table is sorted by tags.
+func (p *printer) message(m types.Message, depth int) error {
+	table, _, err := decode.DecodeMessageTable(m.Raw())
+	if err != nil {
+		return err
+	}
+
+	fields := slices.Clone(table.Fields())
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Offset < fields[j].Offset
+	})
+	if len(fields) == 0 {
+		p.WriteString("{}")
+		return nil
+	}
+
+	p.WriteByte('{')
+	for _, field := range fields {
+		p.newline(depth + 1)
+		p.WriteString(strconv.Itoa(int(field.Tag)))
+		p.WriteString(": ")
+
+		b := m.FieldRaw(field.Tag)
+		if err := p.value(b, depth+1); err != nil {
+			return fmt.Errorf("#%d: %w", field.Tag, err)
+		}
+		p.WriteByte(',')
+	}
+	p.newline(depth)
+	p.WriteByte('}')
+	return nil
+}
+
+// packed prints packed list elements on one line.
+func (p *printer) packed(l types.List, depth int) error {
+	elem := buffer.New()
+
+	p.WriteString("packed(")
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			p.WriteString(", ")
+		}
+
+		elem.Reset()
+		if err := p.value(l.GetBytesTo(elem, i), depth+1); err != nil {
+			return err
+		}
+	}
+	p.WriteByte(')')
+	return nil
+}
+
+// struct_ prints struct field values, which are decoded in reverse order.
+func (p *printer) struct_(v types.Value) error {
+	dataSize, _, err := decode.DecodeStruct(v)
+	if err != nil {
+		return err
+	}
+
+	var fields [][]byte
+	data := v[:dataSize]
+	for off := len(data); off > 0; {
+		_, n, err := types.ParseValue(data[:off])
+		if err != nil {
+			return err
+		}
+		fields = append(fields, data[off-n:off])
+		off -= n
+	}
+	slices.Reverse(fields)
+
+	p.WriteString("struct(")
+	for i, field := range fields {
+		if i > 0 {
+			p.WriteString(", ")
+		}
+		if err := p.value(field, 0); err != nil {
+			return err
+		}
+	}
+	p.WriteByte(')')
+	return nil
+}
+
+func (p *printer) newline(depth int) {
+	p.WriteByte('\n')
+	p.WriteString(strings.Repeat(indent, depth))
+}
+
+func formatFloat32(v float32) string {
+	switch {
+	case math.IsNaN(float64(v)):
+		return "nan"
+	case math.IsInf(float64(v), 1):
+		return "inf"
+	case math.IsInf(float64(v), -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(float64(v), 'g', -1, 32)
+}
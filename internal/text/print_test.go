@@ -0,0 +1,203 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package text
+
+import (
+	"math"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/types"
+	"github.com/basecomplextech/spec/internal/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEncode[T any](t *testing.T, encode func(buffer.Buffer, T) (int, error), v T) []byte {
+	buf := buffer.New()
+	_, err := encode(buf, v)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func testRoundTrip(t *testing.T, b []byte) string {
+	s, err := Format(b)
+	require.NoError(t, err)
+
+	w := writer.New(false)
+	defer w.Free()
+
+	b1, err := Parse(w, []byte(s))
+	require.NoError(t, err, s)
+	assert.Equal(t, b, b1, s)
+
+	_, _, err = types.ParseValue(b1)
+	require.NoError(t, err)
+	return s
+}
+
+func testMessage(t *testing.T) []byte {
+	w := writer.New(false)
+	t.Cleanup(w.Free)
+
+	m := w.Value().Message()
+	m.Field(3).String("hello")
+	m.Field(1).Int32(-5)
+
+	l := m.Field(2).List()
+	l.Int64(1)
+	l.Int64(2)
+	l.End()
+
+	sub := m.Field(10).Message()
+	sub.Field(1).Bool(true)
+	sub.Field(2).Bin64(bin.Int64(1))
+	sub.End()
+
+	list := m.Field(4).List()
+	item := list.Message()
+	item.Field(1).Float64(1)
+	item.End()
+	list.End()
+
+	b, err := m.Build()
+	require.NoError(t, err)
+	return b
+}
+
+// Format
+
+func TestFormat__should_format_primitives(t *testing.T) {
+	tests := []struct {
+		value []byte
+		text  string
+	}{
+		{testEncode(t, encode.EncodeBool, true), `true`},
+		{testEncode(t, encode.EncodeBool, false), `false`},
+		{testEncode(t, encode.EncodeByte, 255), `byte(255)`},
+		{testEncode(t, encode.EncodeInt16, -1), `int16(-1)`},
+		{testEncode(t, encode.EncodeInt32, math.MaxInt32), `int32(2147483647)`},
+		{testEncode(t, encode.EncodeInt64, math.MinInt64), `-9223372036854775808`},
+		{testEncode(t, encode.EncodeVarint, -300), `varint(-300)`},
+		{testEncode(t, encode.EncodeUint16, 1), `uint16(1)`},
+		{testEncode(t, encode.EncodeUint32, 1), `uint32(1)`},
+		{testEncode(t, encode.EncodeUint64, math.MaxUint64), `uint64(18446744073709551615)`},
+		{testEncode(t, encode.EncodeUvarint, 300), `uvarint(300)`},
+		{testEncode(t, encode.EncodeFloat32, 1.5), `float32(1.5)`},
+		{testEncode(t, encode.EncodeFloat64, 1), `1.0`},
+		{testEncode(t, encode.EncodeFloat64, -1e300), `-1e+300`},
+		{testEncode(t, encode.EncodeFloat64, math.Inf(-1)), `float64(-inf)`},
+		{testEncode(t, encode.EncodeBin64, bin.Int64(1)), `bin64(0x0000000000000001)`},
+		{testEncode(t, encode.EncodeBin128, bin.Int128(1, 2)),
+			`bin128(0x00000000000000010000000000000002)`},
+		{testEncode(t, encode.EncodeBytes, []byte("hi\x00")), `bytes("hi\x00")`},
+		{testEncode(t, encode.EncodeString, "hello\n"), `"hello\n"`},
+	}
+
+	for _, tt := range tests {
+		s := testRoundTrip(t, tt.value)
+		assert.Equal(t, tt.text, s)
+	}
+}
+
+func TestFormat__should_format_nan(t *testing.T) {
+	b := testEncode(t, encode.EncodeFloat64, math.NaN())
+
+	s, err := Format(b)
+	require.NoError(t, err)
+	assert.Equal(t, "float64(nan)", s)
+
+	b1, err := Parse(writer.New(true), []byte(s))
+	require.NoError(t, err)
+	v, _, err := types.ParseValue(b1)
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(v.Float64()))
+}
+
+func TestFormat__should_format_message_fields_in_data_order(t *testing.T) {
+	b := testMessage(t)
+
+	s := testRoundTrip(t, b)
+	assert.Equal(t, `{
+    3: "hello",
+    1: int32(-5),
+    2: [1, 2],
+    10: {
+        1: true,
+        2: bin64(0x0000000000000001),
+    },
+    4: [
+        {
+            1: 1.0,
+        },
+    ],
+}`, s)
+}
+
+func TestFormat__should_format_empty_list_and_message(t *testing.T) {
+	w := writer.New(false)
+	defer w.Free()
+
+	m := w.Value().Message()
+	m.Field(1).List().End()
+	sub := m.Field(2).Message()
+	sub.End()
+	b, err := m.Build()
+	require.NoError(t, err)
+
+	s := testRoundTrip(t, b)
+	assert.Equal(t, "{\n    1: [],\n    2: {},\n}", s)
+}
+
+func TestFormat__should_format_structs(t *testing.T) {
+	buf := buffer.New()
+	encode.EncodeInt32(buf, 1)
+	encode.EncodeString(buf, "a")
+	encode.EncodeStruct(buf, buf.Len())
+
+	start := buf.Len()
+	encode.EncodeBool(buf, true)
+	encode.EncodeStruct(buf, buf.Len()-start)
+	encode.EncodeStruct(buf, buf.Len())
+
+	s := testRoundTrip(t, buf.Bytes())
+	assert.Equal(t, `struct(struct(int32(1), "a"), struct(true))`, s)
+}
+
+func TestFormat__should_format_struct_list(t *testing.T) {
+	buf := buffer.New()
+	encode.EncodeInt64(buf, 1)
+	encode.EncodeStruct(buf, buf.Len())
+
+	w := writer.New(false)
+	defer w.Free()
+
+	l := w.Value().List()
+	l.Any(buf.Bytes())
+	l.Any(buf.Bytes())
+	b, err := l.Build()
+	require.NoError(t, err)
+
+	s := testRoundTrip(t, b)
+	assert.Equal(t, `[struct(1), struct(1)]`, s)
+}
+
+func TestFormat__should_format_packed_list(t *testing.T) {
+	w := writer.New(false)
+	defer w.Free()
+
+	l := w.Value().List()
+	require.NoError(t, l.Pack(format.TypeInt32))
+	l.Int32(1)
+	l.Int32(-2)
+	b, err := l.Build()
+	require.NoError(t, err)
+
+	s := testRoundTrip(t, b)
+	assert.Equal(t, `packed(int32(1), int32(-2))`, s)
+}
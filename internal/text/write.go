@@ -0,0 +1,421 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package text
+
+import (
+	"strconv"
+
+	"github.com/basecomplextech/baselibrary/alloc"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/writer"
+)
+
+// Parse parses a schema-free text value and writes it with a writer, returns the value bytes.
+func Parse(w writer.Writer, src []byte) ([]byte, error) {
+	node, err := ParseNode(src)
+	if err != nil {
+		return nil, err
+	}
+	return Write(w, node)
+}
+
+// Write writes a schema-free text value with a writer, returns the value bytes.
+func Write(w writer.Writer, node *Node) ([]byte, error) {
+	v := w.Value()
+
+	switch node.Kind {
+	case NodeList:
+		l := v.List()
+		if err := writeElements(l, node); err != nil {
+			return nil, err
+		}
+		return l.Build()
+
+	case NodeObject:
+		m := v.Message()
+		if err := writeFields(m, node); err != nil {
+			return nil, err
+		}
+		return m.Build()
+	}
+
+	if node.Kind == NodeCall && node.Text == "packed" {
+		l := v.List()
+		if err := writePacked(l, node); err != nil {
+			return nil, err
+		}
+		return l.Build()
+	}
+
+	if err := writeValue(v, node); err != nil {
+		return nil, err
+	}
+	return v.Build()
+}
+
+// internal
+
+// varint and uvarint are scalars of varint-encoded integers.
+type (
+	varint  int64
+	uvarint uint64
+)
+
+// valueWriter is implemented by value, element and field writers.
+type valueWriter interface {
+	Any(b []byte) error
+	Bool(v bool) error
+	Byte(v byte) error
+
+	Int16(v int16) error
+	Int32(v int32) error
+	Int64(v int64) error
+
+	Uint16(v uint16) error
+	Uint32(v uint32) error
+	Uint64(v uint64) error
+
+	Float32(v float32) error
+	Float64(v float64) error
+
+	Bin64(v bin.Bin64) error
+	Bin128(v bin.Bin128) error
+	Bin256(v bin.Bin256) error
+
+	Bytes(v []byte) error
+	String(v string) error
+
+	List() writer.ListWriter
+	Message() writer.MessageWriter
+}
+
+func writeValue(w valueWriter, node *Node) error {
+	switch node.Kind {
+	case NodeList:
+		l := w.List()
+		if err := writeElements(l, node); err != nil {
+			return err
+		}
+		return l.End()
+
+	case NodeObject:
+		m := w.Message()
+		if err := writeFields(m, node); err != nil {
+			return err
+		}
+		return m.End()
+	}
+
+	if node.Kind == NodeCall && node.Text == "packed" {
+		l := w.List()
+		if err := writePacked(l, node); err != nil {
+			return err
+		}
+		return l.End()
+	}
+
+	if node.Kind == NodeCall && node.Text == "struct" {
+		buf := alloc.AcquireBuffer()
+		defer buf.Free()
+
+		if err := encodeStruct(buf, node); err != nil {
+			return err
+		}
+		return w.Any(buf.Bytes())
+	}
+
+	v, err := scalar(node)
+	if err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return w.Bool(v)
+	case byte:
+		return w.Byte(v)
+
+	case int16:
+		return w.Int16(v)
+	case int32:
+		return w.Int32(v)
+	case int64:
+		return w.Int64(v)
+
+	case uint16:
+		return w.Uint16(v)
+	case uint32:
+		return w.Uint32(v)
+	case uint64:
+		return w.Uint64(v)
+
+	case varint, uvarint:
+		buf := alloc.AcquireBuffer()
+		defer buf.Free()
+
+		if err := encodeScalar(buf, v); err != nil {
+			return err
+		}
+		return w.Any(buf.Bytes())
+
+	case float32:
+		return w.Float32(v)
+	case float64:
+		return w.Float64(v)
+
+	case bin.Bin64:
+		return w.Bin64(v)
+	case bin.Bin128:
+		return w.Bin128(v)
+	case bin.Bin256:
+		return w.Bin256(v)
+
+	case []byte:
+		return w.Bytes(v)
+	case string:
+		return w.String(v)
+	}
+	return node.Errorf("unsupported value %v", node)
+}
+
+func writeElements(w writer.ListWriter, node *Node) error {
+	for _, elem := range node.Args {
+		if err := writeValue(w, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePacked writes packed list elements, the first element specifies the element type.
+func writePacked(w writer.ListWriter, node *Node) error {
+	if len(node.Args) == 0 {
+		return nil
+	}
+
+	first := node.Args[0]
+	typ := format.TypeStruct
+	if first.Kind != NodeCall || first.Text != "struct" {
+		v, err := scalar(first)
+		if err != nil {
+			return err
+		}
+		typ = scalarType(v)
+	}
+	if !format.IsPackable(typ) {
+		return first.Errorf("packed list element %v is not packable", first)
+	}
+
+	if err := w.Pack(typ); err != nil {
+		return err
+	}
+	for _, elem := range node.Args {
+		if err := writeValue(w, elem); err != nil {
+			return elem.Errorf("%v", err)
+		}
+	}
+	return nil
+}
+
+// writeFields writes message fields in the text order.
+func writeFields(w writer.MessageWriter, node *Node) error {
+	for _, field := range node.Fields {
+		tag, err := strconv.ParseUint(field.Key, 10, 16)
+		if err != nil {
+			return &Error{Line: field.Line, Msg: "invalid field tag " + strconv.Quote(field.Key)}
+		}
+		if w.HasField(uint16(tag)) {
+			return &Error{Line: field.Line, Msg: "duplicate field tag " + field.Key}
+		}
+
+		if err := writeValue(w.Field(uint16(tag)), field.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeStruct encodes struct field values, structs are fixed and written directly.
+func encodeStruct(buf buffer.Buffer, node *Node) error {
+	start := buf.Len()
+
+	for _, arg := range node.Args {
+		if arg.Kind == NodeCall && arg.Text == "struct" {
+			if err := encodeStruct(buf, arg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, err := scalar(arg)
+		if err != nil {
+			return err
+		}
+		if err := encodeScalar(buf, v); err != nil {
+			return arg.Errorf("%v", err)
+		}
+	}
+
+	_, err := encode.EncodeStruct(buf, buf.Len()-start)
+	return err
+}
+
+func encodeScalar(buf buffer.Buffer, v any) (err error) {
+	switch v := v.(type) {
+	case bool:
+		_, err = encode.EncodeBool(buf, v)
+	case byte:
+		_, err = encode.EncodeByte(buf, v)
+
+	case int16:
+		_, err = encode.EncodeInt16(buf, v)
+	case int32:
+		_, err = encode.EncodeInt32(buf, v)
+	case int64:
+		_, err = encode.EncodeInt64(buf, v)
+
+	case uint16:
+		_, err = encode.EncodeUint16(buf, v)
+	case uint32:
+		_, err = encode.EncodeUint32(buf, v)
+	case uint64:
+		_, err = encode.EncodeUint64(buf, v)
+
+	case varint:
+		_, err = encode.EncodeVarint(buf, int64(v))
+	case uvarint:
+		_, err = encode.EncodeUvarint(buf, uint64(v))
+
+	case float32:
+		_, err = encode.EncodeFloat32(buf, v)
+	case float64:
+		_, err = encode.EncodeFloat64(buf, v)
+
+	case bin.Bin64:
+		_, err = encode.EncodeBin64(buf, v)
+	case bin.Bin128:
+		_, err = encode.EncodeBin128(buf, v)
+	case bin.Bin256:
+		_, err = encode.EncodeBin256(buf, v)
+
+	case []byte:
+		_, err = encode.EncodeBytes(buf, v)
+	case string:
+		_, err = encode.EncodeString(buf, v)
+	}
+	return err
+}
+
+// scalarType returns a format type of a scalar value.
+func scalarType(v any) format.Type {
+	switch v.(type) {
+	case int16:
+		return format.TypeInt16
+	case int32:
+		return format.TypeInt32
+	case int64:
+		return format.TypeInt64
+
+	case uint16:
+		return format.TypeUint16
+	case uint32:
+		return format.TypeUint32
+	case uint64:
+		return format.TypeUint64
+
+	case float32:
+		return format.TypeFloat32
+	case float64:
+		return format.TypeFloat64
+
+	case bin.Bin64:
+		return format.TypeBin64
+	case bin.Bin128:
+		return format.TypeBin128
+	case bin.Bin256:
+		return format.TypeBin256
+	}
+	return format.TypeUndefined
+}
+
+// scalar returns a primitive, bin, bytes or string value of a literal or a typed literal.
+func scalar(node *Node) (any, error) {
+	switch node.Kind {
+	case NodeBool:
+		return node.Bool()
+	case NodeInt:
+		return node.Int(64)
+	case NodeFloat:
+		return node.Float(64)
+	case NodeString:
+		return node.Text, nil
+	case NodeCall:
+	default:
+		return nil, node.Errorf("unexpected %v, expected a typed literal", node)
+	}
+
+	if len(node.Args) != 1 {
+		return nil, node.Errorf("%v expects one argument", node.Text)
+	}
+	arg := node.Args[0]
+
+	switch node.Text {
+	case "bool":
+		return arg.Bool()
+	case "byte":
+		v, err := arg.Uint(8)
+		return byte(v), err
+
+	case "int16":
+		v, err := arg.Int(16)
+		return int16(v), err
+	case "int32":
+		v, err := arg.Int(32)
+		return int32(v), err
+	case "int64":
+		return arg.Int(64)
+
+	case "uint16":
+		v, err := arg.Uint(16)
+		return uint16(v), err
+	case "uint32":
+		v, err := arg.Uint(32)
+		return uint32(v), err
+	case "uint64":
+		return arg.Uint(64)
+
+	case "varint":
+		v, err := arg.Int(64)
+		return varint(v), err
+	case "uvarint":
+		v, err := arg.Uint(64)
+		return uvarint(v), err
+
+	case "float32":
+		v, err := arg.Float(32)
+		return float32(v), err
+	case "float64":
+		return arg.Float(64)
+
+	case "bin64":
+		return arg.Bin64()
+	case "bin128":
+		return arg.Bin128()
+	case "bin256":
+		return arg.Bin256()
+
+	case "bytes":
+		return arg.Bytes()
+	case "string":
+		if arg.Kind != NodeString {
+			return nil, arg.Errorf("expected string, got %v", arg)
+		}
+		return arg.Text, nil
+	}
+	return nil, node.Errorf("unknown type %v", node.Text)
+}
@@ -0,0 +1,602 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// jsonStruct is a json object key of struct values.
+const jsonStruct = "$struct"
+
+// jsonPacked is a json object key of packed list elements.
+const jsonPacked = "$packed"
+
+// jsonTags are string prefixes of tagged json values.
+var jsonTags = []string{
+	"byte",
+	"int16",
+	"int32",
+	"varint",
+	"uint16",
+	"uint32",
+	"uint64",
+	"uvarint",
+	"float32",
+	"float64",
+	"bin64",
+	"bin128",
+	"bin256",
+	"bytes",
+	"string",
+}
+
+// MarshalJSON returns a schema-free json representation of a value, see [ToJSON].
+func (v Value) MarshalJSON() ([]byte, error) {
+	return ToJSON(v)
+}
+
+// ToJSON returns a schema-free json representation of a value using its self-describing types.
+//
+// The mapping preserves the value types, so that [FromJSON] reconstructs identical bytes:
+//   - bools are json booleans, int64 values are json numbers,
+//     float64 values are json numbers with a fraction or an exponent, i.e. 1.0,
+//   - other numbers, bins and bytes are tagged strings, i.e. "int32:1", "float32:1.5",
+//     "bin64:0123456789abcdef", "bytes:aGVsbG8=", non-finite floats as "float64:NaN",
+//   - strings are json strings, strings which start with a tag are tagged as "string:...",
+//   - lists are arrays, messages are objects keyed by tag numbers in the data order,
+//   - structs are objects with a single "$struct" array of field values,
+//   - packed lists are objects with a single "$packed" array of elements.
+//
+// An empty value is null. Strings must be valid utf-8 for a round trip.
+func ToJSON(v Value) ([]byte, error) {
+	return ToJSONWithOptions(v, ParseOptions{})
+}
+
+// ToJSONWithOptions returns a schema-free json representation of a value, see [ToJSON],
+// returns an [ErrParseLimit] error when the value exceeds the limits.
+//
+// The value is parsed and validated once, so that untrusted input can be safely converted.
+func ToJSONWithOptions(v Value, opts ParseOptions) ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+
+	v, _, err := ParseValueWithOptions(v, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := appendJSON(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FromJSON reconstructs a value from its schema-free json representation, see [ToJSON].
+func FromJSON(data []byte) (Value, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("from json: %w", err)
+	}
+	if tok == nil {
+		if err := jsonEnd(dec); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	buf := buffer.New()
+	if err := encodeJSON(buf, dec, tok); err != nil {
+		return nil, fmt.Errorf("from json: %w", err)
+	}
+	if err := jsonEnd(dec); err != nil {
+		return nil, err
+	}
+	return Value(buf.Bytes()), nil
+}
+
+// append
+
+// appendJSON appends a value which is already parsed and validated, does not parse it again.
+func appendJSON(buf *bytes.Buffer, b []byte) error {
+	v, err := OpenValueErr(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ := v.Type(); typ {
+	case format.TypeTrue:
+		buf.WriteString("true")
+	case format.TypeFalse:
+		buf.WriteString("false")
+	case format.TypeByte:
+		appendJSONTagged(buf, "byte", strconv.FormatUint(uint64(v.Byte()), 10))
+
+	case format.TypeInt16:
+		appendJSONTagged(buf, "int16", strconv.FormatInt(int64(v.Int16()), 10))
+	case format.TypeInt32:
+		appendJSONTagged(buf, "int32", strconv.FormatInt(int64(v.Int32()), 10))
+	case format.TypeInt64:
+		buf.WriteString(strconv.FormatInt(v.Int64(), 10))
+	case format.TypeVarint:
+		appendJSONTagged(buf, "varint", strconv.FormatInt(v.Int64(), 10))
+
+	case format.TypeUint16:
+		appendJSONTagged(buf, "uint16", strconv.FormatUint(uint64(v.Uint16()), 10))
+	case format.TypeUint32:
+		appendJSONTagged(buf, "uint32", strconv.FormatUint(uint64(v.Uint32()), 10))
+	case format.TypeUint64:
+		appendJSONTagged(buf, "uint64", strconv.FormatUint(v.Uint64(), 10))
+	case format.TypeUvarint:
+		appendJSONTagged(buf, "uvarint", strconv.FormatUint(v.Uint64(), 10))
+
+	case format.TypeFloat32:
+		appendJSONTagged(buf, "float32", strconv.FormatFloat(float64(v.Float32()), 'g', -1, 32))
+	case format.TypeFloat64:
+		appendJSONFloat64(buf, v.Float64())
+
+	case format.TypeBin64:
+		appendJSONTagged(buf, "bin64", v.Bin64().String())
+	case format.TypeBin128:
+		appendJSONTagged(buf, "bin128", v.Bin128().String())
+	case format.TypeBin256:
+		appendJSONTagged(buf, "bin256", v.Bin256().String())
+
+	case format.TypeBytes:
+		appendJSONTagged(buf, "bytes", base64.StdEncoding.EncodeToString(v.Bytes()))
+	case format.TypeString:
+		s := string(v.String())
+		if hasJSONTag(s) {
+			appendJSONTagged(buf, "string", s)
+		} else {
+			appendJSONString(buf, s)
+		}
+
+	case format.TypeList, format.TypeBigList, format.TypePackedList:
+		return appendJSONList(buf, v.List())
+	case format.TypeMessage, format.TypeBigMessage:
+		return appendJSONMessage(buf, v.Message())
+	case format.TypeStruct:
+		return appendJSONStruct(buf, v)
+
+	default:
+		return fmt.Errorf("to json: unsupported type %v", typ)
+	}
+	return nil
+}
+
+func appendJSONList(buf *bytes.Buffer, l List) error {
+	if l.Packed() {
+		buf.WriteString(`{"` + jsonPacked + `":`)
+		defer buf.WriteByte('}')
+	}
+
+	var elem buffer.Buffer
+	if l.Packed() {
+		elem = buffer.New()
+	}
+
+	buf.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if elem != nil {
+			elem.Reset()
+		}
+		if err := appendJSON(buf, l.GetBytesTo(elem, i)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// appendJSONMessage appends message fields in the data order, the table is sorted by tags.
+func appendJSONMessage(buf *bytes.Buffer, m Message) error {
+	fields := slices.Clone(m.table.Fields())
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Offset < fields[j].Offset
+	})
+
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		appendJSONString(buf, strconv.Itoa(int(field.Tag)))
+		buf.WriteByte(':')
+
+		b := m.FieldRaw(field.Tag)
+		if err := appendJSON(buf, b); err != nil {
+			return fmt.Errorf("#%d: %w", field.Tag, err)
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// appendJSONStruct appends struct field values, which are decoded in reverse order.
+func appendJSONStruct(buf *bytes.Buffer, v Value) error {
+	dataSize, _, err := decode.DecodeStruct(v)
+	if err != nil {
+		return err
+	}
+
+	var fields [][]byte
+	data := v[:dataSize]
+	for off := len(data); off > 0; {
+		_, n, err := decode.DecodeTypeSize(data[:off])
+		if err != nil {
+			return err
+		}
+		fields = append(fields, data[off-n:off])
+		off -= n
+	}
+	slices.Reverse(fields)
+
+	buf.WriteString(`{"` + jsonStruct + `":[`)
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := appendJSON(buf, field); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("]}")
+	return nil
+}
+
+func appendJSONFloat64(buf *bytes.Buffer, v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		appendJSONTagged(buf, "float64", strconv.FormatFloat(v, 'g', -1, 64))
+		return
+	}
+
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	buf.WriteString(s)
+}
+
+func appendJSONTagged(buf *bytes.Buffer, tag string, s string) {
+	appendJSONString(buf, tag+":"+s)
+}
+
+func appendJSONString(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// encode
+
+func encodeJSON(buf buffer.Buffer, dec *json.Decoder, tok json.Token) (err error) {
+	switch tok := tok.(type) {
+	case bool:
+		_, err = encode.EncodeBool(buf, tok)
+	case json.Number:
+		err = encodeJSONNumber(buf, string(tok))
+	case string:
+		err = encodeJSONString(buf, tok)
+
+	case json.Delim:
+		switch tok {
+		case '[':
+			return encodeJSONList(buf, dec)
+		case '{':
+			return encodeJSONObject(buf, dec)
+		}
+		return fmt.Errorf("unexpected %v", tok)
+
+	case nil:
+		return errors.New("null values are not supported in lists, messages and structs")
+	default:
+		return fmt.Errorf("unexpected token %v", tok)
+	}
+	return err
+}
+
+func encodeJSONNumber(buf buffer.Buffer, s string) error {
+	if strings.ContainsAny(s, ".eE") {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		_, err = encode.EncodeFloat64(buf, v)
+		return err
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = encode.EncodeInt64(buf, v)
+	return err
+}
+
+func encodeJSONString(buf buffer.Buffer, s string) (err error) {
+	tag, v, ok := strings.Cut(s, ":")
+	if !ok || !slices.Contains(jsonTags, tag) {
+		_, err = encode.EncodeString(buf, s)
+		return err
+	}
+
+	switch tag {
+	case "byte":
+		var n uint64
+		if n, err = strconv.ParseUint(v, 10, 8); err == nil {
+			_, err = encode.EncodeByte(buf, byte(n))
+		}
+
+	case "int16":
+		var n int64
+		if n, err = strconv.ParseInt(v, 10, 16); err == nil {
+			_, err = encode.EncodeInt16(buf, int16(n))
+		}
+	case "int32":
+		var n int64
+		if n, err = strconv.ParseInt(v, 10, 32); err == nil {
+			_, err = encode.EncodeInt32(buf, int32(n))
+		}
+	case "varint":
+		var n int64
+		if n, err = strconv.ParseInt(v, 10, 64); err == nil {
+			_, err = encode.EncodeVarint(buf, n)
+		}
+
+	case "uint16":
+		var n uint64
+		if n, err = strconv.ParseUint(v, 10, 16); err == nil {
+			_, err = encode.EncodeUint16(buf, uint16(n))
+		}
+	case "uint32":
+		var n uint64
+		if n, err = strconv.ParseUint(v, 10, 32); err == nil {
+			_, err = encode.EncodeUint32(buf, uint32(n))
+		}
+	case "uint64":
+		var n uint64
+		if n, err = strconv.ParseUint(v, 10, 64); err == nil {
+			_, err = encode.EncodeUint64(buf, n)
+		}
+	case "uvarint":
+		var n uint64
+		if n, err = strconv.ParseUint(v, 10, 64); err == nil {
+			_, err = encode.EncodeUvarint(buf, n)
+		}
+
+	case "float32":
+		var f float64
+		if f, err = strconv.ParseFloat(v, 32); err == nil {
+			_, err = encode.EncodeFloat32(buf, float32(f))
+		}
+	case "float64":
+		var f float64
+		if f, err = strconv.ParseFloat(v, 64); err == nil {
+			_, err = encode.EncodeFloat64(buf, f)
+		}
+
+	case "bin64":
+		var b bin.Bin64
+		if b, err = bin.ParseString64(v); err == nil {
+			_, err = encode.EncodeBin64(buf, b)
+		}
+	case "bin128":
+		var b bin.Bin128
+		if b, err = bin.ParseString128(v); err == nil {
+			_, err = encode.EncodeBin128(buf, b)
+		}
+	case "bin256":
+		var b bin.Bin256
+		if b, err = bin.ParseString256(v); err == nil {
+			_, err = encode.EncodeBin256(buf, b)
+		}
+
+	case "bytes":
+		var b []byte
+		if b, err = base64.StdEncoding.DecodeString(v); err == nil {
+			_, err = encode.EncodeBytes(buf, b)
+		}
+	case "string":
+		_, err = encode.EncodeString(buf, v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %v value %q: %w", tag, v, err)
+	}
+	return nil
+}
+
+func encodeJSONList(buf buffer.Buffer, dec *json.Decoder) error {
+	start := buf.Len()
+	var table []format.ListElement
+
+	for i := 0; ; i++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim(']') {
+			break
+		}
+
+		if err := encodeJSON(buf, dec, tok); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+		table = append(table, format.ListElement{Offset: uint32(buf.Len() - start)})
+	}
+
+	_, err := encode.EncodeListTable(buf, buf.Len()-start, table)
+	return err
+}
+
+// encodeJSONObject encodes a message in the key order, or a struct.
+func encodeJSONObject(buf buffer.Buffer, dec *json.Decoder) error {
+	start := buf.Len()
+	var table []format.MessageField
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim('}') {
+			break
+		}
+
+		key := tok.(string)
+		if key == jsonStruct && len(table) == 0 {
+			return encodeJSONStruct(buf, dec)
+		}
+		if key == jsonPacked && len(table) == 0 {
+			return encodeJSONPacked(buf, dec)
+		}
+
+		tag, err := strconv.ParseUint(key, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid message tag %q", key)
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := encodeJSON(buf, dec, tok); err != nil {
+			return fmt.Errorf("#%d: %w", tag, err)
+		}
+
+		// Insert field sorted by tag
+		field := format.MessageField{
+			Tag:    uint16(tag),
+			Offset: uint32(buf.Len() - start),
+		}
+		i, found := slices.BinarySearchFunc(table, field.Tag, func(f format.MessageField, tag uint16) int {
+			return int(f.Tag) - int(tag)
+		})
+		if found {
+			return fmt.Errorf("duplicate message tag %d", tag)
+		}
+		table = slices.Insert(table, i, field)
+	}
+
+	_, err := encode.EncodeMessageTable(buf, buf.Len()-start, table)
+	return err
+}
+
+// encodeJSONStruct encodes struct values, and expects the end of the object.
+func encodeJSONStruct(buf buffer.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("invalid %v, expected array", jsonStruct)
+	}
+
+	start := buf.Len()
+	for i := 0; ; i++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim(']') {
+			break
+		}
+
+		if err := encodeJSON(buf, dec, tok); err != nil {
+			return fmt.Errorf("%v[%d]: %w", jsonStruct, i, err)
+		}
+	}
+
+	tok, err = dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('}') {
+		return fmt.Errorf("invalid struct, unexpected key after %v", jsonStruct)
+	}
+
+	_, err = encode.EncodeStruct(buf, buf.Len()-start)
+	return err
+}
+
+// encodeJSONPacked encodes packed list elements, and expects the end of the object.
+func encodeJSONPacked(buf buffer.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("invalid %v, expected array", jsonPacked)
+	}
+
+	start := buf.Len()
+	elem := buffer.New()
+	p := packer{buf: buf}
+
+	for i := 0; ; i++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim(']') {
+			break
+		}
+
+		elem.Reset()
+		if err := encodeJSON(elem, dec, tok); err != nil {
+			return fmt.Errorf("%v[%d]: %w", jsonPacked, i, err)
+		}
+		if err := p.add(elem.Bytes()); err != nil {
+			return fmt.Errorf("%v[%d]: %w", jsonPacked, i, err)
+		}
+	}
+
+	tok, err = dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('}') {
+		return fmt.Errorf("invalid packed list, unexpected key after %v", jsonPacked)
+	}
+	return p.end(start)
+}
+
+// util
+
+func hasJSONTag(s string) bool {
+	tag, _, ok := strings.Cut(s, ":")
+	return ok && slices.Contains(jsonTags, tag)
+}
+
+func jsonEnd(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("from json: unexpected data after value")
+	}
+	return nil
+}
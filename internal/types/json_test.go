@@ -0,0 +1,164 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEncode[T any](t *testing.T, encode func(buffer.Buffer, T) (int, error), v T) Value {
+	buf := buffer.New()
+	_, err := encode(buf, v)
+	require.NoError(t, err)
+	return Value(buf.Bytes())
+}
+
+func testRoundTrip(t *testing.T, v Value) string {
+	data, err := ToJSON(v)
+	require.NoError(t, err)
+
+	v1, err := FromJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, v, v1, string(data))
+	return string(data)
+}
+
+// ToJSON
+
+func TestToJSON__should_convert_primitives(t *testing.T) {
+	tests := []struct {
+		value Value
+		json  string
+	}{
+		{testEncode(t, encode.EncodeBool, true), `true`},
+		{testEncode(t, encode.EncodeBool, false), `false`},
+		{testEncode(t, encode.EncodeByte, 255), `"byte:255"`},
+		{testEncode(t, encode.EncodeInt16, -1), `"int16:-1"`},
+		{testEncode(t, encode.EncodeInt32, math.MaxInt32), `"int32:2147483647"`},
+		{testEncode(t, encode.EncodeInt64, math.MinInt64), `-9223372036854775808`},
+		{testEncode(t, encode.EncodeVarint, -300), `"varint:-300"`},
+		{testEncode(t, encode.EncodeUint16, 1), `"uint16:1"`},
+		{testEncode(t, encode.EncodeUint32, 1), `"uint32:1"`},
+		{testEncode(t, encode.EncodeUint64, math.MaxUint64), `"uint64:18446744073709551615"`},
+		{testEncode(t, encode.EncodeUvarint, 300), `"uvarint:300"`},
+		{testEncode(t, encode.EncodeFloat32, 1.5), `"float32:1.5"`},
+		{testEncode(t, encode.EncodeFloat64, 1), `1.0`},
+		{testEncode(t, encode.EncodeFloat64, 1e300), `1e+300`},
+		{testEncode(t, encode.EncodeFloat64, math.Inf(-1)), `"float64:-Inf"`},
+		{testEncode(t, encode.EncodeBin64, bin.Int64(1)), `"bin64:0000000000000001"`},
+		{testEncode(t, encode.EncodeBin128, bin.Int128(1, 2)),
+			`"bin128:0000000000000001-0000000000000002"`},
+		{testEncode(t, encode.EncodeBytes, []byte("hello")), `"bytes:aGVsbG8="`},
+		{testEncode(t, encode.EncodeString, "hello"), `"hello"`},
+		{testEncode(t, encode.EncodeString, "int32:1"), `"string:int32:1"`},
+		{testEncode(t, encode.EncodeString, "key:value"), `"key:value"`},
+	}
+
+	for _, tt := range tests {
+		data := testRoundTrip(t, tt.value)
+		assert.Equal(t, tt.json, data)
+	}
+}
+
+func TestToJSON__should_convert_empty_value_to_null(t *testing.T) {
+	data, err := ToJSON(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	v, err := FromJSON(data)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestToJSONWithOptions__should_return_error_when_limit_exceeded(t *testing.T) {
+	data := strings.Repeat("[", 10) + `{"1":["int32:1"]}` + strings.Repeat("]", 10)
+	v := testFromJSON(t, data)
+
+	data1, err := ToJSONWithOptions(v, ParseOptions{MaxDepth: 12})
+	require.NoError(t, err)
+	assert.Equal(t, data, string(data1))
+
+	_, err = ToJSONWithOptions(v, ParseOptions{MaxDepth: 11})
+	assert.ErrorIs(t, err, ErrParseLimit)
+}
+
+func TestToJSON__should_return_error_on_invalid_nested_value(t *testing.T) {
+	v := testFromJSON(t, `[["int32:1"],"int32:2"]`)
+
+	// Corrupt the nested list element type
+	b := slices.Clone(v)
+	i := bytes.IndexByte(b, byte(format.TypeInt32))
+	b[i] = 0xee
+
+	_, err := ToJSON(b)
+	assert.Error(t, err)
+}
+
+// FromJSON
+
+func TestFromJSON__should_preserve_message_field_order(t *testing.T) {
+	v, err := FromJSON([]byte(`{"3": "c", "1": {"2": ["int32:1", 2]}, "2": {"$struct": [1, "b"]}}`))
+	require.NoError(t, err)
+
+	msg := v.Message()
+	assert.Equal(t, "c", msg.Field(3).String().Unwrap())
+	assert.Equal(t, int32(1), msg.Field(1).Message().Field(2).List().Get(0).Int32())
+
+	data := testRoundTrip(t, v)
+	assert.Equal(t, `{"3":"c","1":{"2":["int32:1",2]},"2":{"$struct":[1,"b"]}}`, data)
+}
+
+func TestFromJSON__should_return_error_on_invalid_json(t *testing.T) {
+	tests := []struct {
+		json string
+		err  string
+	}{
+		{`{"a": 1}`, `from json: invalid message tag "a"`},
+		{`{"1": 1, "1": 2}`, `from json: duplicate message tag 1`},
+		{`[null]`, `from json: [0]: null values are not supported in lists, messages and structs`},
+		{`"int16:100000"`, `from json: invalid int16 value "100000": ` +
+			`strconv.ParseInt: parsing "100000": value out of range`},
+		{`1 2`, `from json: unexpected data after value`},
+	}
+
+	for _, tt := range tests {
+		_, err := FromJSON([]byte(tt.json))
+		assert.EqualError(t, err, tt.err, tt.json)
+	}
+}
+
+// MarshalJSON
+
+func TestValue_MarshalJSON__should_convert_value(t *testing.T) {
+	v := testEncode(t, encode.EncodeInt32, 1)
+
+	data, err := json.Marshal(struct{ V Value }{v})
+	require.NoError(t, err)
+	assert.Equal(t, `{"V":"int32:1"}`, string(data))
+}
+
+func TestFromJSON__should_convert_packed_list(t *testing.T) {
+	v, err := FromJSON([]byte(`{"1": {"$packed": ["int32:1", "int32:-2"]}}`))
+	require.NoError(t, err)
+
+	l := v.Message().Field(1).List()
+	assert.True(t, l.Packed())
+	assert.Equal(t, int32(-2), l.Get(1).Int32())
+
+	data := testRoundTrip(t, v)
+	assert.Equal(t, `{"1":{"$packed":["int32:1","int32:-2"]}}`, data)
+}
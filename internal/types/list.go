@@ -7,6 +7,7 @@ package types
 import (
 	"fmt"
 
+	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/spec/internal/decode"
 	"github.com/basecomplextech/spec/internal/format"
 )
@@ -32,24 +33,10 @@ func OpenListErr(b []byte) (List, error) {
 }
 
 // ParseList recursively parses and returns a list.
+// See [ParseListWithOptions] for parsing untrusted input.
 func ParseList(b []byte) (l List, size int, err error) {
-	l, size, err = decodeList(b)
-	if err != nil {
-		return List{}, 0, err
-	}
-
-	ln := l.Len()
-	for i := 0; i < ln; i++ {
-		b1 := l.GetBytes(i)
-		if len(b1) == 0 {
-			continue
-		}
-
-		if _, _, err = ParseValue(b1); err != nil {
-			return
-		}
-	}
-	return l, size, nil
+	p := parser{}
+	return p.list(b)
 }
 
 func decodeList(b []byte) (l List, size int, err error) {
@@ -84,32 +71,27 @@ func (l List) Raw() []byte {
 // Elements
 
 // Get returns an element at index i, panics on out of range.
+//
+// Packed number and bin elements are stored without value types, so each call allocates
+// and encodes a new value. Use [PackedGet] or [List.GetBytesTo] to read them without allocation.
 func (l List) Get(i int) Value {
-	start, end := l.table.Offset(i)
-	if start < 0 {
-		panic(fmt.Sprintf("index out of range: %d", i))
-	}
-
-	size := l.table.DataSize()
-	if end > int(size) {
-		return Value{}
-	}
-	return l.bytes[start:end]
+	return l.GetBytes(i)
 }
 
 // GetBytes returns element bytes at index i, panics on out of range.
+//
+// Packed number and bin elements are stored without value types, so each call allocates
+// and encodes a new value. Use [PackedGet] or [List.GetBytesTo] to read them without allocation.
 func (l List) GetBytes(i int) []byte {
-	start, end := l.table.Offset(i)
-	if start < 0 {
-		panic(fmt.Sprintf("index out of range: %d", i))
-	}
-
-	size := l.table.DataSize()
-	if end > int(size) {
-		return nil
-	}
+	return l.getBytes(nil, i)
+}
 
-	return l.bytes[start:end]
+// GetBytesTo returns element bytes at index i, panics on out of range.
+//
+// Packed number and bin elements are encoded into the buffer, so that a reused buffer
+// reads them without allocation. The returned bytes are valid until the buffer is modified.
+func (l List) GetBytesTo(buf buffer.Buffer, i int) []byte {
+	return l.getBytes(buf, i)
 }
 
 // Clone
@@ -132,3 +114,26 @@ func (l List) CloneTo(b []byte) List {
 	copy(b, l.bytes)
 	return OpenList(b)
 }
+
+// private
+
+func (l List) getBytes(buf buffer.Buffer, i int) []byte {
+	start, end := l.table.Offset(i)
+	if start < 0 {
+		panic(fmt.Sprintf("index out of range: %d", i))
+	}
+
+	size := l.table.DataSize()
+	if end > int(size) {
+		return nil
+	}
+
+	b := l.bytes[start:end]
+	if typ := l.table.ElemType(); typ != format.TypeUndefined && typ != format.TypeStruct {
+		if buf == nil {
+			buf = buffer.NewSize(len(b) + 1)
+		}
+		return appendPacked(buf, typ, b)
+	}
+	return b
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/encode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// Packed returns true if the list is packed, see [format.PackedSize].
+func (l List) Packed() bool {
+	return l.table.Packed()
+}
+
+// ElemType returns the packed list element type, or undefined.
+func (l List) ElemType() format.Type {
+	return l.table.ElemType()
+}
+
+// PackedGet returns a packed list element at index i without allocation,
+// returns false when the list is not packed or T does not match its element type.
+// Panics on out of range.
+func PackedGet[T any](l List, i int) (v T, ok bool) {
+	typ := l.table.ElemType()
+	if typ == format.TypeUndefined || typ != format.PackedType[T]() {
+		return v, false
+	}
+
+	start, end := l.table.Offset(i)
+	if start < 0 {
+		panic(fmt.Sprintf("index out of range: %d", i))
+	}
+	b := l.bytes[start:end]
+
+	switch p := any(&v).(type) {
+	case *int16:
+		*p = int16(binary.LittleEndian.Uint16(b))
+	case *int32:
+		*p = int32(binary.LittleEndian.Uint32(b))
+	case *int64:
+		*p = int64(binary.LittleEndian.Uint64(b))
+
+	case *uint16:
+		*p = binary.LittleEndian.Uint16(b)
+	case *uint32:
+		*p = binary.LittleEndian.Uint32(b)
+	case *uint64:
+		*p = binary.LittleEndian.Uint64(b)
+
+	case *float32:
+		*p = math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case *float64:
+		*p = math.Float64frombits(binary.LittleEndian.Uint64(b))
+
+	case *bin.Bin64:
+		copy(p[:], b)
+	case *bin.Bin128:
+		copy(p[0][:], b[:8])
+		copy(p[1][:], b[8:])
+	case *bin.Bin256:
+		copy(p[0][:], b[:8])
+		copy(p[1][:], b[8:16])
+		copy(p[2][:], b[16:24])
+		copy(p[3][:], b[24:])
+	}
+	return v, true
+}
+
+// PackedView returns a zero-copy slice of packed list elements, returns false when the list
+// is not packed, T does not match its element type, the host is big-endian, or the elements
+// are not aligned. The slice must not be modified, it is valid only while the list bytes are.
+func PackedView[T any](l List) ([]T, bool) {
+	typ := l.table.ElemType()
+	if typ == format.TypeUndefined || typ != format.PackedType[T]() {
+		return nil, false
+	}
+
+	n := l.table.Len()
+	if n == 0 {
+		return nil, true
+	}
+
+	align := format.PackedAlign(typ)
+	if align > 1 && !littleEndian {
+		return nil, false
+	}
+
+	start, _ := l.table.Offset(0)
+	p := unsafe.Pointer(&l.bytes[start])
+	if uintptr(p)%uintptr(align) != 0 {
+		return nil, false
+	}
+	return unsafe.Slice((*T)(p), n), true
+}
+
+// private
+
+var littleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// appendPacked encodes a packed number or bin element into a buffer, returns the encoded value.
+func appendPacked(buf buffer.Buffer, typ format.Type, b []byte) Value {
+	start := buf.Len()
+
+	switch typ {
+	case format.TypeInt16:
+		encode.EncodeInt16(buf, int16(binary.LittleEndian.Uint16(b)))
+	case format.TypeInt32:
+		encode.EncodeInt32(buf, int32(binary.LittleEndian.Uint32(b)))
+	case format.TypeInt64:
+		encode.EncodeInt64(buf, int64(binary.LittleEndian.Uint64(b)))
+
+	case format.TypeUint16:
+		encode.EncodeUint16(buf, binary.LittleEndian.Uint16(b))
+	case format.TypeUint32:
+		encode.EncodeUint32(buf, binary.LittleEndian.Uint32(b))
+	case format.TypeUint64:
+		encode.EncodeUint64(buf, binary.LittleEndian.Uint64(b))
+
+	case format.TypeFloat32:
+		encode.EncodeFloat32(buf, math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	case format.TypeFloat64:
+		encode.EncodeFloat64(buf, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+
+	case format.TypeBin64, format.TypeBin128, format.TypeBin256:
+		buf.Write(b)
+		buf.WriteByte(byte(typ))
+	}
+	return buf.Bytes()[start:]
+}
+
+// packer
+
+// packer packs standalone values into a packed list with the same layout as the writer.
+type packer struct {
+	buf buffer.Buffer
+
+	elemType format.Type
+	elemSize int
+	count    int
+}
+
+// add packs a value, the first value specifies the element type.
+func (p *packer) add(v Value) error {
+	typ := v.Type()
+
+	switch {
+	case p.count == 0:
+		if !format.IsPackable(typ) {
+			return fmt.Errorf("type is not packable, type=%v", typ)
+		}
+		p.elemType = typ
+
+		align := format.PackedAlign(typ)
+		if pad := (align - p.buf.Len()%align) % align; pad > 0 {
+			clear(p.buf.Grow(pad))
+		}
+
+	case typ != p.elemType:
+		return fmt.Errorf("invalid packed element type, type=%v, list type=%v", typ, p.elemType)
+	}
+
+	size := format.PackedSize(typ)
+	if typ == format.TypeStruct {
+		size = len(v)
+	}
+	if p.count > 0 && size != p.elemSize {
+		return fmt.Errorf("invalid packed element size, size=%d, list size=%d", size, p.elemSize)
+	}
+	p.elemSize = size
+	p.count++
+
+	b := p.buf.Grow(size)
+	switch typ {
+	case format.TypeInt16:
+		binary.LittleEndian.PutUint16(b, uint16(v.Int16()))
+	case format.TypeInt32:
+		binary.LittleEndian.PutUint32(b, uint32(v.Int32()))
+	case format.TypeInt64:
+		binary.LittleEndian.PutUint64(b, uint64(v.Int64()))
+
+	case format.TypeUint16:
+		binary.LittleEndian.PutUint16(b, v.Uint16())
+	case format.TypeUint32:
+		binary.LittleEndian.PutUint32(b, v.Uint32())
+	case format.TypeUint64:
+		binary.LittleEndian.PutUint64(b, v.Uint64())
+
+	case format.TypeFloat32:
+		binary.LittleEndian.PutUint32(b, math.Float32bits(v.Float32()))
+	case format.TypeFloat64:
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v.Float64()))
+
+	case format.TypeBin64:
+		v.Bin64().MarshalTo(b)
+	case format.TypeBin128:
+		v.Bin128().MarshalTo(b)
+	case format.TypeBin256:
+		v.Bin256().MarshalTo(b)
+
+	case format.TypeStruct:
+		copy(b, v)
+	}
+	return nil
+}
+
+// end encodes the packed list which starts at start, or an empty list.
+func (p *packer) end(start int) error {
+	if p.count == 0 {
+		_, err := encode.EncodeListTable(p.buf, 0, nil)
+		return err
+	}
+
+	dataSize := p.buf.Len() - start
+	_, err := encode.EncodePackedList(p.buf, dataSize, p.count, p.elemSize, p.elemType)
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPackedList(t *testing.T, data string) List {
+	v := testFromJSON(t, data)
+
+	l, _, err := ParseList(v)
+	require.NoError(t, err)
+	require.True(t, l.Packed())
+	return l
+}
+
+// Get
+
+func TestList_Get__should_return_packed_elements_as_values(t *testing.T) {
+	l := testPackedList(t, `{"$packed": ["int16:1", "int16:-2", "int16:3"]}`)
+
+	assert.Equal(t, 3, l.Len())
+	assert.Equal(t, int16(1), l.Get(0).Int16())
+	assert.Equal(t, int16(-2), l.Get(1).Int16())
+	assert.Equal(t, int16(3), l.Get(2).Int16())
+}
+
+// GetBytesTo
+
+func TestList_GetBytesTo__should_encode_packed_elements_into_buffer(t *testing.T) {
+	l := testPackedList(t, `{"$packed": [1, -200000, 3]}`)
+	buf := buffer.New()
+
+	for i, exp := range []int64{1, -200000, 3} {
+		buf.Reset()
+		v := Value(l.GetBytesTo(buf, i))
+		assert.Equal(t, exp, v.Int64())
+	}
+}
+
+func TestList_GetBytesTo__should_not_allocate_when_buffer_reused(t *testing.T) {
+	l := testPackedList(t, `{"$packed": [1, -200000, 3]}`)
+	buf := buffer.New()
+	l.GetBytesTo(buf, 0)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < l.Len(); i++ {
+			buf.Reset()
+			l.GetBytesTo(buf, i)
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+// PackedGet
+
+func TestPackedGet__should_return_element(t *testing.T) {
+	l := testPackedList(t, `{"$packed": [1.5, -2.5]}`)
+
+	v, ok := PackedGet[float64](l, 1)
+	require.True(t, ok)
+	assert.Equal(t, -2.5, v)
+}
+
+func TestPackedGet__should_return_false_when_type_mismatch(t *testing.T) {
+	l := testPackedList(t, `{"$packed": [1.5, -2.5]}`)
+
+	_, ok := PackedGet[int64](l, 0)
+	assert.False(t, ok)
+}
+
+func TestPackedGet__should_return_bins(t *testing.T) {
+	b := bin.Int128(1, 2)
+	l := testPackedList(t, `{"$packed": ["bin128:`+b.String()+`"]}`)
+
+	v, ok := PackedGet[bin.Bin128](l, 0)
+	require.True(t, ok)
+	assert.Equal(t, b, v)
+}
+
+// PackedView
+
+func TestPackedView__should_return_elements(t *testing.T) {
+	l := testPackedList(t, `{"$packed": ["uint32:1", "uint32:2", "uint32:3"]}`)
+
+	v, ok := PackedView[uint32](l)
+	if !ok {
+		t.Skip("unaligned or big-endian")
+	}
+	assert.Equal(t, []uint32{1, 2, 3}, v)
+}
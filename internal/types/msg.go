@@ -55,30 +55,10 @@ func OpenMessageErr(b []byte) (Message, error) {
 }
 
 // ParseMessage recursively parses and returns a message.
+// See [ParseMessageWithOptions] for parsing untrusted input.
 func ParseMessage(b []byte) (_ Message, size int, err error) {
-	table, size, err := decode.DecodeMessageTable(b)
-	if err != nil {
-		return Message{}, 0, err
-	}
-	bytes := b[len(b)-size:]
-
-	m := Message{
-		table: table,
-		bytes: bytes,
-	}
-
-	num := m.Fields()
-	for i := 0; i < num; i++ {
-		b1 := m.fieldAt(i)
-		if len(b1) == 0 {
-			continue
-		}
-
-		if _, _, err = ParseValue(b1); err != nil {
-			return
-		}
-	}
-	return m, size, nil
+	p := parser{}
+	return p.message(b)
 }
 
 // Empty returns true if bytes are empty or message has no fields.
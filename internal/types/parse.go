@@ -0,0 +1,271 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// ErrParseLimit is returned when a parsed value exceeds parse options limits.
+var ErrParseLimit = errors.New("parse limit exceeded")
+
+// ParseOptions limit recursive parsing of untrusted input, zero values mean no limits.
+type ParseOptions struct {
+	// MaxDepth is a max nesting depth of lists and messages, the root list or message has depth 1.
+	MaxDepth int `json:"max_depth"`
+
+	// MaxSize is a max total value size in bytes.
+	MaxSize int `json:"max_size"`
+
+	// MaxListLen is a max number of elements in a list.
+	MaxListLen int `json:"max_list_len"`
+
+	// MaxFields is a max number of fields in a message.
+	MaxFields int `json:"max_fields"`
+
+	// MaxStringLen is a max string or bytes length.
+	MaxStringLen int `json:"max_string_len"`
+}
+
+// ParseValueWithOptions recursively parses and returns a value, returns an [ErrParseLimit]
+// error when the value exceeds the limits.
+func ParseValueWithOptions(b []byte, opts ParseOptions) (_ Value, n int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return nil, 0, err
+	}
+	return p.value(b)
+}
+
+// ParseMessageWithOptions recursively parses and returns a message, returns an [ErrParseLimit]
+// error when the message exceeds the limits.
+func ParseMessageWithOptions(b []byte, opts ParseOptions) (_ Message, size int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return Message{}, 0, err
+	}
+	return p.message(b)
+}
+
+// ParseListWithOptions recursively parses and returns a list, returns an [ErrParseLimit]
+// error when the list exceeds the limits.
+func ParseListWithOptions(b []byte, opts ParseOptions) (_ List, size int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return List{}, 0, err
+	}
+	return p.list(b)
+}
+
+// parser
+
+// parser recursively parses values, the zero parser has no limits.
+type parser struct {
+	opts  ParseOptions
+	depth int
+}
+
+func (p *parser) value(b []byte) (_ Value, n int, err error) {
+	typ, n, err := decode.DecodeType(b)
+	if err != nil {
+		return
+	}
+
+	switch typ {
+	case format.TypeTrue, format.TypeFalse:
+		// Pass
+
+	case format.TypeByte:
+		_, n, err = decode.DecodeByte(b)
+
+	case format.TypeInt16:
+		_, n, err = decode.DecodeInt16(b)
+	case format.TypeInt32:
+		_, n, err = decode.DecodeInt32(b)
+	case format.TypeInt64, format.TypeVarint:
+		_, n, err = decode.DecodeInt64(b)
+
+	case format.TypeUint16:
+		_, n, err = decode.DecodeUint16(b)
+	case format.TypeUint32:
+		_, n, err = decode.DecodeUint32(b)
+	case format.TypeUint64, format.TypeUvarint:
+		_, n, err = decode.DecodeUint64(b)
+
+	case format.TypeBin64:
+		_, n, err = decode.DecodeBin64(b)
+	case format.TypeBin128:
+		_, n, err = decode.DecodeBin128(b)
+	case format.TypeBin256:
+		_, n, err = decode.DecodeBin256(b)
+
+	case format.TypeFloat32:
+		_, n, err = decode.DecodeFloat32(b)
+	case format.TypeFloat64:
+		_, n, err = decode.DecodeFloat64(b)
+
+	case format.TypeBytes:
+		var v format.Bytes
+		v, n, err = decode.DecodeBytes(b)
+		if err == nil {
+			err = p.checkString(len(v))
+		}
+	case format.TypeString:
+		var v format.String
+		v, n, err = decode.DecodeString(b)
+		if err == nil {
+			err = p.checkString(len(v))
+		}
+
+	case format.TypeList, format.TypeBigList, format.TypePackedList:
+		_, n, err = p.list(b)
+
+	case format.TypeMessage, format.TypeBigMessage:
+		_, n, err = p.message(b)
+
+	case format.TypeStruct:
+		_, n, err = decode.DecodeStruct(b)
+
+	default:
+		n, err = 0, fmt.Errorf("unsupported type %d", typ)
+	}
+	if err != nil {
+		return nil, n, err
+	}
+
+	return b[len(b)-n:], n, nil
+}
+
+func (p *parser) list(b []byte) (l List, size int, err error) {
+	l, size, err = decodeList(b)
+	if err != nil {
+		return List{}, 0, err
+	}
+
+	ln := l.Len()
+	if max := p.opts.MaxListLen; max > 0 && ln > max {
+		return List{}, 0, fmt.Errorf("%w: list length %d, max=%d", ErrParseLimit, ln, max)
+	}
+	if err := p.push(); err != nil {
+		return List{}, 0, err
+	}
+	defer p.pop()
+
+	if l.Packed() {
+		if err := p.packed(l); err != nil {
+			return List{}, 0, err
+		}
+		return l, size, nil
+	}
+
+	for i := 0; i < ln; i++ {
+		b1 := l.GetBytes(i)
+		if len(b1) == 0 {
+			continue
+		}
+
+		if _, _, err = p.value(b1); err != nil {
+			return
+		}
+	}
+	return l, size, nil
+}
+
+// packed checks packed struct elements, packed numbers and bins are always valid.
+func (p *parser) packed(l List) error {
+	if l.ElemType() != format.TypeStruct {
+		return nil
+	}
+
+	ln := l.Len()
+	for i := 0; i < ln; i++ {
+		b1 := l.GetBytes(i)
+
+		_, n, err := decode.DecodeStruct(b1)
+		switch {
+		case err != nil:
+			return err
+		case n != len(b1):
+			return fmt.Errorf("decode list: invalid packed struct, size=%d, elem size=%d", n, len(b1))
+		}
+	}
+	return nil
+}
+
+func (p *parser) message(b []byte) (_ Message, size int, err error) {
+	table, size, err := decode.DecodeMessageTable(b)
+	if err != nil {
+		return Message{}, 0, err
+	}
+	bytes := b[len(b)-size:]
+
+	m := Message{
+		table: table,
+		bytes: bytes,
+	}
+
+	num := m.Fields()
+	if max := p.opts.MaxFields; max > 0 && num > max {
+		return Message{}, 0, fmt.Errorf("%w: message fields %d, max=%d", ErrParseLimit, num, max)
+	}
+	if err := p.push(); err != nil {
+		return Message{}, 0, err
+	}
+	defer p.pop()
+
+	for i := 0; i < num; i++ {
+		b1 := m.fieldAt(i)
+		if len(b1) == 0 {
+			continue
+		}
+
+		if _, _, err = p.value(b1); err != nil {
+			return
+		}
+	}
+	return m, size, nil
+}
+
+// push increments the depth of a list or message.
+func (p *parser) push() error {
+	p.depth++
+
+	if max := p.opts.MaxDepth; max > 0 && p.depth > max {
+		return fmt.Errorf("%w: depth %d, max=%d", ErrParseLimit, p.depth, max)
+	}
+	return nil
+}
+
+func (p *parser) pop() {
+	p.depth--
+}
+
+// checkSize checks the root value size without parsing the value.
+func (p *parser) checkSize(b []byte) error {
+	max := p.opts.MaxSize
+	if max <= 0 {
+		return nil
+	}
+
+	_, n, err := decode.DecodeTypeSize(b)
+	if err != nil {
+		return err
+	}
+	if n > max {
+		return fmt.Errorf("%w: size %d, max=%d", ErrParseLimit, n, max)
+	}
+	return nil
+}
+
+func (p *parser) checkString(n int) error {
+	if max := p.opts.MaxStringLen; max > 0 && n > max {
+		return fmt.Errorf("%w: string length %d, max=%d", ErrParseLimit, n, max)
+	}
+	return nil
+}
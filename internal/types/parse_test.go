@@ -0,0 +1,91 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFromJSON(t *testing.T, data string) Value {
+	v, err := FromJSON([]byte(data))
+	require.NoError(t, err)
+	return v
+}
+
+// ParseValueWithOptions
+
+func TestParseValueWithOptions__should_parse_value_within_limits(t *testing.T) {
+	v := testFromJSON(t, `{"1": [[1, 2], [3]], "2": "hello"}`)
+	opts := ParseOptions{
+		MaxDepth:     3,
+		MaxSize:      len(v),
+		MaxListLen:   2,
+		MaxFields:    2,
+		MaxStringLen: 5,
+	}
+
+	v1, n, err := ParseValueWithOptions(v, opts)
+	require.NoError(t, err)
+	assert.Equal(t, len(v), n)
+	assert.Equal(t, v, v1)
+}
+
+func TestParseValueWithOptions__should_return_error_when_limit_exceeded(t *testing.T) {
+	v := testFromJSON(t, `{"1": [[1, 2], [3]], "2": "hello"}`)
+
+	tests := []struct {
+		opts ParseOptions
+		err  string
+	}{
+		{ParseOptions{MaxDepth: 2}, "parse limit exceeded: depth 3, max=2"},
+		{ParseOptions{MaxSize: len(v) - 1}, "parse limit exceeded: size"},
+		{ParseOptions{MaxListLen: 1}, "parse limit exceeded: list length 2, max=1"},
+		{ParseOptions{MaxFields: 1}, "parse limit exceeded: message fields 2, max=1"},
+		{ParseOptions{MaxStringLen: 4}, "parse limit exceeded: string length 5, max=4"},
+	}
+
+	for _, tt := range tests {
+		_, _, err := ParseValueWithOptions(v, tt.opts)
+		require.ErrorIs(t, err, ErrParseLimit)
+		assert.Contains(t, err.Error(), tt.err)
+	}
+}
+
+func TestParseValueWithOptions__should_limit_deeply_nested_lists(t *testing.T) {
+	data := strings.Repeat("[", 1000) + strings.Repeat("]", 1000)
+	v := testFromJSON(t, data)
+
+	_, _, err := ParseValue(v)
+	require.NoError(t, err)
+
+	_, _, err = ParseValueWithOptions(v, ParseOptions{MaxDepth: 100})
+	assert.ErrorIs(t, err, ErrParseLimit)
+}
+
+func TestParseValueWithOptions__should_check_bytes_length(t *testing.T) {
+	v := testFromJSON(t, `["bytes:aGVsbG8="]`)
+
+	_, _, err := ParseListWithOptions(v, ParseOptions{MaxStringLen: 5})
+	require.NoError(t, err)
+
+	_, _, err = ParseListWithOptions(v, ParseOptions{MaxStringLen: 4})
+	assert.ErrorIs(t, err, ErrParseLimit)
+}
+
+// ParseMessageWithOptions
+
+func TestParseMessageWithOptions__should_return_error_when_limit_exceeded(t *testing.T) {
+	v := testFromJSON(t, `{"1": {"1": {"1": 1}}}`)
+
+	_, _, err := ParseMessageWithOptions(v, ParseOptions{MaxDepth: 3})
+	require.NoError(t, err)
+
+	_, _, err = ParseMessageWithOptions(v, ParseOptions{MaxDepth: 2})
+	assert.ErrorIs(t, err, ErrParseLimit)
+}
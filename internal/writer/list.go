@@ -4,7 +4,13 @@
 
 package writer
 
-import "github.com/basecomplextech/baselibrary/bin"
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/format"
+)
 
 // ListWriter writes a list of elements.
 type ListWriter struct {
@@ -33,17 +39,78 @@ func (l ListWriter) End() error {
 	return err
 }
 
+// Pack switches an empty list into a packed list of fixed-size elements of the given type,
+// see [format.PackedSize]. Packed lists accept only elements of this type.
+func (l ListWriter) Pack(elemType format.Type) error {
+	return l.w.packList(elemType)
+}
+
+// Packed returns true if the list is packed.
+func (l ListWriter) Packed() bool {
+	return l.w.packedType() != format.TypeUndefined
+}
+
 // WriteElement writes a generic element using the given write function.
 func WriteElement[T any](w ListWriter, value T, write WriteFunc[T]) error {
+	if w.Packed() {
+		return writePacked(w, value, write)
+	}
+
 	if err := WriteValue(w.w, value, write); err != nil {
 		return err
 	}
 	return w.w.element()
 }
 
+// writePacked writes a packed element, other values are written as structs using the write function.
+func writePacked[T any](w ListWriter, value T, write WriteFunc[T]) error {
+	switch v := any(value).(type) {
+	case int16:
+		return w.Int16(v)
+	case int32:
+		return w.Int32(v)
+	case int64:
+		return w.Int64(v)
+
+	case uint16:
+		return w.Uint16(v)
+	case uint32:
+		return w.Uint32(v)
+	case uint64:
+		return w.Uint64(v)
+
+	case float32:
+		return w.Float32(v)
+	case float64:
+		return w.Float64(v)
+
+	case bin.Bin64:
+		return w.Bin64(v)
+	case bin.Bin128:
+		return w.Bin128(v)
+	case bin.Bin256:
+		return w.Bin256(v)
+	}
+
+	w1 := w.w
+	if err := w1.beginPacked(format.TypeStruct); err != nil {
+		return err
+	}
+
+	start := w1.buf.Len()
+	if _, err := write(w1.buf, value); err != nil {
+		return w1.fail(err)
+	}
+	return w1.endPacked(w1.buf.Len() - start)
+}
+
 // Elements
 
 func (l ListWriter) Any(v []byte) error {
+	if l.Packed() {
+		return l.w.packedStruct(v)
+	}
+
 	if err := l.w.Value().Any(v); err != nil {
 		return err
 	}
@@ -67,6 +134,15 @@ func (l ListWriter) Byte(v byte) error {
 // Int
 
 func (l ListWriter) Int16(v int16) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeInt16)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint16(p, uint16(v))
+		return nil
+	}
+
 	if err := l.w.Value().Int16(v); err != nil {
 		return err
 	}
@@ -74,6 +150,15 @@ func (l ListWriter) Int16(v int16) error {
 }
 
 func (l ListWriter) Int32(v int32) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeInt32)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint32(p, uint32(v))
+		return nil
+	}
+
 	if err := l.w.Value().Int32(v); err != nil {
 		return err
 	}
@@ -81,6 +166,15 @@ func (l ListWriter) Int32(v int32) error {
 }
 
 func (l ListWriter) Int64(v int64) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeInt64)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(p, uint64(v))
+		return nil
+	}
+
 	if err := l.w.Value().Int64(v); err != nil {
 		return err
 	}
@@ -90,6 +184,15 @@ func (l ListWriter) Int64(v int64) error {
 // Uint
 
 func (l ListWriter) Uint16(v uint16) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeUint16)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint16(p, v)
+		return nil
+	}
+
 	if err := l.w.Value().Uint16(v); err != nil {
 		return err
 	}
@@ -97,6 +200,15 @@ func (l ListWriter) Uint16(v uint16) error {
 }
 
 func (l ListWriter) Uint32(v uint32) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeUint32)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint32(p, v)
+		return nil
+	}
+
 	if err := l.w.Value().Uint32(v); err != nil {
 		return err
 	}
@@ -104,6 +216,15 @@ func (l ListWriter) Uint32(v uint32) error {
 }
 
 func (l ListWriter) Uint64(v uint64) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeUint64)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(p, v)
+		return nil
+	}
+
 	if err := l.w.Value().Uint64(v); err != nil {
 		return err
 	}
@@ -113,6 +234,15 @@ func (l ListWriter) Uint64(v uint64) error {
 // Float
 
 func (l ListWriter) Float32(v float32) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeFloat32)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint32(p, math.Float32bits(v))
+		return nil
+	}
+
 	if err := l.w.Value().Float32(v); err != nil {
 		return err
 	}
@@ -120,6 +250,15 @@ func (l ListWriter) Float32(v float32) error {
 }
 
 func (l ListWriter) Float64(v float64) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeFloat64)
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(p, math.Float64bits(v))
+		return nil
+	}
+
 	if err := l.w.Value().Float64(v); err != nil {
 		return err
 	}
@@ -129,6 +268,15 @@ func (l ListWriter) Float64(v float64) error {
 // Bin
 
 func (l ListWriter) Bin64(v bin.Bin64) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeBin64)
+		if err != nil {
+			return err
+		}
+		v.MarshalTo(p)
+		return nil
+	}
+
 	if err := l.w.Value().Bin64(v); err != nil {
 		return err
 	}
@@ -136,6 +284,15 @@ func (l ListWriter) Bin64(v bin.Bin64) error {
 }
 
 func (l ListWriter) Bin128(v bin.Bin128) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeBin128)
+		if err != nil {
+			return err
+		}
+		v.MarshalTo(p)
+		return nil
+	}
+
 	if err := l.w.Value().Bin128(v); err != nil {
 		return err
 	}
@@ -143,6 +300,15 @@ func (l ListWriter) Bin128(v bin.Bin128) error {
 }
 
 func (l ListWriter) Bin256(v bin.Bin256) error {
+	if l.Packed() {
+		p, err := l.w.packedElement(format.TypeBin256)
+		if err != nil {
+			return err
+		}
+		v.MarshalTo(p)
+		return nil
+	}
+
 	if err := l.w.Value().Bin256(v); err != nil {
 		return err
 	}
@@ -10,7 +10,10 @@ import (
 
 	"github.com/basecomplextech/baselibrary/bin"
 	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListWriter__should_write_list(t *testing.T) {
@@ -60,3 +63,152 @@ func TestListWriter__should_write_list(t *testing.T) {
 
 	assert.Equal(t, len(b), n)
 }
+
+func TestListWriter_Len__should_return_number_of_elements_in_nested_list(t *testing.T) {
+	w := testWriter()
+
+	list := w.List()
+	list.String("hello")
+	list.String("world")
+	assert.Equal(t, 2, list.Len())
+
+	list1 := list.List()
+	assert.Equal(t, 0, list1.Len())
+
+	list1.Int64(1)
+	assert.Equal(t, 1, list1.Len())
+
+	if err := list1.End(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, list.Len())
+}
+
+// Packed
+
+func TestListWriter__should_write_packed_list(t *testing.T) {
+	w := testWriter()
+	w.buf.WriteByte(0) // unaligned start
+
+	list := w.List()
+	if err := list.Pack(format.TypeInt64); err != nil {
+		t.Fatal(err)
+	}
+	list.Int64(1)
+	list.Int64(-2)
+	list.Int64(math.MaxInt64)
+	assert.Equal(t, 3, list.Len())
+
+	b, err := list.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, n, err := decode.DecodeListTable(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(b), n)
+	assert.True(t, table.Packed())
+	assert.Equal(t, format.TypeInt64, table.ElemType())
+	assert.Equal(t, 3, table.Len())
+
+	start, end := table.Offset(1)
+	assert.Equal(t, 8, end-start)
+	assert.Equal(t, 0, (w.buf.Len()-len(b)+start)%8)
+}
+
+func TestListWriter__should_keep_packed_counts_per_list(t *testing.T) {
+	w := testWriter()
+	msg := w.Message()
+
+	// Message field with a list, its element message has a packed list
+	list := msg.Field(1).List()
+	elem := list.Message()
+
+	packed := elem.Field(1).List()
+	require.NoError(t, packed.Pack(format.TypeInt64))
+	packed.Int64(1)
+	packed.Int64(2)
+	packed.Int64(3)
+	assert.Equal(t, 3, packed.Len())
+	require.NoError(t, packed.End())
+	require.NoError(t, elem.End())
+
+	// Sibling packed list with another element type and count
+	packed1 := list.List()
+	require.NoError(t, packed1.Pack(format.TypeInt32))
+	packed1.Int32(4)
+	assert.Equal(t, 1, packed1.Len())
+	require.NoError(t, packed1.End())
+
+	assert.Equal(t, 2, list.Len())
+	require.NoError(t, list.End())
+
+	b, err := msg.Build()
+	require.NoError(t, err)
+
+	m, _, err := types.ParseMessage(b)
+	require.NoError(t, err)
+
+	l := m.Field(1).List()
+	require.Equal(t, 2, l.Len())
+
+	l0 := l.Get(0).Message().Field(1).List()
+	assert.Equal(t, 3, l0.Len())
+	v, ok := types.PackedGet[int64](l0, 2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), v)
+
+	l1 := l.Get(1).List()
+	assert.Equal(t, 1, l1.Len())
+	v1, ok := types.PackedGet[int32](l1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int32(4), v1)
+}
+
+func TestListWriter__should_return_error_when_nested_object_in_packed_list(t *testing.T) {
+	w := testWriter()
+
+	list := w.List()
+	require.NoError(t, list.Pack(format.TypeInt64))
+	list.Int64(1)
+
+	msg := list.Message()
+	msg.Field(1).Int64(2)
+	assert.Error(t, msg.End())
+	assert.Error(t, list.Err())
+}
+
+func TestListWriter__should_write_empty_packed_list_as_list(t *testing.T) {
+	w := testWriter()
+
+	list := w.List()
+	if err := list.Pack(format.TypeFloat64); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := list.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, format.TypeList, format.Type(b[len(b)-1]))
+}
+
+func TestListWriter_Pack__should_return_error_when_list_not_empty(t *testing.T) {
+	w := testWriter()
+
+	list := w.List()
+	list.Int64(1)
+
+	err := list.Pack(format.TypeInt64)
+	assert.Error(t, err)
+}
+
+func TestListWriter_Pack__should_return_error_when_type_not_packable(t *testing.T) {
+	w := testWriter()
+
+	list := w.List()
+	err := list.Pack(format.TypeString)
+	assert.Error(t, err)
+}
@@ -4,6 +4,8 @@
 
 package writer
 
+import "github.com/basecomplextech/spec/internal/format"
+
 type entryType byte
 
 const (
@@ -19,6 +21,11 @@ type stackEntry struct {
 	start      int // start offset in data buffer
 	tableStart int // table offset in list/message stack
 	type_      entryType
+
+	// Packed list, small types keep the entry size
+	elemType    format.Type // packed list element type or undefined
+	packedSize  uint16      // packed element size, struct size is set by the first element
+	packedCount uint32      // packed element count
 }
 
 func (e stackEntry) end() int {
@@ -65,6 +72,15 @@ func (s *stack) peekSecondLast() (stackEntry, bool) {
 	return e, true
 }
 
+// last returns a pointer to the last object or nil, the pointer is valid until the next push.
+func (s *stack) last() *stackEntry {
+	ln := len(s.stack)
+	if ln == 0 {
+		return nil
+	}
+	return &s.stack[ln-1]
+}
+
 // pop removes the top object from the stack.
 func (s *stack) pop() (stackEntry, bool) {
 	ln := len(s.stack)
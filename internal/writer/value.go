@@ -72,7 +72,11 @@ func (w ValueWriter) Int16(v int16) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeInt16(w.w.buf, v)
+	if w.w.varint && encode.VarintShorter(int64(v)) {
+		encode.EncodeVarint(w.w.buf, int64(v))
+	} else {
+		encode.EncodeInt16(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
@@ -84,7 +88,11 @@ func (w ValueWriter) Int32(v int32) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeInt32(w.w.buf, v)
+	if w.w.varint && encode.VarintShorter(int64(v)) {
+		encode.EncodeVarint(w.w.buf, int64(v))
+	} else {
+		encode.EncodeInt32(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
@@ -96,7 +104,11 @@ func (w ValueWriter) Int64(v int64) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeInt64(w.w.buf, v)
+	if w.w.varint && encode.VarintShorter(v) {
+		encode.EncodeVarint(w.w.buf, v)
+	} else {
+		encode.EncodeInt64(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
@@ -110,7 +122,11 @@ func (w ValueWriter) Uint16(v uint16) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeUint16(w.w.buf, v)
+	if w.w.varint && encode.UvarintShorter(uint64(v)) {
+		encode.EncodeUvarint(w.w.buf, uint64(v))
+	} else {
+		encode.EncodeUint16(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
@@ -122,7 +138,11 @@ func (w ValueWriter) Uint32(v uint32) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeUint32(w.w.buf, v)
+	if w.w.varint && encode.UvarintShorter(uint64(v)) {
+		encode.EncodeUvarint(w.w.buf, uint64(v))
+	} else {
+		encode.EncodeUint32(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
@@ -134,7 +154,11 @@ func (w ValueWriter) Uint64(v uint64) error {
 	}
 
 	start := w.w.buf.Len()
-	encode.EncodeUint64(w.w.buf, v)
+	if w.w.varint && encode.UvarintShorter(v) {
+		encode.EncodeUvarint(w.w.buf, v)
+	} else {
+		encode.EncodeUint64(w.w.buf, v)
+	}
 	end := w.w.buf.Len()
 
 	return w.w.pushData(start, end)
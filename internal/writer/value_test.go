@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -42,3 +43,51 @@ func TestValueWriter_End__should_return_error_when_not_root_value(t *testing.T)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not root value")
 }
+
+// Varint
+
+func TestValueWriter_Int64__should_write_varint_when_shorter(t *testing.T) {
+	w := testWriter()
+	w.SetVarint(true)
+
+	msg := w.Message()
+	msg.Field(1).Int64(1)
+	msg.Field(2).Int64(1000)
+	msg.Field(3).Uint32(300)
+
+	b, err := msg.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, _, err := decode.DecodeMessageTable(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := func(tag uint16) []byte {
+		return b[:table.Offset(tag)]
+	}
+
+	assert.Equal(t, format.TypeInt64, format.Type(field(1)[len(field(1))-1]))
+	assert.Equal(t, format.TypeVarint, format.Type(field(2)[len(field(2))-1]))
+	assert.Equal(t, format.TypeUvarint, format.Type(field(3)[len(field(3))-1]))
+
+	v, _, err := decode.DecodeInt64(field(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1000), v)
+}
+
+func TestValueWriter_Int64__should_not_write_varint_by_default(t *testing.T) {
+	w := testWriter()
+
+	v := w.Value()
+	v.Int64(1000)
+
+	b, err := v.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, format.TypeInt64, format.Type(b[len(b)-1]))
+}
@@ -7,6 +7,7 @@ package writer
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/pools"
@@ -23,6 +24,10 @@ type Writer interface {
 	// Reset resets the writer and sets its output buffer.
 	Reset(buf buffer.Buffer)
 
+	// SetVarint enables writing integers as varints when they are shorter than compactints,
+	// see [format.TypeVarint]. Readers must support varint types, disabled by default.
+	SetVarint(enabled bool)
+
 	// Objects
 
 	// List begins a new list and returns a list writer.
@@ -68,7 +73,8 @@ var errClosed = errors.New("operation on closed writer")
 type writer struct {
 	*writerState
 
-	err error
+	err    error
+	varint bool
 }
 
 func newWriter(buf buffer.Buffer, release bool) *writer {
@@ -107,6 +113,12 @@ func (w *writer) Reset(buf buffer.Buffer) {
 	w.writerState = s
 }
 
+// SetVarint enables writing integers as varints when they are shorter than compactints,
+// see [format.TypeVarint]. Readers must support varint types, disabled by default.
+func (w *writer) SetVarint(enabled bool) {
+	w.varint = enabled
+}
+
 // Objects
 
 // List begins a new list and returns a list writer.
@@ -222,6 +234,9 @@ func (w *writer) beginList() error {
 	if w.err != nil {
 		return w.err
 	}
+	if err := w.checkNotPacked("begin list"); err != nil {
+		return err
+	}
 
 	// Push list
 	start := w.buf.Len()
@@ -243,6 +258,8 @@ func (w *writer) beginElement() error {
 		return w.failf("begin element: cannot begin element, parent not list")
 	case list.type_ != entryList:
 		return w.failf("begin element: cannot begin element, parent not list")
+	case list.elemType != format.TypeUndefined:
+		return w.failf("begin element: cannot begin element, parent is packed list")
 	}
 
 	// Push list element
@@ -269,6 +286,8 @@ func (w *writer) element() error {
 		return w.failf("element: cannot encode element, parent not list")
 	case list.type_ != entryList:
 		return w.failf("element: cannot encode element, parent not list")
+	case list.elemType != format.TypeUndefined:
+		return w.failf("element: cannot encode element, parent is packed list, type=%v", list.elemType)
 	}
 
 	// Append element relative offset
@@ -290,10 +309,11 @@ func (w *writer) listLen() int {
 		return 0
 	case list.type_ != entryList:
 		return 0
+	case list.elemType != format.TypeUndefined:
+		return int(list.packedCount)
 	}
 
-	start := list.start
-	return w.elements.len(start)
+	return w.elements.len(list.tableStart)
 }
 
 func (w *writer) endElement() ([]byte, error) {
@@ -354,8 +374,16 @@ func (w *writer) endList() ([]byte, error) {
 	table := w.elements.pop(list.tableStart)
 
 	// Encode list
-	if _, err := encode.EncodeListTable(w.buf, bodySize, table); err != nil {
-		return nil, w.fail(err)
+	if list.elemType != format.TypeUndefined && list.packedCount > 0 {
+		_, err := encode.EncodePackedList(w.buf, bodySize,
+			int(list.packedCount), int(list.packedSize), list.elemType)
+		if err != nil {
+			return nil, w.fail(err)
+		}
+	} else {
+		if _, err := encode.EncodeListTable(w.buf, bodySize, table); err != nil {
+			return nil, w.fail(err)
+		}
 	}
 
 	// Push data entry
@@ -371,12 +399,155 @@ func (w *writer) endList() ([]byte, error) {
 	return b, nil
 }
 
+// packed list
+
+// packList switches the top empty list into a packed list.
+func (w *writer) packList(elemType format.Type) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	list := w.stack.last()
+	switch {
+	case list == nil:
+		return w.failf("pack list: not list")
+	case list.type_ != entryList:
+		return w.failf("pack list: not list")
+	case list.elemType == elemType:
+		return nil
+	case list.elemType != format.TypeUndefined:
+		return w.failf("pack list: list already packed, type=%v", list.elemType)
+	case !format.IsPackable(elemType):
+		return w.failf("pack list: type is not packable, type=%v", elemType)
+	case w.buf.Len() != list.start:
+		return w.failf("pack list: list not empty")
+	}
+
+	list.elemType = elemType
+	list.packedSize = uint16(format.PackedSize(elemType))
+	list.packedCount = 0
+	return nil
+}
+
+// checkNotPacked fails when the top object is a packed list, packed lists have no nested objects.
+func (w *writer) checkNotPacked(op string) error {
+	list := w.stack.last()
+	if list == nil || list.type_ != entryList || list.elemType == format.TypeUndefined {
+		return nil
+	}
+	return w.failf("%v: parent is packed list, type=%v", op, list.elemType)
+}
+
+// packedType returns the top list packed element type or undefined.
+func (w *writer) packedType() format.Type {
+	if w.err != nil {
+		return format.TypeUndefined
+	}
+
+	list := w.stack.last()
+	if list == nil || list.type_ != entryList {
+		return format.TypeUndefined
+	}
+	return list.elemType
+}
+
+// packedElement appends a fixed-size packed element and returns its bytes.
+func (w *writer) packedElement(elemType format.Type) ([]byte, error) {
+	if err := w.beginPacked(elemType); err != nil {
+		return nil, err
+	}
+
+	size := format.PackedSize(elemType)
+	p := w.buf.Grow(size)
+
+	if err := w.endPacked(size); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// packedStruct appends an encoded struct to a packed struct list.
+func (w *writer) packedStruct(v []byte) error {
+	typ, _, err := decode.DecodeType(v)
+	if err != nil {
+		return w.fail(err)
+	}
+	if typ != format.TypeStruct {
+		return w.failf("packed element: expected struct, type=%v", typ)
+	}
+
+	if err := w.beginPacked(format.TypeStruct); err != nil {
+		return err
+	}
+	if _, err := w.buf.Write(v); err != nil {
+		return w.fail(err)
+	}
+	return w.endPacked(len(v))
+}
+
+// beginPacked begins a packed element, pads the first element to its alignment.
+func (w *writer) beginPacked(elemType format.Type) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	list := w.stack.last()
+	switch {
+	case list == nil:
+		return w.failf("packed element: parent not list")
+	case list.type_ != entryList:
+		return w.failf("packed element: parent not list")
+	case list.elemType != elemType:
+		return w.failf("packed element: invalid element type, type=%v, list type=%v",
+			elemType, list.elemType)
+	}
+
+	if list.packedCount == 0 {
+		align := format.PackedAlign(elemType)
+		pad := (align - w.buf.Len()%align) % align
+
+		if pad > 0 {
+			p := w.buf.Grow(pad)
+			clear(p)
+		}
+	}
+	return nil
+}
+
+// endPacked ends a packed element of the given size.
+func (w *writer) endPacked(size int) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	list := w.stack.last()
+	switch {
+	case size == 0:
+		return w.failf("packed element: empty element")
+	case size > math.MaxUint16:
+		return w.failf("packed element: element too large, size=%d", size)
+	case list.packedCount == math.MaxUint32:
+		return w.failf("packed element: too many elements")
+	case list.packedSize == 0:
+		list.packedSize = uint16(size)
+	case int(list.packedSize) != size:
+		return w.failf("packed element: invalid element size, size=%d, list size=%d",
+			size, list.packedSize)
+	}
+
+	list.packedCount++
+	return nil
+}
+
 // message
 
 func (w *writer) beginMessage() error {
 	if w.err != nil {
 		return w.err
 	}
+	if err := w.checkNotPacked("begin message"); err != nil {
+		return err
+	}
 
 	// Push message
 	start := w.buf.Len()
@@ -651,6 +822,7 @@ func (w *writer) reset() {
 		w.writerState.reset()
 	}
 	w.err = nil
+	w.varint = false
 }
 
 // pool
@@ -0,0 +1,30 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// ToJSON returns a schema-free json representation of a value using its self-describing types.
+//
+// Messages are objects keyed by tag numbers, lists are arrays, structs are {"$struct": [...]},
+// numbers other than int64 and float64, bins and bytes are tagged strings, i.e. "int32:1",
+// "bin64:0123456789abcdef", "bytes:aGVsbG8=". See [FromJSON] for the reverse conversion.
+func ToJSON(v Value) ([]byte, error) {
+	return types.ToJSON(v)
+}
+
+// ToJSONWithOptions returns a schema-free json representation of a value, see [ToJSON],
+// returns an [ErrParseLimit] error when the value exceeds the limits.
+func ToJSONWithOptions(v Value, opts ParseOptions) ([]byte, error) {
+	return types.ToJSONWithOptions(v, opts)
+}
+
+// FromJSON reconstructs a value from its schema-free json representation,
+// the result bytes are identical to the bytes passed to [ToJSON].
+func FromJSON(data []byte) (Value, error) {
+	return types.FromJSON(data)
+}
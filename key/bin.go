@@ -0,0 +1,126 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package key
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/bin"
+)
+
+// Bin64
+
+// AppendBin64 appends a bin64 key.
+func AppendBin64(b []byte, v bin.Bin64) []byte {
+	return append(b, v[:]...)
+}
+
+// AppendBin64Desc appends a descending bin64 key.
+func AppendBin64Desc(b []byte, v bin.Bin64) []byte {
+	n := len(b)
+	b = AppendBin64(b, v)
+	invert(b[n:])
+	return b
+}
+
+// DecodeBin64 decodes a bin64 key.
+func DecodeBin64(b []byte) (v bin.Bin64, n int, err error) {
+	if len(b) < bin.Len64 {
+		return v, 0, fmt.Errorf("%w: bin64", ErrInvalid)
+	}
+
+	copy(v[:], b)
+	return v, bin.Len64, nil
+}
+
+// DecodeBin64Desc decodes a descending bin64 key.
+func DecodeBin64Desc(b []byte) (v bin.Bin64, n int, err error) {
+	v, n, err = DecodeBin64(b)
+	if err != nil {
+		return v, 0, err
+	}
+	invert(v[:])
+	return v, n, err
+}
+
+// Bin128
+
+// AppendBin128 appends a bin128 key.
+func AppendBin128(b []byte, v bin.Bin128) []byte {
+	b = append(b, v[0][:]...)
+	return append(b, v[1][:]...)
+}
+
+// AppendBin128Desc appends a descending bin128 key.
+func AppendBin128Desc(b []byte, v bin.Bin128) []byte {
+	n := len(b)
+	b = AppendBin128(b, v)
+	invert(b[n:])
+	return b
+}
+
+// DecodeBin128 decodes a bin128 key.
+func DecodeBin128(b []byte) (v bin.Bin128, n int, err error) {
+	if len(b) < bin.Len128 {
+		return v, 0, fmt.Errorf("%w: bin128", ErrInvalid)
+	}
+
+	copy(v[0][:], b[:8])
+	copy(v[1][:], b[8:16])
+	return v, bin.Len128, nil
+}
+
+// DecodeBin128Desc decodes a descending bin128 key.
+func DecodeBin128Desc(b []byte) (v bin.Bin128, n int, err error) {
+	v, n, err = DecodeBin128(b)
+	if err != nil {
+		return v, 0, err
+	}
+	invert(v[0][:])
+	invert(v[1][:])
+	return v, n, err
+}
+
+// Bin256
+
+// AppendBin256 appends a bin256 key.
+func AppendBin256(b []byte, v bin.Bin256) []byte {
+	for i := range v {
+		b = append(b, v[i][:]...)
+	}
+	return b
+}
+
+// AppendBin256Desc appends a descending bin256 key.
+func AppendBin256Desc(b []byte, v bin.Bin256) []byte {
+	n := len(b)
+	b = AppendBin256(b, v)
+	invert(b[n:])
+	return b
+}
+
+// DecodeBin256 decodes a bin256 key.
+func DecodeBin256(b []byte) (v bin.Bin256, n int, err error) {
+	if len(b) < bin.Len256 {
+		return v, 0, fmt.Errorf("%w: bin256", ErrInvalid)
+	}
+
+	for i := range v {
+		copy(v[i][:], b[i*8:])
+	}
+	return v, bin.Len256, nil
+}
+
+// DecodeBin256Desc decodes a descending bin256 key.
+func DecodeBin256Desc(b []byte) (v bin.Bin256, n int, err error) {
+	v, n, err = DecodeBin256(b)
+	if err != nil {
+		return v, 0, err
+	}
+	for i := range v {
+		invert(v[i][:])
+	}
+	return v, n, err
+}
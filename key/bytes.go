@@ -0,0 +1,96 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package key
+
+import "fmt"
+
+// Bytes
+
+// AppendBytes appends a bytes key, escapes zero bytes and appends a terminator.
+func AppendBytes(b []byte, v []byte) []byte {
+	return appendBytes(b, v, 0)
+}
+
+// AppendBytesDesc appends a descending bytes key.
+func AppendBytesDesc(b []byte, v []byte) []byte {
+	return appendBytes(b, v, 0xff)
+}
+
+// DecodeBytes decodes a bytes key into a new slice.
+func DecodeBytes(b []byte) ([]byte, int, error) {
+	return decodeBytes(b, 0, "bytes")
+}
+
+// DecodeBytesDesc decodes a descending bytes key into a new slice.
+func DecodeBytesDesc(b []byte) ([]byte, int, error) {
+	return decodeBytes(b, 0xff, "bytes")
+}
+
+// String
+
+// AppendString appends a string key, escapes zero bytes and appends a terminator.
+func AppendString(b []byte, v string) []byte {
+	return appendBytes(b, v, 0)
+}
+
+// AppendStringDesc appends a descending string key.
+func AppendStringDesc(b []byte, v string) []byte {
+	return appendBytes(b, v, 0xff)
+}
+
+// DecodeString decodes a string key.
+func DecodeString(b []byte) (string, int, error) {
+	v, n, err := decodeBytes(b, 0, "string")
+	return string(v), n, err
+}
+
+// DecodeStringDesc decodes a descending string key.
+func DecodeStringDesc(b []byte) (string, int, error) {
+	v, n, err := decodeBytes(b, 0xff, "string")
+	return string(v), n, err
+}
+
+// private
+
+// appendBytes appends escaped bytes and a terminator, xors all bytes with a mask.
+func appendBytes[S []byte | string](b []byte, v S, mask byte) []byte {
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c == escape {
+			b = append(b, escape^mask, escaped^mask)
+			continue
+		}
+		b = append(b, c^mask)
+	}
+	return append(b, escape^mask, terminator^mask)
+}
+
+// decodeBytes decodes escaped bytes until a terminator, xors all bytes with a mask.
+func decodeBytes(b []byte, mask byte, name string) ([]byte, int, error) {
+	v := make([]byte, 0, len(b))
+
+	for i := 0; i < len(b); i++ {
+		c := b[i] ^ mask
+		if c != escape {
+			v = append(v, c)
+			continue
+		}
+
+		if i+1 >= len(b) {
+			break
+		}
+		i++
+
+		switch b[i] ^ mask {
+		case escaped:
+			v = append(v, escape)
+		case terminator:
+			return v, i + 1, nil
+		default:
+			return nil, 0, fmt.Errorf("%w: %v, invalid escape", ErrInvalid, name)
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: %v, no terminator", ErrInvalid, name)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package key implements an order-preserving encoding of spec scalar values.
+//
+// Unlike the spec format, which is decoded from the tail, keys are encoded from the head,
+// so that the bytewise order of encoded keys matches the logical order of their values.
+// Composite keys are built by appending components one after another:
+//
+//	b := key.AppendBin128(nil, tenant)
+//	b = key.AppendInt64(b, timestamp)
+//	b = key.AppendString(b, name)
+//
+// Encoding:
+//   - bools are 0x00 or 0x01, bytes are single bytes,
+//   - signed integers are big-endian with an inverted sign bit,
+//   - unsigned integers are big-endian,
+//   - floats are big-endian ieee-754 bits, negative floats are inverted, positive floats
+//     have an inverted sign bit, so -0.0 sorts before +0.0 and nans sort at the ends,
+//   - bins are raw bytes,
+//   - bytes and strings escape 0x00 as 0x00 0xff and end with 0x00 0x01,
+//     so that a prefix sorts before a longer value.
+//
+// Descending variants invert all component bytes, decoders of each order must match encoders.
+package key
+
+import "errors"
+
+// ErrInvalid is returned when a key component is invalid or truncated.
+var ErrInvalid = errors.New("key: invalid data")
+
+const (
+	escape     = 0x00
+	escaped    = 0xff
+	terminator = 0x01
+)
+
+// invert inverts bytes in place and returns them.
+func invert(b []byte) []byte {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+	return b
+}
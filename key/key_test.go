@@ -0,0 +1,370 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertOrder asserts that encoded keys are strictly ascending.
+func assertOrder(t *testing.T, keys [][]byte) {
+	t.Helper()
+
+	for i := 1; i < len(keys); i++ {
+		prev, next := keys[i-1], keys[i]
+		if bytes.Compare(prev, next) >= 0 {
+			t.Fatalf("keys out of order at %d: %x >= %x", i, prev, next)
+		}
+	}
+}
+
+// Int
+
+func TestInt64__should_preserve_order(t *testing.T) {
+	values := []int64{math.MinInt64, -1 << 32, -256, -1, 0, 1, 255, 1 << 32, math.MaxInt64}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendInt64(nil, v)
+		keys = append(keys, b)
+
+		v1, n, err := DecodeInt64(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+		assert.Equal(t, 8, n)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestInt64Desc__should_reverse_order(t *testing.T) {
+	values := []int64{math.MaxInt64, 1, 0, -1, math.MinInt64}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendInt64Desc(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeInt64Desc(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestInt16__should_preserve_order(t *testing.T) {
+	values := []int16{math.MinInt16, -1, 0, 1, math.MaxInt16}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendInt16(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeInt16(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestInt32__should_return_error_when_truncated(t *testing.T) {
+	b := AppendInt32(nil, 123)
+
+	_, _, err := DecodeInt32(b[:3])
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+// Uint
+
+func TestUint32__should_preserve_order(t *testing.T) {
+	values := []uint32{0, 1, 255, 256, 1 << 16, math.MaxUint32}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendUint32(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeUint32(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestUint64Desc__should_reverse_order(t *testing.T) {
+	values := []uint64{math.MaxUint64, 1 << 32, 1, 0}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendUint64Desc(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeUint64Desc(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+	}
+
+	assertOrder(t, keys)
+}
+
+// Float
+
+func TestFloat64__should_preserve_order(t *testing.T) {
+	values := []float64{
+		math.Inf(-1),
+		-math.MaxFloat64,
+		-1.5,
+		-math.SmallestNonzeroFloat64,
+		math.Copysign(0, -1),
+		0,
+		math.SmallestNonzeroFloat64,
+		1.5,
+		math.MaxFloat64,
+		math.Inf(1),
+	}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendFloat64(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeFloat64(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, math.Float64bits(v), math.Float64bits(v1))
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestFloat32Desc__should_reverse_order(t *testing.T) {
+	values := []float32{float32(math.Inf(1)), 1.5, 0, -1.5, float32(math.Inf(-1))}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendFloat32Desc(nil, v)
+		keys = append(keys, b)
+
+		v1, _, err := DecodeFloat32Desc(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+	}
+
+	assertOrder(t, keys)
+}
+
+// Bool
+
+func TestBool__should_return_error_when_invalid(t *testing.T) {
+	_, _, err := DecodeBool([]byte{2})
+	assert.True(t, errors.Is(err, ErrInvalid))
+
+	v, _, err := DecodeBoolDesc(AppendBoolDesc(nil, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, v)
+}
+
+// Bin
+
+func TestBin128__should_preserve_order(t *testing.T) {
+	values := []bin.Bin128{
+		bin.Int128(0, 0),
+		bin.Int128(0, 1),
+		bin.Int128(1, 0),
+		bin.Int128(math.MaxInt64, math.MaxInt64),
+	}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendBin128(nil, v)
+		keys = append(keys, b)
+
+		v1, n, err := DecodeBin128(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+		assert.Equal(t, bin.Len128, n)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestBin256Desc__should_decode_value(t *testing.T) {
+	v := bin.Random256()
+	b := AppendBin256Desc(nil, v)
+
+	v1, _, err := DecodeBin256Desc(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, v, v1)
+}
+
+// String
+
+func TestString__should_preserve_order(t *testing.T) {
+	values := []string{"", "\x00", "\x00\x00", "\x00\x01", "\x01", "a", "a\x00", "a\x00b", "ab", "b", "\xff"}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendString(nil, v)
+		keys = append(keys, b)
+
+		v1, n, err := DecodeString(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+		assert.Equal(t, len(b), n)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestStringDesc__should_reverse_order(t *testing.T) {
+	values := []string{"\xff", "b", "ab", "a\x00", "a", "\x00", ""}
+
+	keys := make([][]byte, 0, len(values))
+	for _, v := range values {
+		b := AppendStringDesc(nil, v)
+		keys = append(keys, b)
+
+		v1, n, err := DecodeStringDesc(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v, v1)
+		assert.Equal(t, len(b), n)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestString__should_return_error_when_no_terminator(t *testing.T) {
+	b := AppendString(nil, "hello")
+
+	_, _, err := DecodeString(b[:len(b)-1])
+	assert.True(t, errors.Is(err, ErrInvalid))
+
+	_, _, err = DecodeString([]byte{'a', escape, 0x02})
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+// Bytes
+
+func TestBytes__should_decode_value(t *testing.T) {
+	v := []byte{0, 1, 0xff, 0, 0}
+	b := AppendBytes(nil, v)
+	b = AppendBytesDesc(b, v)
+
+	v1, n, err := DecodeBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, v, v1)
+
+	v2, m, err := DecodeBytesDesc(b[n:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, v, v2)
+	assert.Equal(t, len(b), n+m)
+}
+
+// Composite
+
+func TestComposite__should_preserve_order(t *testing.T) {
+	type record struct {
+		tenant bin.Bin128
+		time   int64
+		name   string
+	}
+
+	records := []record{
+		{bin.Int128(1, 1), -10, "b"},
+		{bin.Int128(1, 1), 0, ""},
+		{bin.Int128(1, 1), 0, "a"},
+		{bin.Int128(1, 1), 0, "a\x00"},
+		{bin.Int128(1, 1), 0, "ab"},
+		{bin.Int128(1, 1), 5, "a"},
+		{bin.Int128(1, 2), math.MinInt64, "a"},
+	}
+
+	keys := make([][]byte, 0, len(records))
+	for _, r := range records {
+		b := AppendBin128(nil, r.tenant)
+		b = AppendInt64(b, r.time)
+		b = AppendString(b, r.name)
+		keys = append(keys, b)
+
+		tenant, n, err := DecodeBin128(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time, n1, err := DecodeInt64(b[n:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, n2, err := DecodeString(b[n+n1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, r, record{tenant, time, name})
+		assert.Equal(t, len(b), n+n1+n2)
+	}
+
+	assertOrder(t, keys)
+}
+
+func TestComposite__should_support_mixed_order(t *testing.T) {
+	// Ascending tenant, descending time.
+	type record struct {
+		tenant uint32
+		time   int64
+	}
+
+	records := []record{
+		{1, 100},
+		{1, 10},
+		{1, -1},
+		{2, 1000},
+		{2, 0},
+	}
+
+	keys := make([][]byte, 0, len(records))
+	for _, r := range records {
+		b := AppendUint32(nil, r.tenant)
+		b = AppendInt64Desc(b, r.time)
+		keys = append(keys, b)
+	}
+
+	assertOrder(t, keys)
+}
@@ -0,0 +1,354 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package key
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Bool
+
+// AppendBool appends a bool key.
+func AppendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// AppendBoolDesc appends a descending bool key.
+func AppendBoolDesc(b []byte, v bool) []byte {
+	n := len(b)
+	b = AppendBool(b, v)
+	invert(b[n:])
+	return b
+}
+
+// DecodeBool decodes a bool key, returns the value and the number of read bytes.
+func DecodeBool(b []byte) (bool, int, error) {
+	if len(b) < 1 {
+		return false, 0, fmt.Errorf("%w: bool", ErrInvalid)
+	}
+
+	switch b[0] {
+	case 0:
+		return false, 1, nil
+	case 1:
+		return true, 1, nil
+	}
+	return false, 0, fmt.Errorf("%w: bool", ErrInvalid)
+}
+
+// DecodeBoolDesc decodes a descending bool key.
+func DecodeBoolDesc(b []byte) (bool, int, error) {
+	if len(b) < 1 {
+		return false, 0, fmt.Errorf("%w: bool", ErrInvalid)
+	}
+	return DecodeBool([]byte{^b[0]})
+}
+
+// Byte
+
+// AppendByte appends a byte key.
+func AppendByte(b []byte, v byte) []byte {
+	return append(b, v)
+}
+
+// AppendByteDesc appends a descending byte key.
+func AppendByteDesc(b []byte, v byte) []byte {
+	return append(b, ^v)
+}
+
+// DecodeByte decodes a byte key.
+func DecodeByte(b []byte) (byte, int, error) {
+	if len(b) < 1 {
+		return 0, 0, fmt.Errorf("%w: byte", ErrInvalid)
+	}
+	return b[0], 1, nil
+}
+
+// DecodeByteDesc decodes a descending byte key.
+func DecodeByteDesc(b []byte) (byte, int, error) {
+	v, n, err := DecodeByte(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ^v, n, err
+}
+
+// Int
+
+// AppendInt16 appends an int16 key.
+func AppendInt16(b []byte, v int16) []byte {
+	return AppendUint16(b, uint16(v)^(1<<15))
+}
+
+// AppendInt16Desc appends a descending int16 key.
+func AppendInt16Desc(b []byte, v int16) []byte {
+	return AppendUint16(b, ^(uint16(v) ^ (1 << 15)))
+}
+
+// DecodeInt16 decodes an int16 key.
+func DecodeInt16(b []byte) (int16, int, error) {
+	v, n, err := decodeUint16(b, "int16")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int16(v ^ (1 << 15)), n, err
+}
+
+// DecodeInt16Desc decodes a descending int16 key.
+func DecodeInt16Desc(b []byte) (int16, int, error) {
+	v, n, err := decodeUint16(b, "int16")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int16(^v ^ (1 << 15)), n, err
+}
+
+// AppendInt32 appends an int32 key.
+func AppendInt32(b []byte, v int32) []byte {
+	return AppendUint32(b, uint32(v)^(1<<31))
+}
+
+// AppendInt32Desc appends a descending int32 key.
+func AppendInt32Desc(b []byte, v int32) []byte {
+	return AppendUint32(b, ^(uint32(v) ^ (1 << 31)))
+}
+
+// DecodeInt32 decodes an int32 key.
+func DecodeInt32(b []byte) (int32, int, error) {
+	v, n, err := decodeUint32(b, "int32")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(v ^ (1 << 31)), n, err
+}
+
+// DecodeInt32Desc decodes a descending int32 key.
+func DecodeInt32Desc(b []byte) (int32, int, error) {
+	v, n, err := decodeUint32(b, "int32")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(^v ^ (1 << 31)), n, err
+}
+
+// AppendInt64 appends an int64 key.
+func AppendInt64(b []byte, v int64) []byte {
+	return AppendUint64(b, uint64(v)^(1<<63))
+}
+
+// AppendInt64Desc appends a descending int64 key.
+func AppendInt64Desc(b []byte, v int64) []byte {
+	return AppendUint64(b, ^(uint64(v) ^ (1 << 63)))
+}
+
+// DecodeInt64 decodes an int64 key.
+func DecodeInt64(b []byte) (int64, int, error) {
+	v, n, err := decodeUint64(b, "int64")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(v ^ (1 << 63)), n, err
+}
+
+// DecodeInt64Desc decodes a descending int64 key.
+func DecodeInt64Desc(b []byte) (int64, int, error) {
+	v, n, err := decodeUint64(b, "int64")
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(^v ^ (1 << 63)), n, err
+}
+
+// Uint
+
+// AppendUint16 appends a uint16 key.
+func AppendUint16(b []byte, v uint16) []byte {
+	return binary.BigEndian.AppendUint16(b, v)
+}
+
+// AppendUint16Desc appends a descending uint16 key.
+func AppendUint16Desc(b []byte, v uint16) []byte {
+	return binary.BigEndian.AppendUint16(b, ^v)
+}
+
+// DecodeUint16 decodes a uint16 key.
+func DecodeUint16(b []byte) (uint16, int, error) {
+	return decodeUint16(b, "uint16")
+}
+
+// DecodeUint16Desc decodes a descending uint16 key.
+func DecodeUint16Desc(b []byte) (uint16, int, error) {
+	v, n, err := decodeUint16(b, "uint16")
+	if err != nil {
+		return 0, 0, err
+	}
+	return ^v, n, err
+}
+
+// AppendUint32 appends a uint32 key.
+func AppendUint32(b []byte, v uint32) []byte {
+	return binary.BigEndian.AppendUint32(b, v)
+}
+
+// AppendUint32Desc appends a descending uint32 key.
+func AppendUint32Desc(b []byte, v uint32) []byte {
+	return binary.BigEndian.AppendUint32(b, ^v)
+}
+
+// DecodeUint32 decodes a uint32 key.
+func DecodeUint32(b []byte) (uint32, int, error) {
+	return decodeUint32(b, "uint32")
+}
+
+// DecodeUint32Desc decodes a descending uint32 key.
+func DecodeUint32Desc(b []byte) (uint32, int, error) {
+	v, n, err := decodeUint32(b, "uint32")
+	if err != nil {
+		return 0, 0, err
+	}
+	return ^v, n, err
+}
+
+// AppendUint64 appends a uint64 key.
+func AppendUint64(b []byte, v uint64) []byte {
+	return binary.BigEndian.AppendUint64(b, v)
+}
+
+// AppendUint64Desc appends a descending uint64 key.
+func AppendUint64Desc(b []byte, v uint64) []byte {
+	return binary.BigEndian.AppendUint64(b, ^v)
+}
+
+// DecodeUint64 decodes a uint64 key.
+func DecodeUint64(b []byte) (uint64, int, error) {
+	return decodeUint64(b, "uint64")
+}
+
+// DecodeUint64Desc decodes a descending uint64 key.
+func DecodeUint64Desc(b []byte) (uint64, int, error) {
+	v, n, err := decodeUint64(b, "uint64")
+	if err != nil {
+		return 0, 0, err
+	}
+	return ^v, n, err
+}
+
+// Float
+
+// AppendFloat32 appends a float32 key.
+func AppendFloat32(b []byte, v float32) []byte {
+	return AppendUint32(b, float32Bits(v))
+}
+
+// AppendFloat32Desc appends a descending float32 key.
+func AppendFloat32Desc(b []byte, v float32) []byte {
+	return AppendUint32(b, ^float32Bits(v))
+}
+
+// DecodeFloat32 decodes a float32 key.
+func DecodeFloat32(b []byte) (float32, int, error) {
+	v, n, err := decodeUint32(b, "float32")
+	if err != nil {
+		return 0, 0, err
+	}
+	return float32FromBits(v), n, err
+}
+
+// DecodeFloat32Desc decodes a descending float32 key.
+func DecodeFloat32Desc(b []byte) (float32, int, error) {
+	v, n, err := decodeUint32(b, "float32")
+	if err != nil {
+		return 0, 0, err
+	}
+	return float32FromBits(^v), n, err
+}
+
+// AppendFloat64 appends a float64 key.
+func AppendFloat64(b []byte, v float64) []byte {
+	return AppendUint64(b, float64Bits(v))
+}
+
+// AppendFloat64Desc appends a descending float64 key.
+func AppendFloat64Desc(b []byte, v float64) []byte {
+	return AppendUint64(b, ^float64Bits(v))
+}
+
+// DecodeFloat64 decodes a float64 key.
+func DecodeFloat64(b []byte) (float64, int, error) {
+	v, n, err := decodeUint64(b, "float64")
+	if err != nil {
+		return 0, 0, err
+	}
+	return float64FromBits(v), n, err
+}
+
+// DecodeFloat64Desc decodes a descending float64 key.
+func DecodeFloat64Desc(b []byte) (float64, int, error) {
+	v, n, err := decodeUint64(b, "float64")
+	if err != nil {
+		return 0, 0, err
+	}
+	return float64FromBits(^v), n, err
+}
+
+// private
+
+func decodeUint16(b []byte, name string) (uint16, int, error) {
+	if len(b) < 2 {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalid, name)
+	}
+	return binary.BigEndian.Uint16(b), 2, nil
+}
+
+func decodeUint32(b []byte, name string) (uint32, int, error) {
+	if len(b) < 4 {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalid, name)
+	}
+	return binary.BigEndian.Uint32(b), 4, nil
+}
+
+func decodeUint64(b []byte, name string) (uint64, int, error) {
+	if len(b) < 8 {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalid, name)
+	}
+	return binary.BigEndian.Uint64(b), 8, nil
+}
+
+// float32Bits returns ordered float bits, inverts negative floats, flips the sign of positive.
+func float32Bits(v float32) uint32 {
+	u := math.Float32bits(v)
+	if u&(1<<31) != 0 {
+		return ^u
+	}
+	return u | 1<<31
+}
+
+func float32FromBits(u uint32) float32 {
+	if u&(1<<31) != 0 {
+		return math.Float32frombits(u &^ (1 << 31))
+	}
+	return math.Float32frombits(^u)
+}
+
+// float64Bits returns ordered float bits, inverts negative floats, flips the sign of positive.
+func float64Bits(v float64) uint64 {
+	u := math.Float64bits(v)
+	if u&(1<<63) != 0 {
+		return ^u
+	}
+	return u | 1<<63
+}
+
+func float64FromBits(u uint64) float64 {
+	if u&(1<<63) != 0 {
+		return math.Float64frombits(u &^ (1 << 63))
+	}
+	return math.Float64frombits(^u)
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/breaking"
+
+// BreakingChange is a backward incompatible change between package versions.
+type BreakingChange = breaking.Change
+
+// Breaking returns backward incompatible changes from an old to a new package version,
+// i.e. removed or retyped fields, reused tags, renumbered enum values, changed struct layouts
+// and changed methods.
+func Breaking(old *Package, new *Package) []BreakingChange {
+	return breaking.Compare(old.pkg, new.pkg)
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/dynamic"
+
+// Call is an RPC call which is built at runtime from a service definition.
+type Call = dynamic.Call
+
+// ParseCall parses a call as "Service.method" or "Service.subservice(input).method"
+// with json inputs, see [Call].
+func (p *Package) ParseCall(path string, inputs [][]byte) (*Call, error) {
+	return dynamic.ParseCall(p.pkg, path, inputs)
+}
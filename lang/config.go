@@ -0,0 +1,262 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/basecomplextech/spec/internal/lang/compiler"
+	"github.com/basecomplextech/spec/internal/lang/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilename is a generation config file name.
+const ConfigFilename = "spec.yaml"
+
+// Config is a generation config, usually loaded from a spec.yaml file:
+//
+//	import_paths:
+//	  - schemas
+//
+//	packages:
+//	  - src: schemas/users
+//	    out: pkg/users
+//	    mocks: true
+//	  - src: schemas/events
+//	    skip_rpc: true
+//	    plugins:
+//	      - name: ts
+//	        out: web/src/events
+//
+// Relative paths are resolved against the config file directory.
+type Config struct {
+	ImportPaths []string        `yaml:"import_paths"`
+	Packages    []PackageConfig `yaml:"packages"`
+}
+
+// PackageConfig specifies a package source, output directory and generator options.
+type PackageConfig struct {
+	Src     string   `yaml:"src"`      // Source directory
+	Out     string   `yaml:"out"`      // Output directory, defaults to the source directory
+	SkipRPC bool     `yaml:"skip_rpc"` // Skip generating RPC code
+	Mocks   bool     `yaml:"mocks"`    // Generate mock clients and fake services
+	SkipGo  bool     `yaml:"skip_go"`  // Skip generating Go code, i.e. when only running plugins
+	Plugins []Plugin `yaml:"plugins"`  // Plugins, their outputs default to the package output
+}
+
+// LoadConfig loads a yaml generation config from a file,
+// and resolves relative paths against the file directory.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	config.resolve(filepath.Dir(path))
+	return config, nil
+}
+
+// ParseConfig parses a yaml generation config.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate returns an error when packages or plugins are missing required fields or duplicated.
+func (c *Config) Validate() error {
+	srcs := make(map[string]struct{})
+
+	for i, p := range c.Packages {
+		if p.Src == "" {
+			return fmt.Errorf("package %d: missing src", i+1)
+		}
+
+		src := filepath.Clean(p.Src)
+		if _, ok := srcs[src]; ok {
+			return fmt.Errorf("duplicate package %v", p.Src)
+		}
+		srcs[src] = struct{}{}
+
+		names := make(map[string]struct{})
+		for _, plugin := range p.Plugins {
+			if plugin.Name == "" {
+				return fmt.Errorf("package %v: missing plugin name", p.Src)
+			}
+			if _, ok := names[plugin.Name]; ok {
+				return fmt.Errorf("package %v: duplicate plugin %q", p.Src, plugin.Name)
+			}
+			names[plugin.Name] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// GenerateAll compiles and generates all packages in a config.
+//
+// Packages are generated in parallel. Packages in different modules are also compiled
+// in parallel, packages in one module share compiled imports and are compiled one at a time.
+// Only files with changed content are rewritten. Returns the compiled packages, including
+// when some packages fail, and the joined errors of the failed packages.
+func GenerateAll(config *Config) ([]*Package, error) {
+	return renderAll(config, func(_ int, files []GeneratedFile) error {
+		for _, file := range files {
+			if err := generator.WriteFile(file); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CheckAll compiles and generates all packages in a config in memory, and returns
+// unified diffs between files on disk and generated files, or nil when all files are up to date.
+func CheckAll(config *Config) ([]byte, error) {
+	diffs := make([][]byte, len(config.Packages))
+
+	_, err := renderAll(config, func(i int, files []GeneratedFile) error {
+		d, err := DiffFiles(files)
+		if err != nil {
+			return err
+		}
+		diffs[i] = d
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(diffs, nil), nil
+}
+
+// private
+
+func (c *Config) resolve(dir string) {
+	abs := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	for i, path := range c.ImportPaths {
+		c.ImportPaths[i] = abs(path)
+	}
+
+	for i := range c.Packages {
+		p := &c.Packages[i]
+		p.Src = abs(p.Src)
+		p.Out = abs(p.Out)
+
+		for j := range p.Plugins {
+			plugin := &p.Plugins[j]
+			plugin.Out = abs(plugin.Out)
+
+			// Resolve relative executable paths, keep names looked up in PATH
+			if filepath.Base(plugin.Path) != plugin.Path {
+				plugin.Path = abs(plugin.Path)
+			}
+		}
+	}
+}
+
+// renderAll compiles and generates packages in parallel, passes generated files to a function.
+// The compiler serializes compilation of packages in one module.
+func renderAll(config *Config, fn func(i int, files []GeneratedFile) error) ([]*Package, error) {
+	c, err := compiler.New(compiler.Options{
+		ImportPath: config.ImportPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs := make([]*Package, len(config.Packages))
+	errs := make([]error, len(config.Packages))
+	wg := sync.WaitGroup{}
+
+	for i, p := range config.Packages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			pkg, files, err := renderPackage(c, p)
+			if err == nil {
+				err = fn(i, files)
+			}
+			pkgs[i], errs[i] = pkg, err
+		}()
+	}
+	wg.Wait()
+
+	result := make([]*Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg != nil {
+			result = append(result, pkg)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// renderPackage compiles a package, runs the Go generator and plugins, and returns the files.
+func renderPackage(c compiler.Compiler, p PackageConfig) (*Package, []GeneratedFile, error) {
+	// Compile
+	mpkg, err := c.Compile(p.Src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x := newIndex()
+	pkg := x.pkg(mpkg)
+
+	// Generate
+	var files []GeneratedFile
+	if !p.SkipGo {
+		opts := GenerateOptions{
+			SkipRPC: p.SkipRPC,
+			Mocks:   p.Mocks,
+		}
+
+		files, err = GenerateFiles(pkg, p.Out, opts)
+		if err != nil {
+			return pkg, nil, err
+		}
+	}
+
+	// Run plugins
+	for _, plugin := range p.Plugins {
+		if plugin.Out == "" {
+			plugin.Out = p.Out
+		}
+
+		pfiles, err := PluginFiles(pkg, plugin)
+		if err != nil {
+			return pkg, nil, err
+		}
+		files = append(files, pfiles...)
+	}
+	return pkg, files, nil
+}
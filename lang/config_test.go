@@ -0,0 +1,181 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T, names ...string) *Config {
+	tests, err := filepath.Abs("../internal/tests")
+	require.NoError(t, err)
+
+	config := &Config{ImportPaths: []string{tests}}
+	out := t.TempDir()
+
+	for _, name := range names {
+		config.Packages = append(config.Packages, PackageConfig{
+			Src: filepath.Join(tests, name),
+			Out: filepath.Join(out, name),
+		})
+	}
+	return config
+}
+
+// ParseConfig
+
+func TestParseConfig__should_parse_config(t *testing.T) {
+	src := `
+import_paths:
+  - schemas
+packages:
+  - src: schemas/users
+    out: pkg/users
+    mocks: true
+  - src: schemas/events
+    skip_rpc: true
+    plugins:
+      - name: ts
+        out: web/events
+        parameter: esm
+`
+	config, err := ParseConfig([]byte(src))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"schemas"}, config.ImportPaths)
+	assert.Equal(t, []PackageConfig{
+		{Src: "schemas/users", Out: "pkg/users", Mocks: true},
+		{Src: "schemas/events", SkipRPC: true, Plugins: []Plugin{
+			{Name: "ts", Out: "web/events", Parameter: "esm"},
+		}},
+	}, config.Packages)
+}
+
+func TestParseConfig__should_return_error_on_invalid_config(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{"packages:\n  - out: a\n", "package 1: missing src"},
+		{"packages:\n  - src: a\n  - src: ./a\n", "duplicate package ./a"},
+		{"packages:\n  - src: a\n    plugins:\n      - path: b\n", "package a: missing plugin name"},
+		{"packages:\n  - src: a\n    skip: true\n", "field skip not found"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseConfig([]byte(tt.src))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.err)
+	}
+}
+
+// LoadConfig
+
+func TestLoadConfig__should_resolve_relative_paths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ConfigFilename)
+
+	src := `
+import_paths: [schemas, /abs]
+packages:
+  - src: schemas/users
+    plugins:
+      - name: ts
+        path: bin/spec-gen-ts
+      - name: docs
+        path: spec-gen-docs
+        out: docs
+`
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "schemas"), "/abs"}, config.ImportPaths)
+
+	p := config.Packages[0]
+	assert.Equal(t, filepath.Join(dir, "schemas", "users"), p.Src)
+	assert.Equal(t, "", p.Out)
+	assert.Equal(t, filepath.Join(dir, "bin", "spec-gen-ts"), p.Plugins[0].Path)
+	assert.Equal(t, "spec-gen-docs", p.Plugins[1].Path)
+	assert.Equal(t, filepath.Join(dir, "docs"), p.Plugins[1].Out)
+}
+
+// GenerateAll
+
+func TestGenerateAll__should_generate_all_packages(t *testing.T) {
+	config := testConfig(t, "pkg1", "pkg2", "pkg4")
+	config.Packages[1].SkipGo = true
+	config.Packages[1].Plugins = []Plugin{testPlugin(t)}
+	config.Packages[1].Plugins[0].Out = ""
+
+	_, err := GenerateAll(config)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(config.Packages[0].Out, "pkg1_generated.go"))
+	assert.NoFileExists(t, filepath.Join(config.Packages[1].Out, "submessage_generated.go"))
+	assert.FileExists(t, filepath.Join(config.Packages[1].Out, "out", "pkg2.txt"))
+	assert.FileExists(t, filepath.Join(config.Packages[2].Out, "service_generated.go"))
+}
+
+func TestGenerateAll__should_not_rewrite_unchanged_files(t *testing.T) {
+	config := testConfig(t, "pkg2")
+	_, err := GenerateAll(config)
+	require.NoError(t, err)
+
+	path := filepath.Join(config.Packages[0].Out, "submessage_generated.go")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	_, err = GenerateAll(config)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old))
+}
+
+func TestGenerateAll__should_return_errors_of_failed_packages(t *testing.T) {
+	config := testConfig(t, "pkg1", "unknown1", "unknown2")
+
+	pkgs, err := GenerateAll(config)
+	require.Error(t, err)
+	require.Len(t, pkgs, 1)
+	assert.Equal(t, "pkg1", pkgs[0].Name())
+	assert.Contains(t, err.Error(), "unknown1")
+	assert.Contains(t, err.Error(), "unknown2")
+	assert.FileExists(t, filepath.Join(config.Packages[0].Out, "pkg1_generated.go"))
+}
+
+// CheckAll
+
+func TestCheckAll__should_return_diffs_without_writing_files(t *testing.T) {
+	config := testConfig(t, "pkg2")
+	path := filepath.Join(config.Packages[0].Out, "submessage_generated.go")
+
+	diff, err := CheckAll(config)
+	require.NoError(t, err)
+	assert.Contains(t, string(diff), "+++ "+path+"\n")
+	assert.NoFileExists(t, path)
+
+	_, err = GenerateAll(config)
+	require.NoError(t, err)
+
+	diff, err = CheckAll(config)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	require.NoError(t, os.WriteFile(path, []byte("package old\n"), 0644))
+
+	diff, err = CheckAll(config)
+	require.NoError(t, err)
+	assert.Contains(t, string(diff), "-package old\n")
+}
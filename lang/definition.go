@@ -0,0 +1,74 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// DefinitionType is a definition type, i.e. enum, message, struct or service.
+type DefinitionType = model.DefinitionType
+
+const (
+	DefinitionUndefined = model.DefinitionUndefined
+	DefinitionEnum      = model.DefinitionEnum
+	DefinitionMessage   = model.DefinitionMessage
+	DefinitionStruct    = model.DefinitionStruct
+	DefinitionService   = model.DefinitionService
+)
+
+// Definition is a top-level package definition.
+type Definition struct {
+	x   *index
+	def *model.Definition
+}
+
+// Package returns the definition package.
+func (d *Definition) Package() *Package {
+	return d.x.pkg(d.def.Package)
+}
+
+// File returns the definition file.
+func (d *Definition) File() *File {
+	return d.x.file(d.def.File)
+}
+
+// Name returns the definition name.
+func (d *Definition) Name() string {
+	return d.def.Name
+}
+
+// Type returns the definition type.
+func (d *Definition) Type() DefinitionType {
+	return d.def.Type
+}
+
+// Line returns the definition source line.
+func (d *Definition) Line() int {
+	return d.def.Line
+}
+
+// Doc returns the definition doc comment without comment markers.
+func (d *Definition) Doc() string {
+	return d.def.Doc
+}
+
+// Enum returns an enum, or nil if the definition is not an enum.
+func (d *Definition) Enum() *Enum {
+	return d.x.enum(d.def.Enum)
+}
+
+// Message returns a message, or nil if the definition is not a message.
+func (d *Definition) Message() *Message {
+	return d.x.message(d.def.Message)
+}
+
+// Struct returns a struct, or nil if the definition is not a struct.
+func (d *Definition) Struct() *Struct {
+	return d.x.struct_(d.def.Struct)
+}
+
+// Service returns a service, or nil if the definition is not a service.
+func (d *Definition) Service() *Service {
+	return d.x.service(d.def.Service)
+}
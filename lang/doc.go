@@ -0,0 +1,28 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"github.com/basecomplextech/spec/internal/lang/doc"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// DocFormat is a documentation output format.
+type DocFormat = doc.Format
+
+const (
+	DocMarkdown DocFormat = doc.FormatMarkdown
+	DocHTML     DocFormat = doc.FormatHTML
+)
+
+// WriteDocs renders packages and their imports into static documentation pages
+// with an index page, and writes them into an output directory.
+func WriteDocs(pkgs []*Package, out string, format DocFormat) error {
+	mpkgs := make([]*model.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		mpkgs = append(mpkgs, pkg.pkg)
+	}
+	return doc.Write(mpkgs, out, doc.Options{Format: format})
+}
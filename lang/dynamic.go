@@ -0,0 +1,90 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/lang/dynamic"
+)
+
+type (
+	// DynamicMessage is a message which type is known only at runtime.
+	DynamicMessage = dynamic.DynamicMessage
+
+	// DynamicList is a list which element type is known only at runtime.
+	DynamicList = dynamic.DynamicList
+
+	// DynamicEnum is an enum value which type is known only at runtime.
+	DynamicEnum = dynamic.DynamicEnum
+
+	// DynamicStruct is a struct value which type is known only at runtime.
+	DynamicStruct = dynamic.DynamicStruct
+
+	// DynamicWriter writes a message which type is known only at runtime.
+	DynamicWriter = dynamic.DynamicWriter
+
+	// DynamicListWriter writes a list which element type is known only at runtime.
+	DynamicListWriter = dynamic.DynamicListWriter
+)
+
+// Open opens and returns a dynamic message from bytes, or an error.
+// The method decodes the message table, but not the fields.
+func (m *Message) Open(b []byte) (DynamicMessage, error) {
+	return dynamic.OpenDynamicMessageErr(m.msg, b)
+}
+
+// Parse recursively parses and returns a dynamic message.
+func (m *Message) Parse(b []byte) (_ DynamicMessage, size int, err error) {
+	return dynamic.ParseDynamicMessage(m.msg, b)
+}
+
+// NewWriter returns a new dynamic message writer with a new empty buffer.
+//
+// The writer is released on end.
+func (m *Message) NewWriter() DynamicWriter {
+	return dynamic.NewDynamicWriter(m.msg)
+}
+
+// NewWriterBuffer returns a new dynamic message writer with the given buffer.
+func (m *Message) NewWriterBuffer(buf buffer.Buffer) DynamicWriter {
+	return dynamic.NewDynamicWriterBuffer(m.msg, buf)
+}
+
+// NewStruct returns a new dynamic struct with zero field values.
+func (s *Struct) NewStruct() DynamicStruct {
+	return dynamic.NewDynamicStruct(s.str)
+}
+
+// Parse returns a dynamic enum value by its name, or an error.
+func (e *Enum) Parse(name string) (DynamicEnum, error) {
+	return dynamic.ParseDynamicEnum(e.enum, name)
+}
+
+// ParseJSON writes and returns a dynamic message from a json object.
+// See [DynamicMessage.MarshalJSON] for the json mapping.
+func (m *Message) ParseJSON(data []byte) (DynamicMessage, error) {
+	return dynamic.ParseJSON(m.msg, data)
+}
+
+// ParseText writes and returns a dynamic message from a text object.
+// See [DynamicMessage.FormatText] for the text mapping.
+func (m *Message) ParseText(src []byte) (DynamicMessage, error) {
+	return dynamic.ParseText(m.msg, src)
+}
+
+// Value tree
+
+type (
+	// ValueNode is a schema-less value tree node.
+	ValueNode = dynamic.ValueNode
+
+	// FieldNode is a message field in a schema-less value tree.
+	FieldNode = dynamic.FieldNode
+)
+
+// ParseValueTree recursively parses a value without a schema using its self-describing types.
+func ParseValueTree(b []byte) (*ValueNode, error) {
+	return dynamic.ParseValueTree(b)
+}
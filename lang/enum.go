@@ -0,0 +1,66 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// Enum is an enum definition.
+type Enum struct {
+	x    *index
+	enum *model.Enum
+}
+
+// Definition returns the enum definition.
+func (e *Enum) Definition() *Definition {
+	return e.x.def(e.enum.Def)
+}
+
+// Values returns the enum values in the declaration order.
+func (e *Enum) Values() []*EnumValue {
+	return wrapList(e.enum.Values, e.x.enumValue)
+}
+
+// Value returns a value by a name, or nil.
+func (e *Enum) Value(name string) *EnumValue {
+	return e.x.enumValue(e.enum.ValueNames[name])
+}
+
+// ValueByNumber returns a value by a number, or nil.
+func (e *Enum) ValueByNumber(number int) *EnumValue {
+	return e.x.enumValue(e.enum.ValueNumbers[number])
+}
+
+// EnumValue
+
+// EnumValue is an enum value.
+type EnumValue struct {
+	x   *index
+	val *model.EnumValue
+}
+
+// Enum returns the value enum.
+func (v *EnumValue) Enum() *Enum {
+	return v.x.enum(v.val.Enum)
+}
+
+// Name returns the value name.
+func (v *EnumValue) Name() string {
+	return v.val.Name
+}
+
+// Number returns the value number.
+func (v *EnumValue) Number() int {
+	return v.val.Number
+}
+
+// Line returns the enum value source line.
+func (v *EnumValue) Line() int {
+	return v.val.Line
+}
+
+// Doc returns the enum value doc comment without comment markers.
+func (v *EnumValue) Doc() string {
+	return v.val.Doc
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"github.com/basecomplextech/spec/internal/lang/export"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// ExportFormat is a schema export format.
+type ExportFormat = export.Format
+
+const (
+	ExportProto      ExportFormat = export.FormatProto
+	ExportJSONSchema ExportFormat = export.FormatJSONSchema
+)
+
+// ExportWarning is a lossy mapping of a spec construct, i.e. bin256 or any.
+type ExportWarning = export.Warning
+
+// Export exports packages and their imports as proto3 files or JSON Schema documents,
+// and writes them into an output directory.
+func Export(pkgs []*Package, out string, format ExportFormat) ([]ExportWarning, error) {
+	mpkgs := make([]*model.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		mpkgs = append(mpkgs, pkg.pkg)
+	}
+	return export.Write(mpkgs, out, export.Options{Format: format})
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// File is a compiled spec file.
+type File struct {
+	x    *index
+	file *model.File
+}
+
+// Package returns the file package.
+func (f *File) Package() *Package {
+	return f.x.pkg(f.file.Package)
+}
+
+// Name returns the file name.
+func (f *File) Name() string {
+	return f.file.Name
+}
+
+// Path returns the file path.
+func (f *File) Path() string {
+	return f.file.Path
+}
+
+// Imports returns the file imports.
+func (f *File) Imports() []*Import {
+	return wrapList(f.file.Imports, f.x.import_)
+}
+
+// Options returns the file options.
+func (f *File) Options() []*Option {
+	return wrapList(f.file.Options, f.x.option)
+}
+
+// Option returns an option by a name, or nil.
+func (f *File) Option(name string) *Option {
+	return f.x.option(f.file.OptionMap[name])
+}
+
+// Definitions returns the file definitions including generated method requests/responses.
+func (f *File) Definitions() []*Definition {
+	return wrapList(f.file.Definitions, f.x.def)
+}
+
+// Import
+
+// Import is a file import.
+type Import struct {
+	x   *index
+	imp *model.Import
+}
+
+// File returns the importing file.
+func (i *Import) File() *File {
+	return i.x.file(i.imp.File)
+}
+
+// ID returns the imported package id.
+func (i *Import) ID() string {
+	return i.imp.ID
+}
+
+// Name returns the import name or alias.
+func (i *Import) Name() string {
+	return i.imp.Name
+}
+
+// Package returns the imported package.
+func (i *Import) Package() *Package {
+	return i.x.pkg(i.imp.Package)
+}
+
+// Option
+
+// Option is a package option, i.e. go_package.
+type Option struct {
+	opt *model.Option
+}
+
+// Name returns the option name.
+func (o *Option) Name() string {
+	return o.opt.Name
+}
+
+// Value returns the option value.
+func (o *Option) Value() string {
+	return o.opt.Value
+}
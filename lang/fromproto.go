@@ -0,0 +1,33 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"path/filepath"
+
+	"github.com/basecomplextech/spec/internal/lang/fromproto"
+	"github.com/basecomplextech/spec/internal/lang/generator"
+)
+
+// ProtoIssue is a proto construct which has no spec equivalent, or is converted with a loss.
+type ProtoIssue = fromproto.Issue
+
+// FromProto converts proto3 files into spec files and writes them into an output directory,
+// proto packages are written into subdirectories, i.e. "acme.users.v1" into "acme/users/v1".
+// Imported proto files are searched in import paths and only used to resolve types.
+func FromProto(paths []string, importPaths []string, out string) ([]ProtoIssue, error) {
+	files, issues, err := fromproto.Convert(paths, fromproto.Options{ImportPaths: importPaths})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(out, filepath.FromSlash(file.Path))
+		if err := generator.WriteFile(generator.File{Path: path, Content: file.Content}); err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"io"
+
+	"github.com/basecomplextech/spec/internal/lang/graph"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Graph is an import graph between packages and a reference graph between definitions.
+type Graph = graph.Graph
+
+// UnusedDefinition is a definition which is not reachable from roots.
+type UnusedDefinition = graph.Unused
+
+// BuildGraph returns a graph of packages and their transitive imports.
+func BuildGraph(pkgs []*Package) *Graph {
+	mpkgs := make([]*model.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		mpkgs = append(mpkgs, pkg.pkg)
+	}
+	return graph.Build(mpkgs)
+}
+
+// FindUnused returns package definitions which are not reachable from roots,
+// or from services when no roots are given. Roots are type names as "Name" or "pkg.Name".
+func FindUnused(pkgs []*Package, roots []string) ([]UnusedDefinition, error) {
+	mpkgs := make([]*model.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		mpkgs = append(mpkgs, pkg.pkg)
+	}
+	return graph.FindUnused(mpkgs, roots)
+}
+
+// WriteGraphJSON writes a graph as a json object.
+func WriteGraphJSON(w io.Writer, g *Graph) error {
+	return graph.WriteJSON(w, g)
+}
+
+// WriteImportsDOT writes a package import graph in the Graphviz DOT format.
+func WriteImportsDOT(w io.Writer, g *Graph) error {
+	return graph.WriteImportsDOT(w, g)
+}
+
+// WriteDefinitionsDOT writes a definition reference graph in the Graphviz DOT format.
+func WriteDefinitionsDOT(w io.Writer, g *Graph) error {
+	return graph.WriteDefinitionsDOT(w, g)
+}
+
+// WriteUnusedText writes unused definitions as "file:line: type pkg.Name is unused" lines.
+func WriteUnusedText(w io.Writer, unused []UnusedDefinition) error {
+	return graph.WriteUnusedText(w, unused)
+}
+
+// WriteUnusedJSON writes unused definitions as a json array.
+func WriteUnusedJSON(w io.Writer, unused []UnusedDefinition) error {
+	return graph.WriteUnusedJSON(w, unused)
+}
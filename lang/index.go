@@ -0,0 +1,126 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// index maps internal model objects to their public wrappers,
+// so that each object is wrapped at most once and wrappers can be compared by pointers.
+type index struct {
+	m map[any]any
+}
+
+func newIndex() *index {
+	return &index{m: make(map[any]any)}
+}
+
+func wrap[T any, W any](x *index, v *T, fn func(*T) *W) *W {
+	if v == nil {
+		return nil
+	}
+
+	w, ok := x.m[v]
+	if ok {
+		return w.(*W)
+	}
+
+	w1 := fn(v)
+	x.m[v] = w1
+	return w1
+}
+
+func wrapList[T any, W any](list []*T, fn func(*T) *W) []*W {
+	result := make([]*W, 0, len(list))
+	for _, v := range list {
+		result = append(result, fn(v))
+	}
+	return result
+}
+
+// wrappers
+
+func (x *index) pkg(p *model.Package) *Package {
+	return wrap(x, p, func(p *model.Package) *Package {
+		return &Package{x: x, pkg: p}
+	})
+}
+
+func (x *index) file(f *model.File) *File {
+	return wrap(x, f, func(f *model.File) *File {
+		return &File{x: x, file: f}
+	})
+}
+
+func (x *index) import_(imp *model.Import) *Import {
+	return wrap(x, imp, func(imp *model.Import) *Import {
+		return &Import{x: x, imp: imp}
+	})
+}
+
+func (x *index) option(opt *model.Option) *Option {
+	return wrap(x, opt, func(opt *model.Option) *Option {
+		return &Option{opt: opt}
+	})
+}
+
+func (x *index) def(d *model.Definition) *Definition {
+	return wrap(x, d, func(d *model.Definition) *Definition {
+		return &Definition{x: x, def: d}
+	})
+}
+
+func (x *index) enum(e *model.Enum) *Enum {
+	return wrap(x, e, func(e *model.Enum) *Enum {
+		return &Enum{x: x, enum: e}
+	})
+}
+
+func (x *index) enumValue(v *model.EnumValue) *EnumValue {
+	return wrap(x, v, func(v *model.EnumValue) *EnumValue {
+		return &EnumValue{x: x, val: v}
+	})
+}
+
+func (x *index) message(m *model.Message) *Message {
+	return wrap(x, m, func(m *model.Message) *Message {
+		return &Message{x: x, msg: m}
+	})
+}
+
+func (x *index) field(f *model.Field) *Field {
+	return wrap(x, f, func(f *model.Field) *Field {
+		return &Field{x: x, field: f}
+	})
+}
+
+func (x *index) struct_(s *model.Struct) *Struct {
+	return wrap(x, s, func(s *model.Struct) *Struct {
+		return &Struct{x: x, str: s}
+	})
+}
+
+func (x *index) structField(f *model.StructField) *StructField {
+	return wrap(x, f, func(f *model.StructField) *StructField {
+		return &StructField{x: x, field: f}
+	})
+}
+
+func (x *index) service(s *model.Service) *Service {
+	return wrap(x, s, func(s *model.Service) *Service {
+		return &Service{x: x, srv: s}
+	})
+}
+
+func (x *index) method(m *model.Method) *Method {
+	return wrap(x, m, func(m *model.Method) *Method {
+		return &Method{x: x, method: m}
+	})
+}
+
+func (x *index) type_(t *model.Type) *Type {
+	return wrap(x, t, func(t *model.Type) *Type {
+		return &Type{x: x, typ: t}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lang provides a public API to compile spec packages, inspect
+// the compiled model and run the built-in Go generator.
+//
+// The model types are read-only views over the compiled packages.
+package lang
+
+import (
+	"errors"
+	"os"
+
+	"github.com/basecomplextech/spec/internal/lang/compiler"
+	"github.com/basecomplextech/spec/internal/lang/format"
+	"github.com/basecomplextech/spec/internal/lang/generator"
+)
+
+// Compile parses, compiles and returns a package from a directory.
+// Imported packages are searched in the import paths, then in the spec.mod module
+// of the directory and its requirements when the directory is in a module.
+func Compile(dir string, importPaths []string) (*Package, error) {
+	c, err := compiler.New(compiler.Options{
+		ImportPath: importPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := c.Compile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	x := newIndex()
+	return x.pkg(pkg), nil
+}
+
+// CompileAll compiles packages from directories, imported packages are compiled once
+// and shared by all packages.
+func CompileAll(dirs []string, importPaths []string) ([]*Package, error) {
+	c, err := compiler.New(compiler.Options{
+		ImportPath: importPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	x := newIndex()
+	pkgs := make([]*Package, 0, len(dirs))
+	for _, dir := range dirs {
+		pkg, err := c.Compile(dir)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, x.pkg(pkg))
+	}
+	return pkgs, nil
+}
+
+// Generate
+
+// GenerateOptions specify the built-in Go generator options.
+type GenerateOptions struct {
+	SkipRPC bool // Skip generating RPC code
+	Mocks   bool // Generate mock clients and fake services
+}
+
+// GeneratedFile is a file rendered by the built-in Go generator or a plugin.
+type GeneratedFile = generator.File
+
+// Generate runs the built-in Go generator and writes a Go package into an output directory.
+// The package is written into its source directory when the output directory is empty.
+// Only files with changed content are written.
+func Generate(pkg *Package, out string, opts GenerateOptions) error {
+	if out == "" {
+		out = pkg.Path()
+	}
+
+	gen := generator.New(generator.Options{
+		SkipRPC: opts.SkipRPC,
+		Mocks:   opts.Mocks,
+	})
+	return gen.Package(pkg.pkg, out)
+}
+
+// GenerateFiles runs the built-in Go generator and returns the files without writing them.
+func GenerateFiles(pkg *Package, out string, opts GenerateOptions) ([]GeneratedFile, error) {
+	if out == "" {
+		out = pkg.Path()
+	}
+
+	gen := generator.New(generator.Options{
+		SkipRPC: opts.SkipRPC,
+		Mocks:   opts.Mocks,
+	})
+	return gen.Files(pkg.pkg, out)
+}
+
+// DiffFiles returns unified diffs between files on disk and generated files,
+// or nil when all files are up to date. Missing files are compared with empty files.
+func DiffFiles(files []GeneratedFile) ([]byte, error) {
+	var result []byte
+
+	for _, file := range files {
+		old, err := os.ReadFile(file.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		d := format.Diff(file.Path+".orig", file.Path, old, file.Content)
+		result = append(result, d...)
+	}
+	return result, nil
+}
+
+// Format
+
+// Format parses and formats a spec file source in the canonical layout.
+func Format(src []byte) ([]byte, error) {
+	return format.Source(src)
+}
+
+// FormatFile parses and formats a spec file in the canonical layout.
+func FormatFile(path string) ([]byte, error) {
+	return format.File(path)
+}
+
+// FormatDiff returns a unified diff between two spec files, or nil when they are equal.
+func FormatDiff(oldName string, newName string, old []byte, new []byte) []byte {
+	return format.Diff(oldName, newName, old, new)
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCompile(t *testing.T, name string) *Package {
+	pkg, err := Compile("../internal/tests/"+name, []string{"../internal/tests"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+// Compile
+
+func TestCompile__should_compile_package(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	assert.Equal(t, "pkg1", pkg.Name())
+	assert.Len(t, pkg.Files(), 2)
+	assert.Equal(t, "github.com/basecomplextech/spec/internal/tests/pkg1",
+		pkg.Option("go_package").Value())
+
+	imports := pkg.Imports()
+	require.Len(t, imports, 1)
+	assert.Equal(t, "pkg2", imports[0].Name())
+}
+
+func TestCompile__should_return_error_on_invalid_import_path(t *testing.T) {
+	_, err := Compile("../internal/tests/pkg1", []string{"../internal/tests/unknown"})
+	assert.Error(t, err)
+}
+
+// Model
+
+func TestPackage__should_return_message_fields(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	def := pkg.Definition("Message")
+	require.NotNil(t, def)
+	assert.Equal(t, DefinitionMessage, def.Type())
+	assert.Nil(t, def.Enum())
+
+	msg := def.Message()
+	field := msg.Field("submessages1")
+	require.NotNil(t, field)
+	assert.Equal(t, 75, field.Tag())
+	assert.Equal(t, KindList, field.Type().Kind())
+	assert.Equal(t, "[]pkg2.Submessage", field.Type().String())
+
+	elem := field.Type().Element()
+	assert.Equal(t, KindMessage, elem.Kind())
+	assert.Equal(t, "pkg2", elem.Ref().Package().Name())
+	assert.Equal(t, "pkg2", elem.Import().Name())
+
+	assert.Same(t, field, msg.FieldByTag(75))
+}
+
+func TestPackage__should_return_same_wrappers(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	def := pkg.Definition("Submessage")
+	field := def.Message().Field("next")
+
+	assert.Same(t, def, field.Type().Ref())
+	assert.Same(t, pkg, def.Package())
+}
+
+func TestPackage__should_return_enum_and_struct(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	enum := pkg.Definition("Enum").Enum()
+	require.NotNil(t, enum)
+	assert.Equal(t, 10, enum.Value("TEN").Number())
+	assert.Equal(t, "TWO", enum.ValueByNumber(2).Name())
+
+	str := pkg.Definition("Struct").Struct()
+	fields := str.Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "key", fields[0].Name())
+	assert.Equal(t, KindInt32, fields[1].Type().Kind())
+}
+
+func TestPackage__should_return_service_methods(t *testing.T) {
+	pkg := testCompile(t, "pkg4")
+
+	srv := pkg.Definition("Service").Service()
+	require.NotNil(t, srv)
+
+	m := srv.Method("subservice")
+	assert.Equal(t, MethodType_Subservice, m.Type())
+	assert.Equal(t, "Subservice", m.Subservice().Ref().Name())
+
+	m = srv.Method("method0")
+	assert.Equal(t, MethodType_Oneway, m.Type())
+	assert.True(t, m.Request().Ref().Message().Generated())
+
+	m = srv.Method("method23")
+	assert.Equal(t, MethodType_Channel, m.Type())
+	assert.Equal(t, "In", m.ChannelIn().Name())
+	assert.Equal(t, "Out", m.ChannelOut().Name())
+	assert.Equal(t, "Response", m.Response().Name())
+}
+
+// Dynamic
+
+func TestMessage__should_write_and_open_dynamic_message(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+	msg := pkg.Definition("Submessage").Message()
+
+	w := msg.NewWriter()
+	require.NoError(t, w.String("value", "hello"))
+
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	m1, err := msg.Open(m.Unwrap().Raw())
+	require.NoError(t, err)
+
+	v, err := m1.String("value")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v.Unwrap())
+}
+
+// Generate
+
+func TestGenerate__should_generate_go_package(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+	out := t.TempDir()
+
+	err := Generate(pkg, out, GenerateOptions{})
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(out, "pkg1_generated.go"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"io"
+
+	"github.com/basecomplextech/spec/internal/lang/lint"
+)
+
+// LintConfig enables, disables and configures lint rules.
+type LintConfig = lint.Config
+
+// LintIssue is a lint rule violation.
+type LintIssue = lint.Issue
+
+// LintRule is a registered lint rule.
+type LintRule = lint.Rule
+
+// LoadLintConfig loads a yaml lint config from a file.
+func LoadLintConfig(path string) (*LintConfig, error) {
+	return lint.LoadConfig(path)
+}
+
+// Lint checks a package against lint rules, the config may be nil.
+func Lint(pkg *Package, config *LintConfig) ([]LintIssue, error) {
+	return lint.Run(pkg.pkg, config)
+}
+
+// LintRules returns registered lint rules sorted by name.
+func LintRules() []*LintRule {
+	return lint.Rules()
+}
+
+// LintHasErrors returns true when issues contain errors.
+func LintHasErrors(issues []LintIssue) bool {
+	return lint.HasErrors(issues)
+}
+
+// WriteLintText writes issues as "file:line: severity: message (rule)" lines.
+func WriteLintText(w io.Writer, issues []LintIssue) error {
+	return lint.WriteText(w, issues)
+}
+
+// WriteLintJSON writes issues as a json array.
+func WriteLintJSON(w io.Writer, issues []LintIssue) error {
+	return lint.WriteJSON(w, issues)
+}
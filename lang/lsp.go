@@ -0,0 +1,20 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"io"
+
+	"github.com/basecomplextech/spec/internal/lang/lsp"
+)
+
+// ServeLSP runs a language server for spec files over a reader and a writer,
+// usually stdin and stdout, until the client exits or closes the input.
+// Imported packages are searched in the import paths.
+func ServeLSP(r io.Reader, w io.Writer, importPaths []string) error {
+	return lsp.Serve(r, w, lsp.Options{
+		ImportPaths: importPaths,
+	})
+}
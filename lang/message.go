@@ -0,0 +1,71 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// Message is a message definition.
+type Message struct {
+	x   *index
+	msg *model.Message
+}
+
+// Definition returns the message definition.
+func (m *Message) Definition() *Definition {
+	return m.x.def(m.msg.Def)
+}
+
+// Generated returns true if the message is auto-generated, i.e. a method request/response.
+func (m *Message) Generated() bool {
+	return m.msg.Generated
+}
+
+// Fields returns the message fields in the declaration order.
+func (m *Message) Fields() []*Field {
+	return wrapList(m.msg.Fields.List, m.x.field)
+}
+
+// Field returns a field by a name, or nil.
+func (m *Message) Field(name string) *Field {
+	return m.x.field(m.msg.Fields.Get(name))
+}
+
+// FieldByTag returns a field by a tag, or nil.
+func (m *Message) FieldByTag(tag int) *Field {
+	return m.x.field(m.msg.Fields.GetByTag(tag))
+}
+
+// Field
+
+// Field is a message field.
+type Field struct {
+	x     *index
+	field *model.Field
+}
+
+// Name returns the field name.
+func (f *Field) Name() string {
+	return f.field.Name
+}
+
+// Tag returns the field tag.
+func (f *Field) Tag() int {
+	return f.field.Tag
+}
+
+// Type returns the field type.
+func (f *Field) Type() *Type {
+	return f.x.type_(f.field.Type)
+}
+
+// Line returns the field source line.
+func (f *Field) Line() int {
+	return f.field.Line
+}
+
+// Doc returns the field doc comment without comment markers.
+func (f *Field) Doc() string {
+	return f.field.Doc
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/module"
+
+// ModuleManifest is a parsed spec.mod file which declares a module path and its requirements.
+type ModuleManifest = module.Manifest
+
+// ModuleRequire is a module requirement.
+type ModuleRequire = module.Require
+
+// ModuleTidyResult is a module check result.
+type ModuleTidyResult = module.TidyResult
+
+// FindModule searches for a spec.mod file in a directory and its parents,
+// returns an empty path when not found.
+func FindModule(dir string) (string, error) {
+	return module.Find(dir)
+}
+
+// LoadModule loads a spec.mod file.
+func LoadModule(path string) (*ModuleManifest, error) {
+	return module.Load(path)
+}
+
+// TidyModule compiles all packages in a module and verifies that their imports resolve,
+// returns compile errors and unused requirements.
+func TidyModule(m *ModuleManifest, importPaths []string) (*ModuleTidyResult, error) {
+	return module.Tidy(m, importPaths)
+}
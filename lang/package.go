@@ -0,0 +1,76 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// Package is a compiled spec package.
+type Package struct {
+	x   *index
+	pkg *model.Package
+}
+
+// ID returns the package id, i.e. an import path as "my/example/test".
+func (p *Package) ID() string {
+	return p.pkg.ID
+}
+
+// Name returns the package name, i.e. "test" in "my/example/test".
+func (p *Package) Name() string {
+	return p.pkg.Name
+}
+
+// Path returns the package directory path.
+func (p *Package) Path() string {
+	return p.pkg.Path
+}
+
+// Files returns the package files.
+func (p *Package) Files() []*File {
+	return wrapList(p.pkg.Files, p.x.file)
+}
+
+// File returns a file by a name, or nil.
+func (p *Package) File(name string) *File {
+	return p.x.file(p.pkg.FileNames[name])
+}
+
+// Options returns the package options from all files.
+func (p *Package) Options() []*Option {
+	return wrapList(p.pkg.Options, p.x.option)
+}
+
+// Option returns an option by a name, or nil.
+func (p *Package) Option(name string) *Option {
+	return p.x.option(p.pkg.OptionNames[name])
+}
+
+// Definitions returns the package definitions, see [File.Definitions] for generated ones.
+func (p *Package) Definitions() []*Definition {
+	return wrapList(p.pkg.Definitions, p.x.def)
+}
+
+// Definition returns a definition by a name, or nil.
+func (p *Package) Definition(name string) *Definition {
+	return p.x.def(p.pkg.DefinitionNames[name])
+}
+
+// Imports returns the packages imported by the package files.
+func (p *Package) Imports() []*Package {
+	var result []*Package
+	seen := make(map[*model.Package]struct{})
+
+	for _, file := range p.pkg.Files {
+		for _, imp := range file.Imports {
+			if _, ok := seen[imp.Package]; ok {
+				continue
+			}
+
+			seen[imp.Package] = struct{}{}
+			result = append(result, p.x.pkg(imp.Package))
+		}
+	}
+	return result
+}
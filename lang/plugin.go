@@ -0,0 +1,184 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/generator"
+	"github.com/basecomplextech/spec/internal/lang/plugin"
+)
+
+// Plugin is an external generator executable.
+//
+// The compiler writes a compiled package with its imports as a spec message to the plugin stdin,
+// the plugin writes generated files as a spec message to its stdout, see [ServePlugin].
+type Plugin struct {
+	Name      string `yaml:"name"`      // Plugin name, i.e. "ts"
+	Path      string `yaml:"path"`      // Executable path, defaults to "spec-gen-<name>" in PATH
+	Out       string `yaml:"out"`       // Output directory, defaults to the package directory
+	Parameter string `yaml:"parameter"` // Optional plugin parameter
+}
+
+// PluginFile is a file generated by a plugin.
+type PluginFile = plugin.File
+
+// PluginRequest is a request passed to a plugin.
+type PluginRequest struct {
+	Package   *Package
+	Parameter string
+}
+
+// RunPlugin runs an external generator plugin and writes the returned files
+// into the plugin output directory, only files with changed content are written.
+func RunPlugin(pkg *Package, p Plugin) error {
+	files, err := PluginFiles(pkg, p)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := generator.WriteFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PluginFiles runs an external generator plugin and returns the files
+// with paths in the plugin output directory without writing them.
+func PluginFiles(pkg *Package, p Plugin) ([]GeneratedFile, error) {
+	files, err := CallPlugin(pkg, p)
+	if err != nil {
+		return nil, err
+	}
+
+	out := p.Out
+	if out == "" {
+		out = pkg.Path()
+	}
+	return pluginFilePaths(out, files)
+}
+
+// CallPlugin runs an external generator plugin and returns the generated files.
+func CallPlugin(pkg *Package, p Plugin) ([]PluginFile, error) {
+	path := p.Path
+	if path == "" {
+		path = "spec-gen-" + p.Name
+	}
+
+	req, err := plugin.EncodeRequest(pkg.pkg, p.Parameter)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %v: %w", p.Name, err)
+	}
+
+	// Run plugin
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %v: %w", p.Name, err)
+	}
+
+	// Decode response
+	files, err := plugin.DecodeResponse(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("plugin %v: %w", p.Name, err)
+	}
+	return files, nil
+}
+
+// Serve
+
+// ServePlugin reads a request from stdin, calls a generate function, and writes
+// the generated files or an error to stdout. It is the main function of a plugin.
+//
+// Example:
+//
+//	func main() {
+//		lang.ServePlugin(func(req *lang.PluginRequest) ([]lang.PluginFile, error) {
+//			var b bytes.Buffer
+//			for _, def := range req.Package.Definitions() {
+//				fmt.Fprintln(&b, def.Name())
+//			}
+//			return []lang.PluginFile{{Name: "names.txt", Content: b.Bytes()}}, nil
+//		})
+//	}
+func ServePlugin(generate func(req *PluginRequest) ([]PluginFile, error)) {
+	if err := servePlugin(os.Stdin, os.Stdout, generate); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// ReadPluginRequest reads and decodes a plugin request.
+func ReadPluginRequest(r io.Reader) (*PluginRequest, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, param, err := plugin.DecodeRequest(b)
+	if err != nil {
+		return nil, err
+	}
+
+	x := newIndex()
+	req := &PluginRequest{
+		Package:   x.pkg(pkg),
+		Parameter: param,
+	}
+	return req, nil
+}
+
+// WritePluginResponse encodes and writes generated files, or an error, as a plugin response.
+func WritePluginResponse(w io.Writer, files []PluginFile, genErr error) error {
+	b, err := plugin.EncodeResponse(files, genErr)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// private
+
+func servePlugin(r io.Reader, w io.Writer, generate func(req *PluginRequest) ([]PluginFile, error)) error {
+	req, err := ReadPluginRequest(r)
+	if err != nil {
+		return err
+	}
+
+	files, err := generate(req)
+	return WritePluginResponse(w, files, err)
+}
+
+// pluginFilePaths returns plugin files with paths in an output directory,
+// rejects paths outside the directory.
+func pluginFilePaths(out string, files []PluginFile) ([]GeneratedFile, error) {
+	result := make([]GeneratedFile, 0, len(files))
+	for _, file := range files {
+		name := filepath.Clean(file.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid plugin file path %q", file.Name)
+		}
+
+		result = append(result, GeneratedFile{
+			Path:    filepath.Join(out, name),
+			Content: file.Content,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginEnv makes the test binary run as a plugin.
+const testPluginEnv = "SPEC_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnv) == "" {
+		os.Exit(m.Run())
+	}
+
+	ServePlugin(func(req *PluginRequest) ([]PluginFile, error) {
+		if req.Parameter == "fail" {
+			return nil, errors.New("test failure")
+		}
+
+		var names []string
+		for _, def := range req.Package.Definitions() {
+			names = append(names, def.Name())
+		}
+
+		file := PluginFile{
+			Name:    "out/" + req.Package.Name() + ".txt",
+			Content: []byte(strings.Join(names, "\n")),
+		}
+		return []PluginFile{file}, nil
+	})
+	os.Exit(0)
+}
+
+func testPlugin(t *testing.T) Plugin {
+	t.Setenv(testPluginEnv, "1")
+
+	return Plugin{
+		Name: "test",
+		Path: os.Args[0],
+		Out:  t.TempDir(),
+	}
+}
+
+func TestRunPlugin__should_write_plugin_files(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+	p := testPlugin(t)
+
+	err := RunPlugin(pkg, p)
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(p.Out, "out", "pkg1.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Enum\nMessage\nStruct\nSubmessage\nComplexStruct\nRecord\nVersion", string(b))
+}
+
+func TestRunPlugin__should_return_plugin_error(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+	p := testPlugin(t)
+	p.Parameter = "fail"
+
+	err := RunPlugin(pkg, p)
+	assert.EqualError(t, err, "plugin test: test failure")
+}
+
+func TestPluginFilePaths__should_reject_paths_outside_output(t *testing.T) {
+	files := []PluginFile{{Name: "../escape.txt"}}
+
+	_, err := pluginFilePaths(t.TempDir(), files)
+	assert.Error(t, err)
+}
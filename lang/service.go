@@ -0,0 +1,113 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// MethodType is a method type, i.e. request, oneway, channel or subservice.
+type MethodType = model.MethodType
+
+const (
+	MethodType_Undefined  = model.MethodType_Undefined
+	MethodType_Request    = model.MethodType_Request
+	MethodType_Oneway     = model.MethodType_Oneway
+	MethodType_Channel    = model.MethodType_Channel
+	MethodType_Subservice = model.MethodType_Subservice
+)
+
+// Service is a service definition.
+type Service struct {
+	x   *index
+	srv *model.Service
+}
+
+// Definition returns the service definition.
+func (s *Service) Definition() *Definition {
+	return s.x.def(s.srv.Def)
+}
+
+// Sub returns true if the service is a subservice.
+func (s *Service) Sub() bool {
+	return s.srv.Sub
+}
+
+// Methods returns the service methods in the declaration order.
+func (s *Service) Methods() []*Method {
+	return wrapList(s.srv.Methods, s.x.method)
+}
+
+// Method returns a method by a name, or nil.
+func (s *Service) Method(name string) *Method {
+	return s.x.method(s.srv.MethodNames[name])
+}
+
+// Method
+
+// Method is a service method.
+type Method struct {
+	x      *index
+	method *model.Method
+}
+
+// Service returns the method service.
+func (m *Method) Service() *Service {
+	return m.x.service(m.method.Service)
+}
+
+// Name returns the method name.
+func (m *Method) Name() string {
+	return m.method.Name
+}
+
+// Type returns the method type.
+func (m *Method) Type() MethodType {
+	return m.method.Type
+}
+
+// Line returns the method source line.
+func (m *Method) Line() int {
+	return m.method.Line
+}
+
+// Doc returns the method doc comment without comment markers.
+func (m *Method) Doc() string {
+	return m.method.Doc
+}
+
+// Oneway returns true if the method is oneway.
+func (m *Method) Oneway() bool {
+	return m.method.Oneway
+}
+
+// Request returns the request message type, or nil.
+func (m *Method) Request() *Type {
+	return m.x.type_(m.method.Request)
+}
+
+// Response returns the response message type, or nil.
+func (m *Method) Response() *Type {
+	return m.x.type_(m.method.Response)
+}
+
+// Subservice returns the subservice type, or nil.
+func (m *Method) Subservice() *Type {
+	return m.x.type_(m.method.Subservice)
+}
+
+// ChannelIn returns the channel input message type, or nil.
+func (m *Method) ChannelIn() *Type {
+	if m.method.Channel == nil {
+		return nil
+	}
+	return m.x.type_(m.method.Channel.In)
+}
+
+// ChannelOut returns the channel output message type, or nil.
+func (m *Method) ChannelOut() *Type {
+	if m.method.Channel == nil {
+		return nil
+	}
+	return m.x.type_(m.method.Channel.Out)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// Struct is a struct definition.
+type Struct struct {
+	x   *index
+	str *model.Struct
+}
+
+// Definition returns the struct definition.
+func (s *Struct) Definition() *Definition {
+	return s.x.def(s.str.Def)
+}
+
+// Fields returns the struct fields in the declaration order.
+func (s *Struct) Fields() []*StructField {
+	return wrapList(s.str.Fields.Values(), s.x.structField)
+}
+
+// Field returns a field by a name, or nil.
+func (s *Struct) Field(name string) *StructField {
+	f, _ := s.str.Fields.Get(name)
+	return s.x.structField(f)
+}
+
+// StructField
+
+// StructField is a struct field.
+type StructField struct {
+	x     *index
+	field *model.StructField
+}
+
+// Struct returns the field struct.
+func (f *StructField) Struct() *Struct {
+	return f.x.struct_(f.field.Struct)
+}
+
+// Name returns the field name.
+func (f *StructField) Name() string {
+	return f.field.Name
+}
+
+// Type returns the field type.
+func (f *StructField) Type() *Type {
+	return f.x.type_(f.field.Type)
+}
+
+// Line returns the field source line.
+func (f *StructField) Line() int {
+	return f.field.Line
+}
+
+// Doc returns the field doc comment without comment markers.
+func (f *StructField) Doc() string {
+	return f.field.Doc
+}
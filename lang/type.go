@@ -0,0 +1,94 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec/internal/lang/model"
+
+// Kind is a type kind.
+type Kind = model.Kind
+
+const (
+	KindUndefined = model.KindUndefined
+	KindAny       = model.KindAny
+
+	KindBool = model.KindBool
+	KindByte = model.KindByte
+
+	KindInt16 = model.KindInt16
+	KindInt32 = model.KindInt32
+	KindInt64 = model.KindInt64
+
+	KindUint16 = model.KindUint16
+	KindUint32 = model.KindUint32
+	KindUint64 = model.KindUint64
+
+	KindBin64  = model.KindBin64
+	KindBin128 = model.KindBin128
+	KindBin256 = model.KindBin256
+
+	KindFloat32 = model.KindFloat32
+	KindFloat64 = model.KindFloat64
+
+	KindBytes      = model.KindBytes
+	KindString     = model.KindString
+	KindAnyMessage = model.KindAnyMessage
+
+	KindList = model.KindList
+
+	KindEnum    = model.KindEnum
+	KindMessage = model.KindMessage
+	KindStruct  = model.KindStruct
+
+	KindService = model.KindService
+)
+
+// Type is a field or method type.
+type Type struct {
+	x   *index
+	typ *model.Type
+}
+
+// Kind returns the type kind.
+func (t *Type) Kind() Kind {
+	return t.typ.Kind
+}
+
+// Name returns the type name, i.e. "int64" or "Message" in "pkg.Message".
+func (t *Type) Name() string {
+	return t.typ.Name
+}
+
+// ImportName returns the imported package name, i.e. "pkg" in "pkg.Message".
+func (t *Type) ImportName() string {
+	return t.typ.ImportName
+}
+
+// Element returns the list element type, or nil.
+func (t *Type) Element() *Type {
+	return t.x.type_(t.typ.Element)
+}
+
+// Ref returns the referenced definition, or nil for builtin types and lists.
+func (t *Type) Ref() *Definition {
+	return t.x.def(t.typ.Ref)
+}
+
+// Import returns the import of a referenced definition, or nil for local types.
+func (t *Type) Import() *Import {
+	return t.x.import_(t.typ.Import)
+}
+
+// String returns the type as in a schema, i.e. "[]pkg.Message".
+func (t *Type) String() string {
+	switch t.typ.Kind {
+	case KindList:
+		return "[]" + t.Element().String()
+	case KindEnum, KindMessage, KindStruct, KindService:
+		if t.typ.ImportName != "" {
+			return t.typ.ImportName + "." + t.typ.Name
+		}
+	}
+	return t.typ.Name
+}
@@ -27,3 +27,9 @@ func OpenListErr(b []byte) (List, error) {
 func ParseList(b []byte) (l List, size int, err error) {
 	return types.ParseList(b)
 }
+
+// ParseListWithOptions recursively parses and returns a list,
+// returns an [ErrParseLimit] error when the list exceeds the limits.
+func ParseListWithOptions(b []byte, opts ParseOptions) (l List, size int, err error) {
+	return types.ParseListWithOptions(b, opts)
+}
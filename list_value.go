@@ -4,6 +4,8 @@
 
 package spec
 
+import "github.com/basecomplextech/spec/internal/types"
+
 type ValueList[T any] struct {
 	list   List
 	decode func([]byte) (T, int, error)
@@ -84,6 +86,10 @@ func (l ValueList[T]) Empty() bool {
 
 // Get returns an decode at index i, panics on out of range.
 func (l ValueList[T]) Get(i int) T {
+	if v, ok := types.PackedGet[T](l.list, i); ok {
+		return v
+	}
+
 	b := l.list.GetBytes(i)
 	elem, _, _ := l.decode(b)
 	return elem
@@ -91,6 +97,10 @@ func (l ValueList[T]) Get(i int) T {
 
 // GetErr returns an decode at index i or an error.
 func (l ValueList[T]) GetErr(i int) (T, error) {
+	if v, ok := types.PackedGet[T](l.list, i); ok {
+		return v, nil
+	}
+
 	b := l.list.GetBytes(i)
 	elem, _, err := l.decode(b)
 	return elem, err
@@ -101,6 +111,13 @@ func (l ValueList[T]) GetBytes(i int) []byte {
 	return l.list.GetBytes(i)
 }
 
+// View returns a zero-copy slice of packed list elements, or false when the list is not packed,
+// the elements are not aligned or are stored in a different byte order.
+// The slice must not be modified, it is valid only while the list bytes are.
+func (l ValueList[T]) View() ([]T, bool) {
+	return types.PackedView[T](l.list)
+}
+
 // Values converts a list into a slice.
 func (l ValueList[T]) Values() []T {
 	result := make([]T, 0, l.list.Len())
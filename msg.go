@@ -30,3 +30,9 @@ func OpenMessageErr(b []byte) (Message, error) {
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	return types.ParseMessage(b)
 }
+
+// ParseMessageWithOptions recursively parses and returns a message,
+// returns an [ErrParseLimit] error when the message exceeds the limits.
+func ParseMessageWithOptions(b []byte, opts ParseOptions) (_ Message, size int, err error) {
+	return types.ParseMessageWithOptions(b, opts)
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// ParseOptions limit recursive parsing of untrusted input, zero values mean no limits.
+type ParseOptions = types.ParseOptions
+
+// ErrParseLimit is returned when a parsed value exceeds parse options limits.
+var ErrParseLimit = types.ErrParseLimit
@@ -132,18 +132,17 @@ func OpenMessage(b []byte) Message {
 
 func OpenMessageErr(b []byte) (_ Message, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Message{msg}, nil
+	return Message{msg}, err
 }
 
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Message{msg}, size, nil
+	return Message{msg}, size, err
+}
+
+func ParseMessageWithOptions(b []byte, opts spec.ParseOptions) (_ Message, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Message{msg}, size, err
 }
 
 func (m Message) Code() Code                       { return OpenCode(m.msg.FieldRaw(1)) }
@@ -164,11 +163,12 @@ func (m Message) HasChannelClose() bool    { return m.msg.HasField(11) }
 func (m Message) HasChannelData() bool     { return m.msg.HasField(12) }
 func (m Message) HasChannelWindow() bool   { return m.msg.HasField(13) }
 
-func (m Message) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
 func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
 func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
-func (m Message) Unwrap() spec.Message                  { return m.msg }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
 
 // ConnectRequest
 
@@ -187,18 +187,17 @@ func OpenConnectRequest(b []byte) ConnectRequest {
 
 func OpenConnectRequestErr(b []byte) (_ ConnectRequest, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ConnectRequest{msg}, nil
+	return ConnectRequest{msg}, err
 }
 
 func ParseConnectRequest(b []byte) (_ ConnectRequest, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ConnectRequest{msg}, size, nil
+	return ConnectRequest{msg}, size, err
+}
+
+func ParseConnectRequestWithOptions(b []byte, opts spec.ParseOptions) (_ ConnectRequest, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ConnectRequest{msg}, size, err
 }
 
 func (m ConnectRequest) Versions() spec.ValueList[Version] {
@@ -211,7 +210,6 @@ func (m ConnectRequest) Compression() spec.ValueList[ConnectCompression] {
 func (m ConnectRequest) HasVersions() bool    { return m.msg.HasField(1) }
 func (m ConnectRequest) HasCompression() bool { return m.msg.HasField(2) }
 
-func (m ConnectRequest) IsEmpty() bool         { return m.msg.Empty() }
 func (m ConnectRequest) Clone() ConnectRequest { return ConnectRequest{m.msg.Clone()} }
 func (m ConnectRequest) CloneToArena(a alloc.Arena) ConnectRequest {
 	return ConnectRequest{m.msg.CloneToArena(a)}
@@ -219,6 +217,8 @@ func (m ConnectRequest) CloneToArena(a alloc.Arena) ConnectRequest {
 func (m ConnectRequest) CloneToBuffer(b buffer.Buffer) ConnectRequest {
 	return ConnectRequest{m.msg.CloneToBuffer(b)}
 }
+
+func (m ConnectRequest) IsEmpty() bool        { return m.msg.Empty() }
 func (m ConnectRequest) Unwrap() spec.Message { return m.msg }
 
 // ConnectResponse
@@ -238,18 +238,17 @@ func OpenConnectResponse(b []byte) ConnectResponse {
 
 func OpenConnectResponseErr(b []byte) (_ ConnectResponse, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ConnectResponse{msg}, nil
+	return ConnectResponse{msg}, err
 }
 
 func ParseConnectResponse(b []byte) (_ ConnectResponse, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ConnectResponse{msg}, size, nil
+	return ConnectResponse{msg}, size, err
+}
+
+func ParseConnectResponseWithOptions(b []byte, opts spec.ParseOptions) (_ ConnectResponse, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ConnectResponse{msg}, size, err
 }
 
 func (m ConnectResponse) Ok() bool           { return m.msg.Bool(1) }
@@ -264,7 +263,6 @@ func (m ConnectResponse) HasError() bool       { return m.msg.HasField(2) }
 func (m ConnectResponse) HasVersion() bool     { return m.msg.HasField(10) }
 func (m ConnectResponse) HasCompression() bool { return m.msg.HasField(11) }
 
-func (m ConnectResponse) IsEmpty() bool          { return m.msg.Empty() }
 func (m ConnectResponse) Clone() ConnectResponse { return ConnectResponse{m.msg.Clone()} }
 func (m ConnectResponse) CloneToArena(a alloc.Arena) ConnectResponse {
 	return ConnectResponse{m.msg.CloneToArena(a)}
@@ -272,6 +270,8 @@ func (m ConnectResponse) CloneToArena(a alloc.Arena) ConnectResponse {
 func (m ConnectResponse) CloneToBuffer(b buffer.Buffer) ConnectResponse {
 	return ConnectResponse{m.msg.CloneToBuffer(b)}
 }
+
+func (m ConnectResponse) IsEmpty() bool        { return m.msg.Empty() }
 func (m ConnectResponse) Unwrap() spec.Message { return m.msg }
 
 // ConnectCompression
@@ -328,29 +328,29 @@ func OpenBatch(b []byte) Batch {
 
 func OpenBatchErr(b []byte) (_ Batch, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Batch{msg}, nil
+	return Batch{msg}, err
 }
 
 func ParseBatch(b []byte) (_ Batch, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Batch{msg}, size, nil
+	return Batch{msg}, size, err
+}
+
+func ParseBatchWithOptions(b []byte, opts spec.ParseOptions) (_ Batch, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Batch{msg}, size, err
 }
 
 func (m Batch) List() spec.MessageList[Message] {
 	return spec.NewMessageList(m.msg.List(1), OpenMessageErr)
 }
 func (m Batch) HasList() bool                       { return m.msg.HasField(1) }
-func (m Batch) IsEmpty() bool                       { return m.msg.Empty() }
 func (m Batch) Clone() Batch                        { return Batch{m.msg.Clone()} }
 func (m Batch) CloneToArena(a alloc.Arena) Batch    { return Batch{m.msg.CloneToArena(a)} }
 func (m Batch) CloneToBuffer(b buffer.Buffer) Batch { return Batch{m.msg.CloneToBuffer(b)} }
-func (m Batch) Unwrap() spec.Message                { return m.msg }
+
+func (m Batch) IsEmpty() bool        { return m.msg.Empty() }
+func (m Batch) Unwrap() spec.Message { return m.msg }
 
 // ChannelOpen
 
@@ -369,18 +369,17 @@ func OpenChannelOpen(b []byte) ChannelOpen {
 
 func OpenChannelOpenErr(b []byte) (_ ChannelOpen, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelOpen{msg}, nil
+	return ChannelOpen{msg}, err
 }
 
 func ParseChannelOpen(b []byte) (_ ChannelOpen, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ChannelOpen{msg}, size, nil
+	return ChannelOpen{msg}, size, err
+}
+
+func ParseChannelOpenWithOptions(b []byte, opts spec.ParseOptions) (_ ChannelOpen, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ChannelOpen{msg}, size, err
 }
 
 func (m ChannelOpen) Id() bin.Bin128   { return m.msg.Bin128(1) }
@@ -391,7 +390,6 @@ func (m ChannelOpen) HasId() bool     { return m.msg.HasField(1) }
 func (m ChannelOpen) HasWindow() bool { return m.msg.HasField(2) }
 func (m ChannelOpen) HasData() bool   { return m.msg.HasField(3) }
 
-func (m ChannelOpen) IsEmpty() bool      { return m.msg.Empty() }
 func (m ChannelOpen) Clone() ChannelOpen { return ChannelOpen{m.msg.Clone()} }
 func (m ChannelOpen) CloneToArena(a alloc.Arena) ChannelOpen {
 	return ChannelOpen{m.msg.CloneToArena(a)}
@@ -399,6 +397,8 @@ func (m ChannelOpen) CloneToArena(a alloc.Arena) ChannelOpen {
 func (m ChannelOpen) CloneToBuffer(b buffer.Buffer) ChannelOpen {
 	return ChannelOpen{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelOpen) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelOpen) Unwrap() spec.Message { return m.msg }
 
 // ChannelClose
@@ -418,18 +418,17 @@ func OpenChannelClose(b []byte) ChannelClose {
 
 func OpenChannelCloseErr(b []byte) (_ ChannelClose, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelClose{msg}, nil
+	return ChannelClose{msg}, err
 }
 
 func ParseChannelClose(b []byte) (_ ChannelClose, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ChannelClose{msg}, size, nil
+	return ChannelClose{msg}, size, err
+}
+
+func ParseChannelCloseWithOptions(b []byte, opts spec.ParseOptions) (_ ChannelClose, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ChannelClose{msg}, size, err
 }
 
 func (m ChannelClose) Id() bin.Bin128   { return m.msg.Bin128(1) }
@@ -438,7 +437,6 @@ func (m ChannelClose) Data() spec.Bytes { return m.msg.Bytes(2) }
 func (m ChannelClose) HasId() bool   { return m.msg.HasField(1) }
 func (m ChannelClose) HasData() bool { return m.msg.HasField(2) }
 
-func (m ChannelClose) IsEmpty() bool       { return m.msg.Empty() }
 func (m ChannelClose) Clone() ChannelClose { return ChannelClose{m.msg.Clone()} }
 func (m ChannelClose) CloneToArena(a alloc.Arena) ChannelClose {
 	return ChannelClose{m.msg.CloneToArena(a)}
@@ -446,6 +444,8 @@ func (m ChannelClose) CloneToArena(a alloc.Arena) ChannelClose {
 func (m ChannelClose) CloneToBuffer(b buffer.Buffer) ChannelClose {
 	return ChannelClose{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelClose) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelClose) Unwrap() spec.Message { return m.msg }
 
 // ChannelData
@@ -465,18 +465,17 @@ func OpenChannelData(b []byte) ChannelData {
 
 func OpenChannelDataErr(b []byte) (_ ChannelData, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelData{msg}, nil
+	return ChannelData{msg}, err
 }
 
 func ParseChannelData(b []byte) (_ ChannelData, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ChannelData{msg}, size, nil
+	return ChannelData{msg}, size, err
+}
+
+func ParseChannelDataWithOptions(b []byte, opts spec.ParseOptions) (_ ChannelData, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ChannelData{msg}, size, err
 }
 
 func (m ChannelData) Id() bin.Bin128   { return m.msg.Bin128(1) }
@@ -485,7 +484,6 @@ func (m ChannelData) Data() spec.Bytes { return m.msg.Bytes(2) }
 func (m ChannelData) HasId() bool   { return m.msg.HasField(1) }
 func (m ChannelData) HasData() bool { return m.msg.HasField(2) }
 
-func (m ChannelData) IsEmpty() bool      { return m.msg.Empty() }
 func (m ChannelData) Clone() ChannelData { return ChannelData{m.msg.Clone()} }
 func (m ChannelData) CloneToArena(a alloc.Arena) ChannelData {
 	return ChannelData{m.msg.CloneToArena(a)}
@@ -493,6 +491,8 @@ func (m ChannelData) CloneToArena(a alloc.Arena) ChannelData {
 func (m ChannelData) CloneToBuffer(b buffer.Buffer) ChannelData {
 	return ChannelData{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelData) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelData) Unwrap() spec.Message { return m.msg }
 
 // ChannelWindow
@@ -512,18 +512,17 @@ func OpenChannelWindow(b []byte) ChannelWindow {
 
 func OpenChannelWindowErr(b []byte) (_ ChannelWindow, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelWindow{msg}, nil
+	return ChannelWindow{msg}, err
 }
 
 func ParseChannelWindow(b []byte) (_ ChannelWindow, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return ChannelWindow{msg}, size, nil
+	return ChannelWindow{msg}, size, err
+}
+
+func ParseChannelWindowWithOptions(b []byte, opts spec.ParseOptions) (_ ChannelWindow, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return ChannelWindow{msg}, size, err
 }
 
 func (m ChannelWindow) Id() bin.Bin128 { return m.msg.Bin128(1) }
@@ -532,7 +531,6 @@ func (m ChannelWindow) Delta() int32   { return m.msg.Int32(2) }
 func (m ChannelWindow) HasId() bool    { return m.msg.HasField(1) }
 func (m ChannelWindow) HasDelta() bool { return m.msg.HasField(2) }
 
-func (m ChannelWindow) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelWindow) Clone() ChannelWindow { return ChannelWindow{m.msg.Clone()} }
 func (m ChannelWindow) CloneToArena(a alloc.Arena) ChannelWindow {
 	return ChannelWindow{m.msg.CloneToArena(a)}
@@ -540,6 +538,8 @@ func (m ChannelWindow) CloneToArena(a alloc.Arena) ChannelWindow {
 func (m ChannelWindow) CloneToBuffer(b buffer.Buffer) ChannelWindow {
 	return ChannelWindow{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelWindow) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelWindow) Unwrap() spec.Message { return m.msg }
 
 // MessageWriter
@@ -0,0 +1,7 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+//go:generate spec generate --skip-rpc .
+
+package pplugin
@@ -0,0 +1,177 @@
+// Plugin protocol, the compiler writes a request to a plugin stdin,
+// and reads a response from its stdout.
+
+// Request
+
+message Request {
+    package   Package   1; // Package to generate
+    imports   []Package 2; // Transitively imported packages
+    parameter string    3; // Plugin parameter
+}
+
+// Response
+
+message Response {
+    files []OutputFile 1;
+    error string       2;
+}
+
+message OutputFile {
+    name    string 1; // Relative file path
+    content bytes  2;
+}
+
+// Package
+
+message Package {
+    id          string       1;
+    name        string       2;
+    path        string       3;
+    files       []File       4;
+    options     []Option     5;
+    definitions []Definition 6;
+}
+
+message File {
+    name    string   1;
+    path    string   2;
+    imports []Import 3;
+    options []Option 4;
+}
+
+message Import {
+    id   string 1;
+    name string 2;
+}
+
+message Option {
+    name  string 1;
+    value string 2;
+}
+
+// Definition
+
+enum DefinitionType {
+    UNDEFINED = 0;
+    ENUM      = 1;
+    MESSAGE   = 2;
+    STRUCT    = 3;
+    SERVICE   = 4;
+}
+
+message Definition {
+    name string         1;
+    type DefinitionType 2;
+    file string         3; // File name
+
+    enum    Enum    10;
+    message Message 11;
+    struct  Struct  12;
+    service Service 13;
+}
+
+// Enum
+
+message Enum {
+    values []EnumValue 1;
+}
+
+message EnumValue {
+    name   string 1;
+    number int32  2;
+}
+
+// Message
+
+message Message {
+    fields    []Field 1;
+    generated bool    2;
+}
+
+message Field {
+    name string 1;
+    tag  int32  2;
+    type Type   3;
+}
+
+// Struct
+
+message Struct {
+    fields []StructField 1;
+    key    bool          2;
+}
+
+message StructField {
+    name string 1;
+    type Type   2;
+}
+
+// Service
+
+enum MethodType {
+    UNDEFINED  = 0;
+    REQUEST    = 1;
+    ONEWAY     = 2;
+    CHANNEL    = 3;
+    SUBSERVICE = 4;
+}
+
+message Service {
+    sub     bool     1;
+    methods []Method 2;
+}
+
+message Method {
+    name        string     1;
+    type        MethodType 2;
+    request     Type       3;
+    response    Type       4;
+    subservice  Type       5;
+    channel_in  Type       6;
+    channel_out Type       7;
+}
+
+// Type
+
+enum Kind {
+    UNDEFINED = 0;
+    ANY       = 1;
+
+    BOOL = 2;
+    BYTE = 3;
+
+    INT16 = 4;
+    INT32 = 5;
+    INT64 = 6;
+
+    UINT16 = 7;
+    UINT32 = 8;
+    UINT64 = 9;
+
+    BIN64  = 10;
+    BIN128 = 11;
+    BIN256 = 12;
+
+    FLOAT32 = 13;
+    FLOAT64 = 14;
+
+    BYTES       = 15;
+    STRING      = 16;
+    ANY_MESSAGE = 17;
+
+    LIST = 18;
+
+    ENUM    = 19;
+    MESSAGE = 20;
+    STRUCT  = 21;
+
+    SERVICE = 22;
+}
+
+message Type {
+    kind    Kind   1;
+    name    string 2;
+    import  string 3; // Import name, "pkg" in "pkg.Type"
+    package string 4; // Referenced definition package id
+    element Type   5; // List element type
+}
@@ -0,0 +1,1879 @@
+package pplugin
+
+import (
+	"github.com/basecomplextech/baselibrary/alloc"
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/ref"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
+)
+
+var (
+	_ alloc.Buffer
+	_ async.Context
+	_ bin.Bin128
+	_ buffer.Buffer
+	_ spec.MessageTable
+	_ pools.Pool[any]
+	_ ref.Ref
+	_ spec.Type
+	_ status.Status
+)
+
+// Request
+
+type Request struct {
+	msg spec.Message
+}
+
+func NewRequest(msg spec.Message) Request {
+	return Request{msg}
+}
+
+func OpenRequest(b []byte) Request {
+	msg := spec.OpenMessage(b)
+	return Request{msg}
+}
+
+func OpenRequestErr(b []byte) (_ Request, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Request{msg}, err
+}
+
+func ParseRequest(b []byte) (_ Request, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Request{msg}, size, err
+}
+
+func ParseRequestWithOptions(b []byte, opts spec.ParseOptions) (_ Request, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Request{msg}, size, err
+}
+
+func (m Request) Package() Package { return NewPackage(m.msg.Message(1)) }
+func (m Request) Imports() spec.MessageList[Package] {
+	return spec.NewMessageList(m.msg.List(2), OpenPackageErr)
+}
+func (m Request) Parameter() spec.String { return m.msg.String(3) }
+
+func (m Request) HasPackage() bool   { return m.msg.HasField(1) }
+func (m Request) HasImports() bool   { return m.msg.HasField(2) }
+func (m Request) HasParameter() bool { return m.msg.HasField(3) }
+
+func (m Request) Clone() Request                        { return Request{m.msg.Clone()} }
+func (m Request) CloneToArena(a alloc.Arena) Request    { return Request{m.msg.CloneToArena(a)} }
+func (m Request) CloneToBuffer(b buffer.Buffer) Request { return Request{m.msg.CloneToBuffer(b)} }
+
+func (m Request) IsEmpty() bool        { return m.msg.Empty() }
+func (m Request) Unwrap() spec.Message { return m.msg }
+
+// Response
+
+type Response struct {
+	msg spec.Message
+}
+
+func NewResponse(msg spec.Message) Response {
+	return Response{msg}
+}
+
+func OpenResponse(b []byte) Response {
+	msg := spec.OpenMessage(b)
+	return Response{msg}
+}
+
+func OpenResponseErr(b []byte) (_ Response, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Response{msg}, err
+}
+
+func ParseResponse(b []byte) (_ Response, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Response{msg}, size, err
+}
+
+func ParseResponseWithOptions(b []byte, opts spec.ParseOptions) (_ Response, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Response{msg}, size, err
+}
+
+func (m Response) Files() spec.MessageList[OutputFile] {
+	return spec.NewMessageList(m.msg.List(1), OpenOutputFileErr)
+}
+func (m Response) Error() spec.String { return m.msg.String(2) }
+
+func (m Response) HasFiles() bool { return m.msg.HasField(1) }
+func (m Response) HasError() bool { return m.msg.HasField(2) }
+
+func (m Response) Clone() Response                        { return Response{m.msg.Clone()} }
+func (m Response) CloneToArena(a alloc.Arena) Response    { return Response{m.msg.CloneToArena(a)} }
+func (m Response) CloneToBuffer(b buffer.Buffer) Response { return Response{m.msg.CloneToBuffer(b)} }
+
+func (m Response) IsEmpty() bool        { return m.msg.Empty() }
+func (m Response) Unwrap() spec.Message { return m.msg }
+
+// OutputFile
+
+type OutputFile struct {
+	msg spec.Message
+}
+
+func NewOutputFile(msg spec.Message) OutputFile {
+	return OutputFile{msg}
+}
+
+func OpenOutputFile(b []byte) OutputFile {
+	msg := spec.OpenMessage(b)
+	return OutputFile{msg}
+}
+
+func OpenOutputFileErr(b []byte) (_ OutputFile, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return OutputFile{msg}, err
+}
+
+func ParseOutputFile(b []byte) (_ OutputFile, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return OutputFile{msg}, size, err
+}
+
+func ParseOutputFileWithOptions(b []byte, opts spec.ParseOptions) (_ OutputFile, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return OutputFile{msg}, size, err
+}
+
+func (m OutputFile) Name() spec.String   { return m.msg.String(1) }
+func (m OutputFile) Content() spec.Bytes { return m.msg.Bytes(2) }
+
+func (m OutputFile) HasName() bool    { return m.msg.HasField(1) }
+func (m OutputFile) HasContent() bool { return m.msg.HasField(2) }
+
+func (m OutputFile) Clone() OutputFile                     { return OutputFile{m.msg.Clone()} }
+func (m OutputFile) CloneToArena(a alloc.Arena) OutputFile { return OutputFile{m.msg.CloneToArena(a)} }
+func (m OutputFile) CloneToBuffer(b buffer.Buffer) OutputFile {
+	return OutputFile{m.msg.CloneToBuffer(b)}
+}
+
+func (m OutputFile) IsEmpty() bool        { return m.msg.Empty() }
+func (m OutputFile) Unwrap() spec.Message { return m.msg }
+
+// Package
+
+type Package struct {
+	msg spec.Message
+}
+
+func NewPackage(msg spec.Message) Package {
+	return Package{msg}
+}
+
+func OpenPackage(b []byte) Package {
+	msg := spec.OpenMessage(b)
+	return Package{msg}
+}
+
+func OpenPackageErr(b []byte) (_ Package, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Package{msg}, err
+}
+
+func ParsePackage(b []byte) (_ Package, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Package{msg}, size, err
+}
+
+func ParsePackageWithOptions(b []byte, opts spec.ParseOptions) (_ Package, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Package{msg}, size, err
+}
+
+func (m Package) Id() spec.String   { return m.msg.String(1) }
+func (m Package) Name() spec.String { return m.msg.String(2) }
+func (m Package) Path() spec.String { return m.msg.String(3) }
+func (m Package) Files() spec.MessageList[File] {
+	return spec.NewMessageList(m.msg.List(4), OpenFileErr)
+}
+func (m Package) Options() spec.MessageList[Option] {
+	return spec.NewMessageList(m.msg.List(5), OpenOptionErr)
+}
+func (m Package) Definitions() spec.MessageList[Definition] {
+	return spec.NewMessageList(m.msg.List(6), OpenDefinitionErr)
+}
+
+func (m Package) HasId() bool          { return m.msg.HasField(1) }
+func (m Package) HasName() bool        { return m.msg.HasField(2) }
+func (m Package) HasPath() bool        { return m.msg.HasField(3) }
+func (m Package) HasFiles() bool       { return m.msg.HasField(4) }
+func (m Package) HasOptions() bool     { return m.msg.HasField(5) }
+func (m Package) HasDefinitions() bool { return m.msg.HasField(6) }
+
+func (m Package) Clone() Package                        { return Package{m.msg.Clone()} }
+func (m Package) CloneToArena(a alloc.Arena) Package    { return Package{m.msg.CloneToArena(a)} }
+func (m Package) CloneToBuffer(b buffer.Buffer) Package { return Package{m.msg.CloneToBuffer(b)} }
+
+func (m Package) IsEmpty() bool        { return m.msg.Empty() }
+func (m Package) Unwrap() spec.Message { return m.msg }
+
+// File
+
+type File struct {
+	msg spec.Message
+}
+
+func NewFile(msg spec.Message) File {
+	return File{msg}
+}
+
+func OpenFile(b []byte) File {
+	msg := spec.OpenMessage(b)
+	return File{msg}
+}
+
+func OpenFileErr(b []byte) (_ File, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return File{msg}, err
+}
+
+func ParseFile(b []byte) (_ File, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return File{msg}, size, err
+}
+
+func ParseFileWithOptions(b []byte, opts spec.ParseOptions) (_ File, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return File{msg}, size, err
+}
+
+func (m File) Name() spec.String { return m.msg.String(1) }
+func (m File) Path() spec.String { return m.msg.String(2) }
+func (m File) Imports() spec.MessageList[Import] {
+	return spec.NewMessageList(m.msg.List(3), OpenImportErr)
+}
+func (m File) Options() spec.MessageList[Option] {
+	return spec.NewMessageList(m.msg.List(4), OpenOptionErr)
+}
+
+func (m File) HasName() bool    { return m.msg.HasField(1) }
+func (m File) HasPath() bool    { return m.msg.HasField(2) }
+func (m File) HasImports() bool { return m.msg.HasField(3) }
+func (m File) HasOptions() bool { return m.msg.HasField(4) }
+
+func (m File) Clone() File                        { return File{m.msg.Clone()} }
+func (m File) CloneToArena(a alloc.Arena) File    { return File{m.msg.CloneToArena(a)} }
+func (m File) CloneToBuffer(b buffer.Buffer) File { return File{m.msg.CloneToBuffer(b)} }
+
+func (m File) IsEmpty() bool        { return m.msg.Empty() }
+func (m File) Unwrap() spec.Message { return m.msg }
+
+// Import
+
+type Import struct {
+	msg spec.Message
+}
+
+func NewImport(msg spec.Message) Import {
+	return Import{msg}
+}
+
+func OpenImport(b []byte) Import {
+	msg := spec.OpenMessage(b)
+	return Import{msg}
+}
+
+func OpenImportErr(b []byte) (_ Import, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Import{msg}, err
+}
+
+func ParseImport(b []byte) (_ Import, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Import{msg}, size, err
+}
+
+func ParseImportWithOptions(b []byte, opts spec.ParseOptions) (_ Import, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Import{msg}, size, err
+}
+
+func (m Import) Id() spec.String   { return m.msg.String(1) }
+func (m Import) Name() spec.String { return m.msg.String(2) }
+
+func (m Import) HasId() bool   { return m.msg.HasField(1) }
+func (m Import) HasName() bool { return m.msg.HasField(2) }
+
+func (m Import) Clone() Import                        { return Import{m.msg.Clone()} }
+func (m Import) CloneToArena(a alloc.Arena) Import    { return Import{m.msg.CloneToArena(a)} }
+func (m Import) CloneToBuffer(b buffer.Buffer) Import { return Import{m.msg.CloneToBuffer(b)} }
+
+func (m Import) IsEmpty() bool        { return m.msg.Empty() }
+func (m Import) Unwrap() spec.Message { return m.msg }
+
+// Option
+
+type Option struct {
+	msg spec.Message
+}
+
+func NewOption(msg spec.Message) Option {
+	return Option{msg}
+}
+
+func OpenOption(b []byte) Option {
+	msg := spec.OpenMessage(b)
+	return Option{msg}
+}
+
+func OpenOptionErr(b []byte) (_ Option, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Option{msg}, err
+}
+
+func ParseOption(b []byte) (_ Option, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Option{msg}, size, err
+}
+
+func ParseOptionWithOptions(b []byte, opts spec.ParseOptions) (_ Option, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Option{msg}, size, err
+}
+
+func (m Option) Name() spec.String  { return m.msg.String(1) }
+func (m Option) Value() spec.String { return m.msg.String(2) }
+
+func (m Option) HasName() bool  { return m.msg.HasField(1) }
+func (m Option) HasValue() bool { return m.msg.HasField(2) }
+
+func (m Option) Clone() Option                        { return Option{m.msg.Clone()} }
+func (m Option) CloneToArena(a alloc.Arena) Option    { return Option{m.msg.CloneToArena(a)} }
+func (m Option) CloneToBuffer(b buffer.Buffer) Option { return Option{m.msg.CloneToBuffer(b)} }
+
+func (m Option) IsEmpty() bool        { return m.msg.Empty() }
+func (m Option) Unwrap() spec.Message { return m.msg }
+
+// DefinitionType
+
+type DefinitionType int32
+
+const (
+	DefinitionType_Undefined DefinitionType = 0
+	DefinitionType_Enum      DefinitionType = 1
+	DefinitionType_Message   DefinitionType = 2
+	DefinitionType_Struct    DefinitionType = 3
+	DefinitionType_Service   DefinitionType = 4
+)
+
+func OpenDefinitionType(b []byte) DefinitionType {
+	v, _, _ := spec.DecodeInt32(b)
+	return DefinitionType(v)
+}
+
+func DecodeDefinitionType(b []byte) (result DefinitionType, size int, err error) {
+	v, size, err := spec.DecodeInt32(b)
+	if err != nil || size == 0 {
+		return
+	}
+	result = DefinitionType(v)
+	return
+}
+
+func EncodeDefinitionTypeTo(b buffer.Buffer, v DefinitionType) (int, error) {
+	return spec.EncodeInt32(b, int32(v))
+}
+
+func (e DefinitionType) String() string {
+	switch e {
+	case DefinitionType_Undefined:
+		return "undefined"
+	case DefinitionType_Enum:
+		return "enum"
+	case DefinitionType_Message:
+		return "message"
+	case DefinitionType_Struct:
+		return "struct"
+	case DefinitionType_Service:
+		return "service"
+	}
+	return ""
+}
+
+// Definition
+
+type Definition struct {
+	msg spec.Message
+}
+
+func NewDefinition(msg spec.Message) Definition {
+	return Definition{msg}
+}
+
+func OpenDefinition(b []byte) Definition {
+	msg := spec.OpenMessage(b)
+	return Definition{msg}
+}
+
+func OpenDefinitionErr(b []byte) (_ Definition, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Definition{msg}, err
+}
+
+func ParseDefinition(b []byte) (_ Definition, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Definition{msg}, size, err
+}
+
+func ParseDefinitionWithOptions(b []byte, opts spec.ParseOptions) (_ Definition, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Definition{msg}, size, err
+}
+
+func (m Definition) Name() spec.String    { return m.msg.String(1) }
+func (m Definition) Type() DefinitionType { return OpenDefinitionType(m.msg.FieldRaw(2)) }
+func (m Definition) File() spec.String    { return m.msg.String(3) }
+func (m Definition) Enum() Enum           { return NewEnum(m.msg.Message(10)) }
+func (m Definition) Message() Message     { return NewMessage(m.msg.Message(11)) }
+func (m Definition) Struct() Struct       { return NewStruct(m.msg.Message(12)) }
+func (m Definition) Service() Service     { return NewService(m.msg.Message(13)) }
+
+func (m Definition) HasName() bool    { return m.msg.HasField(1) }
+func (m Definition) HasType() bool    { return m.msg.HasField(2) }
+func (m Definition) HasFile() bool    { return m.msg.HasField(3) }
+func (m Definition) HasEnum() bool    { return m.msg.HasField(10) }
+func (m Definition) HasMessage() bool { return m.msg.HasField(11) }
+func (m Definition) HasStruct() bool  { return m.msg.HasField(12) }
+func (m Definition) HasService() bool { return m.msg.HasField(13) }
+
+func (m Definition) Clone() Definition                     { return Definition{m.msg.Clone()} }
+func (m Definition) CloneToArena(a alloc.Arena) Definition { return Definition{m.msg.CloneToArena(a)} }
+func (m Definition) CloneToBuffer(b buffer.Buffer) Definition {
+	return Definition{m.msg.CloneToBuffer(b)}
+}
+
+func (m Definition) IsEmpty() bool        { return m.msg.Empty() }
+func (m Definition) Unwrap() spec.Message { return m.msg }
+
+// Enum
+
+type Enum struct {
+	msg spec.Message
+}
+
+func NewEnum(msg spec.Message) Enum {
+	return Enum{msg}
+}
+
+func OpenEnum(b []byte) Enum {
+	msg := spec.OpenMessage(b)
+	return Enum{msg}
+}
+
+func OpenEnumErr(b []byte) (_ Enum, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Enum{msg}, err
+}
+
+func ParseEnum(b []byte) (_ Enum, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Enum{msg}, size, err
+}
+
+func ParseEnumWithOptions(b []byte, opts spec.ParseOptions) (_ Enum, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Enum{msg}, size, err
+}
+
+func (m Enum) Values() spec.MessageList[EnumValue] {
+	return spec.NewMessageList(m.msg.List(1), OpenEnumValueErr)
+}
+func (m Enum) HasValues() bool                    { return m.msg.HasField(1) }
+func (m Enum) Clone() Enum                        { return Enum{m.msg.Clone()} }
+func (m Enum) CloneToArena(a alloc.Arena) Enum    { return Enum{m.msg.CloneToArena(a)} }
+func (m Enum) CloneToBuffer(b buffer.Buffer) Enum { return Enum{m.msg.CloneToBuffer(b)} }
+
+func (m Enum) IsEmpty() bool        { return m.msg.Empty() }
+func (m Enum) Unwrap() spec.Message { return m.msg }
+
+// EnumValue
+
+type EnumValue struct {
+	msg spec.Message
+}
+
+func NewEnumValue(msg spec.Message) EnumValue {
+	return EnumValue{msg}
+}
+
+func OpenEnumValue(b []byte) EnumValue {
+	msg := spec.OpenMessage(b)
+	return EnumValue{msg}
+}
+
+func OpenEnumValueErr(b []byte) (_ EnumValue, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return EnumValue{msg}, err
+}
+
+func ParseEnumValue(b []byte) (_ EnumValue, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return EnumValue{msg}, size, err
+}
+
+func ParseEnumValueWithOptions(b []byte, opts spec.ParseOptions) (_ EnumValue, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return EnumValue{msg}, size, err
+}
+
+func (m EnumValue) Name() spec.String { return m.msg.String(1) }
+func (m EnumValue) Number() int32     { return m.msg.Int32(2) }
+
+func (m EnumValue) HasName() bool   { return m.msg.HasField(1) }
+func (m EnumValue) HasNumber() bool { return m.msg.HasField(2) }
+
+func (m EnumValue) Clone() EnumValue                        { return EnumValue{m.msg.Clone()} }
+func (m EnumValue) CloneToArena(a alloc.Arena) EnumValue    { return EnumValue{m.msg.CloneToArena(a)} }
+func (m EnumValue) CloneToBuffer(b buffer.Buffer) EnumValue { return EnumValue{m.msg.CloneToBuffer(b)} }
+
+func (m EnumValue) IsEmpty() bool        { return m.msg.Empty() }
+func (m EnumValue) Unwrap() spec.Message { return m.msg }
+
+// Message
+
+type Message struct {
+	msg spec.Message
+}
+
+func NewMessage(msg spec.Message) Message {
+	return Message{msg}
+}
+
+func OpenMessage(b []byte) Message {
+	msg := spec.OpenMessage(b)
+	return Message{msg}
+}
+
+func OpenMessageErr(b []byte) (_ Message, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Message{msg}, err
+}
+
+func ParseMessage(b []byte) (_ Message, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Message{msg}, size, err
+}
+
+func ParseMessageWithOptions(b []byte, opts spec.ParseOptions) (_ Message, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Message{msg}, size, err
+}
+
+func (m Message) Fields() spec.MessageList[Field] {
+	return spec.NewMessageList(m.msg.List(1), OpenFieldErr)
+}
+func (m Message) Generated() bool { return m.msg.Bool(2) }
+
+func (m Message) HasFields() bool    { return m.msg.HasField(1) }
+func (m Message) HasGenerated() bool { return m.msg.HasField(2) }
+
+func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
+func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
+func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
+
+// Field
+
+type Field struct {
+	msg spec.Message
+}
+
+func NewField(msg spec.Message) Field {
+	return Field{msg}
+}
+
+func OpenField(b []byte) Field {
+	msg := spec.OpenMessage(b)
+	return Field{msg}
+}
+
+func OpenFieldErr(b []byte) (_ Field, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Field{msg}, err
+}
+
+func ParseField(b []byte) (_ Field, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Field{msg}, size, err
+}
+
+func ParseFieldWithOptions(b []byte, opts spec.ParseOptions) (_ Field, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Field{msg}, size, err
+}
+
+func (m Field) Name() spec.String { return m.msg.String(1) }
+func (m Field) Tag() int32        { return m.msg.Int32(2) }
+func (m Field) Type() Type        { return NewType(m.msg.Message(3)) }
+
+func (m Field) HasName() bool { return m.msg.HasField(1) }
+func (m Field) HasTag() bool  { return m.msg.HasField(2) }
+func (m Field) HasType() bool { return m.msg.HasField(3) }
+
+func (m Field) Clone() Field                        { return Field{m.msg.Clone()} }
+func (m Field) CloneToArena(a alloc.Arena) Field    { return Field{m.msg.CloneToArena(a)} }
+func (m Field) CloneToBuffer(b buffer.Buffer) Field { return Field{m.msg.CloneToBuffer(b)} }
+
+func (m Field) IsEmpty() bool        { return m.msg.Empty() }
+func (m Field) Unwrap() spec.Message { return m.msg }
+
+// Struct
+
+type Struct struct {
+	msg spec.Message
+}
+
+func NewStruct(msg spec.Message) Struct {
+	return Struct{msg}
+}
+
+func OpenStruct(b []byte) Struct {
+	msg := spec.OpenMessage(b)
+	return Struct{msg}
+}
+
+func OpenStructErr(b []byte) (_ Struct, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Struct{msg}, err
+}
+
+func ParseStruct(b []byte) (_ Struct, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Struct{msg}, size, err
+}
+
+func ParseStructWithOptions(b []byte, opts spec.ParseOptions) (_ Struct, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Struct{msg}, size, err
+}
+
+func (m Struct) Fields() spec.MessageList[StructField] {
+	return spec.NewMessageList(m.msg.List(1), OpenStructFieldErr)
+}
+func (m Struct) Key() bool { return m.msg.Bool(2) }
+
+func (m Struct) HasFields() bool { return m.msg.HasField(1) }
+func (m Struct) HasKey() bool    { return m.msg.HasField(2) }
+
+func (m Struct) Clone() Struct                        { return Struct{m.msg.Clone()} }
+func (m Struct) CloneToArena(a alloc.Arena) Struct    { return Struct{m.msg.CloneToArena(a)} }
+func (m Struct) CloneToBuffer(b buffer.Buffer) Struct { return Struct{m.msg.CloneToBuffer(b)} }
+
+func (m Struct) IsEmpty() bool        { return m.msg.Empty() }
+func (m Struct) Unwrap() spec.Message { return m.msg }
+
+// StructField
+
+type StructField struct {
+	msg spec.Message
+}
+
+func NewStructField(msg spec.Message) StructField {
+	return StructField{msg}
+}
+
+func OpenStructField(b []byte) StructField {
+	msg := spec.OpenMessage(b)
+	return StructField{msg}
+}
+
+func OpenStructFieldErr(b []byte) (_ StructField, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return StructField{msg}, err
+}
+
+func ParseStructField(b []byte) (_ StructField, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return StructField{msg}, size, err
+}
+
+func ParseStructFieldWithOptions(b []byte, opts spec.ParseOptions) (_ StructField, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return StructField{msg}, size, err
+}
+
+func (m StructField) Name() spec.String { return m.msg.String(1) }
+func (m StructField) Type() Type        { return NewType(m.msg.Message(2)) }
+
+func (m StructField) HasName() bool { return m.msg.HasField(1) }
+func (m StructField) HasType() bool { return m.msg.HasField(2) }
+
+func (m StructField) Clone() StructField { return StructField{m.msg.Clone()} }
+func (m StructField) CloneToArena(a alloc.Arena) StructField {
+	return StructField{m.msg.CloneToArena(a)}
+}
+func (m StructField) CloneToBuffer(b buffer.Buffer) StructField {
+	return StructField{m.msg.CloneToBuffer(b)}
+}
+
+func (m StructField) IsEmpty() bool        { return m.msg.Empty() }
+func (m StructField) Unwrap() spec.Message { return m.msg }
+
+// MethodType
+
+type MethodType int32
+
+const (
+	MethodType_Undefined  MethodType = 0
+	MethodType_Request    MethodType = 1
+	MethodType_Oneway     MethodType = 2
+	MethodType_Channel    MethodType = 3
+	MethodType_Subservice MethodType = 4
+)
+
+func OpenMethodType(b []byte) MethodType {
+	v, _, _ := spec.DecodeInt32(b)
+	return MethodType(v)
+}
+
+func DecodeMethodType(b []byte) (result MethodType, size int, err error) {
+	v, size, err := spec.DecodeInt32(b)
+	if err != nil || size == 0 {
+		return
+	}
+	result = MethodType(v)
+	return
+}
+
+func EncodeMethodTypeTo(b buffer.Buffer, v MethodType) (int, error) {
+	return spec.EncodeInt32(b, int32(v))
+}
+
+func (e MethodType) String() string {
+	switch e {
+	case MethodType_Undefined:
+		return "undefined"
+	case MethodType_Request:
+		return "request"
+	case MethodType_Oneway:
+		return "oneway"
+	case MethodType_Channel:
+		return "channel"
+	case MethodType_Subservice:
+		return "subservice"
+	}
+	return ""
+}
+
+// Service
+
+type Service struct {
+	msg spec.Message
+}
+
+func NewService(msg spec.Message) Service {
+	return Service{msg}
+}
+
+func OpenService(b []byte) Service {
+	msg := spec.OpenMessage(b)
+	return Service{msg}
+}
+
+func OpenServiceErr(b []byte) (_ Service, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Service{msg}, err
+}
+
+func ParseService(b []byte) (_ Service, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Service{msg}, size, err
+}
+
+func ParseServiceWithOptions(b []byte, opts spec.ParseOptions) (_ Service, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Service{msg}, size, err
+}
+
+func (m Service) Sub() bool { return m.msg.Bool(1) }
+func (m Service) Methods() spec.MessageList[Method] {
+	return spec.NewMessageList(m.msg.List(2), OpenMethodErr)
+}
+
+func (m Service) HasSub() bool     { return m.msg.HasField(1) }
+func (m Service) HasMethods() bool { return m.msg.HasField(2) }
+
+func (m Service) Clone() Service                        { return Service{m.msg.Clone()} }
+func (m Service) CloneToArena(a alloc.Arena) Service    { return Service{m.msg.CloneToArena(a)} }
+func (m Service) CloneToBuffer(b buffer.Buffer) Service { return Service{m.msg.CloneToBuffer(b)} }
+
+func (m Service) IsEmpty() bool        { return m.msg.Empty() }
+func (m Service) Unwrap() spec.Message { return m.msg }
+
+// Method
+
+type Method struct {
+	msg spec.Message
+}
+
+func NewMethod(msg spec.Message) Method {
+	return Method{msg}
+}
+
+func OpenMethod(b []byte) Method {
+	msg := spec.OpenMessage(b)
+	return Method{msg}
+}
+
+func OpenMethodErr(b []byte) (_ Method, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Method{msg}, err
+}
+
+func ParseMethod(b []byte) (_ Method, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Method{msg}, size, err
+}
+
+func ParseMethodWithOptions(b []byte, opts spec.ParseOptions) (_ Method, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Method{msg}, size, err
+}
+
+func (m Method) Name() spec.String { return m.msg.String(1) }
+func (m Method) Type() MethodType  { return OpenMethodType(m.msg.FieldRaw(2)) }
+func (m Method) Request() Type     { return NewType(m.msg.Message(3)) }
+func (m Method) Response() Type    { return NewType(m.msg.Message(4)) }
+func (m Method) Subservice() Type  { return NewType(m.msg.Message(5)) }
+func (m Method) ChannelIn() Type   { return NewType(m.msg.Message(6)) }
+func (m Method) ChannelOut() Type  { return NewType(m.msg.Message(7)) }
+
+func (m Method) HasName() bool       { return m.msg.HasField(1) }
+func (m Method) HasType() bool       { return m.msg.HasField(2) }
+func (m Method) HasRequest() bool    { return m.msg.HasField(3) }
+func (m Method) HasResponse() bool   { return m.msg.HasField(4) }
+func (m Method) HasSubservice() bool { return m.msg.HasField(5) }
+func (m Method) HasChannelIn() bool  { return m.msg.HasField(6) }
+func (m Method) HasChannelOut() bool { return m.msg.HasField(7) }
+
+func (m Method) Clone() Method                        { return Method{m.msg.Clone()} }
+func (m Method) CloneToArena(a alloc.Arena) Method    { return Method{m.msg.CloneToArena(a)} }
+func (m Method) CloneToBuffer(b buffer.Buffer) Method { return Method{m.msg.CloneToBuffer(b)} }
+
+func (m Method) IsEmpty() bool        { return m.msg.Empty() }
+func (m Method) Unwrap() spec.Message { return m.msg }
+
+// Kind
+
+type Kind int32
+
+const (
+	Kind_Undefined  Kind = 0
+	Kind_Any        Kind = 1
+	Kind_Bool       Kind = 2
+	Kind_Byte       Kind = 3
+	Kind_Int16      Kind = 4
+	Kind_Int32      Kind = 5
+	Kind_Int64      Kind = 6
+	Kind_Uint16     Kind = 7
+	Kind_Uint32     Kind = 8
+	Kind_Uint64     Kind = 9
+	Kind_Bin64      Kind = 10
+	Kind_Bin128     Kind = 11
+	Kind_Bin256     Kind = 12
+	Kind_Float32    Kind = 13
+	Kind_Float64    Kind = 14
+	Kind_Bytes      Kind = 15
+	Kind_String     Kind = 16
+	Kind_AnyMessage Kind = 17
+	Kind_List       Kind = 18
+	Kind_Enum       Kind = 19
+	Kind_Message    Kind = 20
+	Kind_Struct     Kind = 21
+	Kind_Service    Kind = 22
+)
+
+func OpenKind(b []byte) Kind {
+	v, _, _ := spec.DecodeInt32(b)
+	return Kind(v)
+}
+
+func DecodeKind(b []byte) (result Kind, size int, err error) {
+	v, size, err := spec.DecodeInt32(b)
+	if err != nil || size == 0 {
+		return
+	}
+	result = Kind(v)
+	return
+}
+
+func EncodeKindTo(b buffer.Buffer, v Kind) (int, error) {
+	return spec.EncodeInt32(b, int32(v))
+}
+
+func (e Kind) String() string {
+	switch e {
+	case Kind_Undefined:
+		return "undefined"
+	case Kind_Any:
+		return "any"
+	case Kind_Bool:
+		return "bool"
+	case Kind_Byte:
+		return "byte"
+	case Kind_Int16:
+		return "int16"
+	case Kind_Int32:
+		return "int32"
+	case Kind_Int64:
+		return "int64"
+	case Kind_Uint16:
+		return "uint16"
+	case Kind_Uint32:
+		return "uint32"
+	case Kind_Uint64:
+		return "uint64"
+	case Kind_Bin64:
+		return "bin64"
+	case Kind_Bin128:
+		return "bin128"
+	case Kind_Bin256:
+		return "bin256"
+	case Kind_Float32:
+		return "float32"
+	case Kind_Float64:
+		return "float64"
+	case Kind_Bytes:
+		return "bytes"
+	case Kind_String:
+		return "string"
+	case Kind_AnyMessage:
+		return "any_message"
+	case Kind_List:
+		return "list"
+	case Kind_Enum:
+		return "enum"
+	case Kind_Message:
+		return "message"
+	case Kind_Struct:
+		return "struct"
+	case Kind_Service:
+		return "service"
+	}
+	return ""
+}
+
+// Type
+
+type Type struct {
+	msg spec.Message
+}
+
+func NewType(msg spec.Message) Type {
+	return Type{msg}
+}
+
+func OpenType(b []byte) Type {
+	msg := spec.OpenMessage(b)
+	return Type{msg}
+}
+
+func OpenTypeErr(b []byte) (_ Type, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Type{msg}, err
+}
+
+func ParseType(b []byte) (_ Type, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	return Type{msg}, size, err
+}
+
+func ParseTypeWithOptions(b []byte, opts spec.ParseOptions) (_ Type, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Type{msg}, size, err
+}
+
+func (m Type) Kind() Kind           { return OpenKind(m.msg.FieldRaw(1)) }
+func (m Type) Name() spec.String    { return m.msg.String(2) }
+func (m Type) Import() spec.String  { return m.msg.String(3) }
+func (m Type) Package() spec.String { return m.msg.String(4) }
+func (m Type) Element() Type        { return NewType(m.msg.Message(5)) }
+
+func (m Type) HasKind() bool    { return m.msg.HasField(1) }
+func (m Type) HasName() bool    { return m.msg.HasField(2) }
+func (m Type) HasImport() bool  { return m.msg.HasField(3) }
+func (m Type) HasPackage() bool { return m.msg.HasField(4) }
+func (m Type) HasElement() bool { return m.msg.HasField(5) }
+
+func (m Type) Clone() Type                        { return Type{m.msg.Clone()} }
+func (m Type) CloneToArena(a alloc.Arena) Type    { return Type{m.msg.CloneToArena(a)} }
+func (m Type) CloneToBuffer(b buffer.Buffer) Type { return Type{m.msg.CloneToBuffer(b)} }
+
+func (m Type) IsEmpty() bool        { return m.msg.Empty() }
+func (m Type) Unwrap() spec.Message { return m.msg }
+
+// RequestWriter
+
+type RequestWriter struct {
+	w spec.MessageWriter
+}
+
+func NewRequestWriter() RequestWriter {
+	w := spec.NewMessageWriter()
+	return RequestWriter{w}
+}
+
+func NewRequestWriterBuffer(b buffer.Buffer) RequestWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return RequestWriter{w}
+}
+
+func NewRequestWriterTo(w spec.MessageWriter) RequestWriter {
+	return RequestWriter{w}
+}
+
+func (w RequestWriter) Package() PackageWriter {
+	w1 := w.w.Field(1).Message()
+	return NewPackageWriterTo(w1)
+}
+func (w RequestWriter) CopyPackage(v Package) error {
+	return w.w.Field(1).Any(v.Unwrap().Raw())
+}
+func (w RequestWriter) Imports() spec.MessageListWriter[PackageWriter] {
+	w1 := w.w.Field(2).List()
+	return spec.NewMessageListWriter(w1, NewPackageWriterTo)
+}
+func (w RequestWriter) Parameter(v string) { w.w.Field(3).String(v) }
+
+func (w RequestWriter) Merge(msg Request) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w RequestWriter) End() error {
+	return w.w.End()
+}
+
+func (w RequestWriter) Build() (_ Request, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenRequestErr(bytes)
+}
+
+func (w RequestWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ResponseWriter
+
+type ResponseWriter struct {
+	w spec.MessageWriter
+}
+
+func NewResponseWriter() ResponseWriter {
+	w := spec.NewMessageWriter()
+	return ResponseWriter{w}
+}
+
+func NewResponseWriterBuffer(b buffer.Buffer) ResponseWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ResponseWriter{w}
+}
+
+func NewResponseWriterTo(w spec.MessageWriter) ResponseWriter {
+	return ResponseWriter{w}
+}
+
+func (w ResponseWriter) Files() spec.MessageListWriter[OutputFileWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewOutputFileWriterTo)
+}
+func (w ResponseWriter) Error(v string) { w.w.Field(2).String(v) }
+
+func (w ResponseWriter) Merge(msg Response) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ResponseWriter) End() error {
+	return w.w.End()
+}
+
+func (w ResponseWriter) Build() (_ Response, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenResponseErr(bytes)
+}
+
+func (w ResponseWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// OutputFileWriter
+
+type OutputFileWriter struct {
+	w spec.MessageWriter
+}
+
+func NewOutputFileWriter() OutputFileWriter {
+	w := spec.NewMessageWriter()
+	return OutputFileWriter{w}
+}
+
+func NewOutputFileWriterBuffer(b buffer.Buffer) OutputFileWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return OutputFileWriter{w}
+}
+
+func NewOutputFileWriterTo(w spec.MessageWriter) OutputFileWriter {
+	return OutputFileWriter{w}
+}
+
+func (w OutputFileWriter) Name(v string)    { w.w.Field(1).String(v) }
+func (w OutputFileWriter) Content(v []byte) { w.w.Field(2).Bytes(v) }
+
+func (w OutputFileWriter) Merge(msg OutputFile) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w OutputFileWriter) End() error {
+	return w.w.End()
+}
+
+func (w OutputFileWriter) Build() (_ OutputFile, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenOutputFileErr(bytes)
+}
+
+func (w OutputFileWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// PackageWriter
+
+type PackageWriter struct {
+	w spec.MessageWriter
+}
+
+func NewPackageWriter() PackageWriter {
+	w := spec.NewMessageWriter()
+	return PackageWriter{w}
+}
+
+func NewPackageWriterBuffer(b buffer.Buffer) PackageWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return PackageWriter{w}
+}
+
+func NewPackageWriterTo(w spec.MessageWriter) PackageWriter {
+	return PackageWriter{w}
+}
+
+func (w PackageWriter) Id(v string)   { w.w.Field(1).String(v) }
+func (w PackageWriter) Name(v string) { w.w.Field(2).String(v) }
+func (w PackageWriter) Path(v string) { w.w.Field(3).String(v) }
+func (w PackageWriter) Files() spec.MessageListWriter[FileWriter] {
+	w1 := w.w.Field(4).List()
+	return spec.NewMessageListWriter(w1, NewFileWriterTo)
+}
+func (w PackageWriter) Options() spec.MessageListWriter[OptionWriter] {
+	w1 := w.w.Field(5).List()
+	return spec.NewMessageListWriter(w1, NewOptionWriterTo)
+}
+func (w PackageWriter) Definitions() spec.MessageListWriter[DefinitionWriter] {
+	w1 := w.w.Field(6).List()
+	return spec.NewMessageListWriter(w1, NewDefinitionWriterTo)
+}
+
+func (w PackageWriter) Merge(msg Package) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w PackageWriter) End() error {
+	return w.w.End()
+}
+
+func (w PackageWriter) Build() (_ Package, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenPackageErr(bytes)
+}
+
+func (w PackageWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// FileWriter
+
+type FileWriter struct {
+	w spec.MessageWriter
+}
+
+func NewFileWriter() FileWriter {
+	w := spec.NewMessageWriter()
+	return FileWriter{w}
+}
+
+func NewFileWriterBuffer(b buffer.Buffer) FileWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return FileWriter{w}
+}
+
+func NewFileWriterTo(w spec.MessageWriter) FileWriter {
+	return FileWriter{w}
+}
+
+func (w FileWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w FileWriter) Path(v string) { w.w.Field(2).String(v) }
+func (w FileWriter) Imports() spec.MessageListWriter[ImportWriter] {
+	w1 := w.w.Field(3).List()
+	return spec.NewMessageListWriter(w1, NewImportWriterTo)
+}
+func (w FileWriter) Options() spec.MessageListWriter[OptionWriter] {
+	w1 := w.w.Field(4).List()
+	return spec.NewMessageListWriter(w1, NewOptionWriterTo)
+}
+
+func (w FileWriter) Merge(msg File) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w FileWriter) End() error {
+	return w.w.End()
+}
+
+func (w FileWriter) Build() (_ File, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenFileErr(bytes)
+}
+
+func (w FileWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ImportWriter
+
+type ImportWriter struct {
+	w spec.MessageWriter
+}
+
+func NewImportWriter() ImportWriter {
+	w := spec.NewMessageWriter()
+	return ImportWriter{w}
+}
+
+func NewImportWriterBuffer(b buffer.Buffer) ImportWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ImportWriter{w}
+}
+
+func NewImportWriterTo(w spec.MessageWriter) ImportWriter {
+	return ImportWriter{w}
+}
+
+func (w ImportWriter) Id(v string)   { w.w.Field(1).String(v) }
+func (w ImportWriter) Name(v string) { w.w.Field(2).String(v) }
+
+func (w ImportWriter) Merge(msg Import) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ImportWriter) End() error {
+	return w.w.End()
+}
+
+func (w ImportWriter) Build() (_ Import, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenImportErr(bytes)
+}
+
+func (w ImportWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// OptionWriter
+
+type OptionWriter struct {
+	w spec.MessageWriter
+}
+
+func NewOptionWriter() OptionWriter {
+	w := spec.NewMessageWriter()
+	return OptionWriter{w}
+}
+
+func NewOptionWriterBuffer(b buffer.Buffer) OptionWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return OptionWriter{w}
+}
+
+func NewOptionWriterTo(w spec.MessageWriter) OptionWriter {
+	return OptionWriter{w}
+}
+
+func (w OptionWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w OptionWriter) Value(v string) { w.w.Field(2).String(v) }
+
+func (w OptionWriter) Merge(msg Option) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w OptionWriter) End() error {
+	return w.w.End()
+}
+
+func (w OptionWriter) Build() (_ Option, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenOptionErr(bytes)
+}
+
+func (w OptionWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// DefinitionWriter
+
+type DefinitionWriter struct {
+	w spec.MessageWriter
+}
+
+func NewDefinitionWriter() DefinitionWriter {
+	w := spec.NewMessageWriter()
+	return DefinitionWriter{w}
+}
+
+func NewDefinitionWriterBuffer(b buffer.Buffer) DefinitionWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return DefinitionWriter{w}
+}
+
+func NewDefinitionWriterTo(w spec.MessageWriter) DefinitionWriter {
+	return DefinitionWriter{w}
+}
+
+func (w DefinitionWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w DefinitionWriter) Type(v DefinitionType) {
+	spec.WriteField(w.w.Field(2), v, EncodeDefinitionTypeTo)
+}
+func (w DefinitionWriter) File(v string) { w.w.Field(3).String(v) }
+func (w DefinitionWriter) Enum() EnumWriter {
+	w1 := w.w.Field(10).Message()
+	return NewEnumWriterTo(w1)
+}
+func (w DefinitionWriter) CopyEnum(v Enum) error {
+	return w.w.Field(10).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) Message() MessageWriter {
+	w1 := w.w.Field(11).Message()
+	return NewMessageWriterTo(w1)
+}
+func (w DefinitionWriter) CopyMessage(v Message) error {
+	return w.w.Field(11).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) Struct() StructWriter {
+	w1 := w.w.Field(12).Message()
+	return NewStructWriterTo(w1)
+}
+func (w DefinitionWriter) CopyStruct(v Struct) error {
+	return w.w.Field(12).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) Service() ServiceWriter {
+	w1 := w.w.Field(13).Message()
+	return NewServiceWriterTo(w1)
+}
+func (w DefinitionWriter) CopyService(v Service) error {
+	return w.w.Field(13).Any(v.Unwrap().Raw())
+}
+
+func (w DefinitionWriter) Merge(msg Definition) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w DefinitionWriter) End() error {
+	return w.w.End()
+}
+
+func (w DefinitionWriter) Build() (_ Definition, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenDefinitionErr(bytes)
+}
+
+func (w DefinitionWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// EnumWriter
+
+type EnumWriter struct {
+	w spec.MessageWriter
+}
+
+func NewEnumWriter() EnumWriter {
+	w := spec.NewMessageWriter()
+	return EnumWriter{w}
+}
+
+func NewEnumWriterBuffer(b buffer.Buffer) EnumWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return EnumWriter{w}
+}
+
+func NewEnumWriterTo(w spec.MessageWriter) EnumWriter {
+	return EnumWriter{w}
+}
+
+func (w EnumWriter) Values() spec.MessageListWriter[EnumValueWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewEnumValueWriterTo)
+}
+
+func (w EnumWriter) Merge(msg Enum) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w EnumWriter) End() error {
+	return w.w.End()
+}
+
+func (w EnumWriter) Build() (_ Enum, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenEnumErr(bytes)
+}
+
+func (w EnumWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// EnumValueWriter
+
+type EnumValueWriter struct {
+	w spec.MessageWriter
+}
+
+func NewEnumValueWriter() EnumValueWriter {
+	w := spec.NewMessageWriter()
+	return EnumValueWriter{w}
+}
+
+func NewEnumValueWriterBuffer(b buffer.Buffer) EnumValueWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return EnumValueWriter{w}
+}
+
+func NewEnumValueWriterTo(w spec.MessageWriter) EnumValueWriter {
+	return EnumValueWriter{w}
+}
+
+func (w EnumValueWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w EnumValueWriter) Number(v int32) { w.w.Field(2).Int32(v) }
+
+func (w EnumValueWriter) Merge(msg EnumValue) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w EnumValueWriter) End() error {
+	return w.w.End()
+}
+
+func (w EnumValueWriter) Build() (_ EnumValue, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenEnumValueErr(bytes)
+}
+
+func (w EnumValueWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// MessageWriter
+
+type MessageWriter struct {
+	w spec.MessageWriter
+}
+
+func NewMessageWriter() MessageWriter {
+	w := spec.NewMessageWriter()
+	return MessageWriter{w}
+}
+
+func NewMessageWriterBuffer(b buffer.Buffer) MessageWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return MessageWriter{w}
+}
+
+func NewMessageWriterTo(w spec.MessageWriter) MessageWriter {
+	return MessageWriter{w}
+}
+
+func (w MessageWriter) Fields() spec.MessageListWriter[FieldWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewFieldWriterTo)
+}
+func (w MessageWriter) Generated(v bool) { w.w.Field(2).Bool(v) }
+
+func (w MessageWriter) Merge(msg Message) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w MessageWriter) End() error {
+	return w.w.End()
+}
+
+func (w MessageWriter) Build() (_ Message, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenMessageErr(bytes)
+}
+
+func (w MessageWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// FieldWriter
+
+type FieldWriter struct {
+	w spec.MessageWriter
+}
+
+func NewFieldWriter() FieldWriter {
+	w := spec.NewMessageWriter()
+	return FieldWriter{w}
+}
+
+func NewFieldWriterBuffer(b buffer.Buffer) FieldWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return FieldWriter{w}
+}
+
+func NewFieldWriterTo(w spec.MessageWriter) FieldWriter {
+	return FieldWriter{w}
+}
+
+func (w FieldWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w FieldWriter) Tag(v int32)   { w.w.Field(2).Int32(v) }
+func (w FieldWriter) Type() TypeWriter {
+	w1 := w.w.Field(3).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w FieldWriter) CopyType(v Type) error {
+	return w.w.Field(3).Any(v.Unwrap().Raw())
+}
+
+func (w FieldWriter) Merge(msg Field) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w FieldWriter) End() error {
+	return w.w.End()
+}
+
+func (w FieldWriter) Build() (_ Field, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenFieldErr(bytes)
+}
+
+func (w FieldWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// StructWriter
+
+type StructWriter struct {
+	w spec.MessageWriter
+}
+
+func NewStructWriter() StructWriter {
+	w := spec.NewMessageWriter()
+	return StructWriter{w}
+}
+
+func NewStructWriterBuffer(b buffer.Buffer) StructWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return StructWriter{w}
+}
+
+func NewStructWriterTo(w spec.MessageWriter) StructWriter {
+	return StructWriter{w}
+}
+
+func (w StructWriter) Fields() spec.MessageListWriter[StructFieldWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewStructFieldWriterTo)
+}
+func (w StructWriter) Key(v bool) { w.w.Field(2).Bool(v) }
+
+func (w StructWriter) Merge(msg Struct) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w StructWriter) End() error {
+	return w.w.End()
+}
+
+func (w StructWriter) Build() (_ Struct, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenStructErr(bytes)
+}
+
+func (w StructWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// StructFieldWriter
+
+type StructFieldWriter struct {
+	w spec.MessageWriter
+}
+
+func NewStructFieldWriter() StructFieldWriter {
+	w := spec.NewMessageWriter()
+	return StructFieldWriter{w}
+}
+
+func NewStructFieldWriterBuffer(b buffer.Buffer) StructFieldWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return StructFieldWriter{w}
+}
+
+func NewStructFieldWriterTo(w spec.MessageWriter) StructFieldWriter {
+	return StructFieldWriter{w}
+}
+
+func (w StructFieldWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w StructFieldWriter) Type() TypeWriter {
+	w1 := w.w.Field(2).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w StructFieldWriter) CopyType(v Type) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+
+func (w StructFieldWriter) Merge(msg StructField) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w StructFieldWriter) End() error {
+	return w.w.End()
+}
+
+func (w StructFieldWriter) Build() (_ StructField, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenStructFieldErr(bytes)
+}
+
+func (w StructFieldWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ServiceWriter
+
+type ServiceWriter struct {
+	w spec.MessageWriter
+}
+
+func NewServiceWriter() ServiceWriter {
+	w := spec.NewMessageWriter()
+	return ServiceWriter{w}
+}
+
+func NewServiceWriterBuffer(b buffer.Buffer) ServiceWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ServiceWriter{w}
+}
+
+func NewServiceWriterTo(w spec.MessageWriter) ServiceWriter {
+	return ServiceWriter{w}
+}
+
+func (w ServiceWriter) Sub(v bool) { w.w.Field(1).Bool(v) }
+func (w ServiceWriter) Methods() spec.MessageListWriter[MethodWriter] {
+	w1 := w.w.Field(2).List()
+	return spec.NewMessageListWriter(w1, NewMethodWriterTo)
+}
+
+func (w ServiceWriter) Merge(msg Service) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ServiceWriter) End() error {
+	return w.w.End()
+}
+
+func (w ServiceWriter) Build() (_ Service, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenServiceErr(bytes)
+}
+
+func (w ServiceWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// MethodWriter
+
+type MethodWriter struct {
+	w spec.MessageWriter
+}
+
+func NewMethodWriter() MethodWriter {
+	w := spec.NewMessageWriter()
+	return MethodWriter{w}
+}
+
+func NewMethodWriterBuffer(b buffer.Buffer) MethodWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return MethodWriter{w}
+}
+
+func NewMethodWriterTo(w spec.MessageWriter) MethodWriter {
+	return MethodWriter{w}
+}
+
+func (w MethodWriter) Name(v string)     { w.w.Field(1).String(v) }
+func (w MethodWriter) Type(v MethodType) { spec.WriteField(w.w.Field(2), v, EncodeMethodTypeTo) }
+func (w MethodWriter) Request() TypeWriter {
+	w1 := w.w.Field(3).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyRequest(v Type) error {
+	return w.w.Field(3).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) Response() TypeWriter {
+	w1 := w.w.Field(4).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyResponse(v Type) error {
+	return w.w.Field(4).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) Subservice() TypeWriter {
+	w1 := w.w.Field(5).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopySubservice(v Type) error {
+	return w.w.Field(5).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) ChannelIn() TypeWriter {
+	w1 := w.w.Field(6).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyChannelIn(v Type) error {
+	return w.w.Field(6).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) ChannelOut() TypeWriter {
+	w1 := w.w.Field(7).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyChannelOut(v Type) error {
+	return w.w.Field(7).Any(v.Unwrap().Raw())
+}
+
+func (w MethodWriter) Merge(msg Method) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w MethodWriter) End() error {
+	return w.w.End()
+}
+
+func (w MethodWriter) Build() (_ Method, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenMethodErr(bytes)
+}
+
+func (w MethodWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// TypeWriter
+
+type TypeWriter struct {
+	w spec.MessageWriter
+}
+
+func NewTypeWriter() TypeWriter {
+	w := spec.NewMessageWriter()
+	return TypeWriter{w}
+}
+
+func NewTypeWriterBuffer(b buffer.Buffer) TypeWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return TypeWriter{w}
+}
+
+func NewTypeWriterTo(w spec.MessageWriter) TypeWriter {
+	return TypeWriter{w}
+}
+
+func (w TypeWriter) Kind(v Kind)      { spec.WriteField(w.w.Field(1), v, EncodeKindTo) }
+func (w TypeWriter) Name(v string)    { w.w.Field(2).String(v) }
+func (w TypeWriter) Import(v string)  { w.w.Field(3).String(v) }
+func (w TypeWriter) Package(v string) { w.w.Field(4).String(v) }
+func (w TypeWriter) Element() TypeWriter {
+	w1 := w.w.Field(5).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w TypeWriter) CopyElement(v Type) error {
+	return w.w.Field(5).Any(v.Unwrap().Raw())
+}
+
+func (w TypeWriter) Merge(msg Type) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w TypeWriter) End() error {
+	return w.w.End()
+}
+
+func (w TypeWriter) Build() (_ Type, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenTypeErr(bytes)
+}
+
+func (w TypeWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
@@ -86,18 +86,17 @@ func OpenMessage(b []byte) Message {
 
 func OpenMessageErr(b []byte) (_ Message, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Message{msg}, nil
+	return Message{msg}, err
 }
 
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Message{msg}, size, nil
+	return Message{msg}, size, err
+}
+
+func ParseMessageWithOptions(b []byte, opts spec.ParseOptions) (_ Message, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Message{msg}, size, err
 }
 
 func (m Message) Type() MessageType { return OpenMessageType(m.msg.FieldRaw(1)) }
@@ -110,11 +109,12 @@ func (m Message) HasReq() bool  { return m.msg.HasField(2) }
 func (m Message) HasResp() bool { return m.msg.HasField(3) }
 func (m Message) HasMsg() bool  { return m.msg.HasField(4) }
 
-func (m Message) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
 func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
 func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
-func (m Message) Unwrap() spec.Message                  { return m.msg }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
 
 // Request
 
@@ -133,29 +133,29 @@ func OpenRequest(b []byte) Request {
 
 func OpenRequestErr(b []byte) (_ Request, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Request{msg}, nil
+	return Request{msg}, err
 }
 
 func ParseRequest(b []byte) (_ Request, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Request{msg}, size, nil
+	return Request{msg}, size, err
+}
+
+func ParseRequestWithOptions(b []byte, opts spec.ParseOptions) (_ Request, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Request{msg}, size, err
 }
 
 func (m Request) Calls() spec.MessageList[Call] {
 	return spec.NewMessageList(m.msg.List(1), OpenCallErr)
 }
 func (m Request) HasCalls() bool                        { return m.msg.HasField(1) }
-func (m Request) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Request) Clone() Request                        { return Request{m.msg.Clone()} }
 func (m Request) CloneToArena(a alloc.Arena) Request    { return Request{m.msg.CloneToArena(a)} }
 func (m Request) CloneToBuffer(b buffer.Buffer) Request { return Request{m.msg.CloneToBuffer(b)} }
-func (m Request) Unwrap() spec.Message                  { return m.msg }
+
+func (m Request) IsEmpty() bool        { return m.msg.Empty() }
+func (m Request) Unwrap() spec.Message { return m.msg }
 
 // Call
 
@@ -174,18 +174,17 @@ func OpenCall(b []byte) Call {
 
 func OpenCallErr(b []byte) (_ Call, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Call{msg}, nil
+	return Call{msg}, err
 }
 
 func ParseCall(b []byte) (_ Call, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Call{msg}, size, nil
+	return Call{msg}, size, err
+}
+
+func ParseCallWithOptions(b []byte, opts spec.ParseOptions) (_ Call, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Call{msg}, size, err
 }
 
 func (m Call) Method() spec.String { return m.msg.String(1) }
@@ -194,11 +193,12 @@ func (m Call) Input() spec.Message { return m.msg.Field(2).Message() }
 func (m Call) HasMethod() bool { return m.msg.HasField(1) }
 func (m Call) HasInput() bool  { return m.msg.HasField(2) }
 
-func (m Call) IsEmpty() bool                      { return m.msg.Empty() }
 func (m Call) Clone() Call                        { return Call{m.msg.Clone()} }
 func (m Call) CloneToArena(a alloc.Arena) Call    { return Call{m.msg.CloneToArena(a)} }
 func (m Call) CloneToBuffer(b buffer.Buffer) Call { return Call{m.msg.CloneToBuffer(b)} }
-func (m Call) Unwrap() spec.Message               { return m.msg }
+
+func (m Call) IsEmpty() bool        { return m.msg.Empty() }
+func (m Call) Unwrap() spec.Message { return m.msg }
 
 // Response
 
@@ -217,18 +217,17 @@ func OpenResponse(b []byte) Response {
 
 func OpenResponseErr(b []byte) (_ Response, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Response{msg}, nil
+	return Response{msg}, err
 }
 
 func ParseResponse(b []byte) (_ Response, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Response{msg}, size, nil
+	return Response{msg}, size, err
+}
+
+func ParseResponseWithOptions(b []byte, opts spec.ParseOptions) (_ Response, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Response{msg}, size, err
 }
 
 func (m Response) Status() Status     { return NewStatus(m.msg.Message(1)) }
@@ -237,11 +236,12 @@ func (m Response) Result() spec.Value { return m.msg.Field(2) }
 func (m Response) HasStatus() bool { return m.msg.HasField(1) }
 func (m Response) HasResult() bool { return m.msg.HasField(2) }
 
-func (m Response) IsEmpty() bool                          { return m.msg.Empty() }
 func (m Response) Clone() Response                        { return Response{m.msg.Clone()} }
 func (m Response) CloneToArena(a alloc.Arena) Response    { return Response{m.msg.CloneToArena(a)} }
 func (m Response) CloneToBuffer(b buffer.Buffer) Response { return Response{m.msg.CloneToBuffer(b)} }
-func (m Response) Unwrap() spec.Message                   { return m.msg }
+
+func (m Response) IsEmpty() bool        { return m.msg.Empty() }
+func (m Response) Unwrap() spec.Message { return m.msg }
 
 // Status
 
@@ -260,18 +260,17 @@ func OpenStatus(b []byte) Status {
 
 func OpenStatusErr(b []byte) (_ Status, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Status{msg}, nil
+	return Status{msg}, err
 }
 
 func ParseStatus(b []byte) (_ Status, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
-	}
-	return Status{msg}, size, nil
+	return Status{msg}, size, err
+}
+
+func ParseStatusWithOptions(b []byte, opts spec.ParseOptions) (_ Status, size int, err error) {
+	msg, size, err := spec.ParseMessageWithOptions(b, opts)
+	return Status{msg}, size, err
 }
 
 func (m Status) Code() spec.String    { return m.msg.String(1) }
@@ -280,11 +279,12 @@ func (m Status) Message() spec.String { return m.msg.String(2) }
 func (m Status) HasCode() bool    { return m.msg.HasField(1) }
 func (m Status) HasMessage() bool { return m.msg.HasField(2) }
 
-func (m Status) IsEmpty() bool                        { return m.msg.Empty() }
 func (m Status) Clone() Status                        { return Status{m.msg.Clone()} }
 func (m Status) CloneToArena(a alloc.Arena) Status    { return Status{m.msg.CloneToArena(a)} }
 func (m Status) CloneToBuffer(b buffer.Buffer) Status { return Status{m.msg.CloneToBuffer(b)} }
-func (m Status) Unwrap() spec.Message                 { return m.msg }
+
+func (m Status) IsEmpty() bool        { return m.msg.Empty() }
+func (m Status) Unwrap() spec.Message { return m.msg }
 
 // MessageWriter
 
@@ -0,0 +1,83 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/collect/chans"
+)
+
+// MockCall is a call recorded by a generated mock.
+type MockCall struct {
+	Method  string // Go method name, i.e. Method1
+	Request any    // Request message or nil
+}
+
+// MockCalls records calls in generated mocks, it is safe for concurrent use.
+// The methods are used in generated code.
+type MockCalls struct {
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// AddCall records a call.
+func (c *MockCalls) AddCall(method string, req any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, MockCall{Method: method, Request: req})
+}
+
+// Calls returns a copy of the recorded calls.
+func (c *MockCalls) Calls() []MockCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]MockCall, len(c.calls))
+	copy(calls, c.calls)
+	return calls
+}
+
+// CallCount returns the number of calls to a method.
+func (c *MockCalls) CallCount(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, call := range c.calls {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// LastCall returns the last call to a method.
+func (c *MockCalls) LastCall(method string) (MockCall, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.calls) - 1; i >= 0; i-- {
+		call := c.calls[i]
+		if call.Method == method {
+			return call, true
+		}
+	}
+	return MockCall{}, false
+}
+
+// ResetCalls clears the recorded calls.
+func (c *MockCalls) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+}
+
+// MockWait returns a closed channel, it is used by generated fake channels.
+func MockWait() <-chan struct{} {
+	return chans.Closed()
+}
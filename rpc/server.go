@@ -11,6 +11,7 @@ import (
 	"github.com/basecomplextech/baselibrary/logging"
 	"github.com/basecomplextech/baselibrary/ref"
 	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
 	"github.com/basecomplextech/spec/mpx"
 	"github.com/basecomplextech/spec/proto/prpc"
 )
@@ -31,7 +32,18 @@ type Server interface {
 
 // NewServer returns a new RPC server.
 func NewServer(address string, handler Handler, logger logging.Logger, opts Options) Server {
-	return newServer(address, handler, logger, opts)
+	return newServer(address, handler, logger, opts, spec.ParseOptions{})
+}
+
+// NewServerWithParseOptions returns a new RPC server which limits parsing of requests.
+//
+// The max depth limits the depth of call inputs, not of the request message,
+// i.e. a max depth of 1 allows inputs without nested lists or messages.
+// The max size limits the size of the whole request message.
+// Other limits apply to every list, message and string in the request.
+func NewServerWithParseOptions(address string, handler Handler, logger logging.Logger,
+	opts Options, parse spec.ParseOptions) Server {
+	return newServer(address, handler, logger, opts, parse)
 }
 
 // internal
@@ -41,12 +53,16 @@ type server struct {
 
 	handler Handler
 	logger  logging.Logger
+	parse   spec.ParseOptions
 }
 
-func newServer(address string, handler Handler, logger logging.Logger, opts Options) *server {
+func newServer(address string, handler Handler, logger logging.Logger, opts Options,
+	parse spec.ParseOptions) *server {
+
 	s := &server{
 		handler: handler,
 		logger:  logger,
+		parse:   parse,
 	}
 	s.Server = mpx.NewServer(address, s, logger, opts)
 	return s
@@ -62,7 +78,7 @@ func (s *server) HandleChannel(ctx Context, ch mpx.Channel) (st status.Status) {
 	start := time.Now()
 
 	// Parse message
-	msg, _, err := prpc.ParseMessage(b)
+	msg, err := parseRequest(b, s.parse)
 	if err != nil {
 		return WrapErrorf(err, "failed to parse request message")
 	}
@@ -128,6 +144,20 @@ func (s *server) handleRequest(ctx Context, ch *serverChannel) (result ref.R[[]b
 	return s.handler.Handle(ctx, ch)
 }
 
+// requestDepth is the nesting depth of call inputs in a request message,
+// i.e. message.req.calls[i].input.
+const requestDepth = 4
+
+// parseRequest parses a request message, the max depth limits the depth of call inputs.
+func parseRequest(b []byte, opts spec.ParseOptions) (prpc.Message, error) {
+	if opts.MaxDepth > 0 {
+		opts.MaxDepth += requestDepth
+	}
+
+	msg, _, err := prpc.ParseMessageWithOptions(b, opts)
+	return msg, err
+}
+
 func requestMethod(b []byte, req prpc.Request) []byte {
 	calls := req.Calls()
 
@@ -22,7 +22,7 @@ import (
 func testServer(t tests.T, handle HandleFunc) *server {
 	opts := Default()
 	logger := logging.TestLogger(t)
-	server := newServer("localhost:0", handle, logger, opts)
+	server := newServer("localhost:0", handle, logger, opts, spec.ParseOptions{})
 
 	st := server.Start()
 	if !st.OK() {
@@ -142,3 +142,52 @@ func TestServer_handleRequest__should_handle_errors(t *testing.T) {
 	assert.Equal(t, status.CodeUnauthorized, st.Code)
 	assert.Equal(t, "test unauthorized", st.Message)
 }
+
+// parseRequest
+
+func testNestedRequest(t tests.T, depth int) []byte {
+	w := prpc.NewMessageWriter()
+	w.Type(prpc.MessageType_Request)
+
+	req := w.Req()
+	calls := req.Calls()
+	call := calls.Add()
+	call.Method("echo")
+
+	input := call.Input()
+	msgs := []spec.MessageWriter{input}
+	for i := 1; i < depth; i++ {
+		m := msgs[len(msgs)-1].Field(1).Message()
+		msgs = append(msgs, m)
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if err := msgs[i].End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := call.End(); err != nil {
+		t.Fatal(err)
+	}
+	if err := calls.End(); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg.Unwrap().Raw()
+}
+
+func TestParseRequest__should_limit_depth_of_call_inputs(t *testing.T) {
+	b := testNestedRequest(t, 3)
+
+	_, err := parseRequest(b, spec.ParseOptions{MaxDepth: 3})
+	assert.NoError(t, err)
+
+	_, err = parseRequest(b, spec.ParseOptions{MaxDepth: 2})
+	assert.ErrorIs(t, err, spec.ErrParseLimit)
+}
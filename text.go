@@ -0,0 +1,25 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"github.com/basecomplextech/spec/internal/text"
+)
+
+// FormatText returns a canonical schema-free text of a value using its self-describing types.
+//
+// Int64 and float64 values are untyped literals, other values are typed literals,
+// i.e. int32(5), bin64(0x0123456789abcdef), bytes("hello"). Lists are [...], messages are
+// {1: ..., 2: ...} with fields in the data order, structs are struct(...).
+// See [ParseText] for the reverse conversion.
+func FormatText(v Value) (string, error) {
+	return text.Format(v)
+}
+
+// ParseText parses a schema-free text value and writes it with a writer,
+// returns the value bytes. The bytes are identical to the bytes passed to [FormatText].
+func ParseText(w Writer, src []byte) ([]byte, error) {
+	return text.Parse(w, src)
+}
@@ -0,0 +1,2624 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Spec TypeScript runtime.
+//
+// The runtime mirrors the Go spec package: messages and lists are read-only views
+// over a Uint8Array, writers produce the same bytes as the Go writer.
+// See format.md for the binary format.
+//
+// Value mapping:
+//
+//	bool              boolean
+//	byte              number
+//	int16/int32       number
+//	uint16/uint32     number
+//	int64/uint64      bigint
+//	float32/float64   number
+//	bin64/128/256     Uint8Array (8/16/32 bytes)
+//	bytes             Uint8Array
+//	string            string
+//
+// Readers are lenient like in Go: accessors return zero values on invalid data,
+// parse functions throw a SpecError.
+
+// Type
+
+export const Type = {
+	Undefined: 0,
+
+	True: 1,
+	False: 2,
+	Byte: 3,
+
+	Int16: 10,
+	Int32: 11,
+	Int64: 12,
+	Varint: 13,
+
+	Uint16: 20,
+	Uint32: 21,
+	Uint64: 22,
+	Uvarint: 23,
+
+	Bin64: 30,
+	Bin128: 31,
+	Bin256: 32,
+
+	Float32: 40,
+	Float64: 41,
+
+	Bytes: 50,
+	String: 60,
+
+	List: 70,
+	BigList: 71,
+	PackedList: 72,
+
+	Message: 80,
+	BigMessage: 81,
+
+	Struct: 90,
+} as const;
+
+export type Type = (typeof Type)[keyof typeof Type];
+
+export type Bin64 = Uint8Array;
+export type Bin128 = Uint8Array;
+export type Bin256 = Uint8Array;
+
+// SpecError is thrown on invalid data or an invalid writer operation.
+export class SpecError extends Error {
+	constructor(message: string) {
+		super(message);
+		this.name = "SpecError";
+	}
+}
+
+// MaxSize is the maximum size of a value, a table, or a message/list data.
+export const MaxSize = 0xffffffff;
+
+const messageFieldSizeSmall = 1 + 2; // tag(1) + offset(2)
+const messageFieldSizeBig = 2 + 4; // tag(2) + offset(4)
+const listElementSizeSmall = 2;
+const listElementSizeBig = 4;
+
+const uint64Mask = (1n << 64n) - 1n;
+const empty = new Uint8Array(0);
+
+const textEncoder = new TextEncoder();
+const textDecoder = new TextDecoder("utf-8", { fatal: false });
+
+/* Buffer */
+
+// Buffer is a growable byte buffer.
+export class Buffer {
+	private b: Uint8Array;
+	private n = 0;
+
+	constructor(capacity = 256) {
+		this.b = new Uint8Array(capacity);
+	}
+
+	// len returns the number of written bytes.
+	len(): number {
+		return this.n;
+	}
+
+	// bytes returns a view of the written bytes.
+	bytes(): Uint8Array {
+		return this.b.subarray(0, this.n);
+	}
+
+	// grow grows the buffer by n bytes and returns a view of the new bytes.
+	grow(n: number): Uint8Array {
+		const end = this.n + n;
+		if (end > this.b.length) {
+			let capacity = this.b.length * 2;
+			if (capacity < end) {
+				capacity = end;
+			}
+
+			const b = new Uint8Array(capacity);
+			b.set(this.b.subarray(0, this.n));
+			this.b = b;
+		}
+
+		const p = this.b.subarray(this.n, end);
+		this.n = end;
+		return p;
+	}
+
+	// write appends bytes to the buffer.
+	write(b: Uint8Array): void {
+		this.grow(b.length).set(b);
+	}
+
+	// reset resets the buffer.
+	reset(): void {
+		this.n = 0;
+	}
+}
+
+/* Compactint */
+
+// reverseUint32 decodes a reverse compactint from the b end,
+// returns the value and the number of read bytes, 0 on empty or short data, -1 on invalid data.
+function reverseUint32(b: Uint8Array): [number, number] {
+	const ln = b.length;
+	if (ln === 0) {
+		return [0, 0];
+	}
+
+	const f = b[ln - 1];
+	switch (f) {
+		case 0xfd:
+			if (ln < 3) {
+				return [0, 0];
+			}
+			return [(b[ln - 3] << 8) | b[ln - 2], 3];
+
+		case 0xfe:
+			if (ln < 5) {
+				return [0, 0];
+			}
+			return [readUint32(b, ln - 5), 5];
+
+		case 0xff:
+			return [0, -1];
+	}
+	return [f, 1];
+}
+
+// reverseUint64 decodes a reverse compactint from the b end,
+// returns the value and the number of read bytes, 0 on empty or short data.
+function reverseUint64(b: Uint8Array): [bigint, number] {
+	const ln = b.length;
+	if (ln === 0) {
+		return [0n, 0];
+	}
+
+	const f = b[ln - 1];
+	if (f !== 0xff) {
+		const [v, n] = reverseUint32(b);
+		return [BigInt(v), n];
+	}
+
+	if (ln < 9) {
+		return [0n, 0];
+	}
+
+	const hi = BigInt(readUint32(b, ln - 9));
+	const lo = BigInt(readUint32(b, ln - 5));
+	return [(hi << 32n) | lo, 9];
+}
+
+// reverseVarint decodes a reverse varint from the b end,
+// returns the value and the number of read bytes, 0 on empty or short data, -1 on overflow.
+function reverseVarint(b: Uint8Array): [bigint, number] {
+	let v = 0n;
+	let shift = 0n;
+
+	const start = Math.max(0, b.length - 10);
+	for (let i = b.length - 1; i >= start; i--) {
+		const x = b[i];
+		if (x < 0x80) {
+			return [BigInt.asUintN(64, v | (BigInt(x) << shift)), b.length - i];
+		}
+		if (i === 0) {
+			return [0n, -1];
+		}
+
+		v |= BigInt(x & 0x7f) << shift;
+		shift += 7n;
+	}
+	return [0n, b.length === 0 ? 0 : -1];
+}
+
+// putReverseUint32 writes a reverse compactint, returns the number of written bytes.
+function putReverseUint32(b: Buffer, v: number): number {
+	if (v <= 0xfc) {
+		b.grow(1)[0] = v;
+		return 1;
+	}
+
+	if (v <= 0xffff) {
+		const p = b.grow(3);
+		p[0] = v >>> 8;
+		p[1] = v;
+		p[2] = 0xfd;
+		return 3;
+	}
+
+	const p = b.grow(5);
+	putUint32(p, 0, v);
+	p[4] = 0xfe;
+	return 5;
+}
+
+// putReverseUint64 writes a reverse compactint, returns the number of written bytes.
+function putReverseUint64(b: Buffer, v: bigint): number {
+	if (v <= 0xffffffffn) {
+		return putReverseUint32(b, Number(v));
+	}
+
+	const p = b.grow(9);
+	putUint32(p, 0, Number(v >> 32n));
+	putUint32(p, 4, Number(v & 0xffffffffn));
+	p[8] = 0xff;
+	return 9;
+}
+
+function readUint32(b: Uint8Array, off: number): number {
+	return ((b[off] << 24) | (b[off + 1] << 16) | (b[off + 2] << 8) | b[off + 3]) >>> 0;
+}
+
+function putUint32(p: Uint8Array, off: number, v: number): void {
+	p[off] = v >>> 24;
+	p[off + 1] = v >>> 16;
+	p[off + 2] = v >>> 8;
+	p[off + 3] = v;
+}
+
+function zigzag32(x: number): number {
+	return ((x << 1) ^ (x >> 31)) >>> 0;
+}
+
+function unzigzag32(ux: number): number {
+	return (ux >>> 1) ^ -(ux & 1);
+}
+
+function zigzag64(x: bigint): bigint {
+	const ux = BigInt.asUintN(64, x << 1n);
+	return x < 0n ? ~ux & uint64Mask : ux;
+}
+
+function unzigzag64(ux: bigint): bigint {
+	const x = ux >> 1n;
+	return (ux & 1n) !== 0n ? ~x : x;
+}
+
+/* Decode */
+
+// decodeType decodes a value type from the b end, returns undefined on empty bytes.
+export function decodeType(b: Uint8Array): Type {
+	if (b.length === 0) {
+		return Type.Undefined;
+	}
+	return b[b.length - 1] as Type;
+}
+
+// decodeTypeSize decodes a value type and its total size.
+export function decodeTypeSize(b: Uint8Array): [Type, number] {
+	if (b.length === 0) {
+		return [Type.Undefined, 0];
+	}
+
+	const t = decodeType(b);
+	const v = b.subarray(0, b.length - 1);
+
+	switch (t) {
+		case Type.True:
+		case Type.False:
+			return [t, 1];
+
+		case Type.Byte:
+			if (v.length < 1) {
+				throw new SpecError("decode byte: invalid data");
+			}
+			return [t, 2];
+
+		case Type.Int16:
+		case Type.Int32:
+		case Type.Int64:
+		case Type.Uint16:
+		case Type.Uint32:
+		case Type.Uint64: {
+			const m = reverseSize(v);
+			if (m <= 0) {
+				throw new SpecError("decode int: invalid data");
+			}
+			return [t, 1 + m];
+		}
+
+		case Type.Varint:
+		case Type.Uvarint: {
+			const [, m] = reverseVarint(v);
+			if (m <= 0) {
+				throw new SpecError("decode varint: invalid data");
+			}
+			return [t, 1 + m];
+		}
+
+		case Type.Float32:
+			return [t, checkFixed(v, 4, "float32")];
+		case Type.Float64:
+			return [t, checkFixed(v, 8, "float64")];
+
+		case Type.Bin64:
+			return [t, checkFixed(v, 8, "bin64")];
+		case Type.Bin128:
+			return [t, checkFixed(v, 16, "bin128")];
+		case Type.Bin256:
+			return [t, checkFixed(v, 32, "bin256")];
+
+		case Type.Bytes:
+		case Type.String: {
+			const name = t === Type.Bytes ? "bytes" : "string";
+			const [dataSize, m] = reverseUint32(v);
+			if (m <= 0) {
+				throw new SpecError(`decode ${name}: invalid data size`);
+			}
+
+			let size = 1 + m + dataSize;
+			if (t === Type.String) {
+				size++; // null terminator
+			}
+			if (b.length < size) {
+				throw new SpecError(`decode ${name}: invalid data`);
+			}
+			return [t, size];
+		}
+
+		case Type.List:
+		case Type.BigList:
+		case Type.Message:
+		case Type.BigMessage: {
+			const name = t === Type.List || t === Type.BigList ? "list" : "message";
+			const [tableSize, m] = reverseUint32(v);
+			if (m <= 0) {
+				throw new SpecError(`decode ${name}: invalid table size`);
+			}
+
+			const [dataSize, k] = reverseUint32(v.subarray(0, v.length - m));
+			if (k <= 0) {
+				throw new SpecError(`decode ${name}: invalid data size`);
+			}
+
+			const size = 1 + m + k + tableSize + dataSize;
+			if (b.length < size) {
+				throw new SpecError(`decode ${name}: invalid data`);
+			}
+			return [t, size];
+		}
+
+		case Type.PackedList: {
+			const footer = decodePackedFooter(b);
+			return [t, footer.size + footer.dataSize];
+		}
+
+		case Type.Struct: {
+			const [dataSize, m] = reverseUint32(v);
+			if (m <= 0) {
+				throw new SpecError("decode struct: invalid data size");
+			}
+
+			const size = 1 + m + dataSize;
+			if (b.length < size) {
+				throw new SpecError("decode struct: invalid data");
+			}
+			return [t, size];
+		}
+	}
+
+	throw new SpecError(`decode: invalid type, type=${t}`);
+}
+
+// packedFooter is a decoded packed list footer, size is the footer size.
+interface packedFooter {
+	elemType: Type;
+	elemSize: number;
+	count: number;
+	dataSize: number;
+	size: number;
+}
+
+// decodePackedFooter decodes a packed list footer from the b end, throws on invalid data.
+function decodePackedFooter(b: Uint8Array): packedFooter {
+	if (b.length < 2) {
+		throw new SpecError("decode list: invalid data");
+	}
+	let end = b.length - 2;
+
+	const elemType = b[end] as Type;
+	if (!isPackable(elemType)) {
+		throw new SpecError(`decode list: invalid packed element type, type=${elemType}`);
+	}
+
+	// Element size
+	const [elemSize, m] = reverseUint32(b.subarray(0, end));
+	if (m <= 0 || elemSize === 0) {
+		throw new SpecError("decode list: invalid element size");
+	}
+	const exp = packedSize(elemType);
+	if (exp > 0 && elemSize !== exp) {
+		throw new SpecError(`decode list: invalid element size, type=${elemType}, size=${elemSize}`);
+	}
+	end -= m;
+
+	// Count
+	const [count, k] = reverseUint32(b.subarray(0, end));
+	if (k <= 0) {
+		throw new SpecError("decode list: invalid count");
+	}
+	end -= k;
+
+	// Data size
+	const [dataSize, j] = reverseUint32(b.subarray(0, end));
+	if (j <= 0) {
+		throw new SpecError("decode list: invalid data size");
+	}
+	end -= j;
+
+	// Data
+	if (count * elemSize > dataSize) {
+		throw new SpecError("decode list: invalid packed data");
+	}
+	if (end < dataSize) {
+		throw new SpecError("decode list: invalid data");
+	}
+	return { elemType, elemSize, count, dataSize, size: b.length - end };
+}
+
+function reverseSize(b: Uint8Array): number {
+	const ln = b.length;
+	if (ln === 0) {
+		return 0;
+	}
+
+	switch (b[ln - 1]) {
+		case 0xfd:
+			return ln < 3 ? 0 : 3;
+		case 0xfe:
+			return ln < 5 ? 0 : 5;
+		case 0xff:
+			return ln < 9 ? 0 : 9;
+	}
+	return 1;
+}
+
+function checkFixed(v: Uint8Array, n: number, name: string): number {
+	if (v.length < n) {
+		throw new SpecError(`decode ${name}: invalid data`);
+	}
+	return n + 1;
+}
+
+// Bool/byte
+
+// decodeBool decodes a bool from the b end, returns the value and its size.
+export function decodeBool(b: Uint8Array): [boolean, number] {
+	if (b.length === 0) {
+		return [false, 0];
+	}
+
+	const t = decodeType(b);
+	switch (t) {
+		case Type.True:
+			return [true, 1];
+		case Type.False:
+			return [false, 1];
+	}
+	throw new SpecError(`decode bool: invalid type, type=${t}`);
+}
+
+// decodeByte decodes a byte from the b end, returns the value and its size.
+export function decodeByte(b: Uint8Array): [number, number] {
+	if (b.length === 0) {
+		return [0, 0];
+	}
+
+	const t = decodeType(b);
+	if (t !== Type.Byte) {
+		throw new SpecError(`decode byte: invalid type, type=${t}`);
+	}
+	if (b.length < 2) {
+		throw new SpecError("decode byte: invalid data");
+	}
+	return [b[b.length - 2], 2];
+}
+
+// Int
+
+// decodeInt16 decodes an int16 from the b end, returns the value and its size.
+export function decodeInt16(b: Uint8Array): [number, number] {
+	return decodeIntN(b, "int16", -0x8000, 0x7fff);
+}
+
+// decodeInt32 decodes an int32 from the b end, returns the value and its size.
+export function decodeInt32(b: Uint8Array): [number, number] {
+	return decodeIntN(b, "int32", -0x80000000, 0x7fffffff);
+}
+
+// decodeInt64 decodes an int64 from the b end, returns the value and its size.
+export function decodeInt64(b: Uint8Array): [bigint, number] {
+	if (b.length === 0) {
+		return [0n, 0];
+	}
+
+	const t = decodeType(b);
+	const v = b.subarray(0, b.length - 1);
+
+	switch (t) {
+		case Type.Int16:
+		case Type.Int32: {
+			const [ux, m] = reverseUint32(v);
+			if (m <= 0) {
+				throw new SpecError("decode int64: invalid data");
+			}
+			return [BigInt(unzigzag32(ux)), 1 + m];
+		}
+
+		case Type.Int64: {
+			const [ux, m] = reverseUint64(v);
+			if (m <= 0) {
+				throw new SpecError("decode int64: invalid data");
+			}
+			return [unzigzag64(ux), 1 + m];
+		}
+
+		case Type.Varint: {
+			const [ux, m] = reverseVarint(v);
+			if (m <= 0) {
+				throw new SpecError("decode int64: invalid data");
+			}
+			return [unzigzag64(ux), 1 + m];
+		}
+	}
+	throw new SpecError(`decode int64: invalid type, type=${t}`);
+}
+
+function decodeIntN(b: Uint8Array, name: string, min: number, max: number): [number, number] {
+	if (b.length === 0) {
+		return [0, 0];
+	}
+
+	const t = decodeType(b);
+	const v = b.subarray(0, b.length - 1);
+
+	let x: number;
+	let m: number;
+	switch (t) {
+		case Type.Int16:
+		case Type.Int32: {
+			let ux: number;
+			[ux, m] = reverseUint32(v);
+			x = unzigzag32(ux);
+			break;
+		}
+
+		case Type.Int64: {
+			let ux: bigint;
+			[ux, m] = reverseUint64(v);
+			x = Number(unzigzag64(ux));
+			break;
+		}
+
+		case Type.Varint: {
+			let ux: bigint;
+			[ux, m] = reverseVarint(v);
+			x = Number(unzigzag64(ux));
+			break;
+		}
+
+		default:
+			throw new SpecError(`decode ${name}: invalid type, type=${t}`);
+	}
+
+	if (m <= 0) {
+		throw new SpecError(`decode ${name}: invalid data`);
+	}
+	if (x < min) {
+		throw new SpecError(`decode ${name}: overflow, value too small`);
+	}
+	if (x > max) {
+		throw new SpecError(`decode ${name}: overflow, value too large`);
+	}
+	return [x, 1 + m];
+}
+
+// Uint
+
+// decodeUint16 decodes a uint16 from the b end, returns the value and its size.
+export function decodeUint16(b: Uint8Array): [number, number] {
+	return decodeUintN(b, "uint16", 0xffff);
+}
+
+// decodeUint32 decodes a uint32 from the b end, returns the value and its size.
+export function decodeUint32(b: Uint8Array): [number, number] {
+	return decodeUintN(b, "uint32", 0xffffffff);
+}
+
+// decodeUint64 decodes a uint64 from the b end, returns the value and its size.
+export function decodeUint64(b: Uint8Array): [bigint, number] {
+	if (b.length === 0) {
+		return [0n, 0];
+	}
+
+	const t = decodeType(b);
+	switch (t) {
+		case Type.Uint16:
+		case Type.Uint32:
+		case Type.Uint64: {
+			const [v, m] = reverseUint64(b.subarray(0, b.length - 1));
+			if (m <= 0 || (t !== Type.Uint64 && m > 5)) {
+				throw new SpecError("decode uint64: invalid data");
+			}
+			return [v, 1 + m];
+		}
+
+		case Type.Uvarint: {
+			const [v, m] = reverseVarint(b.subarray(0, b.length - 1));
+			if (m <= 0) {
+				throw new SpecError("decode uint64: invalid data");
+			}
+			return [v, 1 + m];
+		}
+	}
+	throw new SpecError(`decode uint64: invalid type, type=${t}`);
+}
+
+function decodeUintN(b: Uint8Array, name: string, max: number): [number, number] {
+	if (b.length === 0) {
+		return [0, 0];
+	}
+
+	const t = decodeType(b);
+	const v = b.subarray(0, b.length - 1);
+
+	let x: number;
+	let m: number;
+	switch (t) {
+		case Type.Uint16:
+		case Type.Uint32:
+			[x, m] = reverseUint32(v);
+			break;
+
+		case Type.Uint64:
+		case Type.Uvarint: {
+			let x64: bigint;
+			[x64, m] = t === Type.Uint64 ? reverseUint64(v) : reverseVarint(v);
+			if (x64 > BigInt(max)) {
+				throw new SpecError(`decode ${name}: overflow, value too large`);
+			}
+			x = Number(x64);
+			break;
+		}
+
+		default:
+			throw new SpecError(`decode ${name}: invalid type, type=${t}`);
+	}
+
+	if (m <= 0) {
+		throw new SpecError(`decode ${name}: invalid data`);
+	}
+	if (x > max) {
+		throw new SpecError(`decode ${name}: overflow, value too large`);
+	}
+	return [x, 1 + m];
+}
+
+// Float
+
+// decodeFloat32 decodes a float32 from the b end, returns the value and its size.
+export function decodeFloat32(b: Uint8Array): [number, number] {
+	return decodeFloat(b, "float32");
+}
+
+// decodeFloat64 decodes a float64 from the b end, returns the value and its size.
+export function decodeFloat64(b: Uint8Array): [number, number] {
+	return decodeFloat(b, "float64");
+}
+
+function decodeFloat(b: Uint8Array, name: string): [number, number] {
+	if (b.length === 0) {
+		return [0, 0];
+	}
+
+	const t = decodeType(b);
+	const view = new DataView(b.buffer, b.byteOffset, b.byteLength);
+
+	switch (t) {
+		case Type.Float32: {
+			const off = b.length - 5;
+			if (off < 0) {
+				throw new SpecError(`decode ${name}: invalid data`);
+			}
+			return [view.getFloat32(off), 5];
+		}
+
+		case Type.Float64: {
+			const off = b.length - 9;
+			if (off < 0) {
+				throw new SpecError(`decode ${name}: invalid data`);
+			}
+			return [view.getFloat64(off), 9];
+		}
+	}
+	throw new SpecError(`decode ${name}: invalid type, type=${t}`);
+}
+
+// Bin
+
+// decodeBin64 decodes a bin64 from the b end, returns a view and its size.
+export function decodeBin64(b: Uint8Array): [Bin64, number] {
+	return decodeBin(b, Type.Bin64, 8, "bin64");
+}
+
+// decodeBin128 decodes a bin128 from the b end, returns a view and its size.
+export function decodeBin128(b: Uint8Array): [Bin128, number] {
+	return decodeBin(b, Type.Bin128, 16, "bin128");
+}
+
+// decodeBin256 decodes a bin256 from the b end, returns a view and its size.
+export function decodeBin256(b: Uint8Array): [Bin256, number] {
+	return decodeBin(b, Type.Bin256, 32, "bin256");
+}
+
+function decodeBin(b: Uint8Array, type: Type, n: number, name: string): [Uint8Array, number] {
+	if (b.length === 0) {
+		return [new Uint8Array(n), 0];
+	}
+
+	const t = decodeType(b);
+	if (t !== type) {
+		throw new SpecError(`decode ${name}: invalid type, type=${t}`);
+	}
+
+	const start = b.length - n - 1;
+	if (start < 0) {
+		throw new SpecError(`decode ${name}: invalid data`);
+	}
+	return [b.subarray(start, start + n), n + 1];
+}
+
+// Bytes/string
+
+// decodeBytes decodes bytes from the b end, returns a view and its size.
+export function decodeBytes(b: Uint8Array): [Uint8Array, number] {
+	if (b.length === 0) {
+		return [empty, 0];
+	}
+
+	const t = decodeType(b);
+	if (t !== Type.Bytes) {
+		throw new SpecError(`decode bytes: invalid type, type=${t}`);
+	}
+
+	const end = b.length - 1;
+	const [dataSize, m] = reverseUint32(b.subarray(0, end));
+	if (m <= 0) {
+		throw new SpecError("decode bytes: invalid data size");
+	}
+
+	const start = end - m - dataSize;
+	if (start < 0) {
+		throw new SpecError("decode bytes: invalid data");
+	}
+	return [b.subarray(start, start + dataSize), 1 + m + dataSize];
+}
+
+// decodeString decodes a string from the b end, returns the string and its size.
+export function decodeString(b: Uint8Array): [string, number] {
+	if (b.length === 0) {
+		return ["", 0];
+	}
+
+	const t = decodeType(b);
+	if (t !== Type.String) {
+		throw new SpecError(`decode string: invalid type, type=${t}`);
+	}
+
+	const end = b.length - 1;
+	const [dataSize, m] = reverseUint32(b.subarray(0, end));
+	if (m <= 0) {
+		throw new SpecError("decode string: invalid data size");
+	}
+
+	const start = end - m - 1 - dataSize; // minus null terminator
+	if (start < 0) {
+		throw new SpecError("decode string: invalid data");
+	}
+
+	const s = textDecoder.decode(b.subarray(start, start + dataSize));
+	return [s, 1 + m + 1 + dataSize];
+}
+
+// Struct
+
+// decodeStruct decodes a struct header from the b end, returns the struct data size and
+// the total size. Struct fields are decoded in reverse order from the data end.
+export function decodeStruct(b: Uint8Array): [number, number] {
+	if (b.length === 0) {
+		return [0, 0];
+	}
+
+	const t = decodeType(b);
+	if (t !== Type.Struct) {
+		throw new SpecError(`decode struct: invalid type, type=${t}`);
+	}
+
+	const [dataSize, m] = reverseUint32(b.subarray(0, b.length - 1));
+	if (m <= 0) {
+		throw new SpecError("decode struct: invalid data size");
+	}
+
+	const size = 1 + m + dataSize;
+	if (b.length < size) {
+		throw new SpecError("decode struct: invalid data");
+	}
+	return [dataSize, size];
+}
+
+/* Encode */
+
+// EncodeFunc encodes a value into a buffer, returns the number of written bytes.
+export type EncodeFunc<T> = (b: Buffer, v: T) => number;
+
+// DecodeFunc decodes a value from the b end, returns the value and its size.
+export type DecodeFunc<T> = (b: Uint8Array) => [T, number];
+
+export function encodeBool(b: Buffer, v: boolean): number {
+	b.grow(1)[0] = v ? Type.True : Type.False;
+	return 1;
+}
+
+export function encodeByte(b: Buffer, v: number): number {
+	const p = b.grow(2);
+	p[0] = v;
+	p[1] = Type.Byte;
+	return 2;
+}
+
+// Int
+
+export function encodeInt16(b: Buffer, v: number): number {
+	return encodeIntType(b, zigzag32(v), Type.Int16);
+}
+
+export function encodeInt32(b: Buffer, v: number): number {
+	return encodeIntType(b, zigzag32(v), Type.Int32);
+}
+
+export function encodeInt64(b: Buffer, v: bigint): number {
+	const n = putReverseUint64(b, zigzag64(BigInt.asIntN(64, v)));
+	b.grow(1)[0] = Type.Int64;
+	return n + 1;
+}
+
+// Uint
+
+export function encodeUint16(b: Buffer, v: number): number {
+	return encodeIntType(b, v & 0xffff, Type.Uint16);
+}
+
+export function encodeUint32(b: Buffer, v: number): number {
+	return encodeIntType(b, v >>> 0, Type.Uint32);
+}
+
+export function encodeUint64(b: Buffer, v: bigint): number {
+	const n = putReverseUint64(b, BigInt.asUintN(64, v));
+	b.grow(1)[0] = Type.Uint64;
+	return n + 1;
+}
+
+function encodeIntType(b: Buffer, ux: number, type: Type): number {
+	const n = putReverseUint32(b, ux);
+	b.grow(1)[0] = type;
+	return n + 1;
+}
+
+// Float
+
+export function encodeFloat32(b: Buffer, v: number): number {
+	const p = b.grow(5);
+	new DataView(p.buffer, p.byteOffset, 5).setFloat32(0, v);
+	p[4] = Type.Float32;
+	return 5;
+}
+
+export function encodeFloat64(b: Buffer, v: number): number {
+	const p = b.grow(9);
+	new DataView(p.buffer, p.byteOffset, 9).setFloat64(0, v);
+	p[8] = Type.Float64;
+	return 9;
+}
+
+// Bin
+
+export function encodeBin64(b: Buffer, v: Bin64): number {
+	return encodeBin(b, v, Type.Bin64, 8);
+}
+
+export function encodeBin128(b: Buffer, v: Bin128): number {
+	return encodeBin(b, v, Type.Bin128, 16);
+}
+
+export function encodeBin256(b: Buffer, v: Bin256): number {
+	return encodeBin(b, v, Type.Bin256, 32);
+}
+
+function encodeBin(b: Buffer, v: Uint8Array, type: Type, n: number): number {
+	if (v.length !== n) {
+		throw new SpecError(`encode: invalid bin length, expected=${n}, actual=${v.length}`);
+	}
+
+	const p = b.grow(n + 1);
+	p.set(v);
+	p[n] = type;
+	return n + 1;
+}
+
+// Bytes/string
+
+export function encodeBytes(b: Buffer, v: Uint8Array): number {
+	checkSize("bytes", v.length);
+
+	b.write(v);
+	return v.length + encodeSizeType(b, v.length, Type.Bytes);
+}
+
+export function encodeString(b: Buffer, v: string): number {
+	const data = textEncoder.encode(v);
+	checkSize("string", data.length);
+
+	const p = b.grow(data.length + 1);
+	p.set(data);
+	p[data.length] = 0; // null terminator
+	return data.length + 1 + encodeSizeType(b, data.length, Type.String);
+}
+
+// Struct
+
+// encodeStruct writes a struct header after its fields, returns the number of written bytes.
+export function encodeStruct(b: Buffer, dataSize: number): number {
+	checkSize("struct", dataSize);
+	return encodeSizeType(b, dataSize, Type.Struct);
+}
+
+// List/message tables
+
+interface messageField {
+	tag: number;
+	offset: number;
+}
+
+function encodeListTable(b: Buffer, dataSize: number, table: number[]): number {
+	checkSize("list", dataSize);
+
+	// Big if count > uint8 or offset > uint16
+	const ln = table.length;
+	const big = ln > 0xff || (ln > 0 && table[ln - 1] > 0xffff);
+	const elemSize = big ? listElementSizeBig : listElementSizeSmall;
+
+	// Write table
+	const tableSize = ln * elemSize;
+	checkSize("list table", tableSize);
+
+	const p = b.grow(tableSize);
+	for (let i = 0; i < ln; i++) {
+		const offset = table[i];
+		const off = i * elemSize;
+
+		if (big) {
+			putUint32(p, off, offset);
+		} else {
+			p[off] = offset >>> 8;
+			p[off + 1] = offset;
+		}
+	}
+
+	// Write data size, table size and type
+	let n = tableSize;
+	n += putReverseUint32(b, dataSize);
+	n += encodeSizeType(b, tableSize, big ? Type.BigList : Type.List);
+	return n;
+}
+
+// encodePackedList writes a packed list footer after count elements of elemSize,
+// the data size includes the elements and their padding.
+function encodePackedList(b: Buffer, dataSize: number, count: number, elemSize: number, elemType: Type): number {
+	checkSize("list", dataSize);
+	if (count * elemSize > dataSize) {
+		throw new SpecError(
+			`encode: invalid packed list, data size=${dataSize}, count=${count}, elem size=${elemSize}`,
+		);
+	}
+
+	let n = putReverseUint32(b, dataSize);
+	n += putReverseUint32(b, count);
+	n += putReverseUint32(b, elemSize);
+
+	const p = b.grow(2);
+	p[0] = elemType;
+	p[1] = Type.PackedList;
+	return n + 2;
+}
+
+function encodeMessageTable(b: Buffer, dataSize: number, table: messageField[]): number {
+	checkSize("message", dataSize);
+
+	// Big if any tag > uint8 or offset > uint16
+	let big = false;
+	for (const f of table) {
+		if (f.tag > 0xff || f.offset > 0xffff) {
+			big = true;
+			break;
+		}
+	}
+	const fieldSize = big ? messageFieldSizeBig : messageFieldSizeSmall;
+
+	// Write table
+	const tableSize = table.length * fieldSize;
+	checkSize("message table", tableSize);
+
+	const p = b.grow(tableSize);
+	for (let i = 0; i < table.length; i++) {
+		const f = table[i];
+		const off = i * fieldSize;
+
+		if (big) {
+			p[off] = f.tag >>> 8;
+			p[off + 1] = f.tag;
+			putUint32(p, off + 2, f.offset);
+		} else {
+			p[off] = f.tag;
+			p[off + 1] = f.offset >>> 8;
+			p[off + 2] = f.offset;
+		}
+	}
+
+	// Write data size, table size and type
+	let n = tableSize;
+	n += putReverseUint32(b, dataSize);
+	n += encodeSizeType(b, tableSize, big ? Type.BigMessage : Type.Message);
+	return n;
+}
+
+function encodeSizeType(b: Buffer, size: number, type: Type): number {
+	const n = putReverseUint32(b, size);
+	b.grow(1)[0] = type;
+	return n + 1;
+}
+
+function checkSize(name: string, size: number): void {
+	if (size > MaxSize) {
+		throw new SpecError(`encode: ${name} too large, max size=${MaxSize}, actual size=${size}`);
+	}
+}
+
+/* Message */
+
+// Message is a read-only view of a message.
+export class Message {
+	static readonly Empty = new Message(empty, empty, 0, false);
+
+	readonly raw: Uint8Array;
+	private readonly table: Uint8Array;
+	private readonly dataSize: number;
+	private readonly big: boolean;
+
+	private constructor(raw: Uint8Array, table: Uint8Array, dataSize: number, big: boolean) {
+		this.raw = raw;
+		this.table = table;
+		this.dataSize = dataSize;
+		this.big = big;
+	}
+
+	// open opens a message, returns an empty message on invalid data.
+	static open(b: Uint8Array): Message {
+		try {
+			return Message.parse(b)[0];
+		} catch {
+			return Message.Empty;
+		}
+	}
+
+	// parse parses a message from the b end, returns the message and its size,
+	// throws on invalid data.
+	static parse(b: Uint8Array): [Message, number] {
+		if (b.length === 0) {
+			return [Message.Empty, 0];
+		}
+
+		const t = decodeType(b);
+		if (t !== Type.Message && t !== Type.BigMessage) {
+			throw new SpecError(`decode message: invalid type, type=${t}`);
+		}
+		const big = t === Type.BigMessage;
+		let end = b.length - 1;
+
+		// Table size
+		const [tableSize, m] = reverseUint32(b.subarray(0, end));
+		if (m <= 0) {
+			throw new SpecError("decode message: invalid table size");
+		}
+		end -= m;
+
+		// Data size
+		const [dataSize, k] = reverseUint32(b.subarray(0, end));
+		if (k <= 0) {
+			throw new SpecError("decode message: invalid data size");
+		}
+		end -= k;
+
+		// Table
+		const fieldSize = big ? messageFieldSizeBig : messageFieldSizeSmall;
+		const tableStart = end - tableSize;
+		if (tableStart < 0 || tableSize % fieldSize !== 0) {
+			throw new SpecError("decode message: invalid table");
+		}
+		const table = b.subarray(tableStart, end);
+
+		// Data
+		const start = tableStart - dataSize;
+		if (start < 0) {
+			throw new SpecError("decode message: invalid data");
+		}
+
+		const size = b.length - start;
+		const msg = new Message(b.subarray(start), table, dataSize, big);
+		return [msg, size];
+	}
+
+	// isEmpty returns true if the message is empty or has no fields.
+	isEmpty(): boolean {
+		return this.raw.length === 0 || this.table.length === 0;
+	}
+
+	// fields returns the number of fields in the message.
+	fields(): number {
+		return this.table.length / (this.big ? messageFieldSizeBig : messageFieldSizeSmall);
+	}
+
+	// tagAt returns a field tag by an index.
+	tagAt(i: number): number {
+		const f = this.fieldAt(i);
+		return f === undefined ? 0 : f.tag;
+	}
+
+	// has returns true if the message contains a field.
+	has(tag: number): boolean {
+		const end = this.offset(tag);
+		return end >= 0 && end <= this.dataSize;
+	}
+
+	// field returns a truncated field value or undefined.
+	field(tag: number): Value | undefined {
+		const b = this.fieldRaw(tag);
+		return b === undefined ? undefined : Value.open(b);
+	}
+
+	// fieldRaw returns raw untruncated field bytes or undefined,
+	// the field value ends at the end of the returned bytes.
+	fieldRaw(tag: number): Uint8Array | undefined {
+		const end = this.offset(tag);
+		if (end < 0 || end > this.dataSize) {
+			return undefined;
+		}
+		return this.raw.subarray(0, end);
+	}
+
+	// Types
+
+	bool(tag: number): boolean {
+		return this.decode(tag, decodeBool, false);
+	}
+
+	byte(tag: number): number {
+		return this.decode(tag, decodeByte, 0);
+	}
+
+	int16(tag: number): number {
+		return this.decode(tag, decodeInt16, 0);
+	}
+
+	int32(tag: number): number {
+		return this.decode(tag, decodeInt32, 0);
+	}
+
+	int64(tag: number): bigint {
+		return this.decode(tag, decodeInt64, 0n);
+	}
+
+	uint16(tag: number): number {
+		return this.decode(tag, decodeUint16, 0);
+	}
+
+	uint32(tag: number): number {
+		return this.decode(tag, decodeUint32, 0);
+	}
+
+	uint64(tag: number): bigint {
+		return this.decode(tag, decodeUint64, 0n);
+	}
+
+	float32(tag: number): number {
+		return this.decode(tag, decodeFloat32, 0);
+	}
+
+	float64(tag: number): number {
+		return this.decode(tag, decodeFloat64, 0);
+	}
+
+	bin64(tag: number): Bin64 {
+		return this.decode(tag, decodeBin64, new Uint8Array(8));
+	}
+
+	bin128(tag: number): Bin128 {
+		return this.decode(tag, decodeBin128, new Uint8Array(16));
+	}
+
+	bin256(tag: number): Bin256 {
+		return this.decode(tag, decodeBin256, new Uint8Array(32));
+	}
+
+	bytes(tag: number): Uint8Array {
+		return this.decode(tag, decodeBytes, empty);
+	}
+
+	string(tag: number): string {
+		return this.decode(tag, decodeString, "");
+	}
+
+	list(tag: number): List {
+		const b = this.fieldRaw(tag);
+		return b === undefined ? List.Empty : List.open(b);
+	}
+
+	message(tag: number): Message {
+		const b = this.fieldRaw(tag);
+		return b === undefined ? Message.Empty : Message.open(b);
+	}
+
+	// decode decodes a field value using a decode function, returns a default value
+	// when the field is absent or invalid.
+	decode<T>(tag: number, decode: DecodeFunc<T>, default_: T): T {
+		const b = this.fieldRaw(tag);
+		if (b === undefined) {
+			return default_;
+		}
+
+		try {
+			const [v, n] = decode(b);
+			return n === 0 ? default_ : v;
+		} catch {
+			return default_;
+		}
+	}
+
+	// internal
+
+	private fieldAt(i: number): messageField | undefined {
+		const size = this.big ? messageFieldSizeBig : messageFieldSizeSmall;
+		const off = i * size;
+		if (i < 0 || off + size > this.table.length) {
+			return undefined;
+		}
+
+		const t = this.table;
+		if (this.big) {
+			return { tag: (t[off] << 8) | t[off + 1], offset: readUint32(t, off + 2) };
+		}
+		return { tag: t[off], offset: (t[off + 1] << 8) | t[off + 2] };
+	}
+
+	// offset returns a field end offset by a tag or -1, uses binary search.
+	private offset(tag: number): number {
+		let left = 0;
+		let right = this.fields() - 1;
+
+		while (left <= right) {
+			const middle = (left + right) >>> 1;
+			const f = this.fieldAt(middle)!;
+
+			if (f.tag < tag) {
+				left = middle + 1;
+			} else if (f.tag > tag) {
+				right = middle - 1;
+			} else {
+				return f.offset;
+			}
+		}
+		return -1;
+	}
+}
+
+/* Packed */
+
+// packedSize returns the element size of a packable scalar type, or 0.
+// Structs are packable as well, but their size is specified by the first element.
+export function packedSize(t: Type): number {
+	switch (t) {
+		case Type.Int16:
+		case Type.Uint16:
+			return 2;
+		case Type.Int32:
+		case Type.Uint32:
+		case Type.Float32:
+			return 4;
+		case Type.Int64:
+		case Type.Uint64:
+		case Type.Float64:
+		case Type.Bin64:
+			return 8;
+		case Type.Bin128:
+			return 16;
+		case Type.Bin256:
+			return 32;
+	}
+	return 0;
+}
+
+// packedAlign returns the element alignment of a packable type, or 1.
+function packedAlign(t: Type): number {
+	switch (t) {
+		case Type.Bin64:
+		case Type.Bin128:
+		case Type.Bin256:
+		case Type.Struct:
+			return 1;
+	}
+	return packedSize(t) || 1;
+}
+
+// isPackable returns true if elements of the type can be stored in a packed list.
+export function isPackable(t: Type): boolean {
+	return t === Type.Struct || packedSize(t) > 0;
+}
+
+// putPacked writes a little-endian number or raw bin into a packed element.
+function putPacked(p: Uint8Array, t: Type, v: unknown): void {
+	const view = new DataView(p.buffer, p.byteOffset, p.length);
+
+	switch (t) {
+		case Type.Int16:
+			view.setInt16(0, v as number, true);
+			return;
+		case Type.Int32:
+			view.setInt32(0, v as number, true);
+			return;
+		case Type.Int64:
+			view.setBigInt64(0, BigInt.asIntN(64, v as bigint), true);
+			return;
+
+		case Type.Uint16:
+			view.setUint16(0, v as number, true);
+			return;
+		case Type.Uint32:
+			view.setUint32(0, v as number, true);
+			return;
+		case Type.Uint64:
+			view.setBigUint64(0, BigInt.asUintN(64, v as bigint), true);
+			return;
+
+		case Type.Float32:
+			view.setFloat32(0, v as number, true);
+			return;
+		case Type.Float64:
+			view.setFloat64(0, v as number, true);
+			return;
+
+		case Type.Bin64:
+		case Type.Bin128:
+		case Type.Bin256: {
+			const b = v as Uint8Array;
+			if (b.length !== p.length) {
+				throw new SpecError(`encode: invalid bin length, expected=${p.length}, actual=${b.length}`);
+			}
+			p.set(b);
+			return;
+		}
+	}
+	throw new SpecError(`encode: type is not packable, type=${t}`);
+}
+
+// encodePacked encodes a packed number or bin element into a standalone value.
+function encodePacked(t: Type, p: Uint8Array): Uint8Array {
+	const view = new DataView(p.buffer, p.byteOffset, p.length);
+	const b = new Buffer(p.length + 10);
+
+	switch (t) {
+		case Type.Int16:
+			encodeInt16(b, view.getInt16(0, true));
+			break;
+		case Type.Int32:
+			encodeInt32(b, view.getInt32(0, true));
+			break;
+		case Type.Int64:
+			encodeInt64(b, view.getBigInt64(0, true));
+			break;
+
+		case Type.Uint16:
+			encodeUint16(b, view.getUint16(0, true));
+			break;
+		case Type.Uint32:
+			encodeUint32(b, view.getUint32(0, true));
+			break;
+		case Type.Uint64:
+			encodeUint64(b, view.getBigUint64(0, true));
+			break;
+
+		case Type.Float32:
+			encodeFloat32(b, view.getFloat32(0, true));
+			break;
+		case Type.Float64:
+			encodeFloat64(b, view.getFloat64(0, true));
+			break;
+
+		case Type.Bin64:
+		case Type.Bin128:
+		case Type.Bin256:
+			encodeBin(b, p, t, p.length);
+			break;
+
+		default:
+			return p;
+	}
+	return b.bytes();
+}
+
+/* List */
+
+// List is a read-only view of a list.
+export class List {
+	static readonly Empty = new List(empty, empty, 0, false);
+
+	readonly raw: Uint8Array;
+	private readonly table: Uint8Array;
+	private readonly dataSize: number;
+	private readonly big: boolean;
+
+	// Packed list
+	private readonly elemType: Type;
+	private readonly elemSize: number;
+	private readonly count: number;
+
+	private constructor(
+		raw: Uint8Array,
+		table: Uint8Array,
+		dataSize: number,
+		big: boolean,
+		elemType: Type = Type.Undefined,
+		elemSize = 0,
+		count = 0,
+	) {
+		this.raw = raw;
+		this.table = table;
+		this.dataSize = dataSize;
+		this.big = big;
+		this.elemType = elemType;
+		this.elemSize = elemSize;
+		this.count = count;
+	}
+
+	// open opens a list, returns an empty list on invalid data.
+	static open(b: Uint8Array): List {
+		try {
+			return List.parse(b)[0];
+		} catch {
+			return List.Empty;
+		}
+	}
+
+	// parse parses a list from the b end, returns the list and its size,
+	// throws on invalid data.
+	static parse(b: Uint8Array): [List, number] {
+		if (b.length === 0) {
+			return [List.Empty, 0];
+		}
+
+		const t = decodeType(b);
+		if (t === Type.PackedList) {
+			return List.parsePacked(b);
+		}
+		if (t !== Type.List && t !== Type.BigList) {
+			throw new SpecError(`decode list: invalid type, type=${t}`);
+		}
+		const big = t === Type.BigList;
+		let end = b.length - 1;
+
+		// Table size
+		const [tableSize, m] = reverseUint32(b.subarray(0, end));
+		if (m <= 0) {
+			throw new SpecError("decode list: invalid table size");
+		}
+		end -= m;
+
+		// Data size
+		const [dataSize, k] = reverseUint32(b.subarray(0, end));
+		if (k <= 0) {
+			throw new SpecError("decode list: invalid data size");
+		}
+		end -= k;
+
+		// Table
+		const elemSize = big ? listElementSizeBig : listElementSizeSmall;
+		const tableStart = end - tableSize;
+		if (tableStart < 0 || tableSize % elemSize !== 0) {
+			throw new SpecError("decode list: invalid table");
+		}
+		const table = b.subarray(tableStart, end);
+
+		// Data
+		const start = tableStart - dataSize;
+		if (start < 0) {
+			throw new SpecError("decode list: invalid data");
+		}
+
+		const size = b.length - start;
+		const list = new List(b.subarray(start), table, dataSize, big);
+		return [list, size];
+	}
+
+	private static parsePacked(b: Uint8Array): [List, number] {
+		const f = decodePackedFooter(b);
+		const size = f.size + f.dataSize;
+		const raw = b.subarray(b.length - size);
+
+		const list = new List(raw, empty, f.dataSize, false, f.elemType, f.elemSize, f.count);
+		return [list, size];
+	}
+
+	// len returns the number of elements.
+	len(): number {
+		if (this.elemType !== Type.Undefined) {
+			return this.count;
+		}
+		return this.table.length / (this.big ? listElementSizeBig : listElementSizeSmall);
+	}
+
+	// packed returns true if the list is a packed list of fixed-size elements.
+	packed(): boolean {
+		return this.elemType !== Type.Undefined;
+	}
+
+	// packedType returns the packed list element type, or undefined.
+	packedType(): Type {
+		return this.elemType;
+	}
+
+	// isEmpty returns true if the list is empty.
+	isEmpty(): boolean {
+		return this.len() === 0;
+	}
+
+	// get returns an element value or undefined.
+	get(i: number): Value | undefined {
+		const b = this.getBytes(i);
+		return b === undefined ? undefined : Value.open(b);
+	}
+
+	// getBytes returns element bytes or undefined,
+	// packed numbers and bins are re-encoded into new values.
+	getBytes(i: number): Uint8Array | undefined {
+		const n = this.len();
+		if (i < 0 || i >= n) {
+			return undefined;
+		}
+
+		if (this.elemType !== Type.Undefined) {
+			const start = this.dataSize - (n - i) * this.elemSize;
+			const p = this.raw.subarray(start, start + this.elemSize);
+			return encodePacked(this.elemType, p);
+		}
+
+		const start = i === 0 ? 0 : this.offsetAt(i - 1);
+		const end = this.offsetAt(i);
+		if (start > end || end > this.dataSize) {
+			return undefined;
+		}
+		return this.raw.subarray(start, end);
+	}
+
+	private offsetAt(i: number): number {
+		const t = this.table;
+		if (this.big) {
+			return readUint32(t, i * listElementSizeBig);
+		}
+		const off = i * listElementSizeSmall;
+		return (t[off] << 8) | t[off + 1];
+	}
+}
+
+// ValueList is a list of values decoded with a decode function.
+export class ValueList<T> {
+	readonly list: List;
+	private readonly decode: DecodeFunc<T>;
+
+	constructor(list: List, decode: DecodeFunc<T>) {
+		this.list = list;
+		this.decode = decode;
+	}
+
+	len(): number {
+		return this.list.len();
+	}
+
+	// get decodes and returns an element, throws on invalid data.
+	get(i: number): T {
+		const b = this.list.getBytes(i);
+		if (b === undefined) {
+			throw new SpecError(`list: index out of range, index=${i}`);
+		}
+		return this.decode(b)[0];
+	}
+
+	// values decodes and returns all elements.
+	values(): T[] {
+		const result: T[] = [];
+		for (let i = 0; i < this.len(); i++) {
+			result.push(this.get(i));
+		}
+		return result;
+	}
+
+	*[Symbol.iterator](): Iterator<T> {
+		for (let i = 0; i < this.len(); i++) {
+			yield this.get(i);
+		}
+	}
+}
+
+// MessageList is a list of messages parsed with a parse function.
+export class MessageList<T> {
+	readonly list: List;
+	private readonly parse: DecodeFunc<T>;
+
+	constructor(list: List, parse: DecodeFunc<T>) {
+		this.list = list;
+		this.parse = parse;
+	}
+
+	len(): number {
+		return this.list.len();
+	}
+
+	// get parses and returns an element, throws on invalid data.
+	get(i: number): T {
+		const b = this.list.getBytes(i);
+		if (b === undefined) {
+			throw new SpecError(`list: index out of range, index=${i}`);
+		}
+		return this.parse(b)[0];
+	}
+
+	// values parses and returns all elements.
+	values(): T[] {
+		const result: T[] = [];
+		for (let i = 0; i < this.len(); i++) {
+			result.push(this.get(i));
+		}
+		return result;
+	}
+
+	*[Symbol.iterator](): Iterator<T> {
+		for (let i = 0; i < this.len(); i++) {
+			yield this.get(i);
+		}
+	}
+}
+
+/* Value */
+
+// Value is a read-only view of any value.
+export class Value {
+	static readonly Empty = new Value(empty);
+
+	readonly raw: Uint8Array;
+
+	private constructor(raw: Uint8Array) {
+		this.raw = raw;
+	}
+
+	// open opens a value and truncates it to its size, returns an empty value on invalid data.
+	static open(b: Uint8Array): Value {
+		try {
+			return Value.parse(b)[0];
+		} catch {
+			return Value.Empty;
+		}
+	}
+
+	// parse parses a value from the b end, returns the value and its size,
+	// throws on invalid data.
+	static parse(b: Uint8Array): [Value, number] {
+		const [, size] = decodeTypeSize(b);
+		return [new Value(b.subarray(b.length - size)), size];
+	}
+
+	type(): Type {
+		return decodeType(this.raw);
+	}
+
+	isEmpty(): boolean {
+		return this.raw.length === 0;
+	}
+
+	bool(): boolean {
+		return decodeBool(this.raw)[0];
+	}
+
+	byte(): number {
+		return decodeByte(this.raw)[0];
+	}
+
+	int16(): number {
+		return decodeInt16(this.raw)[0];
+	}
+
+	int32(): number {
+		return decodeInt32(this.raw)[0];
+	}
+
+	int64(): bigint {
+		return decodeInt64(this.raw)[0];
+	}
+
+	uint16(): number {
+		return decodeUint16(this.raw)[0];
+	}
+
+	uint32(): number {
+		return decodeUint32(this.raw)[0];
+	}
+
+	uint64(): bigint {
+		return decodeUint64(this.raw)[0];
+	}
+
+	float32(): number {
+		return decodeFloat32(this.raw)[0];
+	}
+
+	float64(): number {
+		return decodeFloat64(this.raw)[0];
+	}
+
+	bin64(): Bin64 {
+		return decodeBin64(this.raw)[0];
+	}
+
+	bin128(): Bin128 {
+		return decodeBin128(this.raw)[0];
+	}
+
+	bin256(): Bin256 {
+		return decodeBin256(this.raw)[0];
+	}
+
+	bytes(): Uint8Array {
+		return decodeBytes(this.raw)[0];
+	}
+
+	string(): string {
+		return decodeString(this.raw)[0];
+	}
+
+	list(): List {
+		return List.parse(this.raw)[0];
+	}
+
+	message(): Message {
+		return Message.parse(this.raw)[0];
+	}
+}
+
+// decodeValue parses a value from the b end, returns the value and its size.
+export function decodeValue(b: Uint8Array): [Value, number] {
+	return Value.parse(b);
+}
+
+// encodeValue writes raw value bytes, returns the number of written bytes.
+export function encodeValue(b: Buffer, v: Value): number {
+	b.write(v.raw);
+	return v.raw.length;
+}
+
+// decodeMessage parses a message from the b end, returns the message and its size.
+export function decodeMessage(b: Uint8Array): [Message, number] {
+	return Message.parse(b);
+}
+
+// encodeMessage writes raw message bytes, returns the number of written bytes.
+export function encodeMessage(b: Buffer, m: Message): number {
+	b.write(m.raw);
+	return m.raw.length;
+}
+
+/* Writer */
+
+const entryData = 1; // data holds the last written data start/end
+const entryList = 2;
+const entryElement = 3;
+const entryMessage = 4;
+const entryField = 5;
+
+interface stackEntry {
+	type: number;
+	start: number; // start offset in data buffer
+	table: number; // table offset in list/message stack, data end, or field tag
+	elemType?: Type; // packed list element type
+}
+
+// Writer writes spec objects, it produces the same bytes as the Go writer.
+//
+// Use MessageWriter/ListWriter directly in most cases.
+export class Writer {
+	readonly buf: Buffer;
+
+	private stack: stackEntry[] = [];
+	private fields: messageField[] = [];
+	private elements: number[] = [];
+	private err: Error | undefined;
+
+	// Only the top list can be packed, packed lists have no nested objects.
+	private packedSize = 0;
+	private packedCount = 0;
+
+	constructor(buf?: Buffer) {
+		this.buf = buf ?? new Buffer();
+	}
+
+	// message begins a new message and returns a message writer.
+	message(): MessageWriter {
+		this.beginMessage();
+		return new MessageWriter(this);
+	}
+
+	// list begins a new list and returns a list writer.
+	list(): ListWriter {
+		this.beginList();
+		return new ListWriter(this);
+	}
+
+	// internal
+
+	/** @internal */
+	end(): Uint8Array {
+		this.check();
+
+		const entry = this.peek();
+		if (entry === undefined) {
+			throw this.fail("end: stack is empty");
+		}
+
+		let result: Uint8Array;
+		switch (entry.type) {
+			case entryList:
+				result = this.endList();
+				break;
+			case entryMessage:
+				result = this.endMessage();
+				break;
+			default:
+				throw this.fail(`end: cannot end object, invalid entry type: ${entry.type}`);
+		}
+
+		// Maybe end parent field/element
+		const parent = this.stack.length < 2 ? undefined : this.stack[this.stack.length - 2];
+		if (parent === undefined) {
+			this.err = new SpecError("operation on closed writer");
+			return result;
+		}
+
+		switch (parent.type) {
+			case entryElement:
+				return this.endElement();
+			case entryField:
+				return this.endField();
+		}
+		return result;
+	}
+
+	/** @internal */
+	write<T>(v: T, encode: EncodeFunc<T>): void {
+		this.check();
+
+		const start = this.buf.len();
+		try {
+			encode(this.buf, v);
+		} catch (e) {
+			throw this.fail(e);
+		}
+		const end = this.buf.len();
+
+		this.pushData(start, end);
+	}
+
+	/** @internal */
+	writeAny(b: Uint8Array): void {
+		this.check();
+
+		if (b.length === 0) {
+			throw this.fail("decode type: invalid data");
+		}
+
+		const start = this.buf.len();
+		this.buf.write(b);
+		const end = this.buf.len();
+
+		this.pushData(start, end);
+	}
+
+	// list
+
+	/** @internal */
+	beginList(): void {
+		this.check();
+
+		const start = this.buf.len();
+		this.stack.push({ type: entryList, start, table: this.elements.length });
+	}
+
+	/** @internal */
+	beginElement(): void {
+		this.check();
+
+		const list = this.peek();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("begin element: cannot begin element, parent not list");
+		}
+		if (list.elemType !== undefined) {
+			throw this.fail("begin element: cannot begin element in packed list");
+		}
+
+		const start = this.buf.len();
+		this.stack.push({ type: entryElement, start, table: 0 });
+	}
+
+	/** @internal */
+	element(): void {
+		this.check();
+
+		const [, end] = this.popData();
+
+		const list = this.peek();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("element: cannot encode element, parent not list");
+		}
+		if (list.elemType !== undefined) {
+			throw this.fail("element: cannot encode element in packed list");
+		}
+
+		this.elements.push(end - list.start);
+	}
+
+	/** @internal */
+	listLen(): number {
+		const list = this.peek();
+		if (this.err !== undefined || list === undefined || list.type !== entryList) {
+			return 0;
+		}
+		if (list.elemType !== undefined) {
+			return this.packedCount;
+		}
+		return this.elements.length - list.table;
+	}
+
+	private endElement(): Uint8Array {
+		this.check();
+
+		const [, end] = this.popData();
+
+		const elem = this.stack.pop();
+		if (elem === undefined || elem.type !== entryElement) {
+			throw this.fail("end element: not element");
+		}
+
+		const list = this.peek();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("end element: parent not list");
+		}
+
+		this.elements.push(end - list.start);
+		return this.buf.bytes().subarray(elem.start, end);
+	}
+
+	private endList(): Uint8Array {
+		this.check();
+
+		const list = this.stack.pop();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("end list: not list");
+		}
+
+		const dataSize = this.buf.len() - list.start;
+		const table = this.elements.splice(list.table);
+
+		try {
+			if (list.elemType !== undefined && this.packedCount > 0) {
+				encodePackedList(this.buf, dataSize, this.packedCount, this.packedSize, list.elemType);
+			} else {
+				encodeListTable(this.buf, dataSize, table);
+			}
+		} catch (e) {
+			throw this.fail(e);
+		}
+
+		const start = list.start;
+		const end = this.buf.len();
+		this.pushData(start, end);
+		return this.buf.bytes().subarray(start, end);
+	}
+
+	// packed list
+
+	/** @internal */
+	packList(elemType: Type): void {
+		this.check();
+
+		const list = this.peek();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("pack list: not list");
+		}
+		if (list.elemType === elemType) {
+			return;
+		}
+		if (list.elemType !== undefined) {
+			throw this.fail(`pack list: list already packed, type=${list.elemType}`);
+		}
+		if (packedSize(elemType) === 0) {
+			throw this.fail(`pack list: type is not packable, type=${elemType}`);
+		}
+		if (this.buf.len() !== list.start) {
+			throw this.fail("pack list: list not empty");
+		}
+
+		list.elemType = elemType;
+		this.packedSize = packedSize(elemType);
+		this.packedCount = 0;
+	}
+
+	/** @internal */
+	packedType(): Type | undefined {
+		const list = this.peek();
+		if (this.err !== undefined || list === undefined || list.type !== entryList) {
+			return undefined;
+		}
+		return list.elemType;
+	}
+
+	// packedElement appends a fixed-size packed element, pads the first element
+	// to its alignment, returns the element bytes.
+	/** @internal */
+	packedElement(elemType: Type): Uint8Array {
+		this.check();
+
+		const list = this.peek();
+		if (list === undefined || list.type !== entryList) {
+			throw this.fail("packed element: parent not list");
+		}
+		if (list.elemType !== elemType) {
+			throw this.fail(
+				`packed element: invalid element type, type=${elemType}, list type=${list.elemType}`,
+			);
+		}
+
+		if (this.packedCount === 0) {
+			const align = packedAlign(elemType);
+			const pad = (align - (this.buf.len() % align)) % align;
+			if (pad > 0) {
+				this.buf.grow(pad).fill(0);
+			}
+		}
+
+		this.packedCount++;
+		return this.buf.grow(this.packedSize);
+	}
+
+	// message
+
+	/** @internal */
+	beginMessage(): void {
+		this.check();
+
+		const start = this.buf.len();
+		this.stack.push({ type: entryMessage, start, table: this.fields.length });
+	}
+
+	/** @internal */
+	beginField(tag: number): void {
+		this.check();
+
+		const message = this.peek();
+		if (message === undefined || message.type !== entryMessage) {
+			throw this.fail("begin field: cannot begin field, parent not message");
+		}
+
+		const start = this.buf.len();
+		this.stack.push({ type: entryField, start, table: tag });
+	}
+
+	/** @internal */
+	field(tag: number): void {
+		this.check();
+
+		const [, end] = this.popData();
+
+		const message = this.peek();
+		if (message === undefined || message.type !== entryMessage) {
+			throw this.fail("field: cannot encode field, parent not message");
+		}
+
+		this.insertField(message.table, { tag, offset: end - message.start });
+	}
+
+	/** @internal */
+	hasField(tag: number): boolean {
+		const message = this.peek();
+		if (this.err !== undefined || message === undefined || message.type !== entryMessage) {
+			return false;
+		}
+
+		for (let i = message.table; i < this.fields.length; i++) {
+			if (this.fields[i].tag === tag) {
+				return true;
+			}
+		}
+		return false;
+	}
+
+	private endField(): Uint8Array {
+		this.check();
+
+		const [, end] = this.popData();
+
+		const field = this.stack.pop();
+		if (field === undefined || field.type !== entryField) {
+			throw this.fail("end field: not field");
+		}
+
+		const message = this.peek();
+		if (message === undefined || message.type !== entryMessage) {
+			throw this.fail("field: cannot encode field, parent not message");
+		}
+
+		this.insertField(message.table, { tag: field.table, offset: end - message.start });
+		return this.buf.bytes().subarray(field.start, end);
+	}
+
+	private endMessage(): Uint8Array {
+		this.check();
+
+		const message = this.stack.pop();
+		if (message === undefined || message.type !== entryMessage) {
+			throw this.fail("end message: parent not message");
+		}
+
+		const dataSize = this.buf.len() - message.start;
+		const table = this.fields.splice(message.table);
+
+		try {
+			encodeMessageTable(this.buf, dataSize, table);
+		} catch (e) {
+			throw this.fail(e);
+		}
+
+		const start = message.start;
+		const end = this.buf.len();
+		this.pushData(start, end);
+		return this.buf.bytes().subarray(start, end);
+	}
+
+	// insertField inserts a field into the last table, keeps the table sorted by tags
+	// using the insertion sort.
+	private insertField(tableStart: number, f: messageField): void {
+		const fields = this.fields;
+		fields.push(f);
+
+		for (let i = fields.length - 1; i > tableStart; i--) {
+			const left = fields[i - 1];
+			const right = fields[i];
+			if (left.tag < right.tag) {
+				break;
+			}
+
+			fields[i - 1] = right;
+			fields[i] = left;
+		}
+	}
+
+	// data
+
+	private pushData(start: number, end: number): void {
+		const entry = this.peek();
+		if (entry !== undefined && entry.type === entryData) {
+			throw this.fail("cannot push more data, element/field must be written first");
+		}
+		this.stack.push({ type: entryData, start, table: end });
+	}
+
+	private popData(): [number, number] {
+		const entry = this.stack.pop();
+		if (entry === undefined) {
+			throw this.fail("cannot pop data, no data");
+		}
+		if (entry.type !== entryData) {
+			throw this.fail(`cannot pop data, not data, type=${entry.type}`);
+		}
+		return [entry.start, entry.table];
+	}
+
+	private peek(): stackEntry | undefined {
+		return this.stack[this.stack.length - 1];
+	}
+
+	// errors
+
+	private check(): void {
+		if (this.err !== undefined) {
+			throw this.err;
+		}
+	}
+
+	private fail(e: unknown): Error {
+		if (this.err !== undefined) {
+			return this.err;
+		}
+
+		const err = e instanceof Error ? e : new SpecError(String(e));
+		this.err = err;
+		return err;
+	}
+}
+
+// MessageWriter writes a message.
+export class MessageWriter {
+	readonly w: Writer;
+
+	// constructor returns a message writer which writes to a writer,
+	// or begins a new root message when no writer is given.
+	constructor(w?: Writer) {
+		if (w === undefined) {
+			w = new Writer();
+			w.beginMessage();
+		}
+		this.w = w;
+	}
+
+	// field returns a field writer.
+	field(tag: number): FieldWriter {
+		return new FieldWriter(this.w, tag);
+	}
+
+	// has returns true if the message has the given field,
+	// only valid when there is no pending field.
+	has(tag: number): boolean {
+		return this.w.hasField(tag);
+	}
+
+	// copy copies absent fields from the given message.
+	copy(src: Message): void {
+		const n = src.fields();
+		for (let i = 0; i < n; i++) {
+			const tag = src.tagAt(i);
+			if (this.has(tag)) {
+				continue;
+			}
+
+			const value = src.field(tag);
+			if (value !== undefined) {
+				this.field(tag).any(value.raw);
+			}
+		}
+	}
+
+	// end ends the message.
+	end(): void {
+		this.w.end();
+	}
+
+	// build ends the message and returns its bytes.
+	build(): Uint8Array {
+		return this.w.end();
+	}
+}
+
+// FieldWriter writes a message field.
+export class FieldWriter {
+	readonly w: Writer;
+	readonly tag: number;
+
+	constructor(w: Writer, tag: number) {
+		this.w = w;
+		this.tag = tag;
+	}
+
+	// write writes a field value using an encode function.
+	write<T>(v: T, encode: EncodeFunc<T>): void {
+		this.w.write(v, encode);
+		this.w.field(this.tag);
+	}
+
+	// any writes a field with any valid spec value.
+	any(b: Uint8Array): void {
+		this.w.writeAny(b);
+		this.w.field(this.tag);
+	}
+
+	bool(v: boolean): void {
+		this.write(v, encodeBool);
+	}
+
+	byte(v: number): void {
+		this.write(v, encodeByte);
+	}
+
+	int16(v: number): void {
+		this.write(v, encodeInt16);
+	}
+
+	int32(v: number): void {
+		this.write(v, encodeInt32);
+	}
+
+	int64(v: bigint): void {
+		this.write(v, encodeInt64);
+	}
+
+	uint16(v: number): void {
+		this.write(v, encodeUint16);
+	}
+
+	uint32(v: number): void {
+		this.write(v, encodeUint32);
+	}
+
+	uint64(v: bigint): void {
+		this.write(v, encodeUint64);
+	}
+
+	float32(v: number): void {
+		this.write(v, encodeFloat32);
+	}
+
+	float64(v: number): void {
+		this.write(v, encodeFloat64);
+	}
+
+	bin64(v: Bin64): void {
+		this.write(v, encodeBin64);
+	}
+
+	bin128(v: Bin128): void {
+		this.write(v, encodeBin128);
+	}
+
+	bin256(v: Bin256): void {
+		this.write(v, encodeBin256);
+	}
+
+	bytes(v: Uint8Array): void {
+		this.write(v, encodeBytes);
+	}
+
+	string(v: string): void {
+		this.write(v, encodeString);
+	}
+
+	// list begins a list field and returns a list writer.
+	list(): ListWriter {
+		this.w.beginField(this.tag);
+		this.w.beginList();
+		return new ListWriter(this.w);
+	}
+
+	// message begins a message field and returns a message writer.
+	message(): MessageWriter {
+		this.w.beginField(this.tag);
+		this.w.beginMessage();
+		return new MessageWriter(this.w);
+	}
+}
+
+// ListWriter writes a list of elements.
+export class ListWriter {
+	readonly w: Writer;
+
+	// constructor returns a list writer which writes to a writer,
+	// or begins a new root list when no writer is given.
+	constructor(w?: Writer) {
+		if (w === undefined) {
+			w = new Writer();
+			w.beginList();
+		}
+		this.w = w;
+	}
+
+	// len returns the number of written elements,
+	// only valid when there is no pending element.
+	len(): number {
+		return this.w.listLen();
+	}
+
+	// pack switches the empty list into a packed list of fixed-size numbers or bins.
+	pack(elemType: Type): void {
+		this.w.packList(elemType);
+	}
+
+	// packed returns true if the list is packed.
+	packed(): boolean {
+		return this.w.packedType() !== undefined;
+	}
+
+	// write writes an element using an encode function,
+	// packed lists store the element directly without the encode function.
+	write<T>(v: T, encode: EncodeFunc<T>): void {
+		const t = this.w.packedType();
+		if (t !== undefined) {
+			putPacked(this.w.packedElement(t), t, v);
+			return;
+		}
+
+		this.w.write(v, encode);
+		this.w.element();
+	}
+
+	// any writes an element with any valid spec value.
+	any(b: Uint8Array): void {
+		this.w.writeAny(b);
+		this.w.element();
+	}
+
+	bool(v: boolean): void {
+		this.write(v, encodeBool);
+	}
+
+	byte(v: number): void {
+		this.write(v, encodeByte);
+	}
+
+	int16(v: number): void {
+		this.write(v, encodeInt16);
+	}
+
+	int32(v: number): void {
+		this.write(v, encodeInt32);
+	}
+
+	int64(v: bigint): void {
+		this.write(v, encodeInt64);
+	}
+
+	uint16(v: number): void {
+		this.write(v, encodeUint16);
+	}
+
+	uint32(v: number): void {
+		this.write(v, encodeUint32);
+	}
+
+	uint64(v: bigint): void {
+		this.write(v, encodeUint64);
+	}
+
+	float32(v: number): void {
+		this.write(v, encodeFloat32);
+	}
+
+	float64(v: number): void {
+		this.write(v, encodeFloat64);
+	}
+
+	bin64(v: Bin64): void {
+		this.write(v, encodeBin64);
+	}
+
+	bin128(v: Bin128): void {
+		this.write(v, encodeBin128);
+	}
+
+	bin256(v: Bin256): void {
+		this.write(v, encodeBin256);
+	}
+
+	bytes(v: Uint8Array): void {
+		this.write(v, encodeBytes);
+	}
+
+	string(v: string): void {
+		this.write(v, encodeString);
+	}
+
+	// list begins a list element and returns a list writer.
+	list(): ListWriter {
+		this.w.beginElement();
+		this.w.beginList();
+		return new ListWriter(this.w);
+	}
+
+	// message begins a message element and returns a message writer.
+	message(): MessageWriter {
+		this.w.beginElement();
+		this.w.beginMessage();
+		return new MessageWriter(this.w);
+	}
+
+	// end ends the list.
+	end(): void {
+		this.w.end();
+	}
+
+	// build ends the list and returns its bytes.
+	build(): Uint8Array {
+		return this.w.end();
+	}
+}
+
+// ValueListWriter writes a list of values using an encode function.
+export class ValueListWriter<T> {
+	readonly list: ListWriter;
+	private readonly encode: EncodeFunc<T>;
+
+	// constructor packs an empty list when the encode function writes fixed-size numbers or bins.
+	constructor(list: ListWriter, encode: EncodeFunc<T>) {
+		this.list = list;
+		this.encode = encode;
+
+		const t = packedEncoders.get(encode as EncodeFunc<unknown>);
+		if (t !== undefined && list.len() === 0) {
+			list.pack(t);
+		}
+	}
+
+	len(): number {
+		return this.list.len();
+	}
+
+	add(v: T): void {
+		this.list.write(v, this.encode);
+	}
+
+	end(): void {
+		this.list.end();
+	}
+
+	build(): Uint8Array {
+		return this.list.build();
+	}
+}
+
+// packedEncoders maps fixed-size encode functions to packed element types.
+const packedEncoders = new Map<EncodeFunc<unknown>, Type>([
+	[encodeInt16 as EncodeFunc<unknown>, Type.Int16],
+	[encodeInt32 as EncodeFunc<unknown>, Type.Int32],
+	[encodeInt64 as EncodeFunc<unknown>, Type.Int64],
+	[encodeUint16 as EncodeFunc<unknown>, Type.Uint16],
+	[encodeUint32 as EncodeFunc<unknown>, Type.Uint32],
+	[encodeUint64 as EncodeFunc<unknown>, Type.Uint64],
+	[encodeFloat32 as EncodeFunc<unknown>, Type.Float32],
+	[encodeFloat64 as EncodeFunc<unknown>, Type.Float64],
+	[encodeBin64 as EncodeFunc<unknown>, Type.Bin64],
+	[encodeBin128 as EncodeFunc<unknown>, Type.Bin128],
+	[encodeBin256 as EncodeFunc<unknown>, Type.Bin256],
+]);
+
+// MessageListWriter writes a list of messages using generated message writers.
+export class MessageListWriter<W> {
+	readonly list: ListWriter;
+	private readonly next: (w: MessageWriter) => W;
+
+	constructor(list: ListWriter, next: (w: MessageWriter) => W) {
+		this.list = list;
+		this.next = next;
+	}
+
+	len(): number {
+		return this.list.len();
+	}
+
+	// add begins a new message element and returns its writer.
+	add(): W {
+		return this.next(this.list.message());
+	}
+
+	end(): void {
+		this.list.end();
+	}
+
+	build(): Uint8Array {
+		return this.list.build();
+	}
+}
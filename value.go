@@ -31,3 +31,9 @@ func OpenValueErr(b []byte) (Value, error) {
 func ParseValue(b []byte) (_ Value, n int, err error) {
 	return types.ParseValue(b)
 }
+
+// ParseValueWithOptions recursively parses and returns a value,
+// returns an [ErrParseLimit] error when the value exceeds the limits.
+func ParseValueWithOptions(b []byte, opts ParseOptions) (_ Value, n int, err error) {
+	return types.ParseValueWithOptions(b, opts)
+}
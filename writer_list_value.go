@@ -4,24 +4,40 @@
 
 package spec
 
-import "github.com/basecomplextech/spec/internal/writer"
+import (
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/internal/writer"
+)
 
 // ValueListWriter writes a list of primitive values.
 type ValueListWriter[T any] struct {
 	w     ListWriter
 	write writer.WriteFunc[T]
+	err   error // pack error
 }
 
 // NewValueListWriter returns a new value list writer.
+//
+// Lists of builtin numbers and bins are packed, see [TypePackedList].
+// A pack error is returned from [ValueListWriter.Add] and [ValueListWriter.End].
 func NewValueListWriter[T any](w ListWriter, write writer.WriteFunc[T]) (_ ValueListWriter[T]) {
+	var err error
+	if typ := format.PackedType[T](); typ != format.TypeUndefined && w.Len() == 0 {
+		err = w.Pack(typ)
+	}
+
 	return ValueListWriter[T]{
 		w:     w,
 		write: write,
+		err:   err,
 	}
 }
 
 // Add adds the next element.
 func (b ValueListWriter[T]) Add(value T) error {
+	if b.err != nil {
+		return b.err
+	}
 	return writer.WriteElement(b.w, value, b.write)
 }
 
@@ -33,5 +49,8 @@ func (b ValueListWriter[T]) Len() int {
 
 // End ends the list.
 func (b ValueListWriter[T]) End() error {
+	if b.err != nil {
+		return b.err
+	}
 	return b.w.End()
 }